@@ -9,10 +9,13 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/divijg19/physiolink/backend/internal/blob"
 	"github.com/divijg19/physiolink/backend/internal/clock"
 	"github.com/divijg19/physiolink/backend/internal/config"
 	"github.com/divijg19/physiolink/backend/internal/db"
 	"github.com/divijg19/physiolink/backend/internal/handlers"
+	mware "github.com/divijg19/physiolink/backend/internal/middleware"
+	"github.com/divijg19/physiolink/backend/internal/oidc"
 	"github.com/divijg19/physiolink/backend/internal/server"
 	"github.com/divijg19/physiolink/backend/internal/service"
 	"github.com/joho/godotenv"
@@ -23,7 +26,11 @@ func main() {
 	_ = godotenv.Load()
 	_ = godotenv.Load("../.env")
 
-	cfg := config.New()
+	cfg, err := config.Load()
+	if err != nil {
+		slog.Error("invalid configuration", "error", err)
+		os.Exit(1)
+	}
 	// connect DB
 	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
 	defer cancel()
@@ -38,8 +45,23 @@ func main() {
 	authSvc := service.NewAuthService(database, cfg)
 	profileSvc := service.NewProfileService(database, cfg)
 	therapistSvc := service.NewTherapistService(database)
-	reviewSvc := service.NewReviewService(database)
+	favouriteSvc := service.NewFavouriteService(database)
+	recommendationSvc := service.NewRecommendationService(database, nil, clock.NewReal())
+	reviewSvc := service.NewReviewService(database, clock.NewReal(), cfg.ReviewEditWindow, nil)
 	reminderSvc := service.NewReminderService(database.Queries, clock.NewReal())
+	mfaSvc := service.NewMFAService(database, clock.NewReal())
+	oidcSvc := service.NewOIDCService(database)
+	adminSvc := service.NewAdminService(database)
+	sessionSvc := service.NewSessionService(database)
+	apiKeySvc := service.NewAPIKeyService(database)
+	scheduleSvc := service.NewClinicScheduleService(database)
+	verificationSvc := service.NewVerificationService(database)
+	store, files, err := blobStore(cfg)
+	if err != nil {
+		slog.Error("blob store init failed", "error", err)
+		os.Exit(1)
+	}
+	uploadSvc := service.NewUploadService(database, store)
 	// temporal client (optional in dev)
 	tcl, err := service.NewTemporalClient()
 	if err != nil {
@@ -55,11 +77,22 @@ func main() {
 
 	// init handlers
 	handlers.InitAuth(authSvc, cfg)
+	handlers.InitMFA(mfaSvc)
+	handlers.InitOIDC(oidcSvc, discoverOIDCProviders(ctx, cfg), cfg.OIDCAppRedirects)
 	handlers.InitProfile(profileSvc)
+	handlers.InitVerification(verificationSvc)
+	handlers.InitUploads(uploadSvc, files)
 	handlers.InitTherapists(therapistSvc)
+	handlers.InitFavourites(favouriteSvc)
+	handlers.InitRecommendations(recommendationSvc)
 	handlers.InitReviews(reviewSvc)
 	handlers.InitAppointments(apptSvc)
 	handlers.InitReminders(reminderSvc)
+	handlers.InitAdmin(adminSvc)
+	handlers.InitSessions(sessionSvc)
+	mware.InitSessions(sessionSvc, mware.DefaultSessionCacheTTL)
+	handlers.InitIntegrations(scheduleSvc)
+	mware.InitAPIKeys(apiKeySvc)
 
 	srv := server.New(cfg)
 
@@ -88,3 +121,37 @@ func main() {
 
 	slog.Info("server exited")
 }
+
+// discoverOIDCProviders skips providers that can't be reached so an outage at
+// one identity provider doesn't stop the API from starting.
+func discoverOIDCProviders(ctx context.Context, cfg *config.Config) []*oidc.Provider {
+	var out []*oidc.Provider
+	for _, pc := range cfg.OIDCProviders {
+		p, err := oidc.Discover(ctx, nil, pc)
+		if err != nil {
+			slog.Error("oidc provider unavailable", "provider", pc.Name, "error", err)
+			continue
+		}
+		out = append(out, p)
+	}
+	return out
+}
+
+// blobStore builds the configured upload store. The local store also returns
+// the handler that serves its signed links; S3 links go to the bucket.
+func blobStore(cfg *config.Config) (blob.BlobStore, http.Handler, error) {
+	u := cfg.Uploads
+	if u.Backend == "s3" {
+		s3, err := blob.NewS3(blob.S3Config{
+			Endpoint:        u.S3.Endpoint,
+			Region:          u.S3.Region,
+			Bucket:          u.S3.Bucket,
+			AccessKeyID:     u.S3.AccessKeyID,
+			SecretAccessKey: u.S3.SecretAccessKey,
+			PathStyle:       u.S3.PathStyle,
+		}, &http.Client{Timeout: 30 * time.Second}, clock.NewReal())
+		return s3, nil, err
+	}
+	local := blob.NewLocal(u.Dir, "/files", []byte(u.SigningKey), clock.NewReal())
+	return local, local, nil
+}
@@ -0,0 +1,292 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/config"
+	"github.com/divijg19/physiolink/backend/internal/db"
+	"github.com/divijg19/physiolink/backend/internal/middleware"
+	"github.com/divijg19/physiolink/backend/internal/service"
+)
+
+func TestAdminService_DisableResetAndAudit(t *testing.T) {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+	cfg := config.New()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	database, err := db.Connect(ctx, cfg)
+	if err != nil {
+		t.Fatalf("db connect failed: %v", err)
+	}
+	defer database.Close()
+
+	auth := service.NewAuthService(database, cfg)
+	admin := service.NewAdminService(database)
+	suffix := uuid.NewString()
+
+	adminID, _, err := auth.Register(ctx, "admin-"+suffix+"@example.com", "pass1234", "patient")
+	if err != nil {
+		t.Fatalf("register admin: %v", err)
+	}
+	if _, err := database.Queries.UpdateUserRole(ctx, db.UpdateUserRoleParams{ID: adminID, Role: service.RoleAdmin}); err != nil {
+		t.Fatalf("promote: %v", err)
+	}
+	email := "user-" + suffix + "@example.com"
+	userID, _, err := auth.Register(ctx, email, "pass1234", "patient")
+	if err != nil {
+		t.Fatalf("register user: %v", err)
+	}
+
+	// Non-admins are refused
+	if err := admin.SetDisabled(ctx, userID, adminID, true); !errors.Is(err, service.ErrNotAdmin) {
+		t.Fatalf("expected ErrNotAdmin, got %v", err)
+	}
+
+	// Disabled users can't log in until re-enabled
+	if err := admin.SetDisabled(ctx, adminID, userID, true); err != nil {
+		t.Fatalf("disable: %v", err)
+	}
+	if _, _, err := auth.Authenticate(ctx, email, "pass1234"); !errors.Is(err, service.ErrAccountDisabled) {
+		t.Fatalf("expected ErrAccountDisabled, got %v", err)
+	}
+	if err := admin.SetDisabled(ctx, adminID, userID, false); err != nil {
+		t.Fatalf("enable: %v", err)
+	}
+
+	// A forced reset blocks login; the fingerprint works once
+	fp, err := admin.ForcePasswordReset(ctx, adminID, userID)
+	if err != nil {
+		t.Fatalf("force reset: %v", err)
+	}
+	if _, _, err := auth.Authenticate(ctx, email, "pass1234"); !errors.Is(err, service.ErrPasswordResetRequired) {
+		t.Fatalf("expected ErrPasswordResetRequired, got %v", err)
+	}
+	if err := auth.ResetPassword(ctx, userID, fp, "newpass123"); err != nil {
+		t.Fatalf("reset: %v", err)
+	}
+	if err := auth.ResetPassword(ctx, userID, fp, "again"); !errors.Is(err, service.ErrInvalidResetToken) {
+		t.Fatalf("expected reused token to fail, got %v", err)
+	}
+	if _, _, err := auth.Authenticate(ctx, email, "newpass123"); err != nil {
+		t.Fatalf("login after reset: %v", err)
+	}
+
+	events, err := admin.AuditLog(ctx, adminID, uuid.NullUUID{UUID: userID, Valid: true}, 10)
+	if err != nil {
+		t.Fatalf("audit: %v", err)
+	}
+	if len(events) != 3 || events[0].Action != service.AuditPasswordReset {
+		t.Fatalf("unexpected audit trail %+v", events)
+	}
+}
+
+func TestAdminService_ChangeRoleRevokesSessions(t *testing.T) {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+	cfg := config.New()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	database, err := db.Connect(ctx, cfg)
+	if err != nil {
+		t.Fatalf("db connect failed: %v", err)
+	}
+	defer database.Close()
+
+	auth := service.NewAuthService(database, cfg)
+	sessions := service.NewSessionService(database)
+	middleware.InitSessions(sessions, 0)
+	t.Cleanup(func() { middleware.InitSessions(nil, 0) })
+
+	adminID, _, err := auth.Register(ctx, "admin-"+uuid.NewString()+"@example.com", "pass1234", "patient")
+	if err != nil {
+		t.Fatalf("register admin: %v", err)
+	}
+	if _, err := database.Queries.UpdateUserRole(ctx, db.UpdateUserRoleParams{ID: adminID, Role: service.RoleAdmin}); err != nil {
+		t.Fatalf("promote: %v", err)
+	}
+	userID, _, err := auth.Register(ctx, "pt-"+uuid.NewString()+"@example.com", "pass1234", "pt")
+	if err != nil {
+		t.Fatalf("register user: %v", err)
+	}
+	sid, err := sessions.Create(ctx, userID, uuid.NullUUID{}, "test", "127.0.0.1", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("create session: %v", err)
+	}
+	token, err := cfg.KeySet().Sign(jwt.MapClaims{
+		"user": map[string]interface{}{"id": userID.String(), "role": "pt"},
+		"sid":  sid.String(),
+		"exp":  time.Now().Add(time.Hour).Unix(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	protected := middleware.JWTAuth(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	call := func() int {
+		req := httptest.NewRequest(http.MethodGet, "/api/profile/me", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rr := httptest.NewRecorder()
+		protected.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	if code := call(); code != http.StatusOK {
+		t.Fatalf("expected the token to work before the change, got %d", code)
+	}
+	if err := service.NewAdminService(database).ChangeRole(ctx, adminID, userID, "patient"); err != nil {
+		t.Fatalf("change role: %v", err)
+	}
+	if code := call(); code != http.StatusUnauthorized {
+		t.Fatalf("expected the token carrying the old role to be rejected, got %d", code)
+	}
+}
+
+func TestAPIKeys_CreateAuthenticateRevoke(t *testing.T) {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+	cfg := config.New()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	database, err := db.Connect(ctx, cfg)
+	if err != nil {
+		t.Fatalf("db connect failed: %v", err)
+	}
+	defer database.Close()
+
+	auth := service.NewAuthService(database, cfg)
+	admin := service.NewAdminService(database)
+	keys := service.NewAPIKeyService(database)
+	suffix := uuid.NewString()
+
+	adminID, _, err := auth.Register(ctx, "keys-admin-"+suffix+"@example.com", "pass1234", "patient")
+	if err != nil {
+		t.Fatalf("register admin: %v", err)
+	}
+	if _, err := database.Queries.UpdateUserRole(ctx, db.UpdateUserRoleParams{ID: adminID, Role: service.RoleAdmin}); err != nil {
+		t.Fatalf("promote: %v", err)
+	}
+	var clinicID uuid.UUID
+	if err := database.SQL.QueryRowContext(ctx, `INSERT INTO clinics (name) VALUES ($1) RETURNING id`, "Clinic "+suffix).Scan(&clinicID); err != nil {
+		t.Fatalf("create clinic: %v", err)
+	}
+
+	created, err := admin.CreateAPIKey(ctx, adminID, clinicID, "EHR sync", []string{service.ScopeAppointmentsRead})
+	if err != nil {
+		t.Fatalf("create key: %v", err)
+	}
+	_, gotClinic, scopes, err := keys.AuthenticateAPIKey(ctx, created.Key)
+	if err != nil || gotClinic != clinicID || len(scopes) != 1 {
+		t.Fatalf("authenticate: %v %v %v", gotClinic, scopes, err)
+	}
+	if _, _, _, err := keys.AuthenticateAPIKey(ctx, created.Key+"x"); !errors.Is(err, service.ErrInvalidAPIKey) {
+		t.Fatalf("expected tampered key to fail, got %v", err)
+	}
+
+	if err := admin.RevokeAPIKey(ctx, adminID, clinicID, created.ID); err != nil {
+		t.Fatalf("revoke: %v", err)
+	}
+	if _, _, _, err := keys.AuthenticateAPIKey(ctx, created.Key); !errors.Is(err, service.ErrInvalidAPIKey) {
+		t.Fatalf("expected revoked key to fail, got %v", err)
+	}
+}
+
+func TestCredentialVerification_RejectThenApprove(t *testing.T) {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+	cfg := config.New()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	database, err := db.Connect(ctx, cfg)
+	if err != nil {
+		t.Fatalf("db connect failed: %v", err)
+	}
+	defer database.Close()
+
+	auth := service.NewAuthService(database, cfg)
+	admin := service.NewAdminService(database)
+	verification := service.NewVerificationService(database)
+	profiles := service.NewProfileService(database, cfg)
+	suffix := uuid.NewString()
+
+	adminID, _, err := auth.Register(ctx, "verify-admin-"+suffix+"@example.com", "pass1234", "patient")
+	if err != nil {
+		t.Fatalf("register admin: %v", err)
+	}
+	if _, err := database.Queries.UpdateUserRole(ctx, db.UpdateUserRoleParams{ID: adminID, Role: service.RoleAdmin}); err != nil {
+		t.Fatalf("promote: %v", err)
+	}
+	ptID, _, err := auth.Register(ctx, "verify-pt-"+suffix+"@example.com", "pass1234", "pt")
+	if err != nil {
+		t.Fatalf("register pt: %v", err)
+	}
+	in := service.CredentialSubmissionInput{
+		LicenceNumber: "PT-" + suffix[:8],
+		IssuingBody:   "HCPC",
+		Documents:     []service.CredentialDocument{{Name: "licence.pdf", URL: "https://files.example.com/" + suffix + ".pdf"}},
+	}
+
+	if _, err := verification.SubmitCredentials(ctx, adminID, in); !errors.Is(err, service.ErrNotTherapist) {
+		t.Fatalf("expected ErrNotTherapist, got %v", err)
+	}
+	first, err := verification.SubmitCredentials(ctx, ptID, in)
+	if err != nil {
+		t.Fatalf("submit: %v", err)
+	}
+	if _, err := verification.SubmitCredentials(ctx, ptID, in); !errors.Is(err, service.ErrSubmissionPending) {
+		t.Fatalf("expected ErrSubmissionPending, got %v", err)
+	}
+	if _, err := admin.ReviewCredentials(ctx, adminID, first.ID, false, "Document is illegible"); err != nil {
+		t.Fatalf("reject: %v", err)
+	}
+	if _, err := admin.ReviewCredentials(ctx, adminID, first.ID, true, ""); !errors.Is(err, service.ErrSubmissionReviewed) {
+		t.Fatalf("expected ErrSubmissionReviewed, got %v", err)
+	}
+
+	second, err := verification.SubmitCredentials(ctx, ptID, in)
+	if err != nil {
+		t.Fatalf("resubmit: %v", err)
+	}
+	queue, err := admin.ListCredentialSubmissions(ctx, adminID, service.CredentialPending, 100)
+	if err != nil {
+		t.Fatalf("list queue: %v", err)
+	}
+	found := false
+	for _, c := range queue {
+		found = found || c.ID == second.ID
+	}
+	if !found {
+		t.Fatalf("resubmission missing from the pending queue")
+	}
+	if _, err := admin.ReviewCredentials(ctx, adminID, second.ID, true, "Checked against the register"); err != nil {
+		t.Fatalf("approve: %v", err)
+	}
+
+	prof, err := profiles.GetProfile(ctx, ptID)
+	if err != nil {
+		t.Fatalf("get profile: %v", err)
+	}
+	if !deref(prof.IsVerified) {
+		t.Fatalf("expected verified profile, got %+v", prof)
+	}
+	history, err := verification.ListMySubmissions(ctx, ptID)
+	if err != nil || len(history) != 2 || history[1].ReviewNotes != "Document is illegible" {
+		t.Fatalf("unexpected history %+v %v", history, err)
+	}
+}
@@ -42,7 +42,7 @@ func TestE2E_RegisterCreateAvailabilityBook(t *testing.T) {
 
 	clk := clock.NewReal()
 
-	handler := testutil.NewRouterWithServices(cfg, database, clk)
+	handler := testutil.NewRouterWithServices(cfg, database, clk, t.TempDir())
 	ts := httptest.NewServer(handler)
 	defer ts.Close()
 	client := ts.Client()
@@ -0,0 +1,96 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/clock"
+	"github.com/divijg19/physiolink/backend/internal/config"
+	"github.com/divijg19/physiolink/backend/internal/db"
+	"github.com/divijg19/physiolink/backend/internal/service"
+)
+
+func TestFavourites_ViewsAndOpenings(t *testing.T) {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+	cfg := config.New()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	database, err := db.Connect(ctx, cfg)
+	if err != nil {
+		t.Fatalf("db connect failed: %v", err)
+	}
+	defer database.Close()
+
+	auth := service.NewAuthService(database, cfg)
+	favs := service.NewFavouriteService(database)
+	register := func(role string) uuid.UUID {
+		id, _, err := auth.Register(ctx, uuid.NewString()+"@example.com", "pass1234", role)
+		if err != nil {
+			t.Fatalf("register: %v", err)
+		}
+		return id
+	}
+	patient, therapist, other := register("patient"), register("pt"), register("patient")
+	if _, err := database.SQL.ExecContext(ctx, `INSERT INTO profiles (user_id, display_name) VALUES ($1, 'Jane Doe')`, therapist); err != nil {
+		t.Fatalf("profile: %v", err)
+	}
+	slot := func(start time.Time) {
+		if _, err := database.SQL.ExecContext(ctx, `INSERT INTO availability_slots (therapist_id, start_ts, end_ts) VALUES ($1, $2, $3)`, therapist, start, start.Add(time.Hour)); err != nil {
+			t.Fatalf("slot: %v", err)
+		}
+	}
+	start := time.Now().UTC().Truncate(time.Hour).Add(48 * time.Hour)
+	// Published before the patient starred the therapist, so never announced
+	slot(start)
+
+	if _, err := favs.AddFavourite(ctx, patient, other); !errors.Is(err, service.ErrTherapistNotFound) {
+		t.Fatalf("expected ErrTherapistNotFound for a patient, got %v", err)
+	}
+	first, err := favs.AddFavourite(ctx, patient, therapist)
+	if err != nil {
+		t.Fatalf("add: %v", err)
+	}
+	if again, err := favs.AddFavourite(ctx, patient, therapist); err != nil || !again.Equal(first) {
+		t.Fatalf("starring twice should keep the original time: %v %v", again, err)
+	}
+	slot(start.Add(time.Hour))
+
+	reminders := service.NewReminderService(database.Queries, clock.NewReal())
+	items, err := reminders.ListForPatient(ctx, patient)
+	if err != nil {
+		t.Fatalf("reminders: %v", err)
+	}
+	if len(items) != 1 || items[0].Kind != service.ReminderOpening || items[0].TherapistID != therapist.String() {
+		t.Fatalf("expected one opening, got %+v", items)
+	}
+
+	list, err := favs.ListFavourites(ctx, patient)
+	if err != nil || len(list) != 1 || deref(list[0].Profile.FirstName) != "Jane" {
+		t.Fatalf("unexpected favourites %+v (%v)", list, err)
+	}
+	if err := favs.RemoveFavourite(ctx, patient, therapist); err != nil {
+		t.Fatalf("remove: %v", err)
+	}
+	if items, _ := reminders.ListForPatient(ctx, patient); len(items) != 0 {
+		t.Fatalf("expected no openings after unstarring, got %+v", items)
+	}
+
+	if err := favs.RecordView(ctx, patient, therapist); err != nil {
+		t.Fatalf("view: %v", err)
+	}
+	if err := favs.RecordView(ctx, patient, therapist); err != nil {
+		t.Fatalf("view again: %v", err)
+	}
+	viewed, err := favs.RecentlyViewed(ctx, patient)
+	if err != nil || len(viewed) != 1 || viewed[0].ID != therapist.String() || viewed[0].ViewedAt == nil {
+		t.Fatalf("unexpected history %+v (%v)", viewed, err)
+	}
+}
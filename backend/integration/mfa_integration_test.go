@@ -0,0 +1,81 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/clock"
+	"github.com/divijg19/physiolink/backend/internal/config"
+	"github.com/divijg19/physiolink/backend/internal/db"
+	"github.com/divijg19/physiolink/backend/internal/service"
+	"github.com/divijg19/physiolink/backend/internal/totp"
+)
+
+func TestMFA_LockoutAndSingleUseChallenges(t *testing.T) {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+	cfg := config.New()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	database, err := db.Connect(ctx, cfg)
+	if err != nil {
+		t.Fatalf("db connect failed: %v", err)
+	}
+	defer database.Close()
+
+	clk := clock.NewFake(time.Now().UTC())
+	mfa := service.NewMFAService(database, clk)
+	userID, _, err := service.NewAuthService(database, cfg).Register(ctx, uuid.NewString()+"@example.com", "pass1234", "pt")
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	enrollment, err := mfa.BeginEnrollment(ctx, userID)
+	if err != nil {
+		t.Fatalf("enroll: %v", err)
+	}
+	code := func() string {
+		// Each accepted TOTP step can't be used again, so move on to the next
+		clk.Set(clk.Now().Add(30 * time.Second))
+		c, err := totp.Code(enrollment.Secret, clk.Now())
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+	if _, err := mfa.ConfirmEnrollment(ctx, userID, code()); err != nil {
+		t.Fatalf("confirm: %v", err)
+	}
+
+	// A challenge allows one attempt
+	challenge, err := mfa.IssueChallenge(ctx, userID, time.Now().Add(time.Minute))
+	if err != nil {
+		t.Fatalf("issue challenge: %v", err)
+	}
+	if err := mfa.ConsumeChallenge(ctx, challenge, userID); err != nil {
+		t.Fatalf("consume: %v", err)
+	}
+	if err := mfa.ConsumeChallenge(ctx, challenge, userID); !errors.Is(err, service.ErrMFAChallengeUsed) {
+		t.Fatalf("expected a replayed challenge to be refused, got %v", err)
+	}
+
+	// Enough wrong codes lock out the right one too
+	for i := 0; i < 5; i++ {
+		if err := mfa.Verify(ctx, userID, "not-a-code"); !errors.Is(err, service.ErrInvalidMFACode) {
+			t.Fatalf("attempt %d: expected ErrInvalidMFACode, got %v", i+1, err)
+		}
+	}
+	if err := mfa.Verify(ctx, userID, code()); !errors.Is(err, service.ErrMFALocked) {
+		t.Fatalf("expected ErrMFALocked, got %v", err)
+	}
+	clk.Set(clk.Now().Add(15 * time.Minute))
+	if err := mfa.Verify(ctx, userID, code()); err != nil {
+		t.Fatalf("expected the lock to lapse, got %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package integration
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/config"
+	"github.com/divijg19/physiolink/backend/internal/db"
+	"github.com/divijg19/physiolink/backend/internal/service"
+)
+
+func TestOIDCSignIn_ProvisionsAndLinks(t *testing.T) {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+	cfg := config.New()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	database, err := db.Connect(ctx, cfg)
+	if err != nil {
+		t.Fatalf("db connect failed: %v", err)
+	}
+	defer database.Close()
+
+	svc := service.NewOIDCService(database)
+	auth := service.NewAuthService(database, cfg)
+	suffix := uuid.NewString()
+
+	// First login provisions a user with the requested role
+	ident := service.ExternalIdentity{Provider: "stub", Subject: "sub-" + suffix, Email: "oidc-" + suffix + "@example.com", EmailVerified: true}
+	id, role, err := svc.SignIn(ctx, ident, "therapist")
+	if err != nil {
+		t.Fatalf("provision: %v", err)
+	}
+	if role != "therapist" {
+		t.Fatalf("expected therapist, got %q", role)
+	}
+	// Provisioned users have no usable password
+	if _, _, err := auth.Authenticate(ctx, ident.Email, ""); !errors.Is(err, service.ErrInvalidCredentials) {
+		t.Fatalf("expected password login to fail, got %v", err)
+	}
+	// Second login resolves the same user, ignoring the requested role
+	again, role, err := svc.SignIn(ctx, ident, "patient")
+	if err != nil || again != id || role != "therapist" {
+		t.Fatalf("expected same user, got %v %q %v", again, role, err)
+	}
+
+	// A verified email links to an account that only signs in by provider
+	other := service.ExternalIdentity{Provider: "other", Subject: "sub-" + suffix, Email: ident.Email}
+	if linked, _, err := svc.SignIn(ctx, other, ""); !errors.Is(err, service.ErrOIDCAccountExists) {
+		t.Fatalf("expected unverified email to be refused, got %v %v", linked, err)
+	}
+	other.EmailVerified = true
+	if linked, _, err := svc.SignIn(ctx, other, ""); err != nil || linked != id {
+		t.Fatalf("expected link to %v, got %v %v", id, linked, err)
+	}
+
+	// A password account is never linked by email, even a verified one
+	email := "linked-" + suffix + "@example.com"
+	existing, _, err := auth.Register(ctx, email, "pass1234", "patient")
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	verified := service.ExternalIdentity{Provider: "stub", Subject: "other-" + suffix, Email: email, EmailVerified: true}
+	if _, _, err := svc.SignIn(ctx, verified, ""); !errors.Is(err, service.ErrOIDCAccountExists) {
+		t.Fatalf("expected a password account to be refused, got %v", err)
+	}
+	// Its owner links the provider from a signed-in session instead
+	if err := svc.LinkIdentity(ctx, existing, verified); err != nil {
+		t.Fatalf("link: %v", err)
+	}
+	if linked, _, err := svc.SignIn(ctx, verified, ""); err != nil || linked != existing {
+		t.Fatalf("expected the linked identity to sign in to %v, got %v %v", existing, linked, err)
+	}
+	if err := svc.LinkIdentity(ctx, id, verified); !errors.Is(err, service.ErrOIDCIdentityInUse) {
+		t.Fatalf("expected an identity of another account to be refused, got %v", err)
+	}
+}
@@ -0,0 +1,121 @@
+package integration
+
+import (
+	"context"
+	"os"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/config"
+	"github.com/divijg19/physiolink/backend/internal/db"
+	"github.com/divijg19/physiolink/backend/internal/service"
+)
+
+func TestProfile_TypedFieldsRoundTrip(t *testing.T) {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+	cfg := config.New()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	database, err := db.Connect(ctx, cfg)
+	if err != nil {
+		t.Fatalf("db connect failed: %v", err)
+	}
+	defer database.Close()
+
+	auth := service.NewAuthService(database, cfg)
+	profiles := service.NewProfileService(database, cfg)
+	id, _, err := auth.Register(ctx, "profile-"+uuid.NewString()+"@example.com", "pass1234", "pt")
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+
+	age, years := 41, 12
+	if _, err := profiles.UpsertProfile(ctx, id, service.NodeProfileUpdate{
+		FirstName:       "Asha",
+		LastName:        "Rao",
+		Age:             &age,
+		Gender:          "female",
+		Credentials:     "DPT",
+		Location:        "Pune",
+		Specialties:     []string{"Sports", "Neuro"},
+		Languages:       []string{"English", "Marathi"},
+		YearsExperience: &years,
+	}); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+
+	// The mobile app only sends the single specialty; the rest is kept
+	if _, err := profiles.UpsertProfile(ctx, id, service.NodeProfileUpdate{
+		FirstName:   "Asha",
+		LastName:    "Rao",
+		Age:         &age,
+		Specialty:   "Orthopedic",
+		Credentials: "DPT, OCS",
+	}); err != nil {
+		t.Fatalf("upsert from mobile: %v", err)
+	}
+
+	prof, err := profiles.GetProfile(ctx, id)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	if deref(prof.Age) != 41 || deref(prof.Credentials) != "DPT, OCS" || deref(prof.Specialty) != "Orthopedic" || deref(prof.YearsOfExperience) != 12 {
+		t.Fatalf("unexpected profile %+v", prof)
+	}
+	if !reflect.DeepEqual(deref(prof.Specialties), []string{"Orthopedic", "Neuro"}) || !reflect.DeepEqual(deref(prof.Languages), []string{"English", "Marathi"}) {
+		t.Fatalf("unexpected lists %v %v", prof.Specialties, prof.Languages)
+	}
+	if prof.Gender != nil {
+		t.Fatalf("expected gender to be cleared, got %v", *prof.Gender)
+	}
+}
+
+func TestProfile_WebEditKeepsAddress(t *testing.T) {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+	cfg := config.New()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	database, err := db.Connect(ctx, cfg)
+	if err != nil {
+		t.Fatalf("db connect failed: %v", err)
+	}
+	defer database.Close()
+
+	auth := service.NewAuthService(database, cfg)
+	profiles := service.NewProfileService(database, cfg)
+	id, _, err := auth.Register(ctx, "profile-"+uuid.NewString()+"@example.com", "pass1234", "pt")
+	if err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	lat, lng := 18.5204, 73.8567
+	if _, err := profiles.UpsertProfile(ctx, id, service.NodeProfileUpdate{
+		FirstName: "Asha",
+		LastName:  "Rao",
+		Address:   &service.Address{City: "Pune", Country: "IN", Latitude: &lat, Longitude: &lng},
+	}); err != nil {
+		t.Fatalf("upsert: %v", err)
+	}
+
+	// The web profile form sends names and bio only
+	if _, err := profiles.UpsertProfile(ctx, id, service.NodeProfileUpdate{FirstName: "Asha", LastName: "Rao-Kale", Bio: "Knees"}); err != nil {
+		t.Fatalf("upsert from web: %v", err)
+	}
+
+	prof, err := profiles.GetProfile(ctx, id)
+	if err != nil {
+		t.Fatalf("get: %v", err)
+	}
+	a := prof.Address
+	if deref(prof.LastName) != "Rao-Kale" || a == nil || deref(a.City) != "Pune" || deref(a.Latitude) != lat || deref(a.Longitude) != lng {
+		t.Fatalf("expected the address and coordinates to be kept, got %+v", a)
+	}
+}
@@ -0,0 +1,76 @@
+package integration
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+
+	"github.com/divijg19/physiolink/backend/internal/clock"
+	"github.com/divijg19/physiolink/backend/internal/config"
+	"github.com/divijg19/physiolink/backend/internal/db"
+	"github.com/divijg19/physiolink/backend/internal/service"
+)
+
+func TestRecommendations_RankMatchingTherapistFirst(t *testing.T) {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+	cfg := config.New()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	database, err := db.Connect(ctx, cfg)
+	if err != nil {
+		t.Fatalf("db connect failed: %v", err)
+	}
+	defer database.Close()
+
+	auth := service.NewAuthService(database, cfg)
+	register := func(role string) uuid.UUID {
+		id, _, err := auth.Register(ctx, uuid.NewString()+"@example.com", "pass1234", role)
+		if err != nil {
+			t.Fatalf("register: %v", err)
+		}
+		return id
+	}
+	patient, therapist := register("patient"), register("pt")
+	// A specialty no other therapist in the database has
+	specialty := "x" + strings.ReplaceAll(uuid.NewString(), "-", "")
+	if _, err := database.SQL.ExecContext(ctx, `INSERT INTO profiles (user_id, display_name, condition, latitude, longitude) VALUES ($1, 'Pat Ient', $2, 12.97, 77.59)`, patient, specialty+" recovery"); err != nil {
+		t.Fatalf("patient profile: %v", err)
+	}
+	if _, err := database.SQL.ExecContext(ctx, `INSERT INTO profiles (user_id, display_name, specialties, latitude, longitude) VALUES ($1, 'Jane Doe', $2, 12.98, 77.60)`, therapist, pq.Array([]string{specialty})); err != nil {
+		t.Fatalf("therapist profile: %v", err)
+	}
+	start := time.Now().UTC().Truncate(time.Hour).Add(24 * time.Hour)
+	if _, err := database.SQL.ExecContext(ctx, `INSERT INTO availability_slots (therapist_id, start_ts, end_ts) VALUES ($1, $2, $3)`, therapist, start, start.Add(time.Hour)); err != nil {
+		t.Fatalf("slot: %v", err)
+	}
+
+	recs, err := service.NewRecommendationService(database, nil, clock.NewReal()).Recommend(ctx, patient, service.RecommendationParams{Limit: 5})
+	if err != nil {
+		t.Fatalf("recommend: %v", err)
+	}
+	if len(recs) == 0 || deref(recs[0].Id) != therapist.String() {
+		t.Fatalf("expected the matching therapist first, got %+v", recs)
+	}
+	got := recs[0]
+	if len(deref(got.MatchedSpecialties)) != 1 || got.DistanceKm == nil || got.NextAvailableAt == nil || deref(got.AvailableSlotsCount) != 1 {
+		t.Fatalf("unexpected recommendation %+v", got)
+	}
+
+	// With nobody nearby, candidates come from further away
+	lat, lng := -52.0, -30.0
+	recs, err = service.NewRecommendationService(database, nil, clock.NewReal()).Recommend(ctx, patient, service.RecommendationParams{Lat: &lat, Lng: &lng, Limit: 5})
+	if err != nil {
+		t.Fatalf("recommend far away: %v", err)
+	}
+	if len(recs) == 0 || deref(recs[0].Id) != therapist.String() || recs[0].DistanceKm == nil || *recs[0].DistanceKm < 50 {
+		t.Fatalf("expected the distant matching therapist first, got %+v", recs)
+	}
+}
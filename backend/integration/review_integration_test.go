@@ -0,0 +1,574 @@
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/clock"
+	"github.com/divijg19/physiolink/backend/internal/config"
+	"github.com/divijg19/physiolink/backend/internal/db"
+	"github.com/divijg19/physiolink/backend/internal/openapi"
+	"github.com/divijg19/physiolink/backend/internal/service"
+)
+
+func TestReviews_OnePerCompletedAppointment(t *testing.T) {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+	cfg := config.New()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	database, err := db.Connect(ctx, cfg)
+	if err != nil {
+		t.Fatalf("db connect failed: %v", err)
+	}
+	defer database.Close()
+
+	auth := service.NewAuthService(database, cfg)
+	register := func(role string) uuid.UUID {
+		id, _, err := auth.Register(ctx, uuid.NewString()+"@example.com", "pass1234", role)
+		if err != nil {
+			t.Fatalf("register: %v", err)
+		}
+		return id
+	}
+	patient, therapist, other := register("patient"), register("pt"), register("patient")
+	if _, err := database.SQL.ExecContext(ctx, `INSERT INTO profiles (user_id, display_name) VALUES ($1, 'Jane Doe')`, therapist); err != nil {
+		t.Fatalf("profile: %v", err)
+	}
+	start := time.Now().UTC().Truncate(time.Hour).Add(24 * time.Hour)
+	appointment := func(start time.Time, status string) uuid.UUID {
+		var slotID, apptID uuid.UUID
+		if err := database.SQL.QueryRowContext(ctx, `INSERT INTO availability_slots (therapist_id, start_ts, end_ts, status) VALUES ($1, $2, $3, 'booked') RETURNING id`,
+			therapist, start, start.Add(time.Hour)).Scan(&slotID); err != nil {
+			t.Fatalf("slot: %v", err)
+		}
+		if err := database.SQL.QueryRowContext(ctx, `INSERT INTO appointments (slot_id, patient_id, therapist_id, status) VALUES ($1, $2, $3, $4) RETURNING id`,
+			slotID, patient, therapist, status).Scan(&apptID); err != nil {
+			t.Fatalf("appointment: %v", err)
+		}
+		return apptID
+	}
+	confirmed := appointment(start, "confirmed")
+	rejected := appointment(start.Add(2*time.Hour), "rejected")
+
+	clk := clock.NewFake(start.Add(30 * time.Minute))
+	reviews := service.NewReviewService(database, clk, cfg.ReviewEditWindow, nil)
+	var forbidden *service.ForbiddenError
+	if _, err := reviews.CreateReview(ctx, patient, service.NewReview{AppointmentID: &confirmed, Rating: 5}); !errors.As(err, &forbidden) {
+		t.Fatalf("expected a session in progress to be unreviewable, got %v", err)
+	}
+
+	clk.Set(start.Add(4 * time.Hour))
+	if _, err := reviews.CreateReview(ctx, patient, service.NewReview{AppointmentID: &rejected, Rating: 5}); !errors.As(err, &forbidden) {
+		t.Fatalf("expected a rejected appointment to be unreviewable, got %v", err)
+	}
+	if _, err := reviews.CreateReview(ctx, other, service.NewReview{AppointmentID: &confirmed, Rating: 5}); !errors.Is(err, service.ErrAppointmentNotFound) {
+		t.Fatalf("expected someone else's appointment to be not found, got %v", err)
+	}
+	if _, err := reviews.CreateReview(ctx, patient, service.NewReview{AppointmentID: &confirmed, Rating: 6}); !errors.Is(err, service.ErrInvalidRating) {
+		t.Fatalf("expected ErrInvalidRating, got %v", err)
+	}
+
+	// Without an appointment, the latest reviewable one with the therapist is used
+	rev, err := reviews.CreateReview(ctx, patient, service.NewReview{TherapistID: therapist, Rating: 4, Comment: "Helpful"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if rev.AppointmentId == nil || *rev.AppointmentId != confirmed.String() {
+		t.Fatalf("expected the confirmed appointment to be reviewed, got %+v", rev)
+	}
+	if _, err := reviews.CreateReview(ctx, patient, service.NewReview{AppointmentID: &confirmed, Rating: 3}); !errors.Is(err, service.ErrAlreadyReviewed) {
+		t.Fatalf("expected ErrAlreadyReviewed, got %v", err)
+	}
+	if _, err := reviews.CreateReview(ctx, patient, service.NewReview{TherapistID: therapist, Rating: 3}); !errors.As(err, &forbidden) {
+		t.Fatalf("expected nothing left to review, got %v", err)
+	}
+
+	list, err := reviews.GetReviewsForTherapist(ctx, therapist)
+	if err != nil || len(list) != 1 || *list[0].Rating != 4 {
+		t.Fatalf("unexpected reviews %+v (%v)", list, err)
+	}
+	var rating string
+	if err := database.SQL.QueryRowContext(ctx, `SELECT rating::text FROM profiles WHERE user_id = $1`, therapist).Scan(&rating); err != nil || rating != "4.00" {
+		t.Fatalf("expected profile rating 4.00, got %q (%v)", rating, err)
+	}
+}
+
+func TestReviews_EditAndWithdrawWithinWindow(t *testing.T) {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+	cfg := config.New()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	database, err := db.Connect(ctx, cfg)
+	if err != nil {
+		t.Fatalf("db connect failed: %v", err)
+	}
+	defer database.Close()
+
+	auth := service.NewAuthService(database, cfg)
+	register := func(role string) uuid.UUID {
+		id, _, err := auth.Register(ctx, uuid.NewString()+"@example.com", "pass1234", role)
+		if err != nil {
+			t.Fatalf("register: %v", err)
+		}
+		return id
+	}
+	patient, therapist, other := register("patient"), register("pt"), register("patient")
+	if _, err := database.SQL.ExecContext(ctx, `INSERT INTO profiles (user_id, display_name) VALUES ($1, 'Jane Doe')`, therapist); err != nil {
+		t.Fatalf("profile: %v", err)
+	}
+	start := time.Now().UTC().Truncate(time.Hour).Add(-48 * time.Hour)
+	appointment := func() uuid.UUID {
+		var slotID, apptID uuid.UUID
+		if err := database.SQL.QueryRowContext(ctx, `INSERT INTO availability_slots (therapist_id, start_ts, end_ts, status) VALUES ($1, $2, $3, 'booked') RETURNING id`,
+			therapist, start, start.Add(time.Hour)).Scan(&slotID); err != nil {
+			t.Fatalf("slot: %v", err)
+		}
+		if err := database.SQL.QueryRowContext(ctx, `INSERT INTO appointments (slot_id, patient_id, therapist_id, status) VALUES ($1, $2, $3, 'completed') RETURNING id`,
+			slotID, patient, therapist).Scan(&apptID); err != nil {
+			t.Fatalf("appointment: %v", err)
+		}
+		return apptID
+	}
+	apptID := appointment()
+
+	// Reviews get created_at from the database clock
+	clk := clock.NewFake(time.Now())
+	reviews := service.NewReviewService(database, clk, time.Hour, nil)
+	rev, err := reviews.CreateReview(ctx, patient, service.NewReview{AppointmentID: &apptID, Rating: 2, Comment: "Late"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	reviewID := uuid.MustParse(*rev.Id)
+	rating := func() sql.NullString {
+		var r sql.NullString
+		if err := database.SQL.QueryRowContext(ctx, `SELECT rating::text FROM profiles WHERE user_id = $1`, therapist).Scan(&r); err != nil {
+			t.Fatalf("rating: %v", err)
+		}
+		return r
+	}
+
+	var forbidden *service.ForbiddenError
+	if _, err := reviews.UpdateReview(ctx, other, reviewID, service.ReviewEdit{Rating: 5}); !errors.As(err, &forbidden) {
+		t.Fatalf("expected only the author to edit, got %v", err)
+	}
+	updated, err := reviews.UpdateReview(ctx, patient, reviewID, service.ReviewEdit{Rating: 4, Comment: "Late, but worth it"})
+	if err != nil || *updated.Rating != 4 || updated.UpdatedAt == nil {
+		t.Fatalf("update: %+v (%v)", updated, err)
+	}
+	if r := rating(); r.String != "4.00" {
+		t.Fatalf("expected profile rating 4.00 after the edit, got %q", r.String)
+	}
+	var kept int
+	if err := database.SQL.QueryRowContext(ctx, `SELECT COUNT(*) FROM review_revisions WHERE review_id = $1 AND rating = 2 AND comment = 'Late' AND action = 'edited'`, reviewID).Scan(&kept); err != nil || kept != 1 {
+		t.Fatalf("expected the replaced version to be kept, got %d (%v)", kept, err)
+	}
+
+	if err := reviews.DeleteReview(ctx, patient, reviewID); err != nil {
+		t.Fatalf("delete: %v", err)
+	}
+	if r := rating(); r.Valid {
+		t.Fatalf("expected the profile rating to be cleared, got %q", r.String)
+	}
+	if err := reviews.DeleteReview(ctx, patient, reviewID); !errors.Is(err, service.ErrReviewNotFound) {
+		t.Fatalf("expected ErrReviewNotFound, got %v", err)
+	}
+	if _, err := reviews.CreateReview(ctx, patient, service.NewReview{AppointmentID: &apptID, Rating: 5}); !errors.Is(err, service.ErrAlreadyReviewed) {
+		t.Fatalf("expected a withdrawn appointment to stay reviewed, got %v", err)
+	}
+
+	// Past the window nothing can be changed
+	late := appointment()
+	if err := database.SQL.QueryRowContext(ctx, `INSERT INTO reviews (appointment_id, patient_id, rating) VALUES ($1, $2, 3) RETURNING id`, late, patient).Scan(&reviewID); err != nil {
+		t.Fatalf("review: %v", err)
+	}
+	clk.Set(time.Now().Add(2 * time.Hour))
+	if _, err := reviews.UpdateReview(ctx, patient, reviewID, service.ReviewEdit{Rating: 5}); !errors.As(err, &forbidden) {
+		t.Fatalf("expected the edit window to have passed, got %v", err)
+	}
+	if err := reviews.DeleteReview(ctx, patient, reviewID); !errors.As(err, &forbidden) {
+		t.Fatalf("expected the edit window to have passed, got %v", err)
+	}
+}
+
+func TestReviews_ModerationQueue(t *testing.T) {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+	cfg := config.New()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	database, err := db.Connect(ctx, cfg)
+	if err != nil {
+		t.Fatalf("db connect failed: %v", err)
+	}
+	defer database.Close()
+
+	auth := service.NewAuthService(database, cfg)
+	register := func(role string) uuid.UUID {
+		id, _, err := auth.Register(ctx, uuid.NewString()+"@example.com", "pass1234", role)
+		if err != nil {
+			t.Fatalf("register: %v", err)
+		}
+		return id
+	}
+	patient, therapist, adminID := register("patient"), register("pt"), register("patient")
+	if _, err := database.Queries.UpdateUserRole(ctx, db.UpdateUserRoleParams{ID: adminID, Role: service.RoleAdmin}); err != nil {
+		t.Fatalf("promote: %v", err)
+	}
+	if _, err := database.SQL.ExecContext(ctx, `INSERT INTO profiles (user_id, display_name) VALUES ($1, 'Jane Doe')`, therapist); err != nil {
+		t.Fatalf("profile: %v", err)
+	}
+	start := time.Now().UTC().Truncate(time.Hour).Add(-48 * time.Hour)
+	appointment := func() uuid.UUID {
+		var slotID, apptID uuid.UUID
+		if err := database.SQL.QueryRowContext(ctx, `INSERT INTO availability_slots (therapist_id, start_ts, end_ts, status) VALUES ($1, $2, $3, 'booked') RETURNING id`,
+			therapist, start, start.Add(time.Hour)).Scan(&slotID); err != nil {
+			t.Fatalf("slot: %v", err)
+		}
+		if err := database.SQL.QueryRowContext(ctx, `INSERT INTO appointments (slot_id, patient_id, therapist_id, status) VALUES ($1, $2, $3, 'completed') RETURNING id`,
+			slotID, patient, therapist).Scan(&apptID); err != nil {
+			t.Fatalf("appointment: %v", err)
+		}
+		return apptID
+	}
+	first, second := appointment(), appointment()
+
+	reviews := service.NewReviewService(database, clock.NewReal(), time.Hour, service.KeywordFilter{Words: []string{"quack"}})
+	admin := service.NewAdminService(database)
+
+	published, err := reviews.CreateReview(ctx, patient, service.NewReview{AppointmentID: &first, Rating: 4, Comment: "Good exercises"})
+	if err != nil || *published.Status != openapi.ReviewStatusPublished {
+		t.Fatalf("create: %+v (%v)", published, err)
+	}
+	held, err := reviews.CreateReview(ctx, patient, service.NewReview{AppointmentID: &second, Rating: 1, Comment: "Total quack"})
+	if err != nil || *held.Status != openapi.ReviewStatusPending {
+		t.Fatalf("expected the flagged review to be held, got %+v (%v)", held, err)
+	}
+	list, err := reviews.GetReviewsForTherapist(ctx, therapist)
+	if err != nil || len(list) != 1 || *list[0].Id != *published.Id {
+		t.Fatalf("expected only the published review to be listed, got %+v (%v)", list, err)
+	}
+
+	heldID, publishedID := uuid.MustParse(*held.Id), uuid.MustParse(*published.Id)
+	var forbidden *service.ForbiddenError
+	if err := reviews.ReportReview(ctx, patient, publishedID, "spam"); !errors.As(err, &forbidden) {
+		t.Fatalf("expected authors not to report their own review, got %v", err)
+	}
+	if err := reviews.ReportReview(ctx, therapist, heldID, "spam"); !errors.Is(err, service.ErrReviewNotFound) {
+		t.Fatalf("expected a held review not to be reportable, got %v", err)
+	}
+	if err := reviews.ReportReview(ctx, therapist, publishedID, "Describes another clinic"); err != nil {
+		t.Fatalf("report: %v", err)
+	}
+	reported, err := admin.ListReviewModerationQueue(ctx, adminID, "", true, 0)
+	if err != nil || len(reported) != 1 || reported[0].ID != publishedID || len(reported[0].Reports) != 1 {
+		t.Fatalf("unexpected reported queue %+v (%v)", reported, err)
+	}
+
+	if _, err := admin.ModerateReview(ctx, adminID, heldID, service.ReviewRestore, ""); !errors.Is(err, service.ErrReviewDecision) {
+		t.Fatalf("expected only hidden reviews to be restorable, got %v", err)
+	}
+	approved, err := admin.ModerateReview(ctx, adminID, heldID, service.ReviewApprove, "Fair criticism")
+	if err != nil || approved.Status != service.ReviewPublished {
+		t.Fatalf("approve: %+v (%v)", approved, err)
+	}
+	hidden, err := admin.ModerateReview(ctx, adminID, publishedID, service.ReviewHide, "About another clinic")
+	if err != nil || hidden.Status != service.ReviewHidden || len(hidden.Reports) != 0 {
+		t.Fatalf("hide: %+v (%v)", hidden, err)
+	}
+	var rating string
+	if err := database.SQL.QueryRowContext(ctx, `SELECT rating::text FROM profiles WHERE user_id = $1`, therapist).Scan(&rating); err != nil || rating != "1.00" {
+		t.Fatalf("expected only the approved review to count, got %q (%v)", rating, err)
+	}
+	if _, err := admin.ModerateReview(ctx, adminID, publishedID, service.ReviewRestore, ""); err != nil {
+		t.Fatalf("restore: %v", err)
+	}
+	events, err := admin.AuditLog(ctx, adminID, uuid.NullUUID{UUID: patient, Valid: true}, 10)
+	if err != nil || len(events) != 3 {
+		t.Fatalf("expected three audited decisions, got %+v (%v)", events, err)
+	}
+}
+
+func TestReviews_TherapistReply(t *testing.T) {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+	cfg := config.New()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	database, err := db.Connect(ctx, cfg)
+	if err != nil {
+		t.Fatalf("db connect failed: %v", err)
+	}
+	defer database.Close()
+
+	auth := service.NewAuthService(database, cfg)
+	register := func(role string) uuid.UUID {
+		id, _, err := auth.Register(ctx, uuid.NewString()+"@example.com", "pass1234", role)
+		if err != nil {
+			t.Fatalf("register: %v", err)
+		}
+		return id
+	}
+	patient, therapist, other := register("patient"), register("pt"), register("pt")
+	if _, err := database.SQL.ExecContext(ctx, `INSERT INTO profiles (user_id, display_name) VALUES ($1, 'Jane Doe')`, therapist); err != nil {
+		t.Fatalf("profile: %v", err)
+	}
+	start := time.Now().UTC().Truncate(time.Hour).Add(-48 * time.Hour)
+	var slotID, apptID uuid.UUID
+	if err := database.SQL.QueryRowContext(ctx, `INSERT INTO availability_slots (therapist_id, start_ts, end_ts, status) VALUES ($1, $2, $3, 'booked') RETURNING id`,
+		therapist, start, start.Add(time.Hour)).Scan(&slotID); err != nil {
+		t.Fatalf("slot: %v", err)
+	}
+	if err := database.SQL.QueryRowContext(ctx, `INSERT INTO appointments (slot_id, patient_id, therapist_id, status) VALUES ($1, $2, $3, 'completed') RETURNING id`,
+		slotID, patient, therapist).Scan(&apptID); err != nil {
+		t.Fatalf("appointment: %v", err)
+	}
+
+	reviews := service.NewReviewService(database, clock.NewReal(), time.Hour, nil)
+	rev, err := reviews.CreateReview(ctx, patient, service.NewReview{AppointmentID: &apptID, Rating: 5, Comment: "Great"})
+	if err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	revID := uuid.MustParse(*rev.Id)
+
+	var forbidden *service.ForbiddenError
+	if _, err := reviews.ReplyToReview(ctx, other, revID, "Thanks!"); !errors.As(err, &forbidden) {
+		t.Fatalf("expected other therapists not to reply, got %v", err)
+	}
+	if _, err := reviews.ReplyToReview(ctx, therapist, revID, "  "); !errors.Is(err, service.ErrReplyEmpty) {
+		t.Fatalf("expected an empty reply to be rejected, got %v", err)
+	}
+	if _, err := reviews.ReplyToReview(ctx, therapist, revID, "Thanks, keep up the exercises"); err != nil {
+		t.Fatalf("reply: %v", err)
+	}
+	if _, err := reviews.ReplyToReview(ctx, therapist, revID, "Again"); !errors.Is(err, service.ErrAlreadyReplied) {
+		t.Fatalf("expected one reply per review, got %v", err)
+	}
+
+	list, err := reviews.GetReviewsForTherapist(ctx, therapist)
+	if err != nil || len(list) != 1 || list[0].Reply == nil || *list[0].Reply.Body != "Thanks, keep up the exercises" {
+		t.Fatalf("expected the reply nested in the review, got %+v (%v)", list, err)
+	}
+
+	items, err := service.NewReminderService(database.Queries, clock.NewReal()).ListForPatient(ctx, patient)
+	if err != nil {
+		t.Fatalf("reminders: %v", err)
+	}
+	var notified bool
+	for _, it := range items {
+		notified = notified || it.Kind == service.ReminderReply && it.ReviewID == revID.String() && it.Message == "Jane Doe replied to your review"
+	}
+	if !notified {
+		t.Fatalf("expected the patient to be notified of the reply, got %+v", items)
+	}
+}
+
+func TestReviews_RatingTotals(t *testing.T) {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+	cfg := config.New()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	database, err := db.Connect(ctx, cfg)
+	if err != nil {
+		t.Fatalf("db connect failed: %v", err)
+	}
+	defer database.Close()
+
+	auth := service.NewAuthService(database, cfg)
+	register := func(role string) uuid.UUID {
+		id, _, err := auth.Register(ctx, uuid.NewString()+"@example.com", "pass1234", role)
+		if err != nil {
+			t.Fatalf("register: %v", err)
+		}
+		return id
+	}
+	// A specialty unique to this run keeps other therapists out of the sort
+	specialty := "ratings-" + uuid.NewString()
+	patient, newcomer, established := register("patient"), register("pt"), register("pt")
+	for name, id := range map[string]uuid.UUID{"New Comer": newcomer, "Old Hand": established} {
+		if _, err := database.SQL.ExecContext(ctx, `INSERT INTO profiles (user_id, display_name, specialties) VALUES ($1, $2, ARRAY[$3])`, id, name, specialty); err != nil {
+			t.Fatalf("profile: %v", err)
+		}
+	}
+	start := time.Now().UTC().Truncate(time.Hour).Add(-48 * time.Hour)
+	appointment := func(therapist uuid.UUID) uuid.UUID {
+		var slotID, apptID uuid.UUID
+		if err := database.SQL.QueryRowContext(ctx, `INSERT INTO availability_slots (therapist_id, start_ts, end_ts, status) VALUES ($1, $2, $3, 'booked') RETURNING id`,
+			therapist, start, start.Add(time.Hour)).Scan(&slotID); err != nil {
+			t.Fatalf("slot: %v", err)
+		}
+		if err := database.SQL.QueryRowContext(ctx, `INSERT INTO appointments (slot_id, patient_id, therapist_id, status) VALUES ($1, $2, $3, 'completed') RETURNING id`,
+			slotID, patient, therapist).Scan(&apptID); err != nil {
+			t.Fatalf("appointment: %v", err)
+		}
+		return apptID
+	}
+
+	reviews := service.NewReviewService(database, clock.NewReal(), time.Hour, nil)
+	first := appointment(newcomer)
+	if _, err := reviews.CreateReview(ctx, patient, service.NewReview{AppointmentID: &first, Rating: 5}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	// Reviews posted at once must all be counted
+	ratings := []int{5, 5, 5, 5, 5, 5, 5, 5, 4, 4}
+	ids := make([]string, len(ratings))
+	appts := make([]uuid.UUID, len(ratings))
+	for i := range appts {
+		appts[i] = appointment(established)
+	}
+	var wg sync.WaitGroup
+	errs := make(chan error, len(ratings))
+	for i, rating := range ratings {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			rev, err := reviews.CreateReview(ctx, patient, service.NewReview{AppointmentID: &appts[i], Rating: rating})
+			if err != nil {
+				errs <- err
+				return
+			}
+			ids[i] = *rev.Id
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatalf("concurrent create: %v", err)
+	}
+
+	therapists := service.NewTherapistService(database)
+	detail, err := therapists.GetTherapistByID(ctx, established.String(), "")
+	if err != nil {
+		t.Fatalf("detail: %v", err)
+	}
+	r := detail.Ratings
+	if r == nil || *r.Count != 10 || *r.Average != 4.8 || !reflect.DeepEqual(*r.Histogram, []int{0, 0, 0, 2, 8}) {
+		t.Fatalf("unexpected rating summary %+v", r)
+	}
+
+	// One perfect review doesn't outrank a long, slightly lower record
+	res, err := therapists.GetAllTherapists(ctx, service.TherapistQueryParams{Specialty: specialty, Sort: service.SortRating})
+	if err != nil || len(deref(res.Data)) != 2 || deref(deref(res.Data)[0].Id) != established.String() {
+		t.Fatalf("expected the established therapist first, got %+v (%v)", res, err)
+	}
+
+	if err := reviews.DeleteReview(ctx, patient, uuid.MustParse(ids[len(ids)-1])); err != nil {
+		t.Fatalf("withdraw: %v", err)
+	}
+	detail, err = therapists.GetTherapistByID(ctx, established.String(), "")
+	if err != nil || *detail.ReviewCount != 9 || !reflect.DeepEqual(*detail.Ratings.Histogram, []int{0, 0, 0, 1, 8}) {
+		t.Fatalf("expected the withdrawn review to be taken out, got %+v (%v)", detail.Ratings, err)
+	}
+	var rating string
+	if err := database.SQL.QueryRowContext(ctx, `SELECT rating::text FROM profiles WHERE user_id = $1`, established).Scan(&rating); err != nil || rating != "4.89" {
+		t.Fatalf("expected profile rating 4.89, got %q (%v)", rating, err)
+	}
+}
+
+func TestReviews_SubRatings(t *testing.T) {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+	cfg := config.New()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	database, err := db.Connect(ctx, cfg)
+	if err != nil {
+		t.Fatalf("db connect failed: %v", err)
+	}
+	defer database.Close()
+
+	auth := service.NewAuthService(database, cfg)
+	patient, _, err := auth.Register(ctx, uuid.NewString()+"@example.com", "pass1234", "patient")
+	if err != nil {
+		t.Fatalf("register patient: %v", err)
+	}
+	therapist, _, err := auth.Register(ctx, uuid.NewString()+"@example.com", "pass1234", "pt")
+	if err != nil {
+		t.Fatalf("register therapist: %v", err)
+	}
+	start := time.Now().UTC().Truncate(time.Hour).Add(-48 * time.Hour)
+	appointment := func() uuid.UUID {
+		var slotID, apptID uuid.UUID
+		if err := database.SQL.QueryRowContext(ctx, `INSERT INTO availability_slots (therapist_id, start_ts, end_ts, status) VALUES ($1, $2, $3, 'booked') RETURNING id`,
+			therapist, start, start.Add(time.Hour)).Scan(&slotID); err != nil {
+			t.Fatalf("slot: %v", err)
+		}
+		if err := database.SQL.QueryRowContext(ctx, `INSERT INTO appointments (slot_id, patient_id, therapist_id, status) VALUES ($1, $2, $3, 'completed') RETURNING id`,
+			slotID, patient, therapist).Scan(&apptID); err != nil {
+			t.Fatalf("appointment: %v", err)
+		}
+		return apptID
+	}
+
+	reviews := service.NewReviewService(database, clock.NewReal(), time.Hour, nil)
+	create := func(rating int, sub service.SubRatings) openapi.Review {
+		appt := appointment()
+		rev, err := reviews.CreateReview(ctx, patient, service.NewReview{AppointmentID: &appt, Rating: rating, SubRatings: sub})
+		if err != nil {
+			t.Fatalf("create: %v", err)
+		}
+		return rev
+	}
+	first := create(4, service.SubRatings{Communication: 5, Punctuality: 4})
+	if first.SubRatings == nil || *first.SubRatings.Communication != 5 || first.SubRatings.Facility != nil {
+		t.Fatalf("unexpected sub-ratings in response %+v", first.SubRatings)
+	}
+	second := create(3, service.SubRatings{Communication: 3})
+	if rev := create(5, service.SubRatings{}); rev.SubRatings != nil {
+		t.Fatalf("expected no sub-ratings, got %+v", *rev.SubRatings)
+	}
+	appt := appointment()
+	if _, err := reviews.CreateReview(ctx, patient, service.NewReview{AppointmentID: &appt, Rating: 4, SubRatings: service.SubRatings{Facility: 6}}); !errors.Is(err, service.ErrInvalidRating) {
+		t.Fatalf("expected ErrInvalidRating for a sub-rating of 6, got %v", err)
+	}
+
+	therapists := service.NewTherapistService(database)
+	averages := func() openapi.SubRatingAverages {
+		t.Helper()
+		detail, err := therapists.GetTherapistByID(ctx, therapist.String(), "")
+		if err != nil {
+			t.Fatalf("detail: %v", err)
+		}
+		if detail.Ratings == nil || detail.Ratings.SubRatings == nil {
+			return openapi.SubRatingAverages{}
+		}
+		return *detail.Ratings.SubRatings
+	}
+	// Each aspect is averaged over the reviews that rated it
+	if a := averages(); a.Communication == nil || *a.Communication != 4 || a.Punctuality == nil || *a.Punctuality != 4 || a.Effectiveness != nil || a.Facility != nil {
+		t.Fatalf("unexpected averages %+v", a)
+	}
+
+	if _, err := reviews.UpdateReview(ctx, patient, uuid.MustParse(*second.Id), service.ReviewEdit{Rating: 3, SubRatings: service.SubRatings{Communication: 5, Facility: 2}}); err != nil {
+		t.Fatalf("update: %v", err)
+	}
+	if err := reviews.DeleteReview(ctx, patient, uuid.MustParse(*first.Id)); err != nil {
+		t.Fatalf("withdraw: %v", err)
+	}
+	if a := averages(); a.Communication == nil || *a.Communication != 5 || a.Facility == nil || *a.Facility != 2 || a.Punctuality != nil {
+		t.Fatalf("expected averages to follow the edit and withdrawal, got %+v", a)
+	}
+}
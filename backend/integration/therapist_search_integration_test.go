@@ -0,0 +1,286 @@
+package integration
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"os"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/config"
+	"github.com/divijg19/physiolink/backend/internal/db"
+	"github.com/divijg19/physiolink/backend/internal/service"
+)
+
+func TestTherapistSearch_AvailableAndSort(t *testing.T) {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+	cfg := config.New()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	database, err := db.Connect(ctx, cfg)
+	if err != nil {
+		t.Fatalf("db connect failed: %v", err)
+	}
+	defer database.Close()
+
+	auth := service.NewAuthService(database, cfg)
+	svc := service.NewTherapistService(database)
+	// A specialty unique to this run keeps other rows out of the results
+	specialty := "search-" + uuid.NewString()
+	tomorrow := time.Now().UTC().Truncate(24 * time.Hour).Add(36 * time.Hour)
+
+	therapist := func(name string, slot *time.Time) uuid.UUID {
+		id, _, err := auth.Register(ctx, uuid.NewString()+"@example.com", "pass1234", "pt")
+		if err != nil {
+			t.Fatalf("register: %v", err)
+		}
+		if _, err := database.SQL.ExecContext(ctx, `INSERT INTO profiles (user_id, display_name, specialties) VALUES ($1, $2, ARRAY[$3])`, id, name, specialty); err != nil {
+			t.Fatalf("profile: %v", err)
+		}
+		if slot != nil {
+			if _, err := database.SQL.ExecContext(ctx, `INSERT INTO availability_slots (therapist_id, start_ts, end_ts) VALUES ($1, $2, $3)`, id, *slot, slot.Add(time.Hour)); err != nil {
+				t.Fatalf("slot: %v", err)
+			}
+		}
+		return id
+	}
+	later := tomorrow.Add(2 * time.Hour)
+	therapist("Zed Open", &tomorrow)
+	therapist("Amy Open", &later)
+	booked := therapist("Bob Booked", nil)
+
+	res, err := svc.GetAllTherapists(ctx, service.TherapistQueryParams{Specialty: specialty, Sort: service.SortName})
+	if err != nil {
+		t.Fatalf("list: %v", err)
+	}
+	if deref(res.Total) != 3 || deref(deref(res.Data)[0].Profile.FirstName) != "Amy" || deref(deref(res.Data)[2].Profile.FirstName) != "Zed" {
+		t.Fatalf("unexpected name order: %+v", res)
+	}
+
+	res, err = svc.GetAllTherapists(ctx, service.TherapistQueryParams{Specialty: specialty, Available: true, Sort: service.SortAvailability, Limit: 1})
+	if err != nil {
+		t.Fatalf("list available: %v", err)
+	}
+	if deref(res.Total) != 2 || deref(res.TotalPages) != 2 || len(deref(res.Data)) != 1 || deref(deref(res.Data)[0].Profile.FirstName) != "Zed" {
+		t.Fatalf("unexpected available page: %+v", res)
+	}
+
+	res, err = svc.GetAllTherapists(ctx, service.TherapistQueryParams{Specialty: specialty, Available: true, Date: tomorrow.AddDate(0, 0, 1).Format(time.DateOnly)})
+	if err != nil || deref(res.Total) != 0 {
+		t.Fatalf("expected nobody available the day after, got %+v (%v)", res, err)
+	}
+
+	if _, err := svc.GetAllTherapists(ctx, service.TherapistQueryParams{Sort: "price"}); !errors.Is(err, service.ErrInvalidSort) {
+		t.Fatalf("expected ErrInvalidSort, got %v", err)
+	}
+
+	// Disabled therapists drop out of the results and the count, and can't
+	// be fetched by id
+	if _, err := database.SQL.ExecContext(ctx, `UPDATE users SET disabled_at = now() WHERE id = $1`, booked); err != nil {
+		t.Fatalf("disable: %v", err)
+	}
+	res, err = svc.GetAllTherapists(ctx, service.TherapistQueryParams{Specialty: specialty, Sort: service.SortName})
+	if err != nil || deref(res.Total) != 2 || len(deref(res.Data)) != 2 || deref(deref(res.Data)[1].Profile.FirstName) != "Zed" {
+		t.Fatalf("expected the disabled therapist to be left out, got %+v (%v)", res, err)
+	}
+	if _, err := svc.GetTherapistByID(ctx, booked.String(), ""); err == nil {
+		t.Fatalf("expected a disabled therapist to be not found")
+	}
+	if _, err := database.Queries.GetTherapistByID(ctx, booked); !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("expected sql.ErrNoRows for a disabled therapist, got %v", err)
+	}
+}
+
+func TestTherapistSearch_FullText(t *testing.T) {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+	cfg := config.New()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	database, err := db.Connect(ctx, cfg)
+	if err != nil {
+		t.Fatalf("db connect failed: %v", err)
+	}
+	defer database.Close()
+
+	auth := service.NewAuthService(database, cfg)
+	svc := service.NewTherapistService(database)
+	// A made-up word unique to this run stands in for a rare search term
+	term := "zq" + strings.ReplaceAll(uuid.NewString()[:8], "-", "")
+
+	profile := func(name, bio, credentials string) {
+		id, _, err := auth.Register(ctx, uuid.NewString()+"@example.com", "pass1234", "pt")
+		if err != nil {
+			t.Fatalf("register: %v", err)
+		}
+		if _, err := database.SQL.ExecContext(ctx,
+			`INSERT INTO profiles (user_id, display_name, bio, credentials) VALUES ($1, $2, $3, NULLIF($4, ''))`,
+			id, name, bio, credentials); err != nil {
+			t.Fatalf("profile: %v", err)
+		}
+	}
+	profile("Bio Match", "Works with runners on "+term+" rehab", "DPT")
+	profile(term+" Name", "General practice", "")
+	profile("No Match", "Shoulder rehab", "")
+
+	res, err := svc.GetAllTherapists(ctx, service.TherapistQueryParams{Query: term})
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if deref(res.Total) != 2 || len(deref(res.Data)) != 2 {
+		t.Fatalf("expected 2 matches, got %+v", res)
+	}
+	// Name carries more weight than bio
+	if deref(deref(res.Data)[1].Profile.FirstName) != "Bio" || !strings.Contains(deref(deref(res.Data)[1].Snippet), "<mark>"+term+"</mark>") {
+		t.Fatalf("unexpected ranking or snippet: %+v", res.Data)
+	}
+}
+
+func TestTherapistSearch_NearMe(t *testing.T) {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+	cfg := config.New()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	database, err := db.Connect(ctx, cfg)
+	if err != nil {
+		t.Fatalf("db connect failed: %v", err)
+	}
+	defer database.Close()
+
+	auth := service.NewAuthService(database, cfg)
+	profiles := service.NewProfileService(database, cfg)
+	svc := service.NewTherapistService(database)
+	specialty := "geo-" + uuid.NewString()
+
+	therapist := func(first string, lat, lng float64) {
+		id, _, err := auth.Register(ctx, uuid.NewString()+"@example.com", "pass1234", "pt")
+		if err != nil {
+			t.Fatalf("register: %v", err)
+		}
+		_, err = profiles.UpsertProfile(ctx, id, service.NodeProfileUpdate{
+			FirstName: first,
+			Specialty: specialty,
+			Address:   &service.Address{City: "Bengaluru", Country: "IN", Latitude: &lat, Longitude: &lng},
+		})
+		if err != nil {
+			t.Fatalf("profile: %v", err)
+		}
+	}
+	// Roughly 1km, 8km and 300km from the search point
+	therapist("Near", 12.98, 77.59)
+	therapist("Mid", 13.04, 77.59)
+	therapist("Far", 15.66, 77.59)
+
+	lat, lng := 12.971, 77.59
+	res, err := svc.GetAllTherapists(ctx, service.TherapistQueryParams{Specialty: specialty, Lat: &lat, Lng: &lng, RadiusKm: 10})
+	if err != nil {
+		t.Fatalf("search: %v", err)
+	}
+	if deref(res.Total) != 2 || len(deref(res.Data)) != 2 || deref(deref(res.Data)[0].Profile.FirstName) != "Near" {
+		t.Fatalf("unexpected results: %+v", res)
+	}
+	if d := deref(res.Data)[0].DistanceKm; d == nil || *d < 0.5 || *d > 1.5 {
+		t.Fatalf("unexpected distance %v", d)
+	}
+	addr := deref(res.Data)[0].Profile.Address
+	if addr == nil || deref(addr.City) != "Bengaluru" || addr.Latitude == nil {
+		t.Fatalf("expected structured address, got %#v", addr)
+	}
+
+	// Without a radius every therapist is returned, nearest first
+	res, err = svc.GetAllTherapists(ctx, service.TherapistQueryParams{Specialty: specialty, Lat: &lat, Lng: &lng})
+	if err != nil || deref(res.Total) != 3 || deref(deref(res.Data)[2].Profile.FirstName) != "Far" {
+		t.Fatalf("unexpected unbounded results: %+v (%v)", res, err)
+	}
+}
+
+func TestTherapistSearch_PriceInsurerLanguage(t *testing.T) {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+	cfg := config.New()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	database, err := db.Connect(ctx, cfg)
+	if err != nil {
+		t.Fatalf("db connect failed: %v", err)
+	}
+	defer database.Close()
+
+	auth := service.NewAuthService(database, cfg)
+	profiles := service.NewProfileService(database, cfg)
+	svc := service.NewTherapistService(database)
+	// Insurer and language names unique to this run keep other rows out
+	insurer := "Insurer " + uuid.NewString()[:8]
+	language := "Lang" + uuid.NewString()[:8]
+
+	therapist := func(name string, price float64, insurers, languages []string) uuid.UUID {
+		id, _, err := auth.Register(ctx, uuid.NewString()+"@example.com", "pass1234", "pt")
+		if err != nil {
+			t.Fatalf("register: %v", err)
+		}
+		if _, err := profiles.UpsertProfile(ctx, id, service.NodeProfileUpdate{
+			FirstName:    name,
+			SessionPrice: &price,
+			Currency:     "gbp",
+			Insurers:     insurers,
+			Languages:    languages,
+		}); err != nil {
+			t.Fatalf("profile: %v", err)
+		}
+		return id
+	}
+	cheap := therapist("Cheap", 45, []string{insurer}, []string{language, "English"})
+	therapist("Dear", 120, []string{insurer, "Bupa"}, []string{"English"})
+	therapist("Other", 50, []string{"Bupa"}, []string{language})
+
+	// Insurer names match regardless of case
+	res, err := svc.GetAllTherapists(ctx, service.TherapistQueryParams{Insurer: strings.ToUpper(insurer)})
+	if err != nil || deref(res.Total) != 2 {
+		t.Fatalf("insurer filter: %+v %v", res, err)
+	}
+	maxPrice := 100.0
+	res, err = svc.GetAllTherapists(ctx, service.TherapistQueryParams{Insurer: insurer, MaxPrice: &maxPrice, Currency: "GBP"})
+	if err != nil || deref(res.Total) != 1 || deref(deref(res.Data)[0].Id) != cheap.String() {
+		t.Fatalf("price filter: %+v %v", res, err)
+	}
+	if deref(deref(res.Data)[0].Profile.SessionPrice) != 45.0 || deref(deref(res.Data)[0].Profile.Currency) != "GBP" {
+		t.Fatalf("unexpected price fields %+v", deref(res.Data)[0].Profile)
+	}
+	res, err = svc.GetAllTherapists(ctx, service.TherapistQueryParams{Language: strings.ToLower(language), Insurer: insurer})
+	if err != nil || deref(res.Total) != 1 || deref(deref(res.Data)[0].Id) != cheap.String() {
+		t.Fatalf("language filter: %+v %v", res, err)
+	}
+
+	detail, err := svc.GetTherapistByID(ctx, cheap.String(), "")
+	if err != nil {
+		t.Fatalf("detail: %v", err)
+	}
+	prof := deref(detail.Profile)
+	if deref(prof.SessionPrice) != 45.0 || !reflect.DeepEqual(deref(prof.Insurers), []string{insurer}) || !reflect.DeepEqual(deref(prof.Languages), []string{language, "English"}) {
+		t.Fatalf("unexpected detail profile %+v", prof)
+	}
+}
+
+// deref reads an optional model field, giving the zero value when unset.
+func deref[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
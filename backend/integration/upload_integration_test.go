@@ -0,0 +1,134 @@
+package integration
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/blob"
+	"github.com/divijg19/physiolink/backend/internal/clock"
+	"github.com/divijg19/physiolink/backend/internal/config"
+	"github.com/divijg19/physiolink/backend/internal/db"
+	"github.com/divijg19/physiolink/backend/internal/service"
+)
+
+func TestUploads_AvatarAndDocument(t *testing.T) {
+	dbURL := os.Getenv("DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("DATABASE_URL not set")
+	}
+	cfg := config.New()
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	database, err := db.Connect(ctx, cfg)
+	if err != nil {
+		t.Fatalf("db connect failed: %v", err)
+	}
+	defer database.Close()
+
+	store := blob.NewLocal(t.TempDir(), "/files", []byte("test-key"), clock.NewReal())
+	uploads := service.NewUploadService(database, store)
+	auth := service.NewAuthService(database, cfg)
+	patient, _, err := auth.Register(ctx, "upload-"+uuid.NewString()+"@example.com", "pass1234", "patient")
+	if err != nil {
+		t.Fatalf("register patient: %v", err)
+	}
+	therapist, _, err := auth.Register(ctx, "upload-"+uuid.NewString()+"@example.com", "pass1234", "pt")
+	if err != nil {
+		t.Fatalf("register therapist: %v", err)
+	}
+
+	var pic bytes.Buffer
+	if err := png.Encode(&pic, image.NewRGBA(image.Rect(0, 0, 1600, 800))); err != nil {
+		t.Fatal(err)
+	}
+	avatar, err := uploads.UploadAvatar(ctx, patient, "me.png", &pic)
+	if err != nil {
+		t.Fatalf("upload avatar: %v", err)
+	}
+	if len(avatar.Thumbnails) != len(service.AvatarSizes) || avatar.ContentType != "image/png" {
+		t.Fatalf("unexpected avatar %+v", avatar)
+	}
+	prof, err := service.NewProfileService(database, cfg).GetProfile(ctx, patient)
+	if err != nil || deref(prof.ProfileImageUrl) != avatar.URL {
+		t.Fatalf("profile image not set: %v %v", prof.ProfileImageUrl, err)
+	}
+
+	// Avatars are visible to anyone signed in; the thumbnail is 64x64
+	link, err := uploads.DownloadURL(ctx, therapist, avatar.ID, "64")
+	if err != nil {
+		t.Fatalf("thumbnail url: %v", err)
+	}
+	body := fetch(t, store, link)
+	thumb, err := png.Decode(bytes.NewReader(body))
+	if err != nil || thumb.Bounds().Dx() != 64 || thumb.Bounds().Dy() != 64 {
+		t.Fatalf("unexpected thumbnail %v %v", thumb, err)
+	}
+	if _, err := uploads.DownloadURL(ctx, patient, avatar.ID, "999"); !errors.Is(err, service.ErrUnknownUploadSize) {
+		t.Fatalf("expected ErrUnknownUploadSize, got %v", err)
+	}
+
+	// Only therapists upload documents, and only they (or an admin) read them
+	pdf := "%PDF-1.4\n%test\n"
+	if _, err := uploads.UploadDocument(ctx, patient, "x.pdf", strings.NewReader(pdf)); !errors.Is(err, service.ErrNotTherapist) {
+		t.Fatalf("expected ErrNotTherapist, got %v", err)
+	}
+	doc, err := uploads.UploadDocument(ctx, therapist, "licence.pdf", strings.NewReader(pdf))
+	if err != nil {
+		t.Fatalf("upload document: %v", err)
+	}
+	if _, err := uploads.DownloadURL(ctx, patient, doc.ID, ""); !errors.Is(err, service.ErrUploadNotFound) {
+		t.Fatalf("expected document to be hidden from others, got %v", err)
+	}
+	link, err = uploads.DownloadURL(ctx, therapist, doc.ID, "")
+	if err != nil {
+		t.Fatalf("document url: %v", err)
+	}
+	if got := fetch(t, store, link); string(got) != pdf {
+		t.Fatalf("unexpected document body %q", got)
+	}
+
+	// Credential submissions can reference the upload instead of a URL
+	sub, err := service.NewVerificationService(database).SubmitCredentials(ctx, therapist, service.CredentialSubmissionInput{
+		LicenceNumber: "PT-42",
+		IssuingBody:   "HCPC",
+		Documents:     []service.CredentialDocument{{Name: "Licence", UploadID: &doc.ID}},
+	})
+	if err != nil || sub.Documents[0].URL != doc.URL {
+		t.Fatalf("submit with upload: %+v %v", sub, err)
+	}
+	if _, err := service.NewVerificationService(database).SubmitCredentials(ctx, therapist, service.CredentialSubmissionInput{
+		LicenceNumber: "PT-42",
+		IssuingBody:   "HCPC",
+		Documents:     []service.CredentialDocument{{Name: "Avatar", UploadID: &avatar.ID}},
+	}); !errors.Is(err, service.ErrInvalidDocument) {
+		t.Fatalf("expected someone else's upload to be refused, got %v", err)
+	}
+}
+
+// fetch follows a local signed link through the store's handler.
+func fetch(t *testing.T, store *blob.Local, link string) []byte {
+	t.Helper()
+	u, err := url.Parse(link)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u.Path = strings.TrimPrefix(u.Path, "/files")
+	rr := httptest.NewRecorder()
+	store.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, u.String(), nil))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("fetch %s: %d", link, rr.Code)
+	}
+	return rr.Body.Bytes()
+}
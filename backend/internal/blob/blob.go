@@ -0,0 +1,43 @@
+// Package blob stores uploaded files and hands out short-lived links to
+// them. Objects are addressed by slash-separated keys; the database keeps
+// the metadata.
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"time"
+)
+
+var ErrInvalidKey = errors.New("invalid blob key")
+
+// BlobStore is implemented by the local filesystem and S3-compatible stores.
+type BlobStore interface {
+	// Put stores size bytes from body under key, replacing any existing object.
+	Put(ctx context.Context, key, contentType string, body io.Reader, size int64) error
+	// SignedURL returns a link that downloads key without other credentials
+	// until ttl has passed.
+	SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error)
+	Delete(ctx context.Context, key string) error
+}
+
+// checkKey rejects keys that could escape the store's root or aren't
+// portable between backends.
+func checkKey(key string) error {
+	if key == "" || len(key) > 1024 || strings.HasPrefix(key, "/") || strings.HasSuffix(key, "/") {
+		return ErrInvalidKey
+	}
+	for _, part := range strings.Split(key, "/") {
+		if part == "" || part == "." || part == ".." {
+			return ErrInvalidKey
+		}
+	}
+	for _, r := range key {
+		if r < 0x20 || r == 0x7f || r == '\\' {
+			return ErrInvalidKey
+		}
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package blob
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"io"
+	"io/fs"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/divijg19/physiolink/backend/internal/clock"
+)
+
+// Local keeps objects under a directory and serves them itself. Its signed
+// URLs point at baseURL (where the Local handler is mounted) and carry an
+// HMAC of the key and expiry.
+type Local struct {
+	dir     string
+	baseURL string
+	key     []byte
+	clk     clock.Clock
+}
+
+// NewLocal stores files under dir. baseURL is where ServeHTTP is reachable,
+// e.g. "https://api.example.com/files"; signingKey authenticates its links.
+func NewLocal(dir, baseURL string, signingKey []byte, clk clock.Clock) *Local {
+	return &Local{dir: dir, baseURL: strings.TrimSuffix(baseURL, "/"), key: signingKey, clk: clk}
+}
+
+func (l *Local) Put(ctx context.Context, key, contentType string, body io.Reader, size int64) error {
+	p, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(p), 0o750); err != nil {
+		return err
+	}
+	// Write to a temporary file first so readers never see a partial object
+	f, err := os.CreateTemp(filepath.Dir(p), ".upload-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	n, err := io.Copy(f, body)
+	if cerr := f.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return err
+	}
+	if n != size {
+		return errors.New("blob: short write")
+	}
+	return os.Rename(f.Name(), p)
+}
+
+func (l *Local) SignedURL(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+	exp := strconv.FormatInt(l.clk.Now().Add(ttl).Unix(), 10)
+	q := url.Values{"exp": {exp}, "sig": {l.sign(key, exp)}}
+	return l.baseURL + "/" + (&url.URL{Path: key}).EscapedPath() + "?" + q.Encode(), nil
+}
+
+func (l *Local) Delete(ctx context.Context, key string) error {
+	p, err := l.path(key)
+	if err != nil {
+		return err
+	}
+	if err := os.Remove(p); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+// ServeHTTP serves an object for a signed link. Mount it with the prefix
+// stripped so the request path is the key.
+func (l *Local) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	key := strings.TrimPrefix(r.URL.Path, "/")
+	exp := r.URL.Query().Get("exp")
+	if !l.valid(key, exp, r.URL.Query().Get("sig")) {
+		http.Error(w, "link expired or invalid", http.StatusForbidden)
+		return
+	}
+	p, err := l.path(key)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	f, err := os.Open(p)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil || info.IsDir() {
+		http.NotFound(w, r)
+		return
+	}
+	ctype := mime.TypeByExtension(path.Ext(key))
+	if ctype == "" {
+		ctype = "application/octet-stream"
+	}
+	w.Header().Set("Content-Type", ctype)
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	http.ServeContent(w, r, "", info.ModTime(), f)
+}
+
+func (l *Local) valid(key, exp, sig string) bool {
+	if checkKey(key) != nil {
+		return false
+	}
+	unix, err := strconv.ParseInt(exp, 10, 64)
+	if err != nil || l.clk.Now().Unix() > unix {
+		return false
+	}
+	return hmac.Equal([]byte(sig), []byte(l.sign(key, exp)))
+}
+
+func (l *Local) sign(key, exp string) string {
+	mac := hmac.New(sha256.New, l.key)
+	mac.Write([]byte(key + "\n" + exp))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func (l *Local) path(key string) (string, error) {
+	if err := checkKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(l.dir, filepath.FromSlash(key)), nil
+}
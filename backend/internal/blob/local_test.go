@@ -0,0 +1,88 @@
+package blob
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/divijg19/physiolink/backend/internal/clock"
+)
+
+func TestLocal_SignedURLServesUntilExpiry(t *testing.T) {
+	clk := clock.NewFake(time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC))
+	l := NewLocal(t.TempDir(), "/files/", []byte("secret"), clk)
+	ctx := context.Background()
+	if err := l.Put(ctx, "avatars/u1/a.png", "image/png", strings.NewReader("png!"), 4); err != nil {
+		t.Fatal(err)
+	}
+	link, err := l.SignedURL(ctx, "avatars/u1/a.png", time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(link, "/files/avatars/u1/a.png?") {
+		t.Fatalf("unexpected link %s", link)
+	}
+	get := func(target string) *httptest.ResponseRecorder {
+		u, _ := url.Parse(target)
+		u.Path = strings.TrimPrefix(u.Path, "/files")
+		rr := httptest.NewRecorder()
+		l.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, u.String(), nil))
+		return rr
+	}
+
+	rr := get(link)
+	if rr.Code != http.StatusOK || rr.Body.String() != "png!" {
+		t.Fatalf("expected file, got %d %q", rr.Code, rr.Body.String())
+	}
+	if rr.Header().Get("Content-Type") != "image/png" || rr.Header().Get("X-Content-Type-Options") != "nosniff" {
+		t.Fatalf("unexpected headers %v", rr.Header())
+	}
+
+	// Pointing the signature at another key fails
+	if rr := get(strings.Replace(link, "a.png", "b.png", 1)); rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for tampered key, got %d", rr.Code)
+	}
+
+	clk.Set(clk.Now().Add(2 * time.Minute))
+	if rr := get(link); rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 after expiry, got %d", rr.Code)
+	}
+}
+
+func TestLocal_DeleteAndShortWrite(t *testing.T) {
+	l := NewLocal(t.TempDir(), "/files", []byte("secret"), clock.NewReal())
+	ctx := context.Background()
+	if err := l.Put(ctx, "docs/x.pdf", "application/pdf", strings.NewReader("ab"), 3); err == nil {
+		t.Fatal("expected short write to fail")
+	}
+	if err := l.Put(ctx, "docs/x.pdf", "application/pdf", strings.NewReader("abc"), 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Delete(ctx, "docs/x.pdf"); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Delete(ctx, "docs/x.pdf"); err != nil {
+		t.Fatalf("deleting a missing object should succeed, got %v", err)
+	}
+}
+
+func TestCheckKey(t *testing.T) {
+	for _, key := range []string{"", "/abs", "a/../b", "a//b", "a/", "..", `a\b`, "a\x00b"} {
+		if err := checkKey(key); !errors.Is(err, ErrInvalidKey) {
+			t.Errorf("checkKey(%q) = %v, want ErrInvalidKey", key, err)
+		}
+	}
+	if err := checkKey("avatars/u1/id/128.jpg"); err != nil {
+		t.Fatal(err)
+	}
+	l := NewLocal(t.TempDir(), "/files", nil, clock.NewReal())
+	if err := l.Put(context.Background(), "../escape", "text/plain", io.LimitReader(nil, 0), 0); !errors.Is(err, ErrInvalidKey) {
+		t.Fatalf("expected ErrInvalidKey, got %v", err)
+	}
+}
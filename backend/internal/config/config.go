@@ -1,15 +1,86 @@
 package config
 
 import (
+	"crypto/hkdf"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"os"
+	"strings"
+	"time"
+
+	"github.com/divijg19/physiolink/backend/internal/tokens"
 )
 
+// DefaultJWTSecret is only acceptable when APP_ENV is development.
+const DefaultJWTSecret = "changeme"
+
+// DefaultReviewEditWindow is how long a review can be edited or withdrawn
+// when REVIEW_EDIT_WINDOW is unset.
+const DefaultReviewEditWindow = 48 * time.Hour
+
 type Config struct {
 	BindAddr    string
 	DatabaseURL string
 	RedisURL    string
 	Env         string
 	JWTSecret   string
+	// JWTKeysDir holds <kid>.pem RSA or Ed25519 keys. When set, sessions are
+	// signed asymmetrically and JWTSecret only verifies older HS256 tokens.
+	JWTKeysDir string
+	// JWTActiveKID picks the signing key in JWTKeysDir; defaults to the last
+	// file name in lexical order.
+	JWTActiveKID string
+	// JWTLegacyHS256 keeps HS256 tokens signed with JWTSecret valid next to
+	// the keys in JWTKeysDir. Read from JWT_LEGACY_HS256; on unless set to
+	// false, which retires the shared secret once its tokens have expired.
+	JWTLegacyHS256 bool
+	// JWTKeys is loaded from JWTKeysDir by Load.
+	JWTKeys *tokens.KeySet
+	// OIDCProviders are the identity providers users may sign in with.
+	OIDCProviders []OIDCProvider
+	// OIDCAppRedirects lists the mobile app URIs an OIDC login may hand its
+	// session back to.
+	OIDCAppRedirects []string
+	// Uploads configures where avatars and credential documents are stored.
+	Uploads Uploads
+	// ReviewEditWindow is how long after posting a review its author may
+	// edit or withdraw it. Read from REVIEW_EDIT_WINDOW as a Go duration;
+	// negative when the variable doesn't parse.
+	ReviewEditWindow time.Duration
+}
+
+// Uploads selects the blob store. UPLOAD_BACKEND is "local" (the default),
+// which keeps files under UPLOAD_DIR and serves them from /files, or "s3".
+type Uploads struct {
+	Backend string
+	Dir     string
+	// SigningKey authenticates local download links. It defaults to a key
+	// derived from JWTSecret, so a download link never verifies as a session
+	// or the other way round.
+	SigningKey string
+	S3         S3
+}
+
+// S3 is read from S3_ENDPOINT, S3_REGION, S3_BUCKET, S3_ACCESS_KEY_ID,
+// S3_SECRET_ACCESS_KEY and S3_PATH_STYLE.
+type S3 struct {
+	Endpoint        string
+	Region          string
+	Bucket          string
+	AccessKeyID     string
+	SecretAccessKey string
+	PathStyle       bool
+}
+
+// OIDCProvider is read from OIDC_<NAME>_ISSUER, _CLIENT_ID, _CLIENT_SECRET
+// and _REDIRECT_URL for every name in OIDC_PROVIDERS.
+type OIDCProvider struct {
+	Name         string
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
 }
 
 func New() *Config {
@@ -31,14 +102,158 @@ func New() *Config {
 	}
 	jwt := os.Getenv("JWT_SECRET")
 	if jwt == "" {
-		jwt = "changeme"
+		jwt = DefaultJWTSecret
 	}
 
 	return &Config{
-		BindAddr:    bind,
-		DatabaseURL: db,
-		RedisURL:    redis,
-		Env:         env,
-		JWTSecret:   jwt,
+		BindAddr:         bind,
+		DatabaseURL:      db,
+		RedisURL:         redis,
+		Env:              env,
+		JWTSecret:        jwt,
+		JWTKeysDir:       os.Getenv("JWT_KEYS_DIR"),
+		JWTActiveKID:     os.Getenv("JWT_ACTIVE_KID"),
+		JWTLegacyHS256:   os.Getenv("JWT_LEGACY_HS256") != "false",
+		OIDCProviders:    oidcProviders(),
+		OIDCAppRedirects: splitList(os.Getenv("OIDC_APP_REDIRECTS")),
+		Uploads:          uploads(jwt),
+		ReviewEditWindow: reviewEditWindow(),
+	}
+}
+
+func reviewEditWindow() time.Duration {
+	v := os.Getenv("REVIEW_EDIT_WINDOW")
+	if v == "" {
+		return DefaultReviewEditWindow
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return -1
+	}
+	return d
+}
+
+func uploads(jwt string) Uploads {
+	u := Uploads{
+		Backend:    strings.ToLower(os.Getenv("UPLOAD_BACKEND")),
+		Dir:        os.Getenv("UPLOAD_DIR"),
+		SigningKey: os.Getenv("UPLOAD_SIGNING_KEY"),
+		S3: S3{
+			Endpoint:        os.Getenv("S3_ENDPOINT"),
+			Region:          os.Getenv("S3_REGION"),
+			Bucket:          os.Getenv("S3_BUCKET"),
+			AccessKeyID:     os.Getenv("S3_ACCESS_KEY_ID"),
+			SecretAccessKey: os.Getenv("S3_SECRET_ACCESS_KEY"),
+			PathStyle:       os.Getenv("S3_PATH_STYLE") == "true",
+		},
+	}
+	if u.Backend == "" {
+		u.Backend = "local"
+	}
+	if u.Dir == "" {
+		u.Dir = "uploads"
+	}
+	if u.SigningKey == "" {
+		u.SigningKey = deriveKey(jwt, "uploads")
+	}
+	return u
+}
+
+// deriveKey returns a key for purpose derived from secret with HKDF-SHA256.
+func deriveKey(secret, purpose string) string {
+	k, err := hkdf.Key(sha256.New, []byte(secret), nil, "physiolink "+purpose, 32)
+	if err != nil {
+		// Only possible for an oversized key length.
+		panic(err)
+	}
+	return hex.EncodeToString(k)
+}
+
+func oidcProviders() []OIDCProvider {
+	var out []OIDCProvider
+	for _, name := range splitList(os.Getenv("OIDC_PROVIDERS")) {
+		prefix := "OIDC_" + strings.ToUpper(name) + "_"
+		out = append(out, OIDCProvider{
+			Name:         strings.ToLower(name),
+			Issuer:       os.Getenv(prefix + "ISSUER"),
+			ClientID:     os.Getenv(prefix + "CLIENT_ID"),
+			ClientSecret: os.Getenv(prefix + "CLIENT_SECRET"),
+			RedirectURL:  os.Getenv(prefix + "REDIRECT_URL"),
+		})
+	}
+	return out
+}
+
+func splitList(v string) []string {
+	var out []string
+	for _, s := range strings.Split(v, ",") {
+		if s = strings.TrimSpace(s); s != "" {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// Load reads the environment, validates it and loads the signing keys.
+func Load() (*Config, error) {
+	c := New()
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	if c.JWTKeysDir != "" {
+		legacy := ""
+		if c.JWTLegacyHS256 {
+			legacy = c.JWTSecret
+		}
+		ks, err := tokens.LoadDir(c.JWTKeysDir, c.JWTActiveKID, legacy)
+		if err != nil {
+			return nil, err
+		}
+		c.JWTKeys = ks
+	}
+	return c, nil
+}
+
+// Validate rejects settings that are only safe for local development.
+func (c *Config) Validate() error {
+	if c.Env != "development" && c.JWTSecret == DefaultJWTSecret {
+		return errors.New("JWT_SECRET must be set when APP_ENV is not development")
+	}
+	if !c.JWTLegacyHS256 && c.JWTKeysDir == "" {
+		return errors.New("JWT_LEGACY_HS256=false needs JWT_KEYS_DIR, or nothing could sign tokens")
+	}
+	for _, p := range c.OIDCProviders {
+		if p.Issuer == "" || p.ClientID == "" || p.RedirectURL == "" {
+			return errors.New("OIDC provider " + p.Name + " needs an issuer, client id and redirect url")
+		}
+	}
+	switch c.Uploads.Backend {
+	case "local":
+	case "s3":
+		s := c.Uploads.S3
+		if s.Endpoint == "" || s.Region == "" || s.Bucket == "" || s.AccessKeyID == "" || s.SecretAccessKey == "" {
+			return errors.New("UPLOAD_BACKEND=s3 needs S3_ENDPOINT, S3_REGION, S3_BUCKET and credentials")
+		}
+	default:
+		return errors.New("UPLOAD_BACKEND must be local or s3")
+	}
+	if c.ReviewEditWindow < 0 {
+		return errors.New("REVIEW_EDIT_WINDOW must be a duration such as 48h")
+	}
+	return nil
+}
+
+// SecureCookies reports whether cookies should be marked Secure. Local
+// development runs over plain HTTP.
+func (c *Config) SecureCookies() bool {
+	return c.Env != "development"
+}
+
+// KeySet returns the keys used to sign and verify session tokens, falling
+// back to HS256 with JWTSecret when no asymmetric keys are loaded.
+func (c *Config) KeySet() *tokens.KeySet {
+	if c.JWTKeys != nil {
+		return c.JWTKeys
 	}
+	return tokens.NewHMAC(c.JWTSecret)
 }
@@ -0,0 +1,22 @@
+package config
+
+import "testing"
+
+func TestUploadsSigningKeyIsDerived(t *testing.T) {
+	t.Setenv("UPLOAD_SIGNING_KEY", "")
+	u := uploads("secret")
+	if u.SigningKey == "" || u.SigningKey == "secret" {
+		t.Fatalf("expected a key derived from the JWT secret, got %q", u.SigningKey)
+	}
+	if again := uploads("secret"); again.SigningKey != u.SigningKey {
+		t.Fatal("expected the derived key to be stable")
+	}
+	if other := uploads("other"); other.SigningKey == u.SigningKey {
+		t.Fatal("expected different secrets to derive different keys")
+	}
+
+	t.Setenv("UPLOAD_SIGNING_KEY", "explicit")
+	if u := uploads("secret"); u.SigningKey != "explicit" {
+		t.Fatalf("expected UPLOAD_SIGNING_KEY to win, got %q", u.SigningKey)
+	}
+}
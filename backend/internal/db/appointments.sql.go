@@ -185,6 +185,92 @@ func (q *Queries) InsertReminder(ctx context.Context, arg InsertReminderParams)
 	return err
 }
 
+const listMyAppointmentsPage = `-- name: ListMyAppointmentsPage :many
+SELECT
+    a.id,
+    a.therapist_id,
+    a.patient_id,
+    a.status,
+    s.start_ts,
+    s.end_ts,
+    p_pt.display_name as pt_display_name,
+    p_pt.profile_extra as pt_profile_extra,
+    p_pa.display_name as pa_display_name,
+    p_pa.profile_extra as pa_profile_extra
+FROM appointments a
+JOIN availability_slots s ON s.id = a.slot_id
+LEFT JOIN profiles p_pt ON p_pt.user_id = a.therapist_id
+LEFT JOIN profiles p_pa ON p_pa.user_id = a.patient_id
+WHERE CASE WHEN $2 = 'pt' THEN a.therapist_id = $1 ELSE a.patient_id = $1 END
+  AND (NOT $3::boolean OR (s.start_ts, a.id) > ($4::timestamptz, $5::uuid))
+ORDER BY s.start_ts ASC, a.id ASC
+LIMIT $6
+`
+
+type ListMyAppointmentsPageParams struct {
+	TherapistID uuid.UUID
+	Column2     interface{}
+	Column3     bool
+	Column4     time.Time
+	Column5     uuid.UUID
+	Limit       int32
+}
+
+type ListMyAppointmentsPageRow struct {
+	ID             uuid.UUID
+	TherapistID    uuid.UUID
+	PatientID      uuid.UUID
+	Status         string
+	StartTs        time.Time
+	EndTs          time.Time
+	PtDisplayName  sql.NullString
+	PtProfileExtra pqtype.NullRawMessage
+	PaDisplayName  sql.NullString
+	PaProfileExtra pqtype.NullRawMessage
+}
+
+// params: user_id uuid, role text, has_cursor bool, start_ts timestamptz, id uuid, limit int
+func (q *Queries) ListMyAppointmentsPage(ctx context.Context, arg ListMyAppointmentsPageParams) ([]ListMyAppointmentsPageRow, error) {
+	rows, err := q.db.QueryContext(ctx, listMyAppointmentsPage,
+		arg.TherapistID,
+		arg.Column2,
+		arg.Column3,
+		arg.Column4,
+		arg.Column5,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListMyAppointmentsPageRow
+	for rows.Next() {
+		var i ListMyAppointmentsPageRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.TherapistID,
+			&i.PatientID,
+			&i.Status,
+			&i.StartTs,
+			&i.EndTs,
+			&i.PtDisplayName,
+			&i.PtProfileExtra,
+			&i.PaDisplayName,
+			&i.PaProfileExtra,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listMyAppointmentsWithDetails = `-- name: ListMyAppointmentsWithDetails :many
 SELECT 
     a.id, 
@@ -6,12 +6,26 @@ package db
 
 import (
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/sqlc-dev/pqtype"
 )
 
+type ApiKey struct {
+	ID         uuid.UUID
+	ClinicID   uuid.UUID
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     []string
+	CreatedBy  uuid.NullUUID
+	CreatedAt  time.Time
+	LastUsedAt sql.NullTime
+	RevokedAt  sql.NullTime
+}
+
 type Appointment struct {
 	ID          uuid.UUID
 	SlotID      uuid.NullUUID
@@ -23,6 +37,15 @@ type Appointment struct {
 	UpdatedAt   time.Time
 }
 
+type AuditLog struct {
+	ID           uuid.UUID
+	ActorID      uuid.NullUUID
+	Action       string
+	TargetUserID uuid.NullUUID
+	Details      pqtype.NullRawMessage
+	CreatedAt    time.Time
+}
+
 type AvailabilitySlot struct {
 	ID          uuid.UUID
 	TherapistID uuid.UUID
@@ -33,18 +56,71 @@ type AvailabilitySlot struct {
 	CreatedAt   time.Time
 }
 
+type Clinic struct {
+	ID         uuid.UUID
+	Name       string
+	RequireMfa bool
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+type CredentialSubmission struct {
+	ID            uuid.UUID
+	TherapistID   uuid.UUID
+	LicenceNumber string
+	IssuingBody   string
+	Documents     json.RawMessage
+	Status        string
+	ReviewNotes   sql.NullString
+	ReviewedBy    uuid.NullUUID
+	ReviewedAt    sql.NullTime
+	CreatedAt     time.Time
+}
+
+type MfaChallenge struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	ExpiresAt time.Time
+	UsedAt    sql.NullTime
+}
+
+type MfaRecoveryCode struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	CodeHash  string
+	UsedAt    sql.NullTime
+	CreatedAt time.Time
+}
+
 type Profile struct {
-	ID           uuid.UUID
-	UserID       uuid.UUID
-	DisplayName  sql.NullString
-	Bio          sql.NullString
-	Phone        sql.NullString
-	Address      pqtype.NullRawMessage
-	Specialties  []string
-	ProfileExtra pqtype.NullRawMessage
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
-	Rating       sql.NullString
+	ID                uuid.UUID
+	UserID            uuid.UUID
+	DisplayName       sql.NullString
+	Bio               sql.NullString
+	Phone             sql.NullString
+	Address           pqtype.NullRawMessage
+	Specialties       []string
+	ProfileExtra      pqtype.NullRawMessage
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	Rating            sql.NullString
+	Latitude          sql.NullFloat64
+	Longitude         sql.NullFloat64
+	IsVerified        bool
+	VerifiedAt        sql.NullTime
+	Age               sql.NullInt32
+	Gender            sql.NullString
+	Condition         sql.NullString
+	Goals             sql.NullString
+	Credentials       sql.NullString
+	Location          sql.NullString
+	ProfileImageUrl   sql.NullString
+	YearsExperience   sql.NullInt32
+	Languages         []string
+	SearchTsv         interface{}
+	SessionPriceCents sql.NullInt32
+	PriceCurrency     sql.NullString
+	Insurers          []string
 }
 
 type Reminder struct {
@@ -58,19 +134,130 @@ type Reminder struct {
 }
 
 type Review struct {
-	ID            uuid.UUID
-	AppointmentID uuid.UUID
-	PatientID     uuid.UUID
-	Rating        int32
-	Comment       sql.NullString
-	CreatedAt     time.Time
+	ID                  uuid.UUID
+	AppointmentID       uuid.UUID
+	PatientID           uuid.UUID
+	Rating              int32
+	Comment             sql.NullString
+	CreatedAt           time.Time
+	UpdatedAt           sql.NullTime
+	Status              string
+	ModerationReason    sql.NullString
+	ModeratedBy         uuid.NullUUID
+	ModeratedAt         sql.NullTime
+	CommunicationRating sql.NullInt32
+	PunctualityRating   sql.NullInt32
+	EffectivenessRating sql.NullInt32
+	FacilityRating      sql.NullInt32
+}
+
+type ReviewReply struct {
+	ID          uuid.UUID
+	ReviewID    uuid.UUID
+	TherapistID uuid.UUID
+	Body        string
+	CreatedAt   time.Time
+}
+
+type ReviewReport struct {
+	ID         uuid.UUID
+	ReviewID   uuid.UUID
+	ReporterID uuid.UUID
+	Reason     string
+	CreatedAt  time.Time
+	ResolvedAt sql.NullTime
+}
+
+type ReviewRevision struct {
+	ID                  uuid.UUID
+	ReviewID            uuid.UUID
+	AppointmentID       uuid.UUID
+	PatientID           uuid.UUID
+	Rating              int32
+	Comment             sql.NullString
+	WrittenAt           time.Time
+	Action              string
+	CreatedAt           time.Time
+	CommunicationRating sql.NullInt32
+	PunctualityRating   sql.NullInt32
+	EffectivenessRating sql.NullInt32
+	FacilityRating      sql.NullInt32
+}
+
+type Session struct {
+	ID             uuid.UUID
+	UserID         uuid.UUID
+	UserAgent      string
+	IpAddress      string
+	CreatedAt      time.Time
+	LastSeenAt     time.Time
+	ExpiresAt      time.Time
+	RevokedAt      sql.NullTime
+	ImpersonatorID uuid.NullUUID
+}
+
+type TherapistRating struct {
+	TherapistID        uuid.UUID
+	ReviewCount        int32
+	RatingSum          int32
+	Stars1             int32
+	Stars2             int32
+	Stars3             int32
+	Stars4             int32
+	Stars5             int32
+	UpdatedAt          time.Time
+	CommunicationSum   int32
+	CommunicationCount int32
+	PunctualitySum     int32
+	PunctualityCount   int32
+	EffectivenessSum   int32
+	EffectivenessCount int32
+	FacilitySum        int32
+	FacilityCount      int32
+}
+
+type Upload struct {
+	ID          uuid.UUID
+	OwnerID     uuid.UUID
+	Kind        string
+	Filename    string
+	ContentType string
+	SizeBytes   int64
+	StorageKey  string
+	Variants    []string
+	CreatedAt   time.Time
 }
 
 type User struct {
-	ID           uuid.UUID
-	Email        string
-	PasswordHash string
-	Role         string
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ID                    uuid.UUID
+	Email                 string
+	PasswordHash          string
+	Role                  string
+	CreatedAt             time.Time
+	UpdatedAt             time.Time
+	ClinicID              uuid.NullUUID
+	DisabledAt            sql.NullTime
+	PasswordResetRequired bool
+}
+
+type UserIdentity struct {
+	ID          uuid.UUID
+	UserID      uuid.UUID
+	Provider    string
+	Subject     string
+	Email       sql.NullString
+	CreatedAt   time.Time
+	LastLoginAt time.Time
+}
+
+type UserMfa struct {
+	UserID         uuid.UUID
+	Secret         string
+	Enabled        bool
+	LastUsedStep   int64
+	ConfirmedAt    sql.NullTime
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	FailedAttempts int32
+	LockedUntil    sql.NullTime
 }
@@ -0,0 +1,52 @@
+-- name: ListUsers :many
+-- params: query text, role text, limit int, offset int
+SELECT id, email, password_hash, role, created_at, updated_at, clinic_id, disabled_at, password_reset_required
+FROM users
+WHERE ($1::text = '' OR email ILIKE '%' || $1::text || '%')
+  AND ($2::text = '' OR role = $2::text)
+ORDER BY created_at DESC, id
+LIMIT $3 OFFSET $4;
+
+-- name: CountUsers :one
+-- params: query text, role text
+SELECT COUNT(*)
+FROM users
+WHERE ($1::text = '' OR email ILIKE '%' || $1::text || '%')
+  AND ($2::text = '' OR role = $2::text);
+
+-- name: DisableUser :execrows
+-- params: id uuid
+UPDATE users
+SET disabled_at = COALESCE(disabled_at, now()), updated_at = now()
+WHERE id = $1;
+
+-- name: EnableUser :execrows
+-- params: id uuid
+UPDATE users
+SET disabled_at = NULL, updated_at = now()
+WHERE id = $1;
+
+-- name: UpdateUserRole :execrows
+-- params: id uuid, role text
+UPDATE users
+SET role = $2, updated_at = now()
+WHERE id = $1;
+
+-- name: RequirePasswordReset :execrows
+-- params: id uuid
+UPDATE users
+SET password_reset_required = true, updated_at = now()
+WHERE id = $1;
+
+-- name: InsertAuditEvent :exec
+-- params: actor_id uuid, action text, target_user_id uuid, details jsonb
+INSERT INTO audit_log (actor_id, action, target_user_id, details)
+VALUES ($1, $2, $3, $4);
+
+-- name: ListAuditEvents :many
+-- params: target_user_id uuid (optional), limit int
+SELECT id, actor_id, action, target_user_id, details, created_at
+FROM audit_log
+WHERE ($1::uuid IS NULL OR target_user_id = $1)
+ORDER BY created_at DESC
+LIMIT $2;
@@ -0,0 +1,35 @@
+-- name: ClinicExists :one
+-- params: id uuid
+SELECT EXISTS (SELECT 1 FROM clinics WHERE id = $1);
+
+-- name: CreateAPIKey :one
+-- params: clinic_id uuid, name text, prefix text, key_hash text, scopes text[], created_by uuid
+INSERT INTO api_keys (clinic_id, name, prefix, key_hash, scopes, created_by)
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING id, clinic_id, name, prefix, key_hash, scopes, created_by, created_at, last_used_at, revoked_at;
+
+-- name: GetAPIKeyByPrefix :one
+-- params: prefix text
+SELECT id, clinic_id, name, prefix, key_hash, scopes, created_by, created_at, last_used_at, revoked_at
+FROM api_keys
+WHERE prefix = $1;
+
+-- name: TouchAPIKey :exec
+-- params: id uuid
+-- Throttled to one write per minute per key.
+UPDATE api_keys
+SET last_used_at = now()
+WHERE id = $1 AND (last_used_at IS NULL OR last_used_at < now() - interval '1 minute');
+
+-- name: ListClinicAPIKeys :many
+-- params: clinic_id uuid
+SELECT id, clinic_id, name, prefix, key_hash, scopes, created_by, created_at, last_used_at, revoked_at
+FROM api_keys
+WHERE clinic_id = $1
+ORDER BY created_at DESC;
+
+-- name: RevokeAPIKey :execrows
+-- params: id uuid, clinic_id uuid
+UPDATE api_keys
+SET revoked_at = now()
+WHERE id = $1 AND clinic_id = $2 AND revoked_at IS NULL;
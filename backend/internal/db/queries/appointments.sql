@@ -50,6 +50,28 @@ LEFT JOIN profiles p_pa ON p_pa.user_id = a.patient_id
 WHERE CASE WHEN $2 = 'pt' THEN a.therapist_id = $1 ELSE a.patient_id = $1 END
 ORDER BY s.start_ts ASC;
 
+-- name: ListMyAppointmentsPage :many
+-- params: user_id uuid, role text, has_cursor bool, start_ts timestamptz, id uuid, limit int
+SELECT
+    a.id,
+    a.therapist_id,
+    a.patient_id,
+    a.status,
+    s.start_ts,
+    s.end_ts,
+    p_pt.display_name as pt_display_name,
+    p_pt.profile_extra as pt_profile_extra,
+    p_pa.display_name as pa_display_name,
+    p_pa.profile_extra as pa_profile_extra
+FROM appointments a
+JOIN availability_slots s ON s.id = a.slot_id
+LEFT JOIN profiles p_pt ON p_pt.user_id = a.therapist_id
+LEFT JOIN profiles p_pa ON p_pa.user_id = a.patient_id
+WHERE CASE WHEN $2 = 'pt' THEN a.therapist_id = $1 ELSE a.patient_id = $1 END
+  AND (NOT $3::boolean OR (s.start_ts, a.id) > ($4::timestamptz, $5::uuid))
+ORDER BY s.start_ts ASC, a.id ASC
+LIMIT $6;
+
 -- name: GetAppointmentTherapistID :one
 -- params: appointment_id uuid
 SELECT therapist_id
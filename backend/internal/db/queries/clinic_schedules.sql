@@ -0,0 +1,33 @@
+-- name: IsClinicTherapist :one
+-- params: user_id uuid, clinic_id uuid
+SELECT EXISTS (
+  SELECT 1 FROM users
+  WHERE id = $1 AND clinic_id = $2 AND role IN ('pt', 'therapist') AND disabled_at IS NULL
+);
+
+-- name: ListClinicAppointments :many
+-- params: clinic_id uuid, from timestamptz, to timestamptz
+SELECT
+    a.id,
+    a.therapist_id,
+    a.patient_id,
+    a.status,
+    s.start_ts,
+    s.end_ts,
+    p_pt.display_name AS pt_display_name,
+    p_pa.display_name AS pa_display_name
+FROM appointments a
+JOIN availability_slots s ON s.id = a.slot_id
+JOIN users u ON u.id = a.therapist_id
+LEFT JOIN profiles p_pt ON p_pt.user_id = a.therapist_id
+LEFT JOIN profiles p_pa ON p_pa.user_id = a.patient_id
+WHERE u.clinic_id = $1 AND s.start_ts >= $2 AND s.start_ts < $3
+ORDER BY s.start_ts ASC;
+
+-- name: ListClinicOpenSlots :many
+-- params: clinic_id uuid, from timestamptz, to timestamptz
+SELECT s.id, s.therapist_id, s.start_ts, s.end_ts, s.status
+FROM availability_slots s
+JOIN users u ON u.id = s.therapist_id
+WHERE u.clinic_id = $1 AND s.status = 'open' AND s.start_ts >= $2 AND s.start_ts < $3
+ORDER BY s.start_ts ASC;
@@ -0,0 +1,47 @@
+-- name: CreateCredentialSubmission :one
+-- params: therapist_id uuid, licence_number text, issuing_body text, documents jsonb
+-- Returns no row while the therapist already has a submission pending.
+INSERT INTO credential_submissions (therapist_id, licence_number, issuing_body, documents)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (therapist_id) WHERE status = 'pending' DO NOTHING
+RETURNING id, therapist_id, licence_number, issuing_body, documents, status, review_notes, reviewed_by, reviewed_at, created_at;
+
+-- name: GetCredentialSubmission :one
+-- params: id uuid
+SELECT id, therapist_id, licence_number, issuing_body, documents, status, review_notes, reviewed_by, reviewed_at, created_at
+FROM credential_submissions
+WHERE id = $1;
+
+-- name: ListTherapistCredentialSubmissions :many
+-- params: therapist_id uuid
+SELECT id, therapist_id, licence_number, issuing_body, documents, status, review_notes, reviewed_by, reviewed_at, created_at
+FROM credential_submissions
+WHERE therapist_id = $1
+ORDER BY created_at DESC;
+
+-- name: ListCredentialSubmissions :many
+-- params: status text, limit int
+SELECT c.id, c.therapist_id, c.licence_number, c.issuing_body, c.documents, c.status, c.review_notes, c.reviewed_by, c.reviewed_at, c.created_at,
+       u.email, COALESCE(p.display_name, '') AS display_name
+FROM credential_submissions c
+JOIN users u ON u.id = c.therapist_id
+LEFT JOIN profiles p ON p.user_id = c.therapist_id
+WHERE ($1::text = '' OR c.status = $1::text)
+ORDER BY c.created_at, c.id
+LIMIT $2;
+
+-- name: ReviewCredentialSubmission :one
+-- params: id uuid, status text, review_notes text, reviewed_by uuid
+UPDATE credential_submissions
+SET status = $2, review_notes = $3, reviewed_by = $4, reviewed_at = now()
+WHERE id = $1 AND status = 'pending'
+RETURNING id, therapist_id, licence_number, issuing_body, documents, status, review_notes, reviewed_by, reviewed_at, created_at;
+
+-- name: SetProfileVerified :exec
+-- params: user_id uuid
+INSERT INTO profiles (user_id, is_verified, verified_at)
+VALUES ($1, true, now())
+ON CONFLICT (user_id) DO UPDATE SET
+  is_verified = true,
+  verified_at = now(),
+  updated_at = now();
@@ -0,0 +1,71 @@
+-- name: AddFavourite :one
+-- params: patient_id uuid, therapist_id uuid
+INSERT INTO favourites (patient_id, therapist_id)
+VALUES ($1, $2)
+ON CONFLICT (patient_id, therapist_id) DO UPDATE SET created_at = favourites.created_at
+RETURNING created_at;
+
+-- name: GetFavouriteOpenings :many
+-- params: patient_id uuid, start_ts timestamptz, created_at timestamptz, limit int
+SELECT s.id, s.therapist_id, s.start_ts, s.created_at, COALESCE(p.display_name,'') AS display_name
+FROM favourites f
+JOIN availability_slots s ON s.therapist_id = f.therapist_id
+LEFT JOIN profiles p ON p.user_id = f.therapist_id
+WHERE f.patient_id = $1
+  AND s.status = 'open'
+  AND s.start_ts > $2
+  AND s.created_at > $3
+  AND s.created_at >= f.created_at
+ORDER BY s.created_at DESC, s.start_ts ASC
+LIMIT $4;
+
+-- name: ListFavouriteIDs :many
+-- params: patient_id uuid, therapist_ids uuid[]
+SELECT therapist_id
+FROM favourites
+WHERE patient_id = $1 AND therapist_id = ANY($2::uuid[]);
+
+-- name: ListFavourites :many
+-- params: patient_id uuid
+SELECT u.id, u.email, COALESCE(p.display_name,'') AS display_name,
+       COALESCE(p.specialties, ARRAY[]::text[]) AS specialties, p.rating,
+       COALESCE(p.is_verified, false) AS is_verified, p.profile_image_url, f.created_at
+FROM favourites f
+JOIN users u ON u.id = f.therapist_id
+LEFT JOIN profiles p ON p.user_id = u.id
+WHERE f.patient_id = $1 AND u.role = 'pt' AND u.disabled_at IS NULL
+ORDER BY f.created_at DESC, u.id;
+
+-- name: ListRecentlyViewed :many
+-- params: user_id uuid, limit int
+SELECT u.id, u.email, COALESCE(p.display_name,'') AS display_name,
+       COALESCE(p.specialties, ARRAY[]::text[]) AS specialties, p.rating,
+       COALESCE(p.is_verified, false) AS is_verified, p.profile_image_url, v.viewed_at
+FROM therapist_views v
+JOIN users u ON u.id = v.therapist_id
+LEFT JOIN profiles p ON p.user_id = u.id
+WHERE v.user_id = $1 AND u.role = 'pt' AND u.disabled_at IS NULL
+ORDER BY v.viewed_at DESC, u.id
+LIMIT $2;
+
+-- name: PruneTherapistViews :exec
+-- params: user_id uuid, limit int
+DELETE FROM therapist_views
+WHERE user_id = $1
+  AND therapist_id NOT IN (
+    SELECT therapist_id FROM therapist_views
+    WHERE user_id = $1
+    ORDER BY viewed_at DESC
+    LIMIT $2
+  );
+
+-- name: RecordTherapistView :exec
+-- params: user_id uuid, therapist_id uuid
+INSERT INTO therapist_views (user_id, therapist_id)
+VALUES ($1, $2)
+ON CONFLICT (user_id, therapist_id) DO UPDATE SET viewed_at = now();
+
+-- name: RemoveFavourite :exec
+-- params: patient_id uuid, therapist_id uuid
+DELETE FROM favourites
+WHERE patient_id = $1 AND therapist_id = $2;
@@ -0,0 +1,17 @@
+-- name: GetIdentityUser :one
+-- params: provider text, subject text
+SELECT u.id, u.role, u.disabled_at IS NOT NULL AS disabled
+FROM user_identities i
+JOIN users u ON u.id = i.user_id
+WHERE i.provider = $1 AND i.subject = $2;
+
+-- name: CreateUserIdentity :exec
+-- params: user_id uuid, provider text, subject text, email text
+INSERT INTO user_identities (user_id, provider, subject, email)
+VALUES ($1, $2, $3, $4);
+
+-- name: TouchUserIdentity :exec
+-- params: provider text, subject text
+UPDATE user_identities
+SET last_login_at = now()
+WHERE provider = $1 AND subject = $2;
@@ -0,0 +1,97 @@
+-- name: GetUserMFA :one
+-- params: user_id uuid
+SELECT user_id, secret, enabled, last_used_step, confirmed_at, created_at, updated_at, failed_attempts, locked_until
+FROM user_mfa
+WHERE user_id = $1;
+
+-- name: UpsertPendingMFA :exec
+-- params: user_id uuid, secret text
+INSERT INTO user_mfa (user_id, secret, enabled)
+VALUES ($1, $2, false)
+ON CONFLICT (user_id) DO UPDATE SET
+  secret = EXCLUDED.secret,
+  enabled = false,
+  last_used_step = 0,
+  confirmed_at = NULL,
+  updated_at = now();
+
+-- name: EnableMFA :exec
+-- params: user_id uuid
+UPDATE user_mfa
+SET enabled = true, confirmed_at = now(), updated_at = now()
+WHERE user_id = $1;
+
+-- name: MarkMFAStepUsed :execrows
+-- params: user_id uuid, step bigint
+UPDATE user_mfa
+SET last_used_step = $2, updated_at = now()
+WHERE user_id = $1 AND last_used_step < $2;
+
+-- name: RecordMFAFailure :one
+-- params: user_id uuid
+UPDATE user_mfa
+SET failed_attempts = failed_attempts + 1, updated_at = now()
+WHERE user_id = $1
+RETURNING failed_attempts;
+
+-- name: LockMFA :exec
+-- params: user_id uuid, locked_until timestamptz
+UPDATE user_mfa
+SET failed_attempts = 0, locked_until = $2, updated_at = now()
+WHERE user_id = $1;
+
+-- name: ResetMFAFailures :exec
+-- params: user_id uuid
+UPDATE user_mfa
+SET failed_attempts = 0, updated_at = now()
+WHERE user_id = $1 AND failed_attempts > 0;
+
+-- name: CreateMFAChallenge :exec
+-- params: id uuid, user_id uuid, expires_at timestamptz
+INSERT INTO mfa_challenges (id, user_id, expires_at)
+VALUES ($1, $2, $3);
+
+-- name: ConsumeMFAChallenge :execrows
+-- params: id uuid, user_id uuid
+UPDATE mfa_challenges
+SET used_at = now()
+WHERE id = $1 AND user_id = $2 AND used_at IS NULL AND expires_at > now();
+
+-- name: DeleteExpiredMFAChallenges :exec
+-- params: before timestamptz
+DELETE FROM mfa_challenges
+WHERE expires_at < $1;
+
+-- name: DeleteUserMFA :exec
+-- params: user_id uuid
+DELETE FROM user_mfa
+WHERE user_id = $1;
+
+-- name: DeleteRecoveryCodes :exec
+-- params: user_id uuid
+DELETE FROM mfa_recovery_codes
+WHERE user_id = $1;
+
+-- name: InsertRecoveryCode :exec
+-- params: user_id uuid, code_hash text
+INSERT INTO mfa_recovery_codes (user_id, code_hash)
+VALUES ($1, $2);
+
+-- name: UseRecoveryCode :execrows
+-- params: user_id uuid, code_hash text
+UPDATE mfa_recovery_codes
+SET used_at = now()
+WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL;
+
+-- name: CountUnusedRecoveryCodes :one
+-- params: user_id uuid
+SELECT COUNT(*)
+FROM mfa_recovery_codes
+WHERE user_id = $1 AND used_at IS NULL;
+
+-- name: GetClinicRequiresMFA :one
+-- params: user_id uuid
+SELECT COALESCE(c.require_mfa, false) AS require_mfa
+FROM users u
+LEFT JOIN clinics c ON c.id = u.clinic_id
+WHERE u.id = $1;
@@ -1,21 +1,161 @@
 -- name: GetAppointmentForReview :one
 -- params: appointment_id uuid, patient_id uuid
-SELECT id
-FROM appointments
-WHERE id = $1 AND patient_id = $2 AND status = 'confirmed';
+SELECT a.id, a.therapist_id, a.status, s.end_ts,
+       (EXISTS (SELECT 1 FROM reviews r WHERE r.appointment_id = a.id)
+        OR EXISTS (SELECT 1 FROM review_revisions v WHERE v.appointment_id = a.id AND v.action = 'withdrawn')) AS reviewed
+FROM appointments a
+JOIN availability_slots s ON s.id = a.slot_id
+WHERE a.id = $1 AND a.patient_id = $2;
+
+-- name: GetLatestReviewableAppointment :one
+-- params: patient_id uuid, therapist_id uuid, now timestamptz
+SELECT a.id
+FROM appointments a
+JOIN availability_slots s ON s.id = a.slot_id
+WHERE a.patient_id = $1 AND a.therapist_id = $2
+  AND a.status IN ('confirmed', 'completed')
+  AND s.end_ts <= $3
+  AND NOT EXISTS (SELECT 1 FROM reviews r WHERE r.appointment_id = a.id)
+  AND NOT EXISTS (SELECT 1 FROM review_revisions v WHERE v.appointment_id = a.id AND v.action = 'withdrawn')
+ORDER BY s.end_ts DESC
+LIMIT 1;
 
 -- name: CreateReview :one
--- params: appointment_id uuid, patient_id uuid, rating int, comment text
-INSERT INTO reviews (appointment_id, patient_id, rating, comment)
-VALUES ($1, $2, $3, $4)
-RETURNING id;
+-- params: appointment_id uuid, patient_id uuid, rating int, comment text, status text, moderation_reason text, communication_rating int, punctuality_rating int, effectiveness_rating int, facility_rating int
+INSERT INTO reviews (appointment_id, patient_id, rating, comment, status, moderation_reason,
+                     communication_rating, punctuality_rating, effectiveness_rating, facility_rating)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (appointment_id) DO NOTHING
+RETURNING id, created_at;
 
--- name: GetTherapistAverageRating :one
--- params: therapist_id uuid
-SELECT AVG(r.rating)::float as avg_rating
+-- name: GetReviewForUpdate :one
+-- params: id uuid
+SELECT r.id, r.appointment_id, r.patient_id, r.rating, r.comment, r.created_at,
+       COALESCE(r.updated_at, r.created_at) AS written_at, a.therapist_id, r.status,
+       r.communication_rating, r.punctuality_rating, r.effectiveness_rating, r.facility_rating
+FROM reviews r
+JOIN appointments a ON a.id = r.appointment_id
+WHERE r.id = $1
+FOR UPDATE OF r;
+
+-- name: CreateReviewRevision :exec
+-- params: review_id uuid, appointment_id uuid, patient_id uuid, rating int, comment text, written_at timestamptz, action text, communication_rating int, punctuality_rating int, effectiveness_rating int, facility_rating int
+INSERT INTO review_revisions (review_id, appointment_id, patient_id, rating, comment, written_at, action,
+                              communication_rating, punctuality_rating, effectiveness_rating, facility_rating)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11);
+
+-- name: UpdateReview :one
+-- params: id uuid, rating int, comment text, updated_at timestamptz, held_for text, communication_rating int, punctuality_rating int, effectiveness_rating int, facility_rating int
+UPDATE reviews
+SET rating = $2, comment = $3, updated_at = $4,
+    communication_rating = $6, punctuality_rating = $7, effectiveness_rating = $8, facility_rating = $9,
+    status = CASE WHEN $5::text <> '' THEN 'pending' ELSE status END,
+    moderation_reason = COALESCE(NULLIF($5::text, ''), moderation_reason)
+WHERE id = $1
+RETURNING status;
+
+-- name: DeleteReview :exec
+-- params: id uuid
+DELETE FROM reviews WHERE id = $1;
+
+-- name: GetReviewStatus :one
+-- params: id uuid
+SELECT r.patient_id, a.therapist_id, r.status
+FROM reviews r
+JOIN appointments a ON a.id = r.appointment_id
+WHERE r.id = $1;
+
+-- name: CreateReviewReply :one
+-- params: review_id uuid, therapist_id uuid, body text
+INSERT INTO review_replies (review_id, therapist_id, body)
+VALUES ($1, $2, $3)
+ON CONFLICT (review_id) DO NOTHING
+RETURNING id, created_at;
+
+-- name: GetReviewRepliesForPatient :many
+-- params: patient_id uuid, created_at timestamptz, limit int
+SELECT rp.id, rp.review_id, rp.therapist_id, rp.created_at, COALESCE(p.display_name,'') AS display_name
+FROM review_replies rp
+JOIN reviews r ON r.id = rp.review_id
+LEFT JOIN profiles p ON p.user_id = rp.therapist_id
+WHERE r.patient_id = $1 AND r.status = 'published'
+  AND rp.created_at > $2
+ORDER BY rp.created_at DESC
+LIMIT $3;
+
+-- name: CreateReviewReport :exec
+-- params: review_id uuid, reporter_id uuid, reason text
+INSERT INTO review_reports (review_id, reporter_id, reason)
+VALUES ($1, $2, $3)
+ON CONFLICT (review_id, reporter_id) DO NOTHING;
+
+-- name: ListReviewModerationQueue :many
+-- params: status text, reported bool, limit int
+SELECT r.id, r.appointment_id, a.therapist_id, r.patient_id, r.rating, r.comment, r.status,
+       r.moderation_reason, r.moderated_by, r.moderated_at, r.created_at, r.updated_at,
+       COALESCE((SELECT json_agg(json_build_object('reporterId', rr.reporter_id, 'reason', rr.reason, 'createdAt', rr.created_at) ORDER BY rr.created_at)
+                 FROM review_reports rr WHERE rr.review_id = r.id AND rr.resolved_at IS NULL), '[]')::json AS reports
+FROM reviews r
+JOIN appointments a ON a.id = r.appointment_id
+WHERE ($1::text = '' OR r.status = $1::text)
+  AND (NOT $2::boolean OR EXISTS (SELECT 1 FROM review_reports rr WHERE rr.review_id = r.id AND rr.resolved_at IS NULL))
+ORDER BY r.created_at, r.id
+LIMIT $3;
+
+-- name: GetModeratedReview :one
+-- params: id uuid
+SELECT r.id, r.appointment_id, a.therapist_id, r.patient_id, r.rating, r.comment, r.status,
+       r.moderation_reason, r.moderated_by, r.moderated_at, r.created_at, r.updated_at,
+       COALESCE((SELECT json_agg(json_build_object('reporterId', rr.reporter_id, 'reason', rr.reason, 'createdAt', rr.created_at) ORDER BY rr.created_at)
+                 FROM review_reports rr WHERE rr.review_id = r.id AND rr.resolved_at IS NULL), '[]')::json AS reports
 FROM reviews r
 JOIN appointments a ON a.id = r.appointment_id
-WHERE a.therapist_id = $1;
+WHERE r.id = $1;
+
+-- name: ModerateReview :exec
+-- params: id uuid, status text, moderation_reason text, moderated_by uuid
+UPDATE reviews
+SET status = $2, moderation_reason = $3, moderated_by = $4, moderated_at = now()
+WHERE id = $1;
+
+-- name: ResolveReviewReports :exec
+-- params: review_id uuid
+UPDATE review_reports SET resolved_at = now()
+WHERE review_id = $1 AND resolved_at IS NULL;
+
+-- name: AdjustTherapistRating :one
+-- params: therapist_id uuid, rating int, delta int, communication_rating int, punctuality_rating int, effectiveness_rating int, facility_rating int
+INSERT INTO therapist_ratings AS t (therapist_id, review_count, rating_sum, stars_1, stars_2, stars_3, stars_4, stars_5,
+                                    communication_sum, communication_count, punctuality_sum, punctuality_count,
+                                    effectiveness_sum, effectiveness_count, facility_sum, facility_count)
+VALUES ($1, $3::int, $2::int * $3::int,
+        CASE WHEN $2::int = 1 THEN $3::int ELSE 0 END,
+        CASE WHEN $2::int = 2 THEN $3::int ELSE 0 END,
+        CASE WHEN $2::int = 3 THEN $3::int ELSE 0 END,
+        CASE WHEN $2::int = 4 THEN $3::int ELSE 0 END,
+        CASE WHEN $2::int = 5 THEN $3::int ELSE 0 END,
+        COALESCE($4::int, 0) * $3::int, CASE WHEN $4::int IS NULL THEN 0 ELSE $3::int END,
+        COALESCE($5::int, 0) * $3::int, CASE WHEN $5::int IS NULL THEN 0 ELSE $3::int END,
+        COALESCE($6::int, 0) * $3::int, CASE WHEN $6::int IS NULL THEN 0 ELSE $3::int END,
+        COALESCE($7::int, 0) * $3::int, CASE WHEN $7::int IS NULL THEN 0 ELSE $3::int END)
+ON CONFLICT (therapist_id) DO UPDATE
+SET review_count = t.review_count + EXCLUDED.review_count,
+    rating_sum = t.rating_sum + EXCLUDED.rating_sum,
+    stars_1 = t.stars_1 + EXCLUDED.stars_1,
+    stars_2 = t.stars_2 + EXCLUDED.stars_2,
+    stars_3 = t.stars_3 + EXCLUDED.stars_3,
+    stars_4 = t.stars_4 + EXCLUDED.stars_4,
+    stars_5 = t.stars_5 + EXCLUDED.stars_5,
+    communication_sum = t.communication_sum + EXCLUDED.communication_sum,
+    communication_count = t.communication_count + EXCLUDED.communication_count,
+    punctuality_sum = t.punctuality_sum + EXCLUDED.punctuality_sum,
+    punctuality_count = t.punctuality_count + EXCLUDED.punctuality_count,
+    effectiveness_sum = t.effectiveness_sum + EXCLUDED.effectiveness_sum,
+    effectiveness_count = t.effectiveness_count + EXCLUDED.effectiveness_count,
+    facility_sum = t.facility_sum + EXCLUDED.facility_sum,
+    facility_count = t.facility_count + EXCLUDED.facility_count,
+    updated_at = now()
+RETURNING review_count, rating_sum;
 
 -- name: UpdateProfileRating :exec
 -- params: user_id uuid, rating float
@@ -25,10 +165,28 @@ WHERE user_id = $1;
 
 -- name: GetReviewsForTherapist :many
 -- params: therapist_id uuid
-SELECT r.id::text as review_id, r.patient_id, r.rating, r.comment,
-       p.display_name as patient_name, r.created_at
+SELECT r.id::text as review_id, r.appointment_id, r.patient_id, r.rating, r.comment,
+       p.display_name as patient_name, r.created_at, r.updated_at,
+       r.communication_rating, r.punctuality_rating, r.effectiveness_rating, r.facility_rating,
+       rp.body as reply_body, rp.created_at as reply_created_at
 FROM reviews r
 JOIN appointments a ON a.id = r.appointment_id
 LEFT JOIN profiles p ON p.user_id = r.patient_id
-WHERE a.therapist_id = $1
+LEFT JOIN review_replies rp ON rp.review_id = r.id
+WHERE a.therapist_id = $1 AND r.status = 'published'
 ORDER BY r.created_at DESC;
+
+-- name: GetReviewsForTherapistPage :many
+-- params: therapist_id uuid, has_cursor bool, created_at timestamptz, id uuid, limit int
+SELECT r.id::text as review_id, r.appointment_id, r.patient_id, r.rating, r.comment,
+       p.display_name as patient_name, r.created_at, r.updated_at,
+       r.communication_rating, r.punctuality_rating, r.effectiveness_rating, r.facility_rating,
+       rp.body as reply_body, rp.created_at as reply_created_at
+FROM reviews r
+JOIN appointments a ON a.id = r.appointment_id
+LEFT JOIN profiles p ON p.user_id = r.patient_id
+LEFT JOIN review_replies rp ON rp.review_id = r.id
+WHERE a.therapist_id = $1 AND r.status = 'published'
+  AND (NOT $2::boolean OR (r.created_at, r.id) < ($3::timestamptz, $4::uuid))
+ORDER BY r.created_at DESC, r.id DESC
+LIMIT $5;
@@ -0,0 +1,38 @@
+-- name: CreateSession :one
+-- params: user_id uuid, user_agent text, ip_address text, expires_at timestamptz, impersonator_id uuid
+INSERT INTO sessions (user_id, user_agent, ip_address, expires_at, impersonator_id)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING id;
+
+-- name: TouchSession :execrows
+-- params: id uuid
+-- Reports 0 rows once the session is revoked or expired.
+UPDATE sessions
+SET last_seen_at = now()
+WHERE id = $1 AND revoked_at IS NULL AND expires_at > now();
+
+-- name: ListUserSessions :many
+-- params: user_id uuid
+SELECT id, user_id, user_agent, ip_address, created_at, last_seen_at, expires_at, revoked_at, impersonator_id
+FROM sessions
+WHERE user_id = $1 AND revoked_at IS NULL AND expires_at > now()
+ORDER BY last_seen_at DESC;
+
+-- name: RevokeSession :execrows
+-- params: id uuid, user_id uuid
+UPDATE sessions
+SET revoked_at = now()
+WHERE id = $1 AND user_id = $2 AND revoked_at IS NULL;
+
+-- name: RevokeOtherSessions :many
+-- params: user_id uuid, keep uuid
+UPDATE sessions
+SET revoked_at = now()
+WHERE user_id = $1 AND id <> $2 AND revoked_at IS NULL
+RETURNING id;
+
+-- name: RevokeUserSessions :exec
+-- params: user_id uuid
+UPDATE sessions
+SET revoked_at = now()
+WHERE user_id = $1 AND revoked_at IS NULL;
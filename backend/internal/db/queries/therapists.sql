@@ -1,24 +1,101 @@
 -- name: GetTherapistCount :one
--- params: specialty text, location text
+-- params: specialty text, location text, date text, available bool, q text, lat float8, lng float8, radius_km float8, min_lat float8, max_lat float8, min_lng float8, max_lng float8, verified bool, insurer text, language text, min_price_cents int, max_price_cents int, currency text
 SELECT COUNT(*)
 FROM users u
 LEFT JOIN profiles p ON p.user_id = u.id
-WHERE u.role = 'pt'
+WHERE u.role = 'pt' AND u.disabled_at IS NULL
   AND ($1 = '' OR p.specialties::text ILIKE $1)
-  AND ($2 = '' OR p.address::text ILIKE $2);
+  AND ($2 = '' OR p.address::text ILIKE $2 OR p.location ILIKE $2)
+  AND ($5::text = '' OR p.search_tsv @@ websearch_to_tsquery('english', $5::text))
+  AND ($8::float8 <= 0 OR (
+    p.latitude BETWEEN $9::float8 AND $10::float8
+    AND p.longitude BETWEEN $11::float8 AND $12::float8
+    AND haversine_km($6::float8, $7::float8, p.latitude, p.longitude) <= $8::float8
+  ))
+  AND (NOT $4::boolean OR EXISTS (
+    SELECT 1 FROM availability_slots s
+    WHERE s.therapist_id = u.id
+      AND s.status = 'open'
+      AND s.start_ts > now()
+      AND ($3::text = '' OR s.start_ts::date = NULLIF($3::text, '')::date)
+  ))
+  AND (NOT $13::boolean OR p.is_verified)
+  AND ($14::text = '' OR EXISTS (SELECT 1 FROM unnest(p.insurers) AS i(name) WHERE lower(i.name) = lower($14::text)))
+  AND ($15::text = '' OR EXISTS (SELECT 1 FROM unnest(p.languages) AS l(name) WHERE lower(l.name) = lower($15::text)))
+  AND ($16::int < 0 OR p.session_price_cents >= $16::int)
+  AND ($17::int < 0 OR p.session_price_cents <= $17::int)
+  AND ($18::text = '' OR p.price_currency = $18::text);
 
 -- name: GetTherapists :many
--- params: specialty text, location text, limit int, offset int
-SELECT u.id, u.email, COALESCE(p.display_name,'') AS display_name,
-       COALESCE(p.specialties, ARRAY[]::text[]) AS specialties,
-       p.address, p.rating
-FROM users u
-LEFT JOIN profiles p ON p.user_id = u.id
-WHERE u.role = 'pt'
-  AND ($1 = '' OR p.specialties::text ILIKE $1)
-  AND ($2 = '' OR p.address::text ILIKE $2)
-ORDER BY u.created_at DESC
-LIMIT $3 OFFSET $4;
+-- params: specialty text, location text, date text, available bool, sort text, limit int, offset int, q text, lat float8, lng float8, radius_km float8, min_lat float8, max_lat float8, min_lng float8, max_lng float8, near bool, has_cursor bool, cursor_num float8, cursor_text text, cursor_age float8, cursor_id uuid, verified bool, insurer text, language text, min_price_cents int, max_price_cents int, currency text
+SELECT t.id, t.email, t.display_name, t.specialties, t.address, t.rating, t.review_count, t.next_slot,
+       t.snippet, t.latitude, t.longitude, t.distance_km, t.is_verified,
+       t.session_price_cents, t.price_currency, t.insurers, t.languages, t.sort_num, t.sort_text, t.sort_age
+FROM (
+  SELECT u.id, u.email, COALESCE(p.display_name,'') AS display_name,
+         COALESCE(p.specialties, ARRAY[]::text[]) AS specialties,
+         p.address, p.rating, tr.review_count::bigint AS review_count, ns.next_slot,
+         CASE WHEN $8::text = '' THEN ''
+              ELSE COALESCE(ts_headline('english',
+                     concat_ws(' · ', array_to_string(p.specialties, ', '), p.credentials, p.bio),
+                     websearch_to_tsquery('english', $8::text),
+                     'StartSel=' || chr(2) || ', StopSel=' || chr(3) || ', MaxWords=30, MinWords=10, MaxFragments=2'), '')
+         END::text AS snippet,
+         p.latitude, p.longitude,
+         CASE WHEN $16::boolean THEN haversine_km($9::float8, $10::float8, p.latitude, p.longitude) END AS distance_km,
+         COALESCE(p.is_verified, false) AS is_verified,
+         p.session_price_cents, p.price_currency,
+         COALESCE(p.insurers, ARRAY[]::text[]) AS insurers,
+         COALESCE(p.languages, ARRAY[]::text[]) AS languages,
+         -- Every ordering is expressed as ascending keys so that one row
+         -- comparison can resume after a cursor; missing values sort last.
+         (CASE $5::text
+            WHEN 'relevance' THEN COALESCE(-ts_rank_cd(p.search_tsv, websearch_to_tsquery('english', $8::text))::float8, 'Infinity')
+            WHEN 'distance' THEN COALESCE(haversine_km($9::float8, $10::float8, p.latitude, p.longitude), 'Infinity')
+            WHEN 'rating' THEN COALESCE(-rating_score(tr.rating_sum, tr.review_count, g.prior_mean), 'Infinity')
+            WHEN 'reviews' THEN -COALESCE(tr.review_count, 0)::float8
+            WHEN 'availability' THEN COALESCE(extract(epoch FROM ns.next_slot)::float8, 'Infinity')
+            ELSE 0
+          END)::float8 AS sort_num,
+         (CASE WHEN $5::text = 'name' THEN lower(COALESCE(p.display_name,'')) ELSE '' END)::text AS sort_text,
+         (-extract(epoch FROM u.created_at))::float8 AS sort_age
+  FROM users u
+  LEFT JOIN profiles p ON p.user_id = u.id
+  LEFT JOIN therapist_ratings tr ON tr.therapist_id = u.id
+  -- The rating sort smooths each average toward the mean of all reviews
+  CROSS JOIN (
+    SELECT COALESCE(SUM(rating_sum)::float8 / NULLIF(SUM(review_count), 0), 0) AS prior_mean
+    FROM therapist_ratings
+  ) g
+  LEFT JOIN LATERAL (
+    SELECT MIN(s.start_ts) AS next_slot
+    FROM availability_slots s
+    WHERE s.therapist_id = u.id
+      AND s.status = 'open'
+      AND s.start_ts > now()
+      AND ($3::text = '' OR s.start_ts::date = NULLIF($3::text, '')::date)
+  ) ns ON true
+  WHERE u.role = 'pt' AND u.disabled_at IS NULL
+    AND ($1 = '' OR p.specialties::text ILIKE $1)
+    AND ($2 = '' OR p.address::text ILIKE $2 OR p.location ILIKE $2)
+    AND ($8::text = '' OR p.search_tsv @@ websearch_to_tsquery('english', $8::text))
+    AND ($11::float8 <= 0 OR (
+      p.latitude BETWEEN $12::float8 AND $13::float8
+      AND p.longitude BETWEEN $14::float8 AND $15::float8
+      AND haversine_km($9::float8, $10::float8, p.latitude, p.longitude) <= $11::float8
+    ))
+    AND (NOT $4::boolean OR ns.next_slot IS NOT NULL)
+    AND (NOT $22::boolean OR p.is_verified)
+    AND ($23::text = '' OR EXISTS (SELECT 1 FROM unnest(p.insurers) AS i(name) WHERE lower(i.name) = lower($23::text)))
+    AND ($24::text = '' OR EXISTS (SELECT 1 FROM unnest(p.languages) AS l(name) WHERE lower(l.name) = lower($24::text)))
+    AND ($25::int < 0 OR p.session_price_cents >= $25::int)
+    AND ($26::int < 0 OR p.session_price_cents <= $26::int)
+    AND ($27::text = '' OR p.price_currency = $27::text)
+) t
+WHERE NOT $17::boolean
+   OR (t.sort_num, t.sort_text, t.sort_age, t.id) > ($18::float8, $19::text, $20::float8, $21::uuid)
+ORDER BY t.sort_num, t.sort_text, t.sort_age, t.id
+LIMIT $6 OFFSET $7;
 
 -- name: GetAvailabilityCounts :many
 -- params: therapist_ids text[], date text
@@ -33,7 +110,7 @@ GROUP BY therapist_id;
 -- params: therapist_ids text[]
 SELECT a.therapist_id::text, COUNT(r.id)
 FROM appointments a
-JOIN reviews r ON r.appointment_id = a.id
+JOIN reviews r ON r.appointment_id = a.id AND r.status = 'published'
 WHERE a.therapist_id = ANY($1::uuid[])
 GROUP BY a.therapist_id;
 
@@ -41,7 +118,7 @@ GROUP BY a.therapist_id;
 -- params: id uuid
 SELECT u.id, u.email, COALESCE(p.display_name,''), COALESCE(p.specialties, ARRAY[]::text[]), p.address, COALESCE(p.bio,''), p.rating
 FROM users u LEFT JOIN profiles p ON p.user_id = u.id
-WHERE u.id = $1 AND u.role = 'pt';
+WHERE u.id = $1 AND u.role = 'pt' AND u.disabled_at IS NULL;
 
 -- name: GetTherapistAvailabilitySlots :many
 -- params: therapist_id uuid, date text, limit int
@@ -54,5 +131,38 @@ LIMIT $3;
 -- name: GetTherapistReviewCount :one
 -- params: therapist_id uuid
 SELECT COUNT(r.id)
-FROM appointments a JOIN reviews r ON r.appointment_id = a.id
+FROM appointments a JOIN reviews r ON r.appointment_id = a.id AND r.status = 'published'
 WHERE a.therapist_id = $1;
+
+-- name: ListRecommendationCandidates :many
+-- params: start_ts timestamptz, near bool, lat float8, lng float8, limit int, radius_km float8, min_lat float8, max_lat float8, min_lng float8, max_lng float8
+-- The pool is the best rated by smoothed score. With radius_km > 0 it is
+-- drawn only from therapists that close, so nearby matches can't be crowded
+-- out by better rated ones elsewhere.
+SELECT u.id, u.email, COALESCE(p.display_name,'') AS display_name,
+       COALESCE(p.specialties, ARRAY[]::text[]) AS specialties,
+       p.address, p.rating, p.latitude, p.longitude,
+       CASE WHEN $2::boolean THEN haversine_km($3::float8, $4::float8, p.latitude, p.longitude) END AS distance_km,
+       COALESCE(p.is_verified, false) AS is_verified,
+       COALESCE(tr.review_count, 0)::bigint AS review_count,
+       slots.open_slots, slots.next_slot
+FROM users u
+LEFT JOIN profiles p ON p.user_id = u.id
+LEFT JOIN therapist_ratings tr ON tr.therapist_id = u.id
+CROSS JOIN (
+  SELECT COALESCE(SUM(rating_sum)::float8 / NULLIF(SUM(review_count), 0), 0) AS prior_mean
+  FROM therapist_ratings
+) g
+CROSS JOIN LATERAL (
+  SELECT COUNT(*) AS open_slots, MIN(s.start_ts) AS next_slot
+  FROM availability_slots s
+  WHERE s.therapist_id = u.id AND s.status = 'open' AND s.start_ts > $1
+) slots
+WHERE u.role = 'pt' AND u.disabled_at IS NULL
+  AND ($6::float8 <= 0 OR (
+    p.latitude BETWEEN $7::float8 AND $8::float8
+    AND p.longitude BETWEEN $9::float8 AND $10::float8
+    AND haversine_km($3::float8, $4::float8, p.latitude, p.longitude) <= $6::float8
+  ))
+ORDER BY rating_score(tr.rating_sum, tr.review_count, g.prior_mean) DESC NULLS LAST, u.id
+LIMIT $5;
@@ -0,0 +1,19 @@
+-- name: CreateUpload :one
+-- params: owner_id uuid, kind text, filename text, content_type text, size_bytes bigint, storage_key text, variants text[]
+INSERT INTO uploads (owner_id, kind, filename, content_type, size_bytes, storage_key, variants)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, owner_id, kind, filename, content_type, size_bytes, storage_key, variants, created_at;
+
+-- name: GetUpload :one
+-- params: id uuid
+SELECT id, owner_id, kind, filename, content_type, size_bytes, storage_key, variants, created_at
+FROM uploads
+WHERE id = $1;
+
+-- name: SetProfileImage :exec
+-- params: user_id uuid, profile_image_url text
+INSERT INTO profiles (user_id, profile_image_url)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE SET
+  profile_image_url = EXCLUDED.profile_image_url,
+  updated_at = now();
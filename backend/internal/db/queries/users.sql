@@ -7,34 +7,57 @@ RETURNING id;
 
 -- name: GetUserByEmail :one
 -- params: email text
-SELECT id, email, password_hash, role, created_at, updated_at
+SELECT id, email, password_hash, role, created_at, updated_at, clinic_id, disabled_at, password_reset_required
 FROM users
 WHERE email = $1;
 
 -- name: GetUserByID :one
 -- params: id uuid
-SELECT id, email, password_hash, role, created_at, updated_at
+SELECT id, email, password_hash, role, created_at, updated_at, clinic_id, disabled_at, password_reset_required
 FROM users
 WHERE id = $1;
 
+-- name: UpdateUserPassword :execrows
+-- params: id uuid, password_hash text
+UPDATE users
+SET password_hash = $2, password_reset_required = false, updated_at = now()
+WHERE id = $1;
+
 -- name: CreateOrUpdateProfile :one
--- params: user_id uuid, display_name text, bio text, phone text, address jsonb, specialties text[], profile_extra jsonb
+-- params: user_id uuid, display_name text, bio text, phone text, address jsonb, specialties text[], latitude float8, longitude float8, age int, gender text, condition text, goals text, credentials text, location text, profile_image_url text, years_experience int, languages text[], session_price_cents int, price_currency text, insurers text[]
 -- result: id uuid
-INSERT INTO profiles (user_id, display_name, bio, phone, address, specialties, profile_extra)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO profiles (user_id, display_name, bio, phone, address, specialties, latitude, longitude,
+  age, gender, condition, goals, credentials, location, profile_image_url, years_experience, languages,
+  session_price_cents, price_currency, insurers)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
 ON CONFLICT (user_id) DO UPDATE SET
   display_name = EXCLUDED.display_name,
   bio = EXCLUDED.bio,
   phone = EXCLUDED.phone,
   address = EXCLUDED.address,
   specialties = EXCLUDED.specialties,
-  profile_extra = EXCLUDED.profile_extra,
+  latitude = EXCLUDED.latitude,
+  longitude = EXCLUDED.longitude,
+  age = EXCLUDED.age,
+  gender = EXCLUDED.gender,
+  condition = EXCLUDED.condition,
+  goals = EXCLUDED.goals,
+  credentials = EXCLUDED.credentials,
+  location = EXCLUDED.location,
+  profile_image_url = EXCLUDED.profile_image_url,
+  years_experience = EXCLUDED.years_experience,
+  languages = EXCLUDED.languages,
+  session_price_cents = EXCLUDED.session_price_cents,
+  price_currency = EXCLUDED.price_currency,
+  insurers = EXCLUDED.insurers,
   updated_at = now()
 RETURNING id;
 
 -- name: GetProfileByUserID :one
 -- params: user_id uuid
-SELECT id, user_id, display_name, bio, phone, address, specialties, profile_extra, created_at, updated_at
+SELECT id, user_id, display_name, bio, phone, address, specialties, created_at, updated_at, latitude, longitude, is_verified, verified_at,
+       age, gender, condition, goals, credentials, location, profile_image_url, years_experience, languages,
+       session_price_cents, price_currency, insurers
 FROM profiles
 WHERE user_id = $1;
 
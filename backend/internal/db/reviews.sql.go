@@ -8,41 +8,221 @@ package db
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+const adjustTherapistRating = `-- name: AdjustTherapistRating :one
+INSERT INTO therapist_ratings AS t (therapist_id, review_count, rating_sum, stars_1, stars_2, stars_3, stars_4, stars_5,
+                                    communication_sum, communication_count, punctuality_sum, punctuality_count,
+                                    effectiveness_sum, effectiveness_count, facility_sum, facility_count)
+VALUES ($1, $3::int, $2::int * $3::int,
+        CASE WHEN $2::int = 1 THEN $3::int ELSE 0 END,
+        CASE WHEN $2::int = 2 THEN $3::int ELSE 0 END,
+        CASE WHEN $2::int = 3 THEN $3::int ELSE 0 END,
+        CASE WHEN $2::int = 4 THEN $3::int ELSE 0 END,
+        CASE WHEN $2::int = 5 THEN $3::int ELSE 0 END,
+        COALESCE($4::int, 0) * $3::int, CASE WHEN $4::int IS NULL THEN 0 ELSE $3::int END,
+        COALESCE($5::int, 0) * $3::int, CASE WHEN $5::int IS NULL THEN 0 ELSE $3::int END,
+        COALESCE($6::int, 0) * $3::int, CASE WHEN $6::int IS NULL THEN 0 ELSE $3::int END,
+        COALESCE($7::int, 0) * $3::int, CASE WHEN $7::int IS NULL THEN 0 ELSE $3::int END)
+ON CONFLICT (therapist_id) DO UPDATE
+SET review_count = t.review_count + EXCLUDED.review_count,
+    rating_sum = t.rating_sum + EXCLUDED.rating_sum,
+    stars_1 = t.stars_1 + EXCLUDED.stars_1,
+    stars_2 = t.stars_2 + EXCLUDED.stars_2,
+    stars_3 = t.stars_3 + EXCLUDED.stars_3,
+    stars_4 = t.stars_4 + EXCLUDED.stars_4,
+    stars_5 = t.stars_5 + EXCLUDED.stars_5,
+    communication_sum = t.communication_sum + EXCLUDED.communication_sum,
+    communication_count = t.communication_count + EXCLUDED.communication_count,
+    punctuality_sum = t.punctuality_sum + EXCLUDED.punctuality_sum,
+    punctuality_count = t.punctuality_count + EXCLUDED.punctuality_count,
+    effectiveness_sum = t.effectiveness_sum + EXCLUDED.effectiveness_sum,
+    effectiveness_count = t.effectiveness_count + EXCLUDED.effectiveness_count,
+    facility_sum = t.facility_sum + EXCLUDED.facility_sum,
+    facility_count = t.facility_count + EXCLUDED.facility_count,
+    updated_at = now()
+RETURNING review_count, rating_sum
+`
+
+type AdjustTherapistRatingParams struct {
+	TherapistID         uuid.UUID
+	Rating              int32
+	Delta               int32
+	CommunicationRating sql.NullInt32
+	PunctualityRating   sql.NullInt32
+	EffectivenessRating sql.NullInt32
+	FacilityRating      sql.NullInt32
+}
+
+type AdjustTherapistRatingRow struct {
+	ReviewCount int32
+	RatingSum   int32
+}
+
+// params: therapist_id uuid, rating int, delta int, communication_rating int, punctuality_rating int, effectiveness_rating int, facility_rating int
+func (q *Queries) AdjustTherapistRating(ctx context.Context, arg AdjustTherapistRatingParams) (AdjustTherapistRatingRow, error) {
+	row := q.db.QueryRowContext(ctx, adjustTherapistRating,
+		arg.TherapistID,
+		arg.Rating,
+		arg.Delta,
+		arg.CommunicationRating,
+		arg.PunctualityRating,
+		arg.EffectivenessRating,
+		arg.FacilityRating,
+	)
+	var i AdjustTherapistRatingRow
+	err := row.Scan(&i.ReviewCount, &i.RatingSum)
+	return i, err
+}
+
 const createReview = `-- name: CreateReview :one
-INSERT INTO reviews (appointment_id, patient_id, rating, comment)
-VALUES ($1, $2, $3, $4)
-RETURNING id
+INSERT INTO reviews (appointment_id, patient_id, rating, comment, status, moderation_reason,
+                     communication_rating, punctuality_rating, effectiveness_rating, facility_rating)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+ON CONFLICT (appointment_id) DO NOTHING
+RETURNING id, created_at
 `
 
 type CreateReviewParams struct {
-	AppointmentID uuid.UUID
-	PatientID     uuid.UUID
-	Rating        int32
-	Comment       sql.NullString
+	AppointmentID       uuid.UUID
+	PatientID           uuid.UUID
+	Rating              int32
+	Comment             sql.NullString
+	Status              string
+	ModerationReason    sql.NullString
+	CommunicationRating sql.NullInt32
+	PunctualityRating   sql.NullInt32
+	EffectivenessRating sql.NullInt32
+	FacilityRating      sql.NullInt32
+}
+
+type CreateReviewRow struct {
+	ID        uuid.UUID
+	CreatedAt time.Time
 }
 
-// params: appointment_id uuid, patient_id uuid, rating int, comment text
-func (q *Queries) CreateReview(ctx context.Context, arg CreateReviewParams) (uuid.UUID, error) {
+// params: appointment_id uuid, patient_id uuid, rating int, comment text, status text, moderation_reason text, communication_rating int, punctuality_rating int, effectiveness_rating int, facility_rating int
+func (q *Queries) CreateReview(ctx context.Context, arg CreateReviewParams) (CreateReviewRow, error) {
 	row := q.db.QueryRowContext(ctx, createReview,
 		arg.AppointmentID,
 		arg.PatientID,
 		arg.Rating,
 		arg.Comment,
+		arg.Status,
+		arg.ModerationReason,
+		arg.CommunicationRating,
+		arg.PunctualityRating,
+		arg.EffectivenessRating,
+		arg.FacilityRating,
 	)
-	var id uuid.UUID
-	err := row.Scan(&id)
-	return id, err
+	var i CreateReviewRow
+	err := row.Scan(&i.ID, &i.CreatedAt)
+	return i, err
+}
+
+const createReviewReply = `-- name: CreateReviewReply :one
+INSERT INTO review_replies (review_id, therapist_id, body)
+VALUES ($1, $2, $3)
+ON CONFLICT (review_id) DO NOTHING
+RETURNING id, created_at
+`
+
+type CreateReviewReplyParams struct {
+	ReviewID    uuid.UUID
+	TherapistID uuid.UUID
+	Body        string
+}
+
+type CreateReviewReplyRow struct {
+	ID        uuid.UUID
+	CreatedAt time.Time
+}
+
+// params: review_id uuid, therapist_id uuid, body text
+func (q *Queries) CreateReviewReply(ctx context.Context, arg CreateReviewReplyParams) (CreateReviewReplyRow, error) {
+	row := q.db.QueryRowContext(ctx, createReviewReply, arg.ReviewID, arg.TherapistID, arg.Body)
+	var i CreateReviewReplyRow
+	err := row.Scan(&i.ID, &i.CreatedAt)
+	return i, err
+}
+
+const createReviewReport = `-- name: CreateReviewReport :exec
+INSERT INTO review_reports (review_id, reporter_id, reason)
+VALUES ($1, $2, $3)
+ON CONFLICT (review_id, reporter_id) DO NOTHING
+`
+
+type CreateReviewReportParams struct {
+	ReviewID   uuid.UUID
+	ReporterID uuid.UUID
+	Reason     string
+}
+
+// params: review_id uuid, reporter_id uuid, reason text
+func (q *Queries) CreateReviewReport(ctx context.Context, arg CreateReviewReportParams) error {
+	_, err := q.db.ExecContext(ctx, createReviewReport, arg.ReviewID, arg.ReporterID, arg.Reason)
+	return err
+}
+
+const createReviewRevision = `-- name: CreateReviewRevision :exec
+INSERT INTO review_revisions (review_id, appointment_id, patient_id, rating, comment, written_at, action,
+                              communication_rating, punctuality_rating, effectiveness_rating, facility_rating)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+`
+
+type CreateReviewRevisionParams struct {
+	ReviewID            uuid.UUID
+	AppointmentID       uuid.UUID
+	PatientID           uuid.UUID
+	Rating              int32
+	Comment             sql.NullString
+	WrittenAt           time.Time
+	Action              string
+	CommunicationRating sql.NullInt32
+	PunctualityRating   sql.NullInt32
+	EffectivenessRating sql.NullInt32
+	FacilityRating      sql.NullInt32
+}
+
+// params: review_id uuid, appointment_id uuid, patient_id uuid, rating int, comment text, written_at timestamptz, action text, communication_rating int, punctuality_rating int, effectiveness_rating int, facility_rating int
+func (q *Queries) CreateReviewRevision(ctx context.Context, arg CreateReviewRevisionParams) error {
+	_, err := q.db.ExecContext(ctx, createReviewRevision,
+		arg.ReviewID,
+		arg.AppointmentID,
+		arg.PatientID,
+		arg.Rating,
+		arg.Comment,
+		arg.WrittenAt,
+		arg.Action,
+		arg.CommunicationRating,
+		arg.PunctualityRating,
+		arg.EffectivenessRating,
+		arg.FacilityRating,
+	)
+	return err
+}
+
+const deleteReview = `-- name: DeleteReview :exec
+DELETE FROM reviews WHERE id = $1
+`
+
+// params: id uuid
+func (q *Queries) DeleteReview(ctx context.Context, id uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, deleteReview, id)
+	return err
 }
 
 const getAppointmentForReview = `-- name: GetAppointmentForReview :one
-SELECT id
-FROM appointments
-WHERE id = $1 AND patient_id = $2 AND status = 'confirmed'
+SELECT a.id, a.therapist_id, a.status, s.end_ts,
+       (EXISTS (SELECT 1 FROM reviews r WHERE r.appointment_id = a.id)
+        OR EXISTS (SELECT 1 FROM review_revisions v WHERE v.appointment_id = a.id AND v.action = 'withdrawn')) AS reviewed
+FROM appointments a
+JOIN availability_slots s ON s.id = a.slot_id
+WHERE a.id = $1 AND a.patient_id = $2
 `
 
 type GetAppointmentForReviewParams struct {
@@ -50,31 +230,255 @@ type GetAppointmentForReviewParams struct {
 	PatientID uuid.UUID
 }
 
+type GetAppointmentForReviewRow struct {
+	ID          uuid.UUID
+	TherapistID uuid.UUID
+	Status      string
+	EndTs       time.Time
+	Reviewed    bool
+}
+
 // params: appointment_id uuid, patient_id uuid
-func (q *Queries) GetAppointmentForReview(ctx context.Context, arg GetAppointmentForReviewParams) (uuid.UUID, error) {
+func (q *Queries) GetAppointmentForReview(ctx context.Context, arg GetAppointmentForReviewParams) (GetAppointmentForReviewRow, error) {
 	row := q.db.QueryRowContext(ctx, getAppointmentForReview, arg.ID, arg.PatientID)
+	var i GetAppointmentForReviewRow
+	err := row.Scan(
+		&i.ID,
+		&i.TherapistID,
+		&i.Status,
+		&i.EndTs,
+		&i.Reviewed,
+	)
+	return i, err
+}
+
+const getLatestReviewableAppointment = `-- name: GetLatestReviewableAppointment :one
+SELECT a.id
+FROM appointments a
+JOIN availability_slots s ON s.id = a.slot_id
+WHERE a.patient_id = $1 AND a.therapist_id = $2
+  AND a.status IN ('confirmed', 'completed')
+  AND s.end_ts <= $3
+  AND NOT EXISTS (SELECT 1 FROM reviews r WHERE r.appointment_id = a.id)
+  AND NOT EXISTS (SELECT 1 FROM review_revisions v WHERE v.appointment_id = a.id AND v.action = 'withdrawn')
+ORDER BY s.end_ts DESC
+LIMIT 1
+`
+
+type GetLatestReviewableAppointmentParams struct {
+	PatientID   uuid.UUID
+	TherapistID uuid.UUID
+	Now         time.Time
+}
+
+// params: patient_id uuid, therapist_id uuid, now timestamptz
+func (q *Queries) GetLatestReviewableAppointment(ctx context.Context, arg GetLatestReviewableAppointmentParams) (uuid.UUID, error) {
+	row := q.db.QueryRowContext(ctx, getLatestReviewableAppointment, arg.PatientID, arg.TherapistID, arg.Now)
 	var id uuid.UUID
 	err := row.Scan(&id)
 	return id, err
 }
 
+const getModeratedReview = `-- name: GetModeratedReview :one
+SELECT r.id, r.appointment_id, a.therapist_id, r.patient_id, r.rating, r.comment, r.status,
+       r.moderation_reason, r.moderated_by, r.moderated_at, r.created_at, r.updated_at,
+       COALESCE((SELECT json_agg(json_build_object('reporterId', rr.reporter_id, 'reason', rr.reason, 'createdAt', rr.created_at) ORDER BY rr.created_at)
+                 FROM review_reports rr WHERE rr.review_id = r.id AND rr.resolved_at IS NULL), '[]')::json AS reports
+FROM reviews r
+JOIN appointments a ON a.id = r.appointment_id
+WHERE r.id = $1
+`
+
+type GetModeratedReviewRow struct {
+	ID               uuid.UUID
+	AppointmentID    uuid.UUID
+	TherapistID      uuid.UUID
+	PatientID        uuid.UUID
+	Rating           int32
+	Comment          sql.NullString
+	Status           string
+	ModerationReason sql.NullString
+	ModeratedBy      uuid.NullUUID
+	ModeratedAt      sql.NullTime
+	CreatedAt        time.Time
+	UpdatedAt        sql.NullTime
+	Reports          json.RawMessage
+}
+
+// params: id uuid
+func (q *Queries) GetModeratedReview(ctx context.Context, id uuid.UUID) (GetModeratedReviewRow, error) {
+	row := q.db.QueryRowContext(ctx, getModeratedReview, id)
+	var i GetModeratedReviewRow
+	err := row.Scan(
+		&i.ID,
+		&i.AppointmentID,
+		&i.TherapistID,
+		&i.PatientID,
+		&i.Rating,
+		&i.Comment,
+		&i.Status,
+		&i.ModerationReason,
+		&i.ModeratedBy,
+		&i.ModeratedAt,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Reports,
+	)
+	return i, err
+}
+
+const getReviewForUpdate = `-- name: GetReviewForUpdate :one
+SELECT r.id, r.appointment_id, r.patient_id, r.rating, r.comment, r.created_at,
+       COALESCE(r.updated_at, r.created_at) AS written_at, a.therapist_id, r.status,
+       r.communication_rating, r.punctuality_rating, r.effectiveness_rating, r.facility_rating
+FROM reviews r
+JOIN appointments a ON a.id = r.appointment_id
+WHERE r.id = $1
+FOR UPDATE OF r
+`
+
+type GetReviewForUpdateRow struct {
+	ID                  uuid.UUID
+	AppointmentID       uuid.UUID
+	PatientID           uuid.UUID
+	Rating              int32
+	Comment             sql.NullString
+	CreatedAt           time.Time
+	WrittenAt           time.Time
+	TherapistID         uuid.UUID
+	Status              string
+	CommunicationRating sql.NullInt32
+	PunctualityRating   sql.NullInt32
+	EffectivenessRating sql.NullInt32
+	FacilityRating      sql.NullInt32
+}
+
+// params: id uuid
+func (q *Queries) GetReviewForUpdate(ctx context.Context, id uuid.UUID) (GetReviewForUpdateRow, error) {
+	row := q.db.QueryRowContext(ctx, getReviewForUpdate, id)
+	var i GetReviewForUpdateRow
+	err := row.Scan(
+		&i.ID,
+		&i.AppointmentID,
+		&i.PatientID,
+		&i.Rating,
+		&i.Comment,
+		&i.CreatedAt,
+		&i.WrittenAt,
+		&i.TherapistID,
+		&i.Status,
+		&i.CommunicationRating,
+		&i.PunctualityRating,
+		&i.EffectivenessRating,
+		&i.FacilityRating,
+	)
+	return i, err
+}
+
+const getReviewRepliesForPatient = `-- name: GetReviewRepliesForPatient :many
+SELECT rp.id, rp.review_id, rp.therapist_id, rp.created_at, COALESCE(p.display_name,'') AS display_name
+FROM review_replies rp
+JOIN reviews r ON r.id = rp.review_id
+LEFT JOIN profiles p ON p.user_id = rp.therapist_id
+WHERE r.patient_id = $1 AND r.status = 'published'
+  AND rp.created_at > $2
+ORDER BY rp.created_at DESC
+LIMIT $3
+`
+
+type GetReviewRepliesForPatientParams struct {
+	PatientID uuid.UUID
+	CreatedAt time.Time
+	Limit     int32
+}
+
+type GetReviewRepliesForPatientRow struct {
+	ID          uuid.UUID
+	ReviewID    uuid.UUID
+	TherapistID uuid.UUID
+	CreatedAt   time.Time
+	DisplayName string
+}
+
+// params: patient_id uuid, created_at timestamptz, limit int
+func (q *Queries) GetReviewRepliesForPatient(ctx context.Context, arg GetReviewRepliesForPatientParams) ([]GetReviewRepliesForPatientRow, error) {
+	rows, err := q.db.QueryContext(ctx, getReviewRepliesForPatient, arg.PatientID, arg.CreatedAt, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetReviewRepliesForPatientRow
+	for rows.Next() {
+		var i GetReviewRepliesForPatientRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ReviewID,
+			&i.TherapistID,
+			&i.CreatedAt,
+			&i.DisplayName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReviewStatus = `-- name: GetReviewStatus :one
+SELECT r.patient_id, a.therapist_id, r.status
+FROM reviews r
+JOIN appointments a ON a.id = r.appointment_id
+WHERE r.id = $1
+`
+
+type GetReviewStatusRow struct {
+	PatientID   uuid.UUID
+	TherapistID uuid.UUID
+	Status      string
+}
+
+// params: id uuid
+func (q *Queries) GetReviewStatus(ctx context.Context, id uuid.UUID) (GetReviewStatusRow, error) {
+	row := q.db.QueryRowContext(ctx, getReviewStatus, id)
+	var i GetReviewStatusRow
+	err := row.Scan(&i.PatientID, &i.TherapistID, &i.Status)
+	return i, err
+}
+
 const getReviewsForTherapist = `-- name: GetReviewsForTherapist :many
-SELECT r.id::text as review_id, r.patient_id, r.rating, r.comment,
-       p.display_name as patient_name, r.created_at
+SELECT r.id::text as review_id, r.appointment_id, r.patient_id, r.rating, r.comment,
+       p.display_name as patient_name, r.created_at, r.updated_at,
+       r.communication_rating, r.punctuality_rating, r.effectiveness_rating, r.facility_rating,
+       rp.body as reply_body, rp.created_at as reply_created_at
 FROM reviews r
 JOIN appointments a ON a.id = r.appointment_id
 LEFT JOIN profiles p ON p.user_id = r.patient_id
-WHERE a.therapist_id = $1
+LEFT JOIN review_replies rp ON rp.review_id = r.id
+WHERE a.therapist_id = $1 AND r.status = 'published'
 ORDER BY r.created_at DESC
 `
 
 type GetReviewsForTherapistRow struct {
-	ReviewID    string
-	PatientID   uuid.UUID
-	Rating      int32
-	Comment     sql.NullString
-	PatientName sql.NullString
-	CreatedAt   time.Time
+	ReviewID            string
+	AppointmentID       uuid.UUID
+	PatientID           uuid.UUID
+	Rating              int32
+	Comment             sql.NullString
+	PatientName         sql.NullString
+	CreatedAt           time.Time
+	UpdatedAt           sql.NullTime
+	CommunicationRating sql.NullInt32
+	PunctualityRating   sql.NullInt32
+	EffectivenessRating sql.NullInt32
+	FacilityRating      sql.NullInt32
+	ReplyBody           sql.NullString
+	ReplyCreatedAt      sql.NullTime
 }
 
 // params: therapist_id uuid
@@ -89,11 +493,19 @@ func (q *Queries) GetReviewsForTherapist(ctx context.Context, therapistID uuid.U
 		var i GetReviewsForTherapistRow
 		if err := rows.Scan(
 			&i.ReviewID,
+			&i.AppointmentID,
 			&i.PatientID,
 			&i.Rating,
 			&i.Comment,
 			&i.PatientName,
 			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.CommunicationRating,
+			&i.PunctualityRating,
+			&i.EffectivenessRating,
+			&i.FacilityRating,
+			&i.ReplyBody,
+			&i.ReplyCreatedAt,
 		); err != nil {
 			return nil, err
 		}
@@ -108,19 +520,197 @@ func (q *Queries) GetReviewsForTherapist(ctx context.Context, therapistID uuid.U
 	return items, nil
 }
 
-const getTherapistAverageRating = `-- name: GetTherapistAverageRating :one
-SELECT AVG(r.rating)::float as avg_rating
+const getReviewsForTherapistPage = `-- name: GetReviewsForTherapistPage :many
+SELECT r.id::text as review_id, r.appointment_id, r.patient_id, r.rating, r.comment,
+       p.display_name as patient_name, r.created_at, r.updated_at,
+       r.communication_rating, r.punctuality_rating, r.effectiveness_rating, r.facility_rating,
+       rp.body as reply_body, rp.created_at as reply_created_at
 FROM reviews r
 JOIN appointments a ON a.id = r.appointment_id
-WHERE a.therapist_id = $1
+LEFT JOIN profiles p ON p.user_id = r.patient_id
+LEFT JOIN review_replies rp ON rp.review_id = r.id
+WHERE a.therapist_id = $1 AND r.status = 'published'
+  AND (NOT $2::boolean OR (r.created_at, r.id) < ($3::timestamptz, $4::uuid))
+ORDER BY r.created_at DESC, r.id DESC
+LIMIT $5
 `
 
-// params: therapist_id uuid
-func (q *Queries) GetTherapistAverageRating(ctx context.Context, therapistID uuid.UUID) (float64, error) {
-	row := q.db.QueryRowContext(ctx, getTherapistAverageRating, therapistID)
-	var avg_rating float64
-	err := row.Scan(&avg_rating)
-	return avg_rating, err
+type GetReviewsForTherapistPageParams struct {
+	TherapistID uuid.UUID
+	Column2     bool
+	Column3     time.Time
+	Column4     uuid.UUID
+	Limit       int32
+}
+
+type GetReviewsForTherapistPageRow struct {
+	ReviewID            string
+	AppointmentID       uuid.UUID
+	PatientID           uuid.UUID
+	Rating              int32
+	Comment             sql.NullString
+	PatientName         sql.NullString
+	CreatedAt           time.Time
+	UpdatedAt           sql.NullTime
+	CommunicationRating sql.NullInt32
+	PunctualityRating   sql.NullInt32
+	EffectivenessRating sql.NullInt32
+	FacilityRating      sql.NullInt32
+	ReplyBody           sql.NullString
+	ReplyCreatedAt      sql.NullTime
+}
+
+// params: therapist_id uuid, has_cursor bool, created_at timestamptz, id uuid, limit int
+func (q *Queries) GetReviewsForTherapistPage(ctx context.Context, arg GetReviewsForTherapistPageParams) ([]GetReviewsForTherapistPageRow, error) {
+	rows, err := q.db.QueryContext(ctx, getReviewsForTherapistPage,
+		arg.TherapistID,
+		arg.Column2,
+		arg.Column3,
+		arg.Column4,
+		arg.Limit,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetReviewsForTherapistPageRow
+	for rows.Next() {
+		var i GetReviewsForTherapistPageRow
+		if err := rows.Scan(
+			&i.ReviewID,
+			&i.AppointmentID,
+			&i.PatientID,
+			&i.Rating,
+			&i.Comment,
+			&i.PatientName,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.CommunicationRating,
+			&i.PunctualityRating,
+			&i.EffectivenessRating,
+			&i.FacilityRating,
+			&i.ReplyBody,
+			&i.ReplyCreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listReviewModerationQueue = `-- name: ListReviewModerationQueue :many
+SELECT r.id, r.appointment_id, a.therapist_id, r.patient_id, r.rating, r.comment, r.status,
+       r.moderation_reason, r.moderated_by, r.moderated_at, r.created_at, r.updated_at,
+       COALESCE((SELECT json_agg(json_build_object('reporterId', rr.reporter_id, 'reason', rr.reason, 'createdAt', rr.created_at) ORDER BY rr.created_at)
+                 FROM review_reports rr WHERE rr.review_id = r.id AND rr.resolved_at IS NULL), '[]')::json AS reports
+FROM reviews r
+JOIN appointments a ON a.id = r.appointment_id
+WHERE ($1::text = '' OR r.status = $1::text)
+  AND (NOT $2::boolean OR EXISTS (SELECT 1 FROM review_reports rr WHERE rr.review_id = r.id AND rr.resolved_at IS NULL))
+ORDER BY r.created_at, r.id
+LIMIT $3
+`
+
+type ListReviewModerationQueueParams struct {
+	Column1 string
+	Column2 bool
+	Limit   int32
+}
+
+type ListReviewModerationQueueRow struct {
+	ID               uuid.UUID
+	AppointmentID    uuid.UUID
+	TherapistID      uuid.UUID
+	PatientID        uuid.UUID
+	Rating           int32
+	Comment          sql.NullString
+	Status           string
+	ModerationReason sql.NullString
+	ModeratedBy      uuid.NullUUID
+	ModeratedAt      sql.NullTime
+	CreatedAt        time.Time
+	UpdatedAt        sql.NullTime
+	Reports          json.RawMessage
+}
+
+// params: status text, reported bool, limit int
+func (q *Queries) ListReviewModerationQueue(ctx context.Context, arg ListReviewModerationQueueParams) ([]ListReviewModerationQueueRow, error) {
+	rows, err := q.db.QueryContext(ctx, listReviewModerationQueue, arg.Column1, arg.Column2, arg.Limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListReviewModerationQueueRow
+	for rows.Next() {
+		var i ListReviewModerationQueueRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.AppointmentID,
+			&i.TherapistID,
+			&i.PatientID,
+			&i.Rating,
+			&i.Comment,
+			&i.Status,
+			&i.ModerationReason,
+			&i.ModeratedBy,
+			&i.ModeratedAt,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Reports,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const moderateReview = `-- name: ModerateReview :exec
+UPDATE reviews
+SET status = $2, moderation_reason = $3, moderated_by = $4, moderated_at = now()
+WHERE id = $1
+`
+
+type ModerateReviewParams struct {
+	ID               uuid.UUID
+	Status           string
+	ModerationReason sql.NullString
+	ModeratedBy      uuid.NullUUID
+}
+
+// params: id uuid, status text, moderation_reason text, moderated_by uuid
+func (q *Queries) ModerateReview(ctx context.Context, arg ModerateReviewParams) error {
+	_, err := q.db.ExecContext(ctx, moderateReview,
+		arg.ID,
+		arg.Status,
+		arg.ModerationReason,
+		arg.ModeratedBy,
+	)
+	return err
+}
+
+const resolveReviewReports = `-- name: ResolveReviewReports :exec
+UPDATE review_reports SET resolved_at = now()
+WHERE review_id = $1 AND resolved_at IS NULL
+`
+
+// params: review_id uuid
+func (q *Queries) ResolveReviewReports(ctx context.Context, reviewID uuid.UUID) error {
+	_, err := q.db.ExecContext(ctx, resolveReviewReports, reviewID)
+	return err
 }
 
 const updateProfileRating = `-- name: UpdateProfileRating :exec
@@ -139,3 +729,43 @@ func (q *Queries) UpdateProfileRating(ctx context.Context, arg UpdateProfileRati
 	_, err := q.db.ExecContext(ctx, updateProfileRating, arg.UserID, arg.Rating)
 	return err
 }
+
+const updateReview = `-- name: UpdateReview :one
+UPDATE reviews
+SET rating = $2, comment = $3, updated_at = $4,
+    communication_rating = $6, punctuality_rating = $7, effectiveness_rating = $8, facility_rating = $9,
+    status = CASE WHEN $5::text <> '' THEN 'pending' ELSE status END,
+    moderation_reason = COALESCE(NULLIF($5::text, ''), moderation_reason)
+WHERE id = $1
+RETURNING status
+`
+
+type UpdateReviewParams struct {
+	ID                  uuid.UUID
+	Rating              int32
+	Comment             sql.NullString
+	UpdatedAt           sql.NullTime
+	HeldFor             string
+	CommunicationRating sql.NullInt32
+	PunctualityRating   sql.NullInt32
+	EffectivenessRating sql.NullInt32
+	FacilityRating      sql.NullInt32
+}
+
+// params: id uuid, rating int, comment text, updated_at timestamptz, held_for text, communication_rating int, punctuality_rating int, effectiveness_rating int, facility_rating int
+func (q *Queries) UpdateReview(ctx context.Context, arg UpdateReviewParams) (string, error) {
+	row := q.db.QueryRowContext(ctx, updateReview,
+		arg.ID,
+		arg.Rating,
+		arg.Comment,
+		arg.UpdatedAt,
+		arg.HeldFor,
+		arg.CommunicationRating,
+		arg.PunctualityRating,
+		arg.EffectivenessRating,
+		arg.FacilityRating,
+	)
+	var status string
+	err := row.Scan(&status)
+	return status, err
+}
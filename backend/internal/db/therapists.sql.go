@@ -61,7 +61,7 @@ func (q *Queries) GetAvailabilityCounts(ctx context.Context, arg GetAvailability
 const getReviewCounts = `-- name: GetReviewCounts :many
 SELECT a.therapist_id::text, COUNT(r.id)
 FROM appointments a
-JOIN reviews r ON r.appointment_id = a.id
+JOIN reviews r ON r.appointment_id = a.id AND r.status = 'published'
 WHERE a.therapist_id = ANY($1::uuid[])
 GROUP BY a.therapist_id
 `
@@ -142,7 +142,7 @@ func (q *Queries) GetTherapistAvailabilitySlots(ctx context.Context, arg GetTher
 const getTherapistByID = `-- name: GetTherapistByID :one
 SELECT u.id, u.email, COALESCE(p.display_name,''), COALESCE(p.specialties, ARRAY[]::text[]), p.address, COALESCE(p.bio,''), p.rating
 FROM users u LEFT JOIN profiles p ON p.user_id = u.id
-WHERE u.id = $1 AND u.role = 'pt'
+WHERE u.id = $1 AND u.role = 'pt' AND u.disabled_at IS NULL
 `
 
 type GetTherapistByIDRow struct {
@@ -175,19 +175,73 @@ const getTherapistCount = `-- name: GetTherapistCount :one
 SELECT COUNT(*)
 FROM users u
 LEFT JOIN profiles p ON p.user_id = u.id
-WHERE u.role = 'pt'
+WHERE u.role = 'pt' AND u.disabled_at IS NULL
   AND ($1 = '' OR p.specialties::text ILIKE $1)
-  AND ($2 = '' OR p.address::text ILIKE $2)
+  AND ($2 = '' OR p.address::text ILIKE $2 OR p.location ILIKE $2)
+  AND ($5::text = '' OR p.search_tsv @@ websearch_to_tsquery('english', $5::text))
+  AND ($8::float8 <= 0 OR (
+    p.latitude BETWEEN $9::float8 AND $10::float8
+    AND p.longitude BETWEEN $11::float8 AND $12::float8
+    AND haversine_km($6::float8, $7::float8, p.latitude, p.longitude) <= $8::float8
+  ))
+  AND (NOT $4::boolean OR EXISTS (
+    SELECT 1 FROM availability_slots s
+    WHERE s.therapist_id = u.id
+      AND s.status = 'open'
+      AND s.start_ts > now()
+      AND ($3::text = '' OR s.start_ts::date = NULLIF($3::text, '')::date)
+  ))
+  AND (NOT $13::boolean OR p.is_verified)
+  AND ($14::text = '' OR EXISTS (SELECT 1 FROM unnest(p.insurers) AS i(name) WHERE lower(i.name) = lower($14::text)))
+  AND ($15::text = '' OR EXISTS (SELECT 1 FROM unnest(p.languages) AS l(name) WHERE lower(l.name) = lower($15::text)))
+  AND ($16::int < 0 OR p.session_price_cents >= $16::int)
+  AND ($17::int < 0 OR p.session_price_cents <= $17::int)
+  AND ($18::text = '' OR p.price_currency = $18::text)
 `
 
 type GetTherapistCountParams struct {
-	Column1 interface{}
-	Column2 interface{}
+	Column1  interface{}
+	Column2  interface{}
+	Column3  string
+	Column4  bool
+	Column5  string
+	Column6  float64
+	Column7  float64
+	Column8  float64
+	Column9  float64
+	Column10 float64
+	Column11 float64
+	Column12 float64
+	Column13 bool
+	Column14 string
+	Column15 string
+	Column16 int32
+	Column17 int32
+	Column18 string
 }
 
-// params: specialty text, location text
+// params: specialty text, location text, date text, available bool, q text, lat float8, lng float8, radius_km float8, min_lat float8, max_lat float8, min_lng float8, max_lng float8, verified bool, insurer text, language text, min_price_cents int, max_price_cents int, currency text
 func (q *Queries) GetTherapistCount(ctx context.Context, arg GetTherapistCountParams) (int64, error) {
-	row := q.db.QueryRowContext(ctx, getTherapistCount, arg.Column1, arg.Column2)
+	row := q.db.QueryRowContext(ctx, getTherapistCount,
+		arg.Column1,
+		arg.Column2,
+		arg.Column3,
+		arg.Column4,
+		arg.Column5,
+		arg.Column6,
+		arg.Column7,
+		arg.Column8,
+		arg.Column9,
+		arg.Column10,
+		arg.Column11,
+		arg.Column12,
+		arg.Column13,
+		arg.Column14,
+		arg.Column15,
+		arg.Column16,
+		arg.Column17,
+		arg.Column18,
+	)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
@@ -195,7 +249,7 @@ func (q *Queries) GetTherapistCount(ctx context.Context, arg GetTherapistCountPa
 
 const getTherapistReviewCount = `-- name: GetTherapistReviewCount :one
 SELECT COUNT(r.id)
-FROM appointments a JOIN reviews r ON r.appointment_id = a.id
+FROM appointments a JOIN reviews r ON r.appointment_id = a.id AND r.status = 'published'
 WHERE a.therapist_id = $1
 `
 
@@ -208,49 +262,285 @@ func (q *Queries) GetTherapistReviewCount(ctx context.Context, therapistID uuid.
 }
 
 const getTherapists = `-- name: GetTherapists :many
+SELECT t.id, t.email, t.display_name, t.specialties, t.address, t.rating, t.review_count, t.next_slot,
+       t.snippet, t.latitude, t.longitude, t.distance_km, t.is_verified,
+       t.session_price_cents, t.price_currency, t.insurers, t.languages, t.sort_num, t.sort_text, t.sort_age
+FROM (
+  SELECT u.id, u.email, COALESCE(p.display_name,'') AS display_name,
+         COALESCE(p.specialties, ARRAY[]::text[]) AS specialties,
+         p.address, p.rating, tr.review_count::bigint AS review_count, ns.next_slot,
+         CASE WHEN $8::text = '' THEN ''
+              ELSE COALESCE(ts_headline('english',
+                     concat_ws(' · ', array_to_string(p.specialties, ', '), p.credentials, p.bio),
+                     websearch_to_tsquery('english', $8::text),
+                     'StartSel=' || chr(2) || ', StopSel=' || chr(3) || ', MaxWords=30, MinWords=10, MaxFragments=2'), '')
+         END::text AS snippet,
+         p.latitude, p.longitude,
+         CASE WHEN $16::boolean THEN haversine_km($9::float8, $10::float8, p.latitude, p.longitude) END AS distance_km,
+         COALESCE(p.is_verified, false) AS is_verified,
+         p.session_price_cents, p.price_currency,
+         COALESCE(p.insurers, ARRAY[]::text[]) AS insurers,
+         COALESCE(p.languages, ARRAY[]::text[]) AS languages,
+         -- Every ordering is expressed as ascending keys so that one row
+         -- comparison can resume after a cursor; missing values sort last.
+         (CASE $5::text
+            WHEN 'relevance' THEN COALESCE(-ts_rank_cd(p.search_tsv, websearch_to_tsquery('english', $8::text))::float8, 'Infinity')
+            WHEN 'distance' THEN COALESCE(haversine_km($9::float8, $10::float8, p.latitude, p.longitude), 'Infinity')
+            WHEN 'rating' THEN COALESCE(-rating_score(tr.rating_sum, tr.review_count, g.prior_mean), 'Infinity')
+            WHEN 'reviews' THEN -COALESCE(tr.review_count, 0)::float8
+            WHEN 'availability' THEN COALESCE(extract(epoch FROM ns.next_slot)::float8, 'Infinity')
+            ELSE 0
+          END)::float8 AS sort_num,
+         (CASE WHEN $5::text = 'name' THEN lower(COALESCE(p.display_name,'')) ELSE '' END)::text AS sort_text,
+         (-extract(epoch FROM u.created_at))::float8 AS sort_age
+  FROM users u
+  LEFT JOIN profiles p ON p.user_id = u.id
+  LEFT JOIN therapist_ratings tr ON tr.therapist_id = u.id
+  -- The rating sort smooths each average toward the mean of all reviews
+  CROSS JOIN (
+    SELECT COALESCE(SUM(rating_sum)::float8 / NULLIF(SUM(review_count), 0), 0) AS prior_mean
+    FROM therapist_ratings
+  ) g
+  LEFT JOIN LATERAL (
+    SELECT MIN(s.start_ts) AS next_slot
+    FROM availability_slots s
+    WHERE s.therapist_id = u.id
+      AND s.status = 'open'
+      AND s.start_ts > now()
+      AND ($3::text = '' OR s.start_ts::date = NULLIF($3::text, '')::date)
+  ) ns ON true
+  WHERE u.role = 'pt' AND u.disabled_at IS NULL
+    AND ($1 = '' OR p.specialties::text ILIKE $1)
+    AND ($2 = '' OR p.address::text ILIKE $2 OR p.location ILIKE $2)
+    AND ($8::text = '' OR p.search_tsv @@ websearch_to_tsquery('english', $8::text))
+    AND ($11::float8 <= 0 OR (
+      p.latitude BETWEEN $12::float8 AND $13::float8
+      AND p.longitude BETWEEN $14::float8 AND $15::float8
+      AND haversine_km($9::float8, $10::float8, p.latitude, p.longitude) <= $11::float8
+    ))
+    AND (NOT $4::boolean OR ns.next_slot IS NOT NULL)
+    AND (NOT $22::boolean OR p.is_verified)
+    AND ($23::text = '' OR EXISTS (SELECT 1 FROM unnest(p.insurers) AS i(name) WHERE lower(i.name) = lower($23::text)))
+    AND ($24::text = '' OR EXISTS (SELECT 1 FROM unnest(p.languages) AS l(name) WHERE lower(l.name) = lower($24::text)))
+    AND ($25::int < 0 OR p.session_price_cents >= $25::int)
+    AND ($26::int < 0 OR p.session_price_cents <= $26::int)
+    AND ($27::text = '' OR p.price_currency = $27::text)
+) t
+WHERE NOT $17::boolean
+   OR (t.sort_num, t.sort_text, t.sort_age, t.id) > ($18::float8, $19::text, $20::float8, $21::uuid)
+ORDER BY t.sort_num, t.sort_text, t.sort_age, t.id
+LIMIT $6 OFFSET $7
+`
+
+type GetTherapistsParams struct {
+	Column1  interface{}
+	Column2  interface{}
+	Column3  string
+	Column4  bool
+	Column5  string
+	Limit    int32
+	Offset   int32
+	Column8  string
+	Column9  float64
+	Column10 float64
+	Column11 float64
+	Column12 float64
+	Column13 float64
+	Column14 float64
+	Column15 float64
+	Column16 bool
+	Column17 bool
+	Column18 float64
+	Column19 string
+	Column20 float64
+	Column21 uuid.UUID
+	Column22 bool
+	Column23 string
+	Column24 string
+	Column25 int32
+	Column26 int32
+	Column27 string
+}
+
+type GetTherapistsRow struct {
+	ID                uuid.UUID
+	Email             string
+	DisplayName       string
+	Specialties       []string
+	Address           pqtype.NullRawMessage
+	Rating            sql.NullString
+	ReviewCount       sql.NullInt64
+	NextSlot          sql.NullTime
+	Snippet           string
+	Latitude          sql.NullFloat64
+	Longitude         sql.NullFloat64
+	DistanceKm        sql.NullFloat64
+	IsVerified        bool
+	SessionPriceCents sql.NullInt32
+	PriceCurrency     sql.NullString
+	Insurers          []string
+	Languages         []string
+	SortNum           float64
+	SortText          string
+	SortAge           float64
+}
+
+// params: specialty text, location text, date text, available bool, sort text, limit int, offset int, q text, lat float8, lng float8, radius_km float8, min_lat float8, max_lat float8, min_lng float8, max_lng float8, near bool, has_cursor bool, cursor_num float8, cursor_text text, cursor_age float8, cursor_id uuid, verified bool, insurer text, language text, min_price_cents int, max_price_cents int, currency text
+func (q *Queries) GetTherapists(ctx context.Context, arg GetTherapistsParams) ([]GetTherapistsRow, error) {
+	rows, err := q.db.QueryContext(ctx, getTherapists,
+		arg.Column1,
+		arg.Column2,
+		arg.Column3,
+		arg.Column4,
+		arg.Column5,
+		arg.Limit,
+		arg.Offset,
+		arg.Column8,
+		arg.Column9,
+		arg.Column10,
+		arg.Column11,
+		arg.Column12,
+		arg.Column13,
+		arg.Column14,
+		arg.Column15,
+		arg.Column16,
+		arg.Column17,
+		arg.Column18,
+		arg.Column19,
+		arg.Column20,
+		arg.Column21,
+		arg.Column22,
+		arg.Column23,
+		arg.Column24,
+		arg.Column25,
+		arg.Column26,
+		arg.Column27,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTherapistsRow
+	for rows.Next() {
+		var i GetTherapistsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Email,
+			&i.DisplayName,
+			pq.Array(&i.Specialties),
+			&i.Address,
+			&i.Rating,
+			&i.ReviewCount,
+			&i.NextSlot,
+			&i.Snippet,
+			&i.Latitude,
+			&i.Longitude,
+			&i.DistanceKm,
+			&i.IsVerified,
+			&i.SessionPriceCents,
+			&i.PriceCurrency,
+			pq.Array(&i.Insurers),
+			pq.Array(&i.Languages),
+			&i.SortNum,
+			&i.SortText,
+			&i.SortAge,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRecommendationCandidates = `-- name: ListRecommendationCandidates :many
 SELECT u.id, u.email, COALESCE(p.display_name,'') AS display_name,
        COALESCE(p.specialties, ARRAY[]::text[]) AS specialties,
-       p.address, p.rating
+       p.address, p.rating, p.latitude, p.longitude,
+       CASE WHEN $2::boolean THEN haversine_km($3::float8, $4::float8, p.latitude, p.longitude) END AS distance_km,
+       COALESCE(p.is_verified, false) AS is_verified,
+       COALESCE(tr.review_count, 0)::bigint AS review_count,
+       slots.open_slots, slots.next_slot
 FROM users u
 LEFT JOIN profiles p ON p.user_id = u.id
-WHERE u.role = 'pt'
-  AND ($1 = '' OR p.specialties::text ILIKE $1)
-  AND ($2 = '' OR p.address::text ILIKE $2)
-ORDER BY u.created_at DESC
-LIMIT $3 OFFSET $4
+LEFT JOIN therapist_ratings tr ON tr.therapist_id = u.id
+CROSS JOIN (
+  SELECT COALESCE(SUM(rating_sum)::float8 / NULLIF(SUM(review_count), 0), 0) AS prior_mean
+  FROM therapist_ratings
+) g
+CROSS JOIN LATERAL (
+  SELECT COUNT(*) AS open_slots, MIN(s.start_ts) AS next_slot
+  FROM availability_slots s
+  WHERE s.therapist_id = u.id AND s.status = 'open' AND s.start_ts > $1
+) slots
+WHERE u.role = 'pt' AND u.disabled_at IS NULL
+  AND ($6::float8 <= 0 OR (
+    p.latitude BETWEEN $7::float8 AND $8::float8
+    AND p.longitude BETWEEN $9::float8 AND $10::float8
+    AND haversine_km($3::float8, $4::float8, p.latitude, p.longitude) <= $6::float8
+  ))
+ORDER BY rating_score(tr.rating_sum, tr.review_count, g.prior_mean) DESC NULLS LAST, u.id
+LIMIT $5
 `
 
-type GetTherapistsParams struct {
-	Column1 interface{}
-	Column2 interface{}
-	Limit   int32
-	Offset  int32
+type ListRecommendationCandidatesParams struct {
+	StartTs  time.Time
+	Column2  bool
+	Column3  float64
+	Column4  float64
+	Limit    int32
+	Column6  float64
+	Column7  float64
+	Column8  float64
+	Column9  float64
+	Column10 float64
 }
 
-type GetTherapistsRow struct {
+type ListRecommendationCandidatesRow struct {
 	ID          uuid.UUID
 	Email       string
 	DisplayName string
 	Specialties []string
 	Address     pqtype.NullRawMessage
 	Rating      sql.NullString
+	Latitude    sql.NullFloat64
+	Longitude   sql.NullFloat64
+	DistanceKm  sql.NullFloat64
+	IsVerified  bool
+	ReviewCount int64
+	OpenSlots   int64
+	NextSlot    sql.NullTime
 }
 
-// params: specialty text, location text, limit int, offset int
-func (q *Queries) GetTherapists(ctx context.Context, arg GetTherapistsParams) ([]GetTherapistsRow, error) {
-	rows, err := q.db.QueryContext(ctx, getTherapists,
-		arg.Column1,
+// params: start_ts timestamptz, near bool, lat float8, lng float8, limit int, radius_km float8, min_lat float8, max_lat float8, min_lng float8, max_lng float8
+// The pool is the best rated by smoothed score. With radius_km > 0 it is
+// drawn only from therapists that close, so nearby matches can't be crowded
+// out by better rated ones elsewhere.
+func (q *Queries) ListRecommendationCandidates(ctx context.Context, arg ListRecommendationCandidatesParams) ([]ListRecommendationCandidatesRow, error) {
+	rows, err := q.db.QueryContext(ctx, listRecommendationCandidates,
+		arg.StartTs,
 		arg.Column2,
+		arg.Column3,
+		arg.Column4,
 		arg.Limit,
-		arg.Offset,
+		arg.Column6,
+		arg.Column7,
+		arg.Column8,
+		arg.Column9,
+		arg.Column10,
 	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetTherapistsRow
+	var items []ListRecommendationCandidatesRow
 	for rows.Next() {
-		var i GetTherapistsRow
+		var i ListRecommendationCandidatesRow
 		if err := rows.Scan(
 			&i.ID,
 			&i.Email,
@@ -258,6 +548,13 @@ func (q *Queries) GetTherapists(ctx context.Context, arg GetTherapistsParams) ([
 			pq.Array(&i.Specialties),
 			&i.Address,
 			&i.Rating,
+			&i.Latitude,
+			&i.Longitude,
+			&i.DistanceKm,
+			&i.IsVerified,
+			&i.ReviewCount,
+			&i.OpenSlots,
+			&i.NextSlot,
 		); err != nil {
 			return nil, err
 		}
@@ -28,30 +28,58 @@ func (q *Queries) CreateEmptyProfile(ctx context.Context, userID uuid.UUID) erro
 }
 
 const createOrUpdateProfile = `-- name: CreateOrUpdateProfile :one
-INSERT INTO profiles (user_id, display_name, bio, phone, address, specialties, profile_extra)
-VALUES ($1, $2, $3, $4, $5, $6, $7)
+INSERT INTO profiles (user_id, display_name, bio, phone, address, specialties, latitude, longitude,
+  age, gender, condition, goals, credentials, location, profile_image_url, years_experience, languages,
+  session_price_cents, price_currency, insurers)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
 ON CONFLICT (user_id) DO UPDATE SET
   display_name = EXCLUDED.display_name,
   bio = EXCLUDED.bio,
   phone = EXCLUDED.phone,
   address = EXCLUDED.address,
   specialties = EXCLUDED.specialties,
-  profile_extra = EXCLUDED.profile_extra,
+  latitude = EXCLUDED.latitude,
+  longitude = EXCLUDED.longitude,
+  age = EXCLUDED.age,
+  gender = EXCLUDED.gender,
+  condition = EXCLUDED.condition,
+  goals = EXCLUDED.goals,
+  credentials = EXCLUDED.credentials,
+  location = EXCLUDED.location,
+  profile_image_url = EXCLUDED.profile_image_url,
+  years_experience = EXCLUDED.years_experience,
+  languages = EXCLUDED.languages,
+  session_price_cents = EXCLUDED.session_price_cents,
+  price_currency = EXCLUDED.price_currency,
+  insurers = EXCLUDED.insurers,
   updated_at = now()
 RETURNING id
 `
 
 type CreateOrUpdateProfileParams struct {
-	UserID       uuid.UUID
-	DisplayName  sql.NullString
-	Bio          sql.NullString
-	Phone        sql.NullString
-	Address      pqtype.NullRawMessage
-	Specialties  []string
-	ProfileExtra pqtype.NullRawMessage
+	UserID            uuid.UUID
+	DisplayName       sql.NullString
+	Bio               sql.NullString
+	Phone             sql.NullString
+	Address           pqtype.NullRawMessage
+	Specialties       []string
+	Latitude          sql.NullFloat64
+	Longitude         sql.NullFloat64
+	Age               sql.NullInt32
+	Gender            sql.NullString
+	Condition         sql.NullString
+	Goals             sql.NullString
+	Credentials       sql.NullString
+	Location          sql.NullString
+	ProfileImageUrl   sql.NullString
+	YearsExperience   sql.NullInt32
+	Languages         []string
+	SessionPriceCents sql.NullInt32
+	PriceCurrency     sql.NullString
+	Insurers          []string
 }
 
-// params: user_id uuid, display_name text, bio text, phone text, address jsonb, specialties text[], profile_extra jsonb
+// params: user_id uuid, display_name text, bio text, phone text, address jsonb, specialties text[], latitude float8, longitude float8, age int, gender text, condition text, goals text, credentials text, location text, profile_image_url text, years_experience int, languages text[], session_price_cents int, price_currency text, insurers text[]
 // result: id uuid
 func (q *Queries) CreateOrUpdateProfile(ctx context.Context, arg CreateOrUpdateProfileParams) (uuid.UUID, error) {
 	row := q.db.QueryRowContext(ctx, createOrUpdateProfile,
@@ -61,7 +89,20 @@ func (q *Queries) CreateOrUpdateProfile(ctx context.Context, arg CreateOrUpdateP
 		arg.Phone,
 		arg.Address,
 		pq.Array(arg.Specialties),
-		arg.ProfileExtra,
+		arg.Latitude,
+		arg.Longitude,
+		arg.Age,
+		arg.Gender,
+		arg.Condition,
+		arg.Goals,
+		arg.Credentials,
+		arg.Location,
+		arg.ProfileImageUrl,
+		arg.YearsExperience,
+		pq.Array(arg.Languages),
+		arg.SessionPriceCents,
+		arg.PriceCurrency,
+		pq.Array(arg.Insurers),
 	)
 	var id uuid.UUID
 	err := row.Scan(&id)
@@ -90,22 +131,39 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (uuid.UU
 }
 
 const getProfileByUserID = `-- name: GetProfileByUserID :one
-SELECT id, user_id, display_name, bio, phone, address, specialties, profile_extra, created_at, updated_at
+SELECT id, user_id, display_name, bio, phone, address, specialties, created_at, updated_at, latitude, longitude, is_verified, verified_at,
+       age, gender, condition, goals, credentials, location, profile_image_url, years_experience, languages,
+       session_price_cents, price_currency, insurers
 FROM profiles
 WHERE user_id = $1
 `
 
 type GetProfileByUserIDRow struct {
-	ID           uuid.UUID
-	UserID       uuid.UUID
-	DisplayName  sql.NullString
-	Bio          sql.NullString
-	Phone        sql.NullString
-	Address      pqtype.NullRawMessage
-	Specialties  []string
-	ProfileExtra pqtype.NullRawMessage
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ID                uuid.UUID
+	UserID            uuid.UUID
+	DisplayName       sql.NullString
+	Bio               sql.NullString
+	Phone             sql.NullString
+	Address           pqtype.NullRawMessage
+	Specialties       []string
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+	Latitude          sql.NullFloat64
+	Longitude         sql.NullFloat64
+	IsVerified        bool
+	VerifiedAt        sql.NullTime
+	Age               sql.NullInt32
+	Gender            sql.NullString
+	Condition         sql.NullString
+	Goals             sql.NullString
+	Credentials       sql.NullString
+	Location          sql.NullString
+	ProfileImageUrl   sql.NullString
+	YearsExperience   sql.NullInt32
+	Languages         []string
+	SessionPriceCents sql.NullInt32
+	PriceCurrency     sql.NullString
+	Insurers          []string
 }
 
 // params: user_id uuid
@@ -120,9 +178,24 @@ func (q *Queries) GetProfileByUserID(ctx context.Context, userID uuid.UUID) (Get
 		&i.Phone,
 		&i.Address,
 		pq.Array(&i.Specialties),
-		&i.ProfileExtra,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Latitude,
+		&i.Longitude,
+		&i.IsVerified,
+		&i.VerifiedAt,
+		&i.Age,
+		&i.Gender,
+		&i.Condition,
+		&i.Goals,
+		&i.Credentials,
+		&i.Location,
+		&i.ProfileImageUrl,
+		&i.YearsExperience,
+		pq.Array(&i.Languages),
+		&i.SessionPriceCents,
+		&i.PriceCurrency,
+		pq.Array(&i.Insurers),
 	)
 	return i, err
 }
@@ -149,7 +222,7 @@ func (q *Queries) GetProfileWithUserInfo(ctx context.Context, userID uuid.UUID)
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, password_hash, role, created_at, updated_at
+SELECT id, email, password_hash, role, created_at, updated_at, clinic_id, disabled_at, password_reset_required
 FROM users
 WHERE email = $1
 `
@@ -165,12 +238,15 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.Role,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ClinicID,
+		&i.DisabledAt,
+		&i.PasswordResetRequired,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, password_hash, role, created_at, updated_at
+SELECT id, email, password_hash, role, created_at, updated_at, clinic_id, disabled_at, password_reset_required
 FROM users
 WHERE id = $1
 `
@@ -186,6 +262,29 @@ func (q *Queries) GetUserByID(ctx context.Context, id uuid.UUID) (User, error) {
 		&i.Role,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ClinicID,
+		&i.DisabledAt,
+		&i.PasswordResetRequired,
 	)
 	return i, err
 }
+
+const updateUserPassword = `-- name: UpdateUserPassword :execrows
+UPDATE users
+SET password_hash = $2, password_reset_required = false, updated_at = now()
+WHERE id = $1
+`
+
+type UpdateUserPasswordParams struct {
+	ID           uuid.UUID
+	PasswordHash string
+}
+
+// params: id uuid, password_hash text
+func (q *Queries) UpdateUserPassword(ctx context.Context, arg UpdateUserPasswordParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, updateUserPassword, arg.ID, arg.PasswordHash)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
@@ -0,0 +1,435 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/middleware"
+	"github.com/divijg19/physiolink/backend/internal/service"
+)
+
+// AdminService interface for handler tests.
+type AdminService interface {
+	ListUsers(ctx context.Context, actorID uuid.UUID, q service.AdminUserQuery) (service.AdminUserList, error)
+	SetDisabled(ctx context.Context, actorID, userID uuid.UUID, disabled bool) error
+	ChangeRole(ctx context.Context, actorID, userID uuid.UUID, role string) error
+	ForcePasswordReset(ctx context.Context, actorID, userID uuid.UUID) (string, error)
+	Impersonate(ctx context.Context, actorID, userID uuid.UUID, reason string) (service.AdminUser, error)
+	AuditLog(ctx context.Context, actorID uuid.UUID, target uuid.NullUUID, limit int) ([]service.AuditEvent, error)
+	CreateAPIKey(ctx context.Context, actorID, clinicID uuid.UUID, name string, scopes []string) (service.NewAPIKey, error)
+	ListAPIKeys(ctx context.Context, actorID, clinicID uuid.UUID) ([]service.APIKey, error)
+	RevokeAPIKey(ctx context.Context, actorID, clinicID, keyID uuid.UUID) error
+	ListCredentialSubmissions(ctx context.Context, actorID uuid.UUID, status string, limit int) ([]service.CredentialSubmission, error)
+	ReviewCredentials(ctx context.Context, actorID, submissionID uuid.UUID, approve bool, notes string) (service.CredentialSubmission, error)
+	ListReviewModerationQueue(ctx context.Context, actorID uuid.UUID, status string, reported bool, limit int) ([]service.ModeratedReview, error)
+	ModerateReview(ctx context.Context, actorID, reviewID uuid.UUID, decision, reason string) (service.ModeratedReview, error)
+}
+
+var adminService AdminService
+
+func InitAdmin(s AdminService) { adminService = s }
+
+const (
+	// impersonationTTL keeps support sessions short; they can't be refreshed.
+	impersonationTTL = time.Hour
+	passwordResetTTL = 24 * time.Hour
+)
+
+type roleReq struct {
+	Role string `json:"role"`
+}
+
+type impersonateReq struct {
+	Reason string `json:"reason"`
+}
+
+type createAPIKeyReq struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+}
+
+type reviewCredentialsReq struct {
+	Notes string `json:"notes"`
+}
+
+type moderateReviewReq struct {
+	Reason string `json:"reason"`
+}
+
+type impersonateResponse struct {
+	Token     string            `json:"token"`
+	ExpiresAt time.Time         `json:"expiresAt"`
+	User      service.AdminUser `json:"user"`
+}
+
+type passwordResetResponse struct {
+	ResetToken string    `json:"resetToken"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+}
+
+func AdminListUsers(w http.ResponseWriter, r *http.Request) {
+	actor, ok := adminActor(w, r)
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+	page, _ := strconv.Atoi(q.Get("page"))
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	res, err := adminService.ListUsers(r.Context(), actor, service.AdminUserQuery{
+		Query: q.Get("q"),
+		Role:  q.Get("role"),
+		Page:  page,
+		Limit: limit,
+	})
+	if err != nil {
+		writeAdminError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+func AdminDisableUser(w http.ResponseWriter, r *http.Request) {
+	adminSetDisabled(w, r, true)
+}
+
+func AdminEnableUser(w http.ResponseWriter, r *http.Request) {
+	adminSetDisabled(w, r, false)
+}
+
+func adminSetDisabled(w http.ResponseWriter, r *http.Request, disabled bool) {
+	actor, target, ok := adminTarget(w, r)
+	if !ok {
+		return
+	}
+	if err := adminService.SetDisabled(r.Context(), actor, target, disabled); err != nil {
+		writeAdminError(w, err)
+		return
+	}
+	msg := "User enabled"
+	if disabled {
+		msg = "User disabled"
+	}
+	writeJSON(w, http.StatusOK, errorResponse{Msg: msg})
+}
+
+func AdminChangeRole(w http.ResponseWriter, r *http.Request) {
+	actor, target, ok := adminTarget(w, r)
+	if !ok {
+		return
+	}
+	var req roleReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "invalid request"})
+		return
+	}
+	if err := adminService.ChangeRole(r.Context(), actor, target, req.Role); err != nil {
+		writeAdminError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, errorResponse{Msg: "Role updated"})
+}
+
+// AdminForcePasswordReset blocks password sign-in for the user and returns a
+// one-time reset token for support to pass on.
+func AdminForcePasswordReset(w http.ResponseWriter, r *http.Request) {
+	actor, target, ok := adminTarget(w, r)
+	if !ok {
+		return
+	}
+	fingerprint, err := adminService.ForcePasswordReset(r.Context(), actor, target)
+	if err != nil {
+		writeAdminError(w, err)
+		return
+	}
+	expires := time.Now().Add(passwordResetTTL)
+	token, err := signClaims(jwt.MapClaims{
+		"pwd_reset": map[string]interface{}{
+			"id": target.String(),
+			"fp": fingerprint,
+		},
+		"exp": expires.Unix(),
+	})
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, passwordResetResponse{ResetToken: token, ExpiresAt: expires})
+}
+
+// AdminImpersonate issues a short-lived session for the target user. The
+// token carries the admin in its "act" claim and the audit trail records who
+// impersonated whom and why.
+func AdminImpersonate(w http.ResponseWriter, r *http.Request) {
+	actor, target, ok := adminTarget(w, r)
+	if !ok {
+		return
+	}
+	var req impersonateReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Reason == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "A reason is required"})
+		return
+	}
+	user, err := adminService.Impersonate(r.Context(), actor, target, req.Reason)
+	if err != nil {
+		writeAdminError(w, err)
+		return
+	}
+	expires := time.Now().Add(impersonationTTL)
+	token, err := signSession(r, user.ID, uuid.NullUUID{UUID: actor, Valid: true}, sessionClaims(user.ID, user.Role, false, jwt.MapClaims{
+		"exp": expires.Unix(),
+	}))
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, impersonateResponse{Token: token, ExpiresAt: expires, User: user})
+}
+
+func AdminAuditLog(w http.ResponseWriter, r *http.Request) {
+	actor, ok := adminActor(w, r)
+	if !ok {
+		return
+	}
+	var target uuid.NullUUID
+	if s := r.URL.Query().Get("userId"); s != "" {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "invalid userId"})
+			return
+		}
+		target = uuid.NullUUID{UUID: id, Valid: true}
+	}
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+	events, err := adminService.AuditLog(r.Context(), actor, target, limit)
+	if err != nil {
+		writeAdminError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, events)
+}
+
+// AdminCreateAPIKey issues an integration key for a clinic. The key is in the
+// response only; it can't be retrieved later.
+func AdminCreateAPIKey(w http.ResponseWriter, r *http.Request) {
+	actor, clinic, ok := adminClinic(w, r)
+	if !ok {
+		return
+	}
+	var req createAPIKeyReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "invalid request"})
+		return
+	}
+	key, err := adminService.CreateAPIKey(r.Context(), actor, clinic, req.Name, req.Scopes)
+	if err != nil {
+		writeAdminError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, key)
+}
+
+func AdminListAPIKeys(w http.ResponseWriter, r *http.Request) {
+	actor, clinic, ok := adminClinic(w, r)
+	if !ok {
+		return
+	}
+	keys, err := adminService.ListAPIKeys(r.Context(), actor, clinic)
+	if err != nil {
+		writeAdminError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, keys)
+}
+
+func AdminRevokeAPIKey(w http.ResponseWriter, r *http.Request) {
+	actor, clinic, ok := adminClinic(w, r)
+	if !ok {
+		return
+	}
+	keyID, err := uuid.Parse(chi.URLParam(r, "keyId"))
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, errorResponse{Msg: "API key not found"})
+		return
+	}
+	if err := adminService.RevokeAPIKey(r.Context(), actor, clinic, keyID); err != nil {
+		writeAdminError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, errorResponse{Msg: "API key revoked"})
+}
+
+// AdminListCredentialSubmissions is the therapist verification queue.
+func AdminListCredentialSubmissions(w http.ResponseWriter, r *http.Request) {
+	actor, ok := adminActor(w, r)
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	list, err := adminService.ListCredentialSubmissions(r.Context(), actor, q.Get("status"), limit)
+	if err != nil {
+		writeAdminError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+func AdminApproveCredentials(w http.ResponseWriter, r *http.Request) {
+	adminReviewCredentials(w, r, true)
+}
+
+func AdminRejectCredentials(w http.ResponseWriter, r *http.Request) {
+	adminReviewCredentials(w, r, false)
+}
+
+func adminReviewCredentials(w http.ResponseWriter, r *http.Request, approve bool) {
+	actor, ok := adminActor(w, r)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, errorResponse{Msg: "Submission not found"})
+		return
+	}
+	var req reviewCredentialsReq
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "invalid request"})
+			return
+		}
+	}
+	sub, err := adminService.ReviewCredentials(r.Context(), actor, id, approve, req.Notes)
+	if err != nil {
+		writeAdminError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, sub)
+}
+
+// AdminListReviews is the review moderation queue.
+func AdminListReviews(w http.ResponseWriter, r *http.Request) {
+	actor, ok := adminActor(w, r)
+	if !ok {
+		return
+	}
+	q := r.URL.Query()
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	list, err := adminService.ListReviewModerationQueue(r.Context(), actor, q.Get("status"), q.Get("reported") == "true", limit)
+	if err != nil {
+		writeAdminError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+func AdminApproveReview(w http.ResponseWriter, r *http.Request) {
+	adminModerateReview(w, r, service.ReviewApprove)
+}
+
+func AdminHideReview(w http.ResponseWriter, r *http.Request) {
+	adminModerateReview(w, r, service.ReviewHide)
+}
+
+func AdminRestoreReview(w http.ResponseWriter, r *http.Request) {
+	adminModerateReview(w, r, service.ReviewRestore)
+}
+
+func adminModerateReview(w http.ResponseWriter, r *http.Request, decision string) {
+	actor, ok := adminActor(w, r)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, errorResponse{Msg: "Review not found"})
+		return
+	}
+	var req moderateReviewReq
+	if r.ContentLength != 0 {
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "invalid request"})
+			return
+		}
+	}
+	rev, err := adminService.ModerateReview(r.Context(), actor, id, decision, req.Reason)
+	if err != nil {
+		writeAdminError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, rev)
+}
+
+func adminActor(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	sub, _ := r.Context().Value(middleware.UserIDKey).(string)
+	actor, err := uuid.Parse(sub)
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Msg: "unauthorized"})
+		return uuid.Nil, false
+	}
+	return actor, true
+}
+
+func adminTarget(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	actor, ok := adminActor(w, r)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	target, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "invalid user id"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return actor, target, true
+}
+
+func adminClinic(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	actor, ok := adminActor(w, r)
+	if !ok {
+		return uuid.Nil, uuid.Nil, false
+	}
+	clinic, err := uuid.Parse(chi.URLParam(r, "clinicId"))
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, errorResponse{Msg: "Clinic not found"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return actor, clinic, true
+}
+
+func writeAdminError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrNotAdmin):
+		writeJSON(w, http.StatusForbidden, errorResponse{Msg: "Admin role required"})
+	case errors.Is(err, service.ErrUserNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Msg: "User not found"})
+	case errors.Is(err, service.ErrInvalidRole):
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "Invalid role"})
+	case errors.Is(err, service.ErrSelfAdminAction):
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "You cannot do this to your own account"})
+	case errors.Is(err, service.ErrClinicNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Msg: "Clinic not found"})
+	case errors.Is(err, service.ErrAPIKeyNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Msg: "API key not found"})
+	case errors.Is(err, service.ErrSubmissionNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Msg: "Submission not found"})
+	case errors.Is(err, service.ErrReviewNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Msg: "Review not found"})
+	case errors.Is(err, service.ErrInvalidScope), errors.Is(err, service.ErrAPIKeyNameEmpty),
+		errors.Is(err, service.ErrInvalidSubmissionStatus), errors.Is(err, service.ErrRejectionReasonRequired),
+		errors.Is(err, service.ErrInvalidReviewStatus), errors.Is(err, service.ErrModerationReasonRequired):
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: err.Error()})
+	case errors.Is(err, service.ErrImpersonateAdmin), errors.Is(err, service.ErrAccountDisabled),
+		errors.Is(err, service.ErrSubmissionReviewed), errors.Is(err, service.ErrReviewDecision):
+		writeJSON(w, http.StatusConflict, errorResponse{Msg: err.Error()})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
+	}
+}
@@ -0,0 +1,244 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/handlers"
+	mocks "github.com/divijg19/physiolink/backend/internal/mocks"
+	"github.com/divijg19/physiolink/backend/internal/service"
+)
+
+func TestAdminRoutes_RequireAdminRole(t *testing.T) {
+	cfg, router := setupRouter(t, func() { handlers.InitAdmin(&mocks.AdminServiceMock{}) })
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, authedRequest(http.MethodGet, "/api/admin/users", sessionToken(t, cfg, uuid.New(), "patient", nil), ""))
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for patient, got %d", rr.Code)
+	}
+
+	// An admin impersonating someone must not reach admin routes, even if the
+	// impersonated session somehow carried the admin role.
+	impersonated := sessionToken(t, cfg, uuid.New(), service.RoleAdmin, jwt.MapClaims{
+		"act": map[string]interface{}{"sub": uuid.NewString()},
+	})
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, authedRequest(http.MethodGet, "/api/admin/users", impersonated, ""))
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for impersonated session, got %d", rr.Code)
+	}
+}
+
+func TestAdminDisableUser_OK(t *testing.T) {
+	m := &mocks.AdminServiceMock{}
+	cfg, router := setupRouter(t, func() { handlers.InitAdmin(m) })
+	admin, target := uuid.New(), uuid.New()
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, authedRequest(http.MethodPost, "/api/admin/users/"+target.String()+"/disable", sessionToken(t, cfg, admin, service.RoleAdmin, nil), ""))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if m.ActorID != admin || m.UserID != target || !m.Disabled {
+		t.Fatalf("unexpected call %+v", m)
+	}
+}
+
+func TestAdminChangeRole_MapsErrors(t *testing.T) {
+	cases := map[error]int{
+		service.ErrInvalidRole:     http.StatusBadRequest,
+		service.ErrSelfAdminAction: http.StatusBadRequest,
+		service.ErrUserNotFound:    http.StatusNotFound,
+		service.ErrNotAdmin:        http.StatusForbidden,
+	}
+	for err, want := range cases {
+		cfg, router := setupRouter(t, func() { handlers.InitAdmin(&mocks.AdminServiceMock{Err: err}) })
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, authedRequest(http.MethodPut, "/api/admin/users/"+uuid.NewString()+"/role", sessionToken(t, cfg, uuid.New(), service.RoleAdmin, nil), `{"role":"pt"}`))
+		if rr.Code != want {
+			t.Fatalf("%v: expected %d, got %d", err, want, rr.Code)
+		}
+	}
+}
+
+func TestAdminImpersonate_IssuesActorScopedToken(t *testing.T) {
+	target := service.AdminUser{ID: uuid.New(), Role: "patient"}
+	m := &mocks.AdminServiceMock{Target: target}
+	cfg, router := setupRouter(t, func() { handlers.InitAdmin(m) })
+	sessions := mocks.NewSessionServiceMock()
+	handlers.InitSessions(sessions)
+	t.Cleanup(func() { handlers.InitSessions(nil) })
+	admin := uuid.New()
+	token := sessionToken(t, cfg, admin, service.RoleAdmin, nil)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, authedRequest(http.MethodPost, "/api/admin/users/"+target.ID.String()+"/impersonate", token, `{}`))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 without a reason, got %d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, authedRequest(http.MethodPost, "/api/admin/users/"+target.ID.String()+"/impersonate", token, `{"reason":"ticket 42"}`))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if m.Reason != "ticket 42" {
+		t.Fatalf("expected reason to reach the audit trail, got %q", m.Reason)
+	}
+	var resp struct {
+		Token string `json:"token"`
+	}
+	_ = json.NewDecoder(rr.Body).Decode(&resp)
+	claims, err := cfg.KeySet().Parse(resp.Token)
+	if err != nil {
+		t.Fatalf("impersonation token: %v", err)
+	}
+	user, _ := claims["user"].(map[string]interface{})
+	act, _ := claims["act"].(map[string]interface{})
+	if user["id"] != target.ID.String() || act["sub"] != admin.String() {
+		t.Fatalf("unexpected claims %v", claims)
+	}
+	// The token is a session of the target's, so it is listed and revocable
+	sidClaim, _ := claims["sid"].(string)
+	sid, _ := uuid.Parse(sidClaim)
+	s, ok := sessions.Sessions[sid]
+	if !ok || sessions.Owners[sid] != target.ID || s.ImpersonatedBy == nil || *s.ImpersonatedBy != admin {
+		t.Fatalf("expected an impersonation session of %s by %s, got %+v", target.ID, admin, s)
+	}
+}
+
+func TestResetPassword_WithAdminIssuedToken(t *testing.T) {
+	m := &mocks.AdminServiceMock{Fingerprint: "fp"}
+	cfg, router := setupRouter(t, func() { handlers.InitAdmin(m) })
+	auth := mocks.NewAuthServiceMock()
+	id, _, _ := auth.Register(context.Background(), "pt@example.com", "old", "pt")
+	handlers.InitAuth(auth, cfg)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, authedRequest(http.MethodPost, "/api/admin/users/"+id.String()+"/password-reset", sessionToken(t, cfg, uuid.New(), service.RoleAdmin, nil), ""))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var issued struct {
+		ResetToken string `json:"resetToken"`
+	}
+	_ = json.NewDecoder(rr.Body).Decode(&issued)
+
+	b, _ := json.Marshal(map[string]string{"token": issued.ResetToken, "password": "new"})
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/auth/password/reset", bytes.NewReader(b)))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if auth.Users["pt@example.com"].Hash != "new" {
+		t.Fatalf("expected password to be updated")
+	}
+}
+
+func TestAdminCreateAPIKey_ReturnsKeyOnce(t *testing.T) {
+	clinic := uuid.New()
+	m := &mocks.AdminServiceMock{NewKey: service.NewAPIKey{APIKey: service.APIKey{ClinicID: clinic, Prefix: "plk_abcd"}, Key: "plk_abcd_secret"}}
+	cfg, router := setupRouter(t, func() { handlers.InitAdmin(m) })
+
+	body := `{"name":"EHR sync","scopes":["appointments:read"]}`
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, authedRequest(http.MethodPost, "/api/admin/clinics/"+clinic.String()+"/api-keys", sessionToken(t, cfg, uuid.New(), service.RoleAdmin, nil), body))
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if m.ClinicID != clinic || len(m.Scopes) != 1 || m.Scopes[0] != service.ScopeAppointmentsRead {
+		t.Fatalf("unexpected call %+v", m)
+	}
+	if !strings.Contains(rr.Body.String(), `"key":"plk_abcd_secret"`) {
+		t.Fatalf("expected key in response, got %s", rr.Body.String())
+	}
+}
+
+func TestAdminRejectCredentials_PassesNotes(t *testing.T) {
+	m := &mocks.AdminServiceMock{Reviewed: service.CredentialSubmission{Status: service.CredentialRejected}}
+	cfg, router := setupRouter(t, func() { handlers.InitAdmin(m) })
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, authedRequest(http.MethodPost, "/api/admin/verifications/"+uuid.NewString()+"/reject", sessionToken(t, cfg, uuid.New(), service.RoleAdmin, nil), `{"notes":"Licence has expired"}`))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if m.Approved || m.Notes != "Licence has expired" {
+		t.Fatalf("unexpected call %+v", m)
+	}
+}
+
+func TestAdminReviewCredentials_MapsErrors(t *testing.T) {
+	cases := map[error]int{
+		service.ErrSubmissionNotFound:      http.StatusNotFound,
+		service.ErrSubmissionReviewed:      http.StatusConflict,
+		service.ErrRejectionReasonRequired: http.StatusBadRequest,
+	}
+	for err, want := range cases {
+		cfg, router := setupRouter(t, func() { handlers.InitAdmin(&mocks.AdminServiceMock{Err: err}) })
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, authedRequest(http.MethodPost, "/api/admin/verifications/"+uuid.NewString()+"/approve", sessionToken(t, cfg, uuid.New(), service.RoleAdmin, nil), ""))
+		if rr.Code != want {
+			t.Errorf("%v: expected %d, got %d", err, want, rr.Code)
+		}
+	}
+}
+
+func TestAdminHideReview_PassesDecisionAndReason(t *testing.T) {
+	m := &mocks.AdminServiceMock{Moderated: service.ModeratedReview{Status: service.ReviewHidden}}
+	cfg, router := setupRouter(t, func() { handlers.InitAdmin(m) })
+	reviewID := uuid.New()
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, authedRequest(http.MethodPost, "/api/admin/reviews/"+reviewID.String()+"/hide", sessionToken(t, cfg, uuid.New(), service.RoleAdmin, nil), `{"reason":"Names another patient"}`))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if m.ReviewID != reviewID || m.Decision != service.ReviewHide || m.Reason != "Names another patient" {
+		t.Fatalf("unexpected call %+v", m)
+	}
+}
+
+func TestAdminListReviews_Filters(t *testing.T) {
+	m := &mocks.AdminServiceMock{}
+	cfg, router := setupRouter(t, func() { handlers.InitAdmin(m) })
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, authedRequest(http.MethodGet, "/api/admin/reviews?status=published&reported=true", sessionToken(t, cfg, uuid.New(), service.RoleAdmin, nil), ""))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if m.Status != service.ReviewPublished || !m.Reported {
+		t.Fatalf("unexpected call %+v", m)
+	}
+}
+
+func TestAdminModerateReview_MapsErrors(t *testing.T) {
+	cases := map[error]int{
+		service.ErrReviewNotFound:           http.StatusNotFound,
+		service.ErrReviewDecision:           http.StatusConflict,
+		service.ErrModerationReasonRequired: http.StatusBadRequest,
+	}
+	for err, want := range cases {
+		cfg, router := setupRouter(t, func() { handlers.InitAdmin(&mocks.AdminServiceMock{Err: err}) })
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, authedRequest(http.MethodPost, "/api/admin/reviews/"+uuid.NewString()+"/restore", sessionToken(t, cfg, uuid.New(), service.RoleAdmin, nil), ""))
+		if rr.Code != want {
+			t.Errorf("%v: expected %d, got %d", err, want, rr.Code)
+		}
+	}
+}
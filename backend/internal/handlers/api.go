@@ -0,0 +1,273 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/openapi"
+)
+
+// API serves the operations in openapi.yaml. The generated wrapper has
+// already checked the path and query parameters; the handlers read them from
+// the request themselves, so the parsed values are ignored here.
+type API struct{}
+
+var _ openapi.ServerInterface = API{}
+
+// APIParamError answers requests whose parameters don't match the spec.
+func APIParamError(w http.ResponseWriter, r *http.Request, err error) {
+	writeJSON(w, http.StatusBadRequest, errorResponse{Msg: err.Error()})
+}
+
+func (API) GetWellKnownJwksJson(w http.ResponseWriter, r *http.Request) {
+	JWKS(w, r)
+}
+
+func (API) GetAdminAudit(w http.ResponseWriter, r *http.Request, _ openapi.GetAdminAuditParams) {
+	AdminAuditLog(w, r)
+}
+
+func (API) GetAdminClinicsClinicIdApiKeys(w http.ResponseWriter, r *http.Request, _ string) {
+	AdminListAPIKeys(w, r)
+}
+
+func (API) PostAdminClinicsClinicIdApiKeys(w http.ResponseWriter, r *http.Request, _ string) {
+	AdminCreateAPIKey(w, r)
+}
+
+func (API) DeleteAdminClinicsClinicIdApiKeysKeyId(w http.ResponseWriter, r *http.Request, _, _ string) {
+	AdminRevokeAPIKey(w, r)
+}
+
+func (API) GetAdminReviews(w http.ResponseWriter, r *http.Request, _ openapi.GetAdminReviewsParams) {
+	AdminListReviews(w, r)
+}
+
+func (API) PostAdminReviewsIdApprove(w http.ResponseWriter, r *http.Request, _ string) {
+	AdminApproveReview(w, r)
+}
+
+func (API) PostAdminReviewsIdHide(w http.ResponseWriter, r *http.Request, _ string) {
+	AdminHideReview(w, r)
+}
+
+func (API) PostAdminReviewsIdRestore(w http.ResponseWriter, r *http.Request, _ string) {
+	AdminRestoreReview(w, r)
+}
+
+func (API) GetAdminUsers(w http.ResponseWriter, r *http.Request, _ openapi.GetAdminUsersParams) {
+	AdminListUsers(w, r)
+}
+
+func (API) PostAdminUsersIdDisable(w http.ResponseWriter, r *http.Request, _ string) {
+	AdminDisableUser(w, r)
+}
+
+func (API) PostAdminUsersIdEnable(w http.ResponseWriter, r *http.Request, _ string) {
+	AdminEnableUser(w, r)
+}
+
+func (API) PostAdminUsersIdImpersonate(w http.ResponseWriter, r *http.Request, _ string) {
+	AdminImpersonate(w, r)
+}
+
+func (API) PostAdminUsersIdPasswordReset(w http.ResponseWriter, r *http.Request, _ string) {
+	AdminForcePasswordReset(w, r)
+}
+
+func (API) PutAdminUsersIdRole(w http.ResponseWriter, r *http.Request, _ string) {
+	AdminChangeRole(w, r)
+}
+
+func (API) GetAdminVerifications(w http.ResponseWriter, r *http.Request, _ openapi.GetAdminVerificationsParams) {
+	AdminListCredentialSubmissions(w, r)
+}
+
+func (API) PostAdminVerificationsIdApprove(w http.ResponseWriter, r *http.Request, _ string) {
+	AdminApproveCredentials(w, r)
+}
+
+func (API) PostAdminVerificationsIdReject(w http.ResponseWriter, r *http.Request, _ string) {
+	AdminRejectCredentials(w, r)
+}
+
+func (API) GetAppointmentsAvailability(w http.ResponseWriter, r *http.Request, _ openapi.GetAppointmentsAvailabilityParams) {
+	GetTherapistAvailability(w, r)
+}
+
+func (API) PostAppointmentsAvailability(w http.ResponseWriter, r *http.Request) {
+	CreateAvailability(w, r)
+}
+
+func (API) GetAppointmentsAvailabilityPtId(w http.ResponseWriter, r *http.Request, _ string) {
+	GetTherapistAvailability(w, r)
+}
+
+func (API) GetAppointmentsMe(w http.ResponseWriter, r *http.Request, _ openapi.GetAppointmentsMeParams) {
+	GetMyAppointments(w, r)
+}
+
+func (API) PutAppointmentsIdBook(w http.ResponseWriter, r *http.Request, _ string) {
+	BookAppointment(w, r)
+}
+
+func (API) PutAppointmentsIdStatus(w http.ResponseWriter, r *http.Request, _ string) {
+	UpdateAppointmentStatus(w, r)
+}
+
+func (API) PostAuthLogin(w http.ResponseWriter, r *http.Request) {
+	Login(w, r)
+}
+
+func (API) PostAuthLoginMfa(w http.ResponseWriter, r *http.Request) {
+	LoginMFA(w, r)
+}
+
+func (API) GetAuthMfa(w http.ResponseWriter, r *http.Request) {
+	GetMFAStatus(w, r)
+}
+
+func (API) PostAuthMfaConfirm(w http.ResponseWriter, r *http.Request) {
+	ConfirmMFA(w, r)
+}
+
+func (API) PostAuthMfaDisable(w http.ResponseWriter, r *http.Request) {
+	DisableMFA(w, r)
+}
+
+func (API) PostAuthMfaEnroll(w http.ResponseWriter, r *http.Request) {
+	EnrollMFA(w, r)
+}
+
+func (API) GetAuthOidcProviders(w http.ResponseWriter, r *http.Request) {
+	ListOIDCProviders(w, r)
+}
+
+func (API) GetAuthOidcProvider(w http.ResponseWriter, r *http.Request, _ string, _ openapi.GetAuthOidcProviderParams) {
+	OIDCStart(w, r)
+}
+
+func (API) GetAuthOidcProviderCallback(w http.ResponseWriter, r *http.Request, _ string, _ openapi.GetAuthOidcProviderCallbackParams) {
+	OIDCCallback(w, r)
+}
+
+func (API) PostAuthPasswordReset(w http.ResponseWriter, r *http.Request) {
+	ResetPassword(w, r)
+}
+
+func (API) PostAuthRegister(w http.ResponseWriter, r *http.Request) {
+	Register(w, r)
+}
+
+func (API) GetFavourites(w http.ResponseWriter, r *http.Request) {
+	ListFavourites(w, r)
+}
+
+func (API) DeleteFavouritesTherapistId(w http.ResponseWriter, r *http.Request, _ string) {
+	RemoveFavourite(w, r)
+}
+
+func (API) PutFavouritesTherapistId(w http.ResponseWriter, r *http.Request, _ string) {
+	AddFavourite(w, r)
+}
+
+func (API) GetIntegrationsAppointments(w http.ResponseWriter, r *http.Request, _ openapi.GetIntegrationsAppointmentsParams) {
+	IntegrationAppointments(w, r)
+}
+
+func (API) GetIntegrationsAvailability(w http.ResponseWriter, r *http.Request, _ openapi.GetIntegrationsAvailabilityParams) {
+	IntegrationAvailability(w, r)
+}
+
+func (API) PostIntegrationsAvailability(w http.ResponseWriter, r *http.Request) {
+	IntegrationCreateAvailability(w, r)
+}
+
+func (API) PostProfile(w http.ResponseWriter, r *http.Request) {
+	UpsertMyProfile(w, r)
+}
+
+func (API) GetProfileMe(w http.ResponseWriter, r *http.Request) {
+	GetMyProfile(w, r)
+}
+
+func (API) PutProfileMe(w http.ResponseWriter, r *http.Request) {
+	UpsertMyProfile(w, r)
+}
+
+func (API) GetProfileVerification(w http.ResponseWriter, r *http.Request) {
+	GetMyVerification(w, r)
+}
+
+func (API) PostProfileVerification(w http.ResponseWriter, r *http.Request) {
+	SubmitCredentials(w, r)
+}
+
+func (API) GetRemindersMe(w http.ResponseWriter, r *http.Request) {
+	GetMyReminders(w, r)
+}
+
+func (API) PostReviews(w http.ResponseWriter, r *http.Request) {
+	CreateReview(w, r)
+}
+
+func (API) GetReviewsId(w http.ResponseWriter, r *http.Request, _ string, _ openapi.GetReviewsIdParams) {
+	GetReviewsForTherapist(w, r)
+}
+
+func (API) PutReviewsId(w http.ResponseWriter, r *http.Request, _ string) {
+	UpdateReview(w, r)
+}
+
+func (API) DeleteReviewsId(w http.ResponseWriter, r *http.Request, _ string) {
+	DeleteReview(w, r)
+}
+
+func (API) PostReviewsIdReply(w http.ResponseWriter, r *http.Request, _ string) {
+	ReplyToReview(w, r)
+}
+
+func (API) PostReviewsIdReport(w http.ResponseWriter, r *http.Request, _ string) {
+	ReportReview(w, r)
+}
+
+func (API) DeleteSessions(w http.ResponseWriter, r *http.Request) {
+	RevokeOtherSessions(w, r)
+}
+
+func (API) GetSessions(w http.ResponseWriter, r *http.Request) {
+	ListSessions(w, r)
+}
+
+func (API) DeleteSessionsId(w http.ResponseWriter, r *http.Request, _ string) {
+	RevokeSession(w, r)
+}
+
+func (API) GetTherapists(w http.ResponseWriter, r *http.Request, _ openapi.GetTherapistsParams) {
+	GetAllTherapists(w, r)
+}
+
+func (API) GetTherapistsRecent(w http.ResponseWriter, r *http.Request) {
+	ListRecentlyViewed(w, r)
+}
+
+func (API) GetTherapistsRecommended(w http.ResponseWriter, r *http.Request, _ openapi.GetTherapistsRecommendedParams) {
+	GetRecommendedTherapists(w, r)
+}
+
+func (API) GetTherapistsId(w http.ResponseWriter, r *http.Request, _ string) {
+	GetTherapistByID(w, r)
+}
+
+func (API) PostUploadsAvatar(w http.ResponseWriter, r *http.Request) {
+	UploadAvatar(w, r)
+}
+
+func (API) PostUploadsDocuments(w http.ResponseWriter, r *http.Request) {
+	UploadDocument(w, r)
+}
+
+func (API) GetUploadsId(w http.ResponseWriter, r *http.Request, _ uuid.UUID, _ openapi.GetUploadsIdParams) {
+	GetUpload(w, r)
+}
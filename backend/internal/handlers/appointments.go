@@ -19,6 +19,7 @@ type AppointmentService interface {
 	GetTherapistAvailability(ctx context.Context, therapistID uuid.UUID) ([]service.Slot, error)
 	BookAppointment(ctx context.Context, slotID, patientID uuid.UUID) (uuid.UUID, error)
 	ListMyAppointments(ctx context.Context, userID uuid.UUID, role string) ([]service.AppointmentBrief, error)
+	ListMyAppointmentsPage(ctx context.Context, userID uuid.UUID, role, cursor string, limit int) (service.AppointmentPage, error)
 	UpdateAppointmentStatus(ctx context.Context, appointmentID, therapistID uuid.UUID, status string) (service.AppointmentBrief, error)
 }
 
@@ -132,6 +133,15 @@ func GetMyAppointments(w http.ResponseWriter, r *http.Request) {
 	if role == "" {
 		role = "patient"
 	}
+	if cursor, limit, ok := pageRequest(r); ok {
+		page, err := apptService.ListMyAppointmentsPage(r.Context(), uid, role, cursor, limit)
+		if err != nil {
+			writePageError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, page)
+		return
+	}
 	list, err := apptService.ListMyAppointments(r.Context(), uid, role)
 	if err != nil {
 		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
@@ -149,3 +149,24 @@ func TestGetMyAppointments_OK(t *testing.T) {
 		t.Fatalf("expected 200, got %d", rr.Code)
 	}
 }
+
+func TestGetMyAppointments_CursorPage(t *testing.T) {
+	svc := &mocks.AppointmentServiceMock{PageResp: service.AppointmentPage{Data: []service.AppointmentBrief{{ID: "a1"}}}}
+	handlers.InitAppointments(svc)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/appointments/me?limit=10", nil)
+	req = req.WithContext(withUser(req.Context(), uuid.New().String(), "patient"))
+	rr := httptest.NewRecorder()
+	handlers.GetMyAppointments(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	if svc.Limit != 10 || svc.Cursor != "" {
+		t.Fatalf("unexpected page request cursor=%q limit=%d", svc.Cursor, svc.Limit)
+	}
+	var page service.AppointmentPage
+	if err := json.Unmarshal(rr.Body.Bytes(), &page); err != nil || len(page.Data) != 1 {
+		t.Fatalf("unexpected body %s", rr.Body.String())
+	}
+}
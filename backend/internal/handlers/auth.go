@@ -11,6 +11,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/divijg19/physiolink/backend/internal/config"
+	"github.com/divijg19/physiolink/backend/internal/openapi"
 	"github.com/divijg19/physiolink/backend/internal/service"
 )
 
@@ -21,8 +22,16 @@ type authRequest struct {
 }
 
 type authResponse struct {
-	Token   string                 `json:"token"`
-	Profile map[string]interface{} `json:"profile,omitempty"`
+	Token            string           `json:"token"`
+	Profile          *openapi.Profile `json:"profile,omitempty"`
+	MFASetupRequired bool             `json:"mfaSetupRequired,omitempty"`
+}
+
+// mfaChallengeResponse is returned by Login instead of a session when the
+// user must complete a second factor via LoginMFA.
+type mfaChallengeResponse struct {
+	MFARequired bool   `json:"mfaRequired"`
+	MFAToken    string `json:"mfaToken"`
 }
 
 type errorResponse struct {
@@ -33,6 +42,7 @@ type errorResponse struct {
 type AuthService interface {
 	Register(ctx context.Context, email, password, role string) (uuid.UUID, string, error)
 	Authenticate(ctx context.Context, email, password string) (uuid.UUID, string, error)
+	ResetPassword(ctx context.Context, userID uuid.UUID, fingerprint, password string) error
 }
 
 var authService AuthService
@@ -56,27 +66,24 @@ func Register(w http.ResponseWriter, r *http.Request) {
 			writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "User already exists"})
 			return
 		}
+		if errors.Is(err, service.ErrInvalidRole) {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "Invalid role"})
+			return
+		}
 		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server error"})
 		return
 	}
 	// create token
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
-		"user": map[string]interface{}{
-			"id":   id.String(),
-			"role": role,
-		},
-		"exp": time.Now().Add(5 * time.Hour).Unix(),
-	})
-	signed, err := token.SignedString([]byte(cfg.JWTSecret))
+	signed, err := signToken(r, id, role, false)
 	if err != nil {
 		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server error"})
 		return
 	}
 	// Create empty profile linked to user to mirror Node behavior
-	var prof map[string]interface{}
+	var prof *openapi.Profile
 	if profileService != nil {
 		if p, err := profileService.CreateEmptyProfile(ctx, id); err == nil {
-			prof = p
+			prof = &p
 		}
 	}
 	writeJSON(w, http.StatusOK, authResponse{Token: signed, Profile: prof})
@@ -95,22 +102,121 @@ func Login(w http.ResponseWriter, r *http.Request) {
 			writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "Invalid Credentials"})
 			return
 		}
+		if errors.Is(err, service.ErrAccountDisabled) {
+			writeJSON(w, http.StatusForbidden, errorResponse{Msg: "Account disabled"})
+			return
+		}
+		if errors.Is(err, service.ErrPasswordResetRequired) {
+			writeJSON(w, http.StatusForbidden, errorResponse{Msg: "Password reset required"})
+			return
+		}
 		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server error"})
 		return
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+	mfaReq, token, err := startSession(r, id, role)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server error"})
+		return
+	}
+	if mfaReq == service.MFAChallenge {
+		writeJSON(w, http.StatusOK, mfaChallengeResponse{MFARequired: true, MFAToken: token})
+		return
+	}
+	writeJSON(w, http.StatusOK, authResponse{Token: token, MFASetupRequired: mfaReq == service.MFASetupRequired})
+}
+
+type resetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// ResetPassword sets a new password with a token from AdminForcePasswordReset.
+func ResetPassword(w http.ResponseWriter, r *http.Request) {
+	var req resetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Password == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "invalid request"})
+		return
+	}
+	id, fingerprint, err := parsePasswordReset(req.Token)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "Invalid or expired reset token"})
+		return
+	}
+	if err := authService.ResetPassword(r.Context(), id, fingerprint, req.Password); err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidResetToken):
+			writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "Invalid or expired reset token"})
+		case errors.Is(err, service.ErrAccountDisabled):
+			writeJSON(w, http.StatusForbidden, errorResponse{Msg: "Account disabled"})
+		default:
+			writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server error"})
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, errorResponse{Msg: "Password updated"})
+}
+
+func parsePasswordReset(tokenStr string) (uuid.UUID, string, error) {
+	claims, err := cfg.KeySet().Parse(tokenStr)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+	m, _ := claims["pwd_reset"].(map[string]interface{})
+	idStr, _ := m["id"].(string)
+	fingerprint, _ := m["fp"].(string)
+	id, err := uuid.Parse(idStr)
+	if err != nil || fingerprint == "" {
+		return uuid.Nil, "", errors.New("invalid reset token")
+	}
+	return id, fingerprint, nil
+}
+
+// startSession applies the MFA policy after a successful password check. It
+// returns a session token, or a short-lived challenge token for LoginMFA when
+// the user has a second factor enabled.
+func startSession(r *http.Request, id uuid.UUID, role string) (service.MFARequirement, string, error) {
+	req := service.MFANotRequired
+	if mfaService != nil {
+		var err error
+		if req, err = mfaService.Requirement(r.Context(), id, role); err != nil {
+			return req, "", err
+		}
+	}
+	switch req {
+	case service.MFAChallenge:
+		token, err := signMFAChallenge(r, id, role)
+		return req, token, err
+	case service.MFASetupRequired:
+		token, err := signSession(r, id, uuid.NullUUID{}, sessionClaims(id, role, false, jwt.MapClaims{"mfa_setup_required": true}))
+		return req, token, err
+	default:
+		token, err := signToken(r, id, role, false)
+		return req, token, err
+	}
+}
+
+// signToken issues a session JWT. mfa marks sessions that passed a second factor.
+func signToken(r *http.Request, id uuid.UUID, role string, mfa bool) (string, error) {
+	return signSession(r, id, uuid.NullUUID{}, sessionClaims(id, role, mfa, nil))
+}
+
+func sessionClaims(id uuid.UUID, role string, mfa bool, extra jwt.MapClaims) jwt.MapClaims {
+	claims := jwt.MapClaims{
 		"user": map[string]interface{}{
 			"id":   id.String(),
 			"role": role,
 		},
+		"mfa": mfa,
 		"exp": time.Now().Add(5 * time.Hour).Unix(),
-	})
-	signed, err := token.SignedString([]byte(cfg.JWTSecret))
-	if err != nil {
-		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server error"})
-		return
 	}
-	writeJSON(w, http.StatusOK, authResponse{Token: signed})
+	for k, v := range extra {
+		claims[k] = v
+	}
+	return claims
+}
+
+func signClaims(claims jwt.MapClaims) (string, error) {
+	return cfg.KeySet().Sign(claims)
 }
 
 func writeJSON(w http.ResponseWriter, status int, v interface{}) {
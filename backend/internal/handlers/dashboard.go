@@ -16,7 +16,7 @@ func DashboardPage(w http.ResponseWriter, r *http.Request) {
 	// In a real app, we'd fetch user details from DB using userID
 	// For now, we'll just display the ID and Role
 
-	views.Dashboard(userID, role).Render(r.Context(), w)
+	views.Dashboard(userID, role, oidcProviderNames()).Render(r.Context(), w)
 }
 
 func DashboardAppointments(w http.ResponseWriter, r *http.Request) {
@@ -43,13 +43,8 @@ func DashboardAppointments(w http.ResponseWriter, r *http.Request) {
 }
 
 func Logout(w http.ResponseWriter, r *http.Request) {
-	http.SetCookie(w, &http.Cookie{
-		Name:     "auth_token",
-		Value:    "",
-		Expires:  time.Now().Add(-1 * time.Hour),
-		HttpOnly: true,
-		Path:     "/",
-	})
+	endCurrentSession(r)
+	clearAuthCookie(w)
 
 	w.Header().Set("HX-Redirect", "/")
 	w.WriteHeader(http.StatusOK)
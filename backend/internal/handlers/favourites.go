@@ -0,0 +1,238 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/middleware"
+	"github.com/divijg19/physiolink/backend/internal/openapi"
+	"github.com/divijg19/physiolink/backend/internal/service"
+	"github.com/divijg19/physiolink/backend/internal/views"
+)
+
+// FavouriteService interface for handler tests.
+type FavouriteService interface {
+	AddFavourite(ctx context.Context, patientID, therapistID uuid.UUID) (time.Time, error)
+	RemoveFavourite(ctx context.Context, patientID, therapistID uuid.UUID) error
+	ListFavourites(ctx context.Context, patientID uuid.UUID) ([]service.SavedTherapist, error)
+	FavouriteIDs(ctx context.Context, patientID uuid.UUID, therapistIDs []uuid.UUID) (map[uuid.UUID]bool, error)
+	RecordView(ctx context.Context, userID, therapistID uuid.UUID) error
+	RecentlyViewed(ctx context.Context, userID uuid.UUID) ([]service.SavedTherapist, error)
+}
+
+var favouriteService FavouriteService
+
+// InitFavourites enables favourites and the viewing history. Without it
+// therapist pages are served without stars and views aren't recorded.
+func InitFavourites(s FavouriteService) { favouriteService = s }
+
+type savedTherapistsResponse struct {
+	Data []service.SavedTherapist `json:"data"`
+}
+
+type favouriteResponse struct {
+	TherapistID  string    `json:"therapistId"`
+	FavouritedAt time.Time `json:"favouritedAt"`
+}
+
+// ListFavourites returns the caller's starred therapists.
+func ListFavourites(w http.ResponseWriter, r *http.Request) {
+	uid, ok := favouritePatient(w, r)
+	if !ok {
+		return
+	}
+	list, err := favouriteService.ListFavourites(r.Context(), uid)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, savedTherapistsResponse{Data: list})
+}
+
+// AddFavourite stars a therapist. It is idempotent.
+func AddFavourite(w http.ResponseWriter, r *http.Request) {
+	uid, ok := favouritePatient(w, r)
+	if !ok {
+		return
+	}
+	tid, err := uuid.Parse(chi.URLParam(r, "therapistId"))
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, errorResponse{Msg: "Therapist not found"})
+		return
+	}
+	at, err := favouriteService.AddFavourite(r.Context(), uid, tid)
+	switch {
+	case errors.Is(err, service.ErrTherapistNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Msg: "Therapist not found"})
+	case err != nil:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
+	default:
+		writeJSON(w, http.StatusOK, favouriteResponse{TherapistID: tid.String(), FavouritedAt: at})
+	}
+}
+
+// RemoveFavourite unstars a therapist. It is idempotent.
+func RemoveFavourite(w http.ResponseWriter, r *http.Request) {
+	uid, ok := favouritePatient(w, r)
+	if !ok {
+		return
+	}
+	tid, err := uuid.Parse(chi.URLParam(r, "therapistId"))
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, errorResponse{Msg: "Therapist not found"})
+		return
+	}
+	if err := favouriteService.RemoveFavourite(r.Context(), uid, tid); err != nil {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, errorResponse{Msg: "Favourite removed"})
+}
+
+// ListRecentlyViewed returns the therapists the caller opened most recently.
+func ListRecentlyViewed(w http.ResponseWriter, r *http.Request) {
+	sub, _ := r.Context().Value(middleware.UserIDKey).(string)
+	uid, err := uuid.Parse(sub)
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Msg: "unauthorized"})
+		return
+	}
+	if favouriteService == nil {
+		writeJSON(w, http.StatusOK, savedTherapistsResponse{Data: []service.SavedTherapist{}})
+		return
+	}
+	list, err := favouriteService.RecentlyViewed(r.Context(), uid)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, savedTherapistsResponse{Data: list})
+}
+
+// AddFavouriteWeb and RemoveFavouriteWeb toggle the star on the therapist
+// list and render it again.
+func AddFavouriteWeb(w http.ResponseWriter, r *http.Request) {
+	toggleFavouriteWeb(w, r, true)
+}
+
+func RemoveFavouriteWeb(w http.ResponseWriter, r *http.Request) {
+	toggleFavouriteWeb(w, r, false)
+}
+
+func toggleFavouriteWeb(w http.ResponseWriter, r *http.Request, on bool) {
+	uid, ok := favouritePatient(w, r)
+	if !ok {
+		return
+	}
+	tid, err := uuid.Parse(chi.URLParam(r, "therapistId"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	if on {
+		_, err = favouriteService.AddFavourite(r.Context(), uid, tid)
+	} else {
+		err = favouriteService.RemoveFavourite(r.Context(), uid, tid)
+	}
+	if errors.Is(err, service.ErrTherapistNotFound) {
+		http.NotFound(w, r)
+		return
+	}
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Error saving favourite"))
+		return
+	}
+	views.FavouriteStar(tid.String(), on).Render(r.Context(), w)
+}
+
+// markFavourites flags the therapists on a page the caller has starred.
+// Failing to look them up only loses the stars.
+func markFavourites(r *http.Request, therapists []openapi.Therapist) {
+	uid, ok := favouriteViewer(r)
+	if !ok || len(therapists) == 0 {
+		return
+	}
+	ids := make([]uuid.UUID, 0, len(therapists))
+	for _, t := range therapists {
+		if id, err := uuid.Parse(deref(t.Id)); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	set, err := favouriteService.FavouriteIDs(r.Context(), uid, ids)
+	if err != nil {
+		return
+	}
+	for i := range therapists {
+		id, _ := uuid.Parse(deref(therapists[i].Id))
+		if set[id] {
+			on := true
+			therapists[i].IsFavourite = &on
+		}
+	}
+}
+
+// isFavourite reports whether the caller has starred therapistID.
+func isFavourite(r *http.Request, therapistID string) bool {
+	uid, ok := favouriteViewer(r)
+	tid, err := uuid.Parse(therapistID)
+	if !ok || err != nil {
+		return false
+	}
+	set, err := favouriteService.FavouriteIDs(r.Context(), uid, []uuid.UUID{tid})
+	return err == nil && set[tid]
+}
+
+// recordView adds a therapist to the signed-in caller's viewing history.
+// The page is served even if that fails.
+func recordView(r *http.Request, therapistID string) {
+	if favouriteService == nil {
+		return
+	}
+	sub, _ := r.Context().Value(middleware.UserIDKey).(string)
+	uid, err1 := uuid.Parse(sub)
+	tid, err2 := uuid.Parse(therapistID)
+	if err1 != nil || err2 != nil {
+		return
+	}
+	_ = favouriteService.RecordView(r.Context(), uid, tid)
+}
+
+// favouriteViewer returns the signed-in patient whose stars a page shows.
+func favouriteViewer(r *http.Request) (uuid.UUID, bool) {
+	if favouriteService == nil {
+		return uuid.Nil, false
+	}
+	role, _ := r.Context().Value(middleware.UserRoleKey).(string)
+	if service.IsTherapistRole(role) {
+		return uuid.Nil, false
+	}
+	sub, _ := r.Context().Value(middleware.UserIDKey).(string)
+	uid, err := uuid.Parse(sub)
+	return uid, err == nil
+}
+
+// favouritePatient returns the caller, refusing therapists: favourites are
+// for patients.
+func favouritePatient(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	sub, _ := r.Context().Value(middleware.UserIDKey).(string)
+	uid, err := uuid.Parse(sub)
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Msg: "unauthorized"})
+		return uuid.Nil, false
+	}
+	if role, _ := r.Context().Value(middleware.UserRoleKey).(string); service.IsTherapistRole(role) {
+		writeJSON(w, http.StatusForbidden, errorResponse{Msg: "only patients can favourite therapists"})
+		return uuid.Nil, false
+	}
+	if favouriteService == nil {
+		writeJSON(w, http.StatusNotFound, errorResponse{Msg: "Favourites are not enabled"})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
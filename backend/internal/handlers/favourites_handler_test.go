@@ -0,0 +1,139 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/handlers"
+	mocks "github.com/divijg19/physiolink/backend/internal/mocks"
+	"github.com/divijg19/physiolink/backend/internal/openapi"
+	"github.com/divijg19/physiolink/backend/internal/service"
+)
+
+func TestFavourites_AddListRemove(t *testing.T) {
+	m := mocks.NewFavouriteServiceMock()
+	cfg, router := setupRouter(t, func() {
+		handlers.InitTherapists(&mocks.TherapistServiceMock{})
+		handlers.InitFavourites(m)
+	})
+	patient, therapist := uuid.New(), uuid.New()
+	token := sessionToken(t, cfg, patient, "patient", nil)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, authedRequest(http.MethodPut, "/api/favourites/"+therapist.String(), token, ""))
+	if rr.Code != http.StatusOK || !m.Starred[therapist] || m.PatientID != patient {
+		t.Fatalf("expected the therapist to be starred, got %d: %s", rr.Code, rr.Body.String())
+	}
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, authedRequest(http.MethodGet, "/api/favourites", token, ""))
+	var list struct {
+		Data []service.SavedTherapist `json:"data"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&list); err != nil || len(list.Data) != 1 || list.Data[0].ID != therapist.String() {
+		t.Fatalf("unexpected favourites %v %+v", err, list)
+	}
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, authedRequest(http.MethodDelete, "/api/favourites/"+therapist.String(), token, ""))
+	if rr.Code != http.StatusOK || m.Starred[therapist] {
+		t.Fatalf("expected the star to be removed, got %d", rr.Code)
+	}
+}
+
+func TestFavourites_Errors(t *testing.T) {
+	m := mocks.NewFavouriteServiceMock()
+	cfg, router := setupRouter(t, func() {
+		handlers.InitTherapists(&mocks.TherapistServiceMock{})
+		handlers.InitFavourites(m)
+	})
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, authedRequest(http.MethodPut, "/api/favourites/"+uuid.NewString(), sessionToken(t, cfg, uuid.New(), "pt", nil), ""))
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403 for a therapist, got %d", rr.Code)
+	}
+
+	token := sessionToken(t, cfg, uuid.New(), "patient", nil)
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, authedRequest(http.MethodPut, "/api/favourites/not-a-uuid", token, ""))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for a bad id, got %d", rr.Code)
+	}
+
+	m.Err = service.ErrTherapistNotFound
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, authedRequest(http.MethodPut, "/api/favourites/"+uuid.NewString(), token, ""))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for an unknown therapist, got %d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, authedRequest(http.MethodGet, "/api/favourites", "", ""))
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 without a token, got %d", rr.Code)
+	}
+}
+
+func TestGetTherapistByID_RecordsViewAndFavourite(t *testing.T) {
+	m := mocks.NewFavouriteServiceMock()
+	cfg, router := setupRouter(t, func() {
+		handlers.InitTherapists(&mocks.TherapistServiceMock{})
+		handlers.InitFavourites(m)
+	})
+	first, second := uuid.New(), uuid.New()
+	m.Starred[second] = true
+	token := sessionToken(t, cfg, uuid.New(), "patient", nil)
+
+	for _, id := range []uuid.UUID{first, second} {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, authedRequest(http.MethodGet, "/api/therapists/"+id.String(), token, ""))
+		var res map[string]interface{}
+		if err := json.NewDecoder(rr.Body).Decode(&res); err != nil || rr.Code != http.StatusOK {
+			t.Fatalf("unexpected response %d %v", rr.Code, err)
+		}
+		if res["isFavourite"] != (id == second) {
+			t.Fatalf("isFavourite = %v for %s", res["isFavourite"], id)
+		}
+	}
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, authedRequest(http.MethodGet, "/api/therapists/recent", token, ""))
+	var list struct {
+		Data []service.SavedTherapist `json:"data"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&list); err != nil || len(list.Data) != 2 {
+		t.Fatalf("unexpected history %v %+v", err, list)
+	}
+	if list.Data[0].ID != second.String() || list.Data[1].ID != first.String() {
+		t.Fatalf("expected the latest view first, got %+v", list.Data)
+	}
+}
+
+func TestGetAllTherapists_MarksFavourites(t *testing.T) {
+	m := mocks.NewFavouriteServiceMock()
+	starred := uuid.New()
+	m.Starred[starred] = true
+	ts := &mocks.TherapistServiceMock{}
+	cfg, router := setupRouter(t, func() {
+		handlers.InitTherapists(ts)
+		handlers.InitFavourites(m)
+	})
+
+	for role, want := range map[string]bool{"patient": true, "pt": false} {
+		ts.ListResp = mocks.MakeTherapistListResult([]string{uuid.NewString(), starred.String()})
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, authedRequest(http.MethodGet, "/api/therapists", sessionToken(t, cfg, uuid.New(), role, nil), ""))
+		var res openapi.TherapistList
+		if err := json.NewDecoder(rr.Body).Decode(&res); err != nil || res.Data == nil || len(*res.Data) != 2 {
+			t.Fatalf("unexpected response %v %+v", err, res)
+		}
+		if data := *res.Data; data[0].IsFavourite != nil || (data[1].IsFavourite != nil) != want {
+			t.Fatalf("%s: unexpected stars %+v", role, *res.Data)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/config"
+	"github.com/divijg19/physiolink/backend/internal/handlers"
+	mocks "github.com/divijg19/physiolink/backend/internal/mocks"
+	"github.com/divijg19/physiolink/backend/internal/server"
+)
+
+// setupRouter builds the full router with a mock auth service and no MFA,
+// after init has registered the services under test. Those services are
+// cleared again when the test ends.
+func setupRouter(t *testing.T, init func()) (*config.Config, http.Handler) {
+	t.Helper()
+	cfg := config.New()
+	handlers.InitAuth(mocks.NewAuthServiceMock(), cfg)
+	handlers.InitMFA(nil)
+	init()
+	t.Cleanup(func() {
+		handlers.InitAdmin(nil)
+		handlers.InitTherapists(nil)
+		handlers.InitFavourites(nil)
+		handlers.InitRecommendations(nil)
+		handlers.InitVerification(nil)
+		handlers.InitUploads(nil, nil)
+		handlers.InitOIDC(nil, nil, nil)
+	})
+	return cfg, server.NewRouter(cfg)
+}
+
+func sessionToken(t *testing.T, cfg *config.Config, id uuid.UUID, role string, extra jwt.MapClaims) string {
+	t.Helper()
+	claims := jwt.MapClaims{
+		"user": map[string]interface{}{"id": id.String(), "role": role},
+		"exp":  time.Now().Add(time.Hour).Unix(),
+	}
+	for k, v := range extra {
+		claims[k] = v
+	}
+	token, err := cfg.KeySet().Sign(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func authedRequest(method, path, token, body string) *http.Request {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
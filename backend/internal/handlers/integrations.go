@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/middleware"
+	"github.com/divijg19/physiolink/backend/internal/service"
+)
+
+// ClinicScheduleService interface for handler tests.
+type ClinicScheduleService interface {
+	Appointments(ctx context.Context, clinicID uuid.UUID, from, to time.Time) ([]service.ClinicAppointment, error)
+	OpenSlots(ctx context.Context, clinicID uuid.UUID, from, to time.Time) ([]service.ClinicSlot, error)
+	CreateSlots(ctx context.Context, clinicID, therapistID uuid.UUID, slots []struct{ StartTs, EndTs string }) error
+}
+
+var clinicScheduleService ClinicScheduleService
+
+func InitIntegrations(s ClinicScheduleService) { clinicScheduleService = s }
+
+// defaultScheduleWindow is used when a request gives no "to".
+const defaultScheduleWindow = 30 * 24 * time.Hour
+
+type clinicSlotsReq struct {
+	TherapistID string `json:"therapistId"`
+	Slots       []struct {
+		StartTime string `json:"startTime"`
+		EndTime   string `json:"endTime"`
+	} `json:"slots"`
+}
+
+// IntegrationAppointments exports the appointments of the key's clinic.
+func IntegrationAppointments(w http.ResponseWriter, r *http.Request) {
+	clinic, from, to, ok := scheduleRequest(w, r)
+	if !ok {
+		return
+	}
+	list, err := clinicScheduleService.Appointments(r.Context(), clinic, from, to)
+	if err != nil {
+		writeScheduleError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+// IntegrationAvailability exports the open slots of the key's clinic.
+func IntegrationAvailability(w http.ResponseWriter, r *http.Request) {
+	clinic, from, to, ok := scheduleRequest(w, r)
+	if !ok {
+		return
+	}
+	list, err := clinicScheduleService.OpenSlots(r.Context(), clinic, from, to)
+	if err != nil {
+		writeScheduleError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+// IntegrationCreateAvailability publishes slots for a therapist of the key's clinic.
+func IntegrationCreateAvailability(w http.ResponseWriter, r *http.Request) {
+	clinic, ok := integrationClinic(w, r)
+	if !ok {
+		return
+	}
+	var req clinicSlotsReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "invalid request"})
+		return
+	}
+	tid, err := uuid.Parse(req.TherapistID)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "invalid therapistId"})
+		return
+	}
+	slots := make([]struct{ StartTs, EndTs string }, 0, len(req.Slots))
+	for _, s := range req.Slots {
+		slots = append(slots, struct{ StartTs, EndTs string }{StartTs: s.StartTime, EndTs: s.EndTime})
+	}
+	if err := clinicScheduleService.CreateSlots(r.Context(), clinic, tid, slots); err != nil {
+		writeScheduleError(w, err)
+		return
+	}
+	w.WriteHeader(http.StatusCreated)
+}
+
+func integrationClinic(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	s, _ := r.Context().Value(middleware.ClinicIDKey).(string)
+	id, err := uuid.Parse(s)
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Msg: "unauthorized"})
+		return uuid.Nil, false
+	}
+	return id, true
+}
+
+// scheduleRequest reads the clinic and the from/to window (RFC 3339),
+// defaulting to the next 30 days.
+func scheduleRequest(w http.ResponseWriter, r *http.Request) (uuid.UUID, time.Time, time.Time, bool) {
+	clinic, ok := integrationClinic(w, r)
+	if !ok {
+		return uuid.Nil, time.Time{}, time.Time{}, false
+	}
+	from, to := time.Now(), time.Time{}
+	var err error
+	if s := r.URL.Query().Get("from"); s != "" {
+		if from, err = time.Parse(time.RFC3339, s); err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "invalid from"})
+			return uuid.Nil, time.Time{}, time.Time{}, false
+		}
+	}
+	to = from.Add(defaultScheduleWindow)
+	if s := r.URL.Query().Get("to"); s != "" {
+		if to, err = time.Parse(time.RFC3339, s); err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "invalid to"})
+			return uuid.Nil, time.Time{}, time.Time{}, false
+		}
+	}
+	return clinic, from, to, true
+}
+
+func writeScheduleError(w http.ResponseWriter, err error) {
+	switch {
+	case errors.Is(err, service.ErrInvalidWindow):
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "Invalid time window"})
+	case errors.Is(err, service.ErrInvalidSlot):
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "Invalid slot"})
+	case errors.Is(err, service.ErrNotClinicMember):
+		writeJSON(w, http.StatusForbidden, errorResponse{Msg: "Therapist does not belong to this clinic"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
+	}
+}
@@ -0,0 +1,104 @@
+package handlers_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/config"
+	"github.com/divijg19/physiolink/backend/internal/handlers"
+	mware "github.com/divijg19/physiolink/backend/internal/middleware"
+	mocks "github.com/divijg19/physiolink/backend/internal/mocks"
+	"github.com/divijg19/physiolink/backend/internal/server"
+	"github.com/divijg19/physiolink/backend/internal/service"
+)
+
+const (
+	readKey  = "plk_read_secret"
+	writeKey = "plk_write_secret"
+)
+
+func setupIntegrations(t *testing.T, m *mocks.ClinicScheduleServiceMock, clinic uuid.UUID) http.Handler {
+	t.Helper()
+	store := &mocks.APIKeyStoreMock{Keys: map[string]struct {
+		ID       uuid.UUID
+		ClinicID uuid.UUID
+		Scopes   []string
+	}{
+		readKey:  {ID: uuid.New(), ClinicID: clinic, Scopes: []string{service.ScopeAppointmentsRead, service.ScopeAvailabilityRead}},
+		writeKey: {ID: uuid.New(), ClinicID: clinic, Scopes: []string{service.ScopeAvailabilityWrite}},
+	}}
+	cfg := config.New()
+	handlers.InitAuth(mocks.NewAuthServiceMock(), cfg)
+	handlers.InitIntegrations(m)
+	mware.InitAPIKeys(store)
+	t.Cleanup(func() {
+		handlers.InitIntegrations(nil)
+		mware.InitAPIKeys(nil)
+	})
+	return server.NewRouter(cfg)
+}
+
+func TestIntegrationAppointments_ScopedToKeyClinic(t *testing.T) {
+	clinic := uuid.New()
+	m := &mocks.ClinicScheduleServiceMock{}
+	router := setupIntegrations(t, m, clinic)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/integrations/appointments?from=2026-11-01T00:00:00Z&to=2026-11-08T00:00:00Z", nil)
+	req.Header.Set("Authorization", "Bearer "+readKey)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if m.ClinicID != clinic || m.From.Day() != 1 || m.To.Day() != 8 {
+		t.Fatalf("unexpected call clinic=%v from=%v to=%v", m.ClinicID, m.From, m.To)
+	}
+}
+
+func TestIntegrationAPI_EnforcesScopes(t *testing.T) {
+	router := setupIntegrations(t, &mocks.ClinicScheduleServiceMock{}, uuid.New())
+
+	cases := []struct {
+		method, path, key string
+		want              int
+	}{
+		{http.MethodGet, "/api/integrations/appointments", "", http.StatusUnauthorized},
+		{http.MethodGet, "/api/integrations/appointments", "plk_unknown_key", http.StatusUnauthorized},
+		{http.MethodGet, "/api/integrations/appointments", writeKey, http.StatusForbidden},
+		{http.MethodPost, "/api/integrations/availability", readKey, http.StatusForbidden},
+	}
+	for _, c := range cases {
+		req := httptest.NewRequest(c.method, c.path, strings.NewReader(`{}`))
+		if c.key != "" {
+			req.Header.Set("x-auth-token", c.key)
+		}
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, req)
+		if rr.Code != c.want {
+			t.Fatalf("%s %s with %q: expected %d, got %d", c.method, c.path, c.key, c.want, rr.Code)
+		}
+	}
+}
+
+func TestIntegrationCreateAvailability_RejectsOtherClinicsTherapist(t *testing.T) {
+	m := &mocks.ClinicScheduleServiceMock{Err: service.ErrNotClinicMember}
+	router := setupIntegrations(t, m, uuid.New())
+
+	body := `{"therapistId":"` + uuid.NewString() + `","slots":[{"startTime":"2026-11-01T09:00:00Z","endTime":"2026-11-01T10:00:00Z"}]}`
+	req := httptest.NewRequest(http.MethodPost, "/api/integrations/availability", strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer "+writeKey)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", rr.Code)
+	}
+	if len(m.CreatedSlots) != 1 {
+		t.Fatalf("expected slots to reach the service, got %+v", m.CreatedSlots)
+	}
+}
@@ -0,0 +1,12 @@
+package handlers
+
+import (
+	"net/http"
+)
+
+// JWKS publishes the public signing keys so other services can verify our
+// tokens. Retired keys stay listed until their files are removed.
+func JWKS(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	writeJSON(w, http.StatusOK, cfg.KeySet().JWKS())
+}
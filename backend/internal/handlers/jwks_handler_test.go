@@ -0,0 +1,39 @@
+package handlers_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/divijg19/physiolink/backend/internal/config"
+	"github.com/divijg19/physiolink/backend/internal/handlers"
+	mocks "github.com/divijg19/physiolink/backend/internal/mocks"
+	"github.com/divijg19/physiolink/backend/internal/tokens"
+)
+
+func TestJWKS_PublishesPublicKeysOnly(t *testing.T) {
+	_, priv, _ := ed25519.GenerateKey(rand.Reader)
+	key, _ := tokens.NewKey("2026-10", priv)
+	keys := tokens.NewHMAC("secret")
+	if err := keys.Add(key); err != nil {
+		t.Fatal(err)
+	}
+	handlers.InitAuth(mocks.NewAuthServiceMock(), &config.Config{JWTSecret: "secret", JWTKeys: keys})
+
+	rr := httptest.NewRecorder()
+	handlers.JWKS(rr, httptest.NewRequest(http.MethodGet, "/.well-known/jwks.json", nil))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	var resp tokens.JWKS
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if len(resp.Keys) != 1 || resp.Keys[0].Kid != "2026-10" || resp.Keys[0].Crv != "Ed25519" {
+		t.Fatalf("unexpected keys %+v", resp.Keys)
+	}
+}
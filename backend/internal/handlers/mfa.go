@@ -0,0 +1,272 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/middleware"
+	"github.com/divijg19/physiolink/backend/internal/service"
+	"github.com/divijg19/physiolink/backend/internal/views"
+)
+
+// MFAService interface for handler tests.
+type MFAService interface {
+	Requirement(ctx context.Context, userID uuid.UUID, role string) (service.MFARequirement, error)
+	Status(ctx context.Context, userID uuid.UUID, role string) (service.MFAStatus, error)
+	BeginEnrollment(ctx context.Context, userID uuid.UUID) (service.MFAEnrollment, error)
+	ConfirmEnrollment(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	Verify(ctx context.Context, userID uuid.UUID, code string) error
+	IssueChallenge(ctx context.Context, userID uuid.UUID, expiresAt time.Time) (uuid.UUID, error)
+	ConsumeChallenge(ctx context.Context, challengeID, userID uuid.UUID) error
+	Disable(ctx context.Context, userID uuid.UUID, role, code string) error
+}
+
+var mfaService MFAService
+
+func InitMFA(s MFAService) { mfaService = s }
+
+// mfaChallengeTTL bounds how long a user has to enter their code after
+// passing the password step.
+const mfaChallengeTTL = 5 * time.Minute
+
+var errInvalidMFAChallenge = errors.New("invalid challenge")
+
+type mfaCodeReq struct {
+	Code string `json:"code"`
+}
+
+type loginMFAReq struct {
+	MFAToken string `json:"mfaToken"`
+	Code     string `json:"code"`
+}
+
+type mfaConfirmResponse struct {
+	Token         string   `json:"token"`
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
+
+// LoginMFA exchanges a challenge token from Login plus a TOTP or recovery code
+// for an MFA-verified session. The challenge is spent by any attempt, so a
+// wrong code means logging in again.
+func LoginMFA(w http.ResponseWriter, r *http.Request) {
+	var req loginMFAReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.MFAToken == "" || req.Code == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "invalid request"})
+		return
+	}
+	id, role, err := consumeMFAChallenge(r, req.MFAToken)
+	if err != nil {
+		if errors.Is(err, errInvalidMFAChallenge) {
+			writeJSON(w, http.StatusUnauthorized, errorResponse{Msg: "MFA challenge expired"})
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server error"})
+		return
+	}
+	if err := mfaService.Verify(r.Context(), id, req.Code); err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidMFACode), errors.Is(err, service.ErrMFANotEnrolled):
+			writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "Invalid code"})
+		case errors.Is(err, service.ErrMFALocked):
+			writeJSON(w, http.StatusTooManyRequests, errorResponse{Msg: "Too many invalid codes, try again later"})
+		default:
+			writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server error"})
+		}
+		return
+	}
+	signed, err := signToken(r, id, role, true)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, authResponse{Token: signed})
+}
+
+func GetMFAStatus(w http.ResponseWriter, r *http.Request) {
+	uid, role, ok := mfaUser(w, r)
+	if !ok {
+		return
+	}
+	status, err := mfaService.Status(r.Context(), uid, role)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, status)
+}
+
+// EnrollMFA starts enrollment and returns the secret and otpauth:// URI for
+// the client to show as a QR code.
+func EnrollMFA(w http.ResponseWriter, r *http.Request) {
+	uid, _, ok := mfaUser(w, r)
+	if !ok {
+		return
+	}
+	enrollment, err := mfaService.BeginEnrollment(r.Context(), uid)
+	if err != nil {
+		if errors.Is(err, service.ErrMFAAlreadyEnabled) {
+			writeJSON(w, http.StatusConflict, errorResponse{Msg: "MFA already enabled"})
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, enrollment)
+}
+
+// ConfirmMFA activates MFA and returns the recovery codes together with an
+// MFA-verified session replacing the caller's current one.
+func ConfirmMFA(w http.ResponseWriter, r *http.Request) {
+	uid, role, ok := mfaUser(w, r)
+	if !ok {
+		return
+	}
+	var req mfaCodeReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Code == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "invalid request"})
+		return
+	}
+	codes, err := mfaService.ConfirmEnrollment(r.Context(), uid, req.Code)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrInvalidMFACode):
+			writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "Invalid code"})
+		case errors.Is(err, service.ErrMFANotEnrolled):
+			writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "MFA enrollment not started"})
+		case errors.Is(err, service.ErrMFAAlreadyEnabled):
+			writeJSON(w, http.StatusConflict, errorResponse{Msg: "MFA already enabled"})
+		default:
+			writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
+		}
+		return
+	}
+	signed, err := signToken(r, uid, role, true)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
+		return
+	}
+	endCurrentSession(r)
+	writeJSON(w, http.StatusOK, mfaConfirmResponse{Token: signed, RecoveryCodes: codes})
+}
+
+func DisableMFA(w http.ResponseWriter, r *http.Request) {
+	uid, role, ok := mfaUser(w, r)
+	if !ok {
+		return
+	}
+	var req mfaCodeReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Code == "" {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "invalid request"})
+		return
+	}
+	if err := mfaService.Disable(r.Context(), uid, role, req.Code); err != nil {
+		switch {
+		case errors.Is(err, service.ErrMFARequired):
+			writeJSON(w, http.StatusForbidden, errorResponse{Msg: "Your clinic requires MFA"})
+		case errors.Is(err, service.ErrInvalidMFACode), errors.Is(err, service.ErrMFANotEnrolled):
+			writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "Invalid code"})
+		case errors.Is(err, service.ErrMFALocked):
+			writeJSON(w, http.StatusTooManyRequests, errorResponse{Msg: "Too many invalid codes, try again later"})
+		default:
+			writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
+		}
+		return
+	}
+	writeJSON(w, http.StatusOK, errorResponse{Msg: "MFA disabled"})
+}
+
+// LoginMFASubmit is the second step of the HTMX login form.
+func LoginMFASubmit(w http.ResponseWriter, r *http.Request) {
+	id, role, err := consumeMFAChallenge(r, r.FormValue("mfaToken"))
+	if err != nil {
+		w.WriteHeader(http.StatusUnauthorized)
+		views.FormError("Your sign-in attempt expired. Please start again.").Render(r.Context(), w)
+		return
+	}
+	if err := mfaService.Verify(r.Context(), id, r.FormValue("code")); err != nil {
+		if errors.Is(err, service.ErrMFALocked) {
+			w.WriteHeader(http.StatusTooManyRequests)
+			views.FormError("Too many invalid codes. Please try again later.").Render(r.Context(), w)
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		views.FormError("Invalid authentication code. Please sign in again.").Render(r.Context(), w)
+		return
+	}
+	token, err := signToken(r, id, role, true)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		views.FormError("Server error").Render(r.Context(), w)
+		return
+	}
+	setAuthCookie(w, token)
+	w.Header().Set("HX-Redirect", "/")
+	w.WriteHeader(http.StatusOK)
+}
+
+func mfaUser(w http.ResponseWriter, r *http.Request) (uuid.UUID, string, bool) {
+	sub, _ := r.Context().Value(middleware.UserIDKey).(string)
+	if sub == "" {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Msg: "unauthorized"})
+		return uuid.Nil, "", false
+	}
+	uid, err := uuid.Parse(sub)
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "invalid user"})
+		return uuid.Nil, "", false
+	}
+	role, _ := r.Context().Value(middleware.UserRoleKey).(string)
+	return uid, role, true
+}
+
+// signMFAChallenge issues the token carried between the password and code
+// steps. It has no "user" claim, so the auth middlewares never accept it as
+// a session. Its jti is the recorded challenge, good for one attempt.
+func signMFAChallenge(r *http.Request, id uuid.UUID, role string) (string, error) {
+	expires := time.Now().Add(mfaChallengeTTL)
+	jti, err := mfaService.IssueChallenge(r.Context(), id, expires)
+	if err != nil {
+		return "", err
+	}
+	return signClaims(jwt.MapClaims{
+		"mfa_pending": map[string]interface{}{
+			"id":   id.String(),
+			"role": role,
+		},
+		"jti": jti.String(),
+		"exp": expires.Unix(),
+	})
+}
+
+// consumeMFAChallenge checks a challenge token and spends its challenge.
+func consumeMFAChallenge(r *http.Request, tokenStr string) (uuid.UUID, string, error) {
+	claims, err := cfg.KeySet().Parse(tokenStr)
+	if err != nil {
+		return uuid.Nil, "", errInvalidMFAChallenge
+	}
+	pending, _ := claims["mfa_pending"].(map[string]interface{})
+	sub, _ := pending["id"].(string)
+	role, _ := pending["role"].(string)
+	jti, _ := claims["jti"].(string)
+	id, err := uuid.Parse(sub)
+	if err != nil {
+		return uuid.Nil, "", errInvalidMFAChallenge
+	}
+	challenge, err := uuid.Parse(jti)
+	if err != nil {
+		return uuid.Nil, "", errInvalidMFAChallenge
+	}
+	if err := mfaService.ConsumeChallenge(r.Context(), challenge, id); err != nil {
+		if errors.Is(err, service.ErrMFAChallengeUsed) {
+			return uuid.Nil, "", errInvalidMFAChallenge
+		}
+		return uuid.Nil, "", err
+	}
+	return id, role, nil
+}
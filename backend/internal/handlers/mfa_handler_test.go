@@ -0,0 +1,176 @@
+package handlers_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/divijg19/physiolink/backend/internal/config"
+	"github.com/divijg19/physiolink/backend/internal/handlers"
+	mocks "github.com/divijg19/physiolink/backend/internal/mocks"
+	"github.com/divijg19/physiolink/backend/internal/service"
+)
+
+func loginRequest(t *testing.T, email, password string) *http.Request {
+	t.Helper()
+	b, _ := json.Marshal(map[string]string{"email": email, "password": password})
+	return httptest.NewRequest(http.MethodPost, "/api/auth/login", bytes.NewReader(b))
+}
+
+func setupMFAAuth(t *testing.T, m *mocks.MFAServiceMock) {
+	t.Helper()
+	auth := mocks.NewAuthServiceMock()
+	if _, _, err := auth.Register(context.Background(), "pt@example.com", "pw", "pt"); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	handlers.InitAuth(auth, config.New())
+	handlers.InitMFA(m)
+}
+
+func TestLogin_ReturnsChallengeWhenMFAEnabled(t *testing.T) {
+	setupMFAAuth(t, &mocks.MFAServiceMock{Req: service.MFAChallenge})
+	t.Cleanup(func() { handlers.InitMFA(nil) })
+
+	rr := httptest.NewRecorder()
+	handlers.Login(rr, loginRequest(t, "pt@example.com", "pw"))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	var resp map[string]interface{}
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid json: %v", err)
+	}
+	if resp["mfaRequired"] != true || resp["mfaToken"] == "" {
+		t.Fatalf("expected MFA challenge, got %v", resp)
+	}
+	if _, ok := resp["token"]; ok {
+		t.Fatalf("session token must not be issued before the second factor")
+	}
+}
+
+func TestLoginMFA_IssuesVerifiedToken(t *testing.T) {
+	setupMFAAuth(t, &mocks.MFAServiceMock{Req: service.MFAChallenge})
+	t.Cleanup(func() { handlers.InitMFA(nil) })
+
+	rr := httptest.NewRecorder()
+	handlers.Login(rr, loginRequest(t, "pt@example.com", "pw"))
+	var challenge struct {
+		MFAToken string `json:"mfaToken"`
+	}
+	_ = json.Unmarshal(rr.Body.Bytes(), &challenge)
+
+	b, _ := json.Marshal(map[string]string{"mfaToken": challenge.MFAToken, "code": "123456"})
+	rr = httptest.NewRecorder()
+	handlers.LoginMFA(rr, httptest.NewRequest(http.MethodPost, "/api/auth/login/mfa", bytes.NewReader(b)))
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var resp struct {
+		Token string `json:"token"`
+	}
+	_ = json.Unmarshal(rr.Body.Bytes(), &resp)
+	claims := jwt.MapClaims{}
+	if _, _, err := new(jwt.Parser).ParseUnverified(resp.Token, claims); err != nil {
+		t.Fatalf("token not parseable: %v", err)
+	}
+	if claims["mfa"] != true {
+		t.Fatalf("expected mfa claim, got %v", claims["mfa"])
+	}
+}
+
+func TestLoginMFA_RejectsInvalidCode(t *testing.T) {
+	setupMFAAuth(t, &mocks.MFAServiceMock{Req: service.MFAChallenge, VerifyErr: service.ErrInvalidMFACode})
+	t.Cleanup(func() { handlers.InitMFA(nil) })
+
+	rr := httptest.NewRecorder()
+	handlers.Login(rr, loginRequest(t, "pt@example.com", "pw"))
+	var challenge struct {
+		MFAToken string `json:"mfaToken"`
+	}
+	_ = json.Unmarshal(rr.Body.Bytes(), &challenge)
+
+	b, _ := json.Marshal(map[string]string{"mfaToken": challenge.MFAToken, "code": "000000"})
+	rr = httptest.NewRecorder()
+	handlers.LoginMFA(rr, httptest.NewRequest(http.MethodPost, "/api/auth/login/mfa", bytes.NewReader(b)))
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rr.Code)
+	}
+}
+
+func TestLoginMFA_ChallengeIsSingleUse(t *testing.T) {
+	m := &mocks.MFAServiceMock{Req: service.MFAChallenge, VerifyErr: service.ErrInvalidMFACode}
+	setupMFAAuth(t, m)
+	t.Cleanup(func() { handlers.InitMFA(nil) })
+
+	rr := httptest.NewRecorder()
+	handlers.Login(rr, loginRequest(t, "pt@example.com", "pw"))
+	var challenge struct {
+		MFAToken string `json:"mfaToken"`
+	}
+	_ = json.Unmarshal(rr.Body.Bytes(), &challenge)
+
+	b, _ := json.Marshal(map[string]string{"mfaToken": challenge.MFAToken, "code": "000000"})
+	rr = httptest.NewRecorder()
+	handlers.LoginMFA(rr, httptest.NewRequest(http.MethodPost, "/api/auth/login/mfa", bytes.NewReader(b)))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rr.Code)
+	}
+
+	// A wrong guess spends the challenge, even for the right code
+	m.VerifyErr = nil
+	b, _ = json.Marshal(map[string]string{"mfaToken": challenge.MFAToken, "code": "123456"})
+	rr = httptest.NewRecorder()
+	handlers.LoginMFA(rr, httptest.NewRequest(http.MethodPost, "/api/auth/login/mfa", bytes.NewReader(b)))
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for a replayed challenge, got %d", rr.Code)
+	}
+}
+
+func TestLoginMFA_LockedOut(t *testing.T) {
+	setupMFAAuth(t, &mocks.MFAServiceMock{Req: service.MFAChallenge, VerifyErr: service.ErrMFALocked})
+	t.Cleanup(func() { handlers.InitMFA(nil) })
+
+	rr := httptest.NewRecorder()
+	handlers.Login(rr, loginRequest(t, "pt@example.com", "pw"))
+	var challenge struct {
+		MFAToken string `json:"mfaToken"`
+	}
+	_ = json.Unmarshal(rr.Body.Bytes(), &challenge)
+
+	b, _ := json.Marshal(map[string]string{"mfaToken": challenge.MFAToken, "code": "123456"})
+	rr = httptest.NewRecorder()
+	handlers.LoginMFA(rr, httptest.NewRequest(http.MethodPost, "/api/auth/login/mfa", bytes.NewReader(b)))
+	if rr.Code != http.StatusTooManyRequests {
+		t.Fatalf("expected 429, got %d", rr.Code)
+	}
+}
+
+func TestLoginSubmit_RendersCodeStep(t *testing.T) {
+	setupMFAAuth(t, &mocks.MFAServiceMock{Req: service.MFAChallenge})
+	t.Cleanup(func() { handlers.InitMFA(nil) })
+
+	form := strings.NewReader("email=pt%40example.com&password=pw")
+	req := httptest.NewRequest(http.MethodPost, "/auth/login-form", form)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	rr := httptest.NewRecorder()
+	handlers.LoginSubmit(rr, req)
+
+	if rr.Header().Get("HX-Retarget") != "#login-form" {
+		t.Fatalf("expected form swap, got headers %v", rr.Header())
+	}
+	if len(rr.Result().Cookies()) != 0 {
+		t.Fatalf("no session cookie should be set before the second factor")
+	}
+	if !strings.Contains(rr.Body.String(), `name="mfaToken"`) {
+		t.Fatalf("expected MFA form, got %s", rr.Body.String())
+	}
+}
@@ -0,0 +1,307 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/middleware"
+	"github.com/divijg19/physiolink/backend/internal/oidc"
+	"github.com/divijg19/physiolink/backend/internal/service"
+	"github.com/divijg19/physiolink/backend/internal/views"
+)
+
+// OIDCService interface for handler tests.
+type OIDCService interface {
+	SignIn(ctx context.Context, ident service.ExternalIdentity, role string) (uuid.UUID, string, error)
+	LinkIdentity(ctx context.Context, userID uuid.UUID, ident service.ExternalIdentity) error
+}
+
+var (
+	oidcService      OIDCService
+	oidcProviders    = map[string]*oidc.Provider{}
+	oidcAppRedirects []string
+)
+
+// InitOIDC registers the discovered identity providers. appRedirects are the
+// mobile app URIs a login may return its session to.
+func InitOIDC(s OIDCService, providers []*oidc.Provider, appRedirects []string) {
+	oidcService = s
+	oidcProviders = map[string]*oidc.Provider{}
+	for _, p := range providers {
+		oidcProviders[p.Name] = p
+	}
+	oidcAppRedirects = appRedirects
+}
+
+const (
+	oidcFlowCookie = "oidc_flow"
+	// oidcFlowTTL bounds how long the user may spend at the identity provider.
+	oidcFlowTTL = 10 * time.Minute
+)
+
+// oidcFlow is kept in a signed cookie between OIDCStart and OIDCCallback.
+type oidcFlow struct {
+	Provider    string
+	State       string
+	Nonce       string
+	Verifier    string
+	Role        string
+	AppRedirect string
+	// LinkUser is set when a signed-in user is linking the provider to their
+	// account rather than signing in with it.
+	LinkUser string
+}
+
+type oidcProvidersResponse struct {
+	Providers []string `json:"providers"`
+}
+
+// ListOIDCProviders lets clients render a button per configured provider.
+func ListOIDCProviders(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, oidcProvidersResponse{Providers: oidcProviderNames()})
+}
+
+// OIDCStart redirects to the identity provider. role is used only if the
+// login provisions a new account. Mobile clients pass app_redirect, which
+// must be allow-listed, to get the session back via a deep link.
+func OIDCStart(w http.ResponseWriter, r *http.Request) {
+	p, ok := oidcProviders[chi.URLParam(r, "provider")]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	role, err := service.SignupRole(r.URL.Query().Get("role"))
+	if err != nil {
+		http.Error(w, "invalid role", http.StatusBadRequest)
+		return
+	}
+	appRedirect := r.URL.Query().Get("app_redirect")
+	if appRedirect != "" && !allowedAppRedirect(appRedirect) {
+		http.Error(w, "invalid app_redirect", http.StatusBadRequest)
+		return
+	}
+	beginOIDCFlow(w, r, p, oidcFlow{Provider: p.Name, Role: role, AppRedirect: appRedirect})
+}
+
+// OIDCLinkStart lets a signed-in web user add the provider as a way to sign
+// in to their account. Accounts with a password or admin rights are never
+// linked by email at sign-in, so this is how their owners link one.
+func OIDCLinkStart(w http.ResponseWriter, r *http.Request) {
+	p, ok := oidcProviders[chi.URLParam(r, "provider")]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	// An admin acting as the user must not leave a way back in behind
+	if _, impersonated := r.Context().Value(middleware.ImpersonatorKey).(string); impersonated {
+		http.Error(w, "not allowed while impersonating", http.StatusForbidden)
+		return
+	}
+	userID, _ := r.Context().Value(middleware.UserIDKey).(string)
+	beginOIDCFlow(w, r, p, oidcFlow{Provider: p.Name, LinkUser: userID})
+}
+
+// beginOIDCFlow stores flow in a signed cookie and redirects to the provider.
+func beginOIDCFlow(w http.ResponseWriter, r *http.Request, p *oidc.Provider, flow oidcFlow) {
+	var err error
+	for _, v := range []*string{&flow.State, &flow.Nonce, &flow.Verifier} {
+		if *v, err = oidc.RandomString(); err != nil {
+			http.Error(w, "server error", http.StatusInternalServerError)
+			return
+		}
+	}
+	signed, err := signClaims(jwt.MapClaims{
+		"oidc": map[string]interface{}{
+			"provider":     flow.Provider,
+			"state":        flow.State,
+			"nonce":        flow.Nonce,
+			"verifier":     flow.Verifier,
+			"role":         flow.Role,
+			"app_redirect": flow.AppRedirect,
+			"link_user":    flow.LinkUser,
+		},
+		"exp": time.Now().Add(oidcFlowTTL).Unix(),
+	})
+	if err != nil {
+		http.Error(w, "server error", http.StatusInternalServerError)
+		return
+	}
+	// Lax, not Strict: the cookie must come back on the provider's top-level
+	// redirect to the callback.
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcFlowCookie,
+		Value:    signed,
+		Path:     "/auth/oidc",
+		MaxAge:   int(oidcFlowTTL.Seconds()),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	http.Redirect(w, r, p.AuthCodeURL(flow.State, flow.Nonce, flow.Verifier), http.StatusFound)
+}
+
+// OIDCCallback completes the login started by OIDCStart: it checks state,
+// redeems the code, links or provisions the user and applies the MFA policy
+// like a password login would.
+func OIDCCallback(w http.ResponseWriter, r *http.Request) {
+	p, ok := oidcProviders[chi.URLParam(r, "provider")]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	http.SetCookie(w, &http.Cookie{Name: oidcFlowCookie, Value: "", Path: "/auth/oidc", MaxAge: -1, HttpOnly: true})
+	flow, err := readOIDCFlow(r)
+	if err != nil || flow.Provider != p.Name || r.URL.Query().Get("state") != flow.State {
+		w.WriteHeader(http.StatusBadRequest)
+		views.LoginFailed("Your sign-in attempt expired. Please start again.").Render(r.Context(), w)
+		return
+	}
+	if r.URL.Query().Get("error") != "" {
+		oidcFail(w, r, flow, http.StatusUnauthorized, "access_denied", "Sign-in was cancelled.")
+		return
+	}
+
+	ctx := r.Context()
+	ident, err := p.Exchange(ctx, r.URL.Query().Get("code"), flow.Verifier, flow.Nonce)
+	if err != nil {
+		oidcFail(w, r, flow, http.StatusUnauthorized, "access_denied", "We couldn't verify your sign-in. Please try again.")
+		return
+	}
+	if flow.LinkUser != "" {
+		oidcLink(w, r, flow, service.ExternalIdentity{
+			Provider:      p.Name,
+			Subject:       ident.Subject,
+			Email:         ident.Email,
+			EmailVerified: ident.EmailVerified,
+		})
+		return
+	}
+	id, role, err := oidcService.SignIn(ctx, service.ExternalIdentity{
+		Provider:      p.Name,
+		Subject:       ident.Subject,
+		Email:         ident.Email,
+		EmailVerified: ident.EmailVerified,
+	}, flow.Role)
+	if err != nil {
+		switch {
+		case errors.Is(err, service.ErrOIDCAccountExists):
+			oidcFail(w, r, flow, http.StatusConflict, "account_exists", "An account with this email already exists. Sign in with your password, then link this provider from your dashboard.")
+		case errors.Is(err, service.ErrOIDCEmailRequired):
+			oidcFail(w, r, flow, http.StatusBadRequest, "email_required", "Your identity provider did not share an email address.")
+		case errors.Is(err, service.ErrAccountDisabled):
+			oidcFail(w, r, flow, http.StatusForbidden, "account_disabled", "This account has been disabled.")
+		default:
+			oidcFail(w, r, flow, http.StatusInternalServerError, "server_error", "Server error")
+		}
+		return
+	}
+	mfaReq, token, err := startSession(r, id, role)
+	if err != nil {
+		oidcFail(w, r, flow, http.StatusInternalServerError, "server_error", "Server error")
+		return
+	}
+
+	if flow.AppRedirect != "" {
+		// The fragment never reaches a server, so the session stays on the device.
+		v := url.Values{}
+		switch mfaReq {
+		case service.MFAChallenge:
+			v.Set("mfaRequired", "true")
+			v.Set("mfaToken", token)
+		case service.MFASetupRequired:
+			v.Set("token", token)
+			v.Set("mfaSetupRequired", "true")
+		default:
+			v.Set("token", token)
+		}
+		http.Redirect(w, r, flow.AppRedirect+"#"+v.Encode(), http.StatusFound)
+		return
+	}
+	switch mfaReq {
+	case service.MFAChallenge:
+		views.LoginMFA(token).Render(ctx, w)
+	case service.MFASetupRequired:
+		w.WriteHeader(http.StatusForbidden)
+		views.LoginFailed("Your clinic requires two-factor authentication. Set it up in the Physiolink app, then sign in again.").Render(ctx, w)
+	default:
+		setAuthCookie(w, token)
+		http.Redirect(w, r, "/", http.StatusFound)
+	}
+}
+
+// oidcLink completes a flow started by OIDCLinkStart.
+func oidcLink(w http.ResponseWriter, r *http.Request, flow oidcFlow, ident service.ExternalIdentity) {
+	userID, err := uuid.Parse(flow.LinkUser)
+	if err != nil {
+		oidcFail(w, r, flow, http.StatusBadRequest, "invalid_request", "Your link attempt expired. Please start again.")
+		return
+	}
+	switch err := oidcService.LinkIdentity(r.Context(), userID, ident); {
+	case errors.Is(err, service.ErrOIDCIdentityInUse):
+		oidcFail(w, r, flow, http.StatusConflict, "identity_in_use", "This sign-in is already linked to another account.")
+	case err != nil:
+		oidcFail(w, r, flow, http.StatusInternalServerError, "server_error", "Server error")
+	default:
+		http.Redirect(w, r, "/dashboard", http.StatusFound)
+	}
+}
+
+// oidcFail reports a failed callback to the app via its deep link, or as a
+// page for the web UI.
+func oidcFail(w http.ResponseWriter, r *http.Request, flow oidcFlow, status int, code, msg string) {
+	if flow.AppRedirect != "" {
+		http.Redirect(w, r, flow.AppRedirect+"#"+url.Values{"error": {code}}.Encode(), http.StatusFound)
+		return
+	}
+	w.WriteHeader(status)
+	views.LoginFailed(msg).Render(r.Context(), w)
+}
+
+func readOIDCFlow(r *http.Request) (oidcFlow, error) {
+	var flow oidcFlow
+	c, err := r.Cookie(oidcFlowCookie)
+	if err != nil {
+		return flow, err
+	}
+	claims, err := cfg.KeySet().Parse(c.Value)
+	if err != nil {
+		return flow, err
+	}
+	m, _ := claims["oidc"].(map[string]interface{})
+	flow.Provider, _ = m["provider"].(string)
+	flow.State, _ = m["state"].(string)
+	flow.Nonce, _ = m["nonce"].(string)
+	flow.Verifier, _ = m["verifier"].(string)
+	flow.Role, _ = m["role"].(string)
+	flow.AppRedirect, _ = m["app_redirect"].(string)
+	flow.LinkUser, _ = m["link_user"].(string)
+	if flow.State == "" || flow.Verifier == "" {
+		return flow, errors.New("invalid oidc flow")
+	}
+	return flow, nil
+}
+
+func allowedAppRedirect(u string) bool {
+	for _, a := range oidcAppRedirects {
+		if u == a {
+			return true
+		}
+	}
+	return false
+}
+
+func oidcProviderNames() []string {
+	names := make([]string, 0, len(oidcProviders))
+	for name := range oidcProviders {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
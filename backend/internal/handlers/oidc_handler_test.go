@@ -0,0 +1,209 @@
+package handlers_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/config"
+	"github.com/divijg19/physiolink/backend/internal/handlers"
+	mocks "github.com/divijg19/physiolink/backend/internal/mocks"
+	"github.com/divijg19/physiolink/backend/internal/oidc"
+	"github.com/divijg19/physiolink/backend/internal/oidc/oidctest"
+	"github.com/divijg19/physiolink/backend/internal/service"
+)
+
+const appRedirect = "physiolink://auth"
+
+// stubProvider discovers a provider backed by a stub IdP that lives as long
+// as the test.
+func stubProvider(t *testing.T) *oidc.Provider {
+	t.Helper()
+	idp := oidctest.NewIdP()
+	t.Cleanup(idp.Close)
+	p, err := oidc.Discover(context.Background(), nil, config.OIDCProvider{
+		Name:        "stub",
+		Issuer:      idp.Issuer(),
+		ClientID:    oidctest.ClientID,
+		RedirectURL: "http://physiolink.test/auth/oidc/stub/callback",
+	})
+	if err != nil {
+		t.Fatalf("discover: %v", err)
+	}
+	return p
+}
+
+// oidcLogin starts a login, lets the stub IdP approve it and returns the
+// request the browser would make to our callback.
+func oidcLogin(t *testing.T, router http.Handler, query string) *http.Request {
+	t.Helper()
+	return oidcFlow(t, router, httptest.NewRequest(http.MethodGet, "/auth/oidc/stub?"+query, nil))
+}
+
+// oidcFlow runs start through the stub IdP and returns the callback request.
+func oidcFlow(t *testing.T, router http.Handler, start *http.Request) *http.Request {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, start)
+	if rr.Code != http.StatusFound {
+		t.Fatalf("start: expected 302, got %d: %s", rr.Code, rr.Body.String())
+	}
+	var flow *http.Cookie
+	for _, c := range rr.Result().Cookies() {
+		if c.Name == "oidc_flow" {
+			flow = c
+		}
+	}
+	if flow == nil || !flow.HttpOnly || flow.SameSite != http.SameSiteLaxMode {
+		t.Fatalf("expected an HttpOnly, SameSite=Lax flow cookie, got %+v", flow)
+	}
+
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(rr.Header().Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+	cb, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodGet, cb.RequestURI(), nil)
+	req.AddCookie(flow)
+	return req
+}
+
+func TestOIDCCallback_WebSetsSessionCookie(t *testing.T) {
+	uid := uuid.New()
+	m := &mocks.OIDCServiceMock{UserID: uid}
+	cfg, router := setupRouter(t, func() {
+		handlers.InitOIDC(m, []*oidc.Provider{stubProvider(t)}, []string{appRedirect})
+	})
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, oidcLogin(t, router, "role=therapist"))
+
+	if rr.Code != http.StatusFound || rr.Header().Get("Location") != "/" {
+		t.Fatalf("expected redirect to /, got %d %q", rr.Code, rr.Header().Get("Location"))
+	}
+	if m.Identity.Provider != "stub" || m.Identity.Subject != "stub-user" || !m.Identity.EmailVerified || m.AskRole != "therapist" {
+		t.Fatalf("unexpected sign-in %+v role=%q", m.Identity, m.AskRole)
+	}
+	var session string
+	for _, c := range rr.Result().Cookies() {
+		if c.Name == "auth_token" {
+			session = c.Value
+		}
+	}
+	claims, err := cfg.KeySet().Parse(session)
+	if err != nil {
+		t.Fatalf("session cookie: %v", err)
+	}
+	if user, _ := claims["user"].(map[string]interface{}); user["id"] != uid.String() {
+		t.Fatalf("unexpected session claims %v", claims)
+	}
+}
+
+func TestOIDCCallback_MobileRedirectsToApp(t *testing.T) {
+	_, router := setupRouter(t, func() {
+		handlers.InitOIDC(&mocks.OIDCServiceMock{UserID: uuid.New()}, []*oidc.Provider{stubProvider(t)}, []string{appRedirect})
+	})
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, oidcLogin(t, router, "app_redirect="+url.QueryEscape(appRedirect)))
+
+	loc := rr.Header().Get("Location")
+	if rr.Code != http.StatusFound || !strings.HasPrefix(loc, appRedirect+"#") {
+		t.Fatalf("expected redirect to app, got %d %q", rr.Code, loc)
+	}
+	frag, _ := url.ParseQuery(strings.TrimPrefix(loc, appRedirect+"#"))
+	if frag.Get("token") == "" {
+		t.Fatalf("expected token in fragment, got %q", loc)
+	}
+}
+
+func TestOIDCCallback_MobileReportsLinkConflict(t *testing.T) {
+	_, router := setupRouter(t, func() {
+		handlers.InitOIDC(&mocks.OIDCServiceMock{Err: service.ErrOIDCAccountExists}, []*oidc.Provider{stubProvider(t)}, []string{appRedirect})
+	})
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, oidcLogin(t, router, "app_redirect="+url.QueryEscape(appRedirect)))
+
+	if loc := rr.Header().Get("Location"); loc != appRedirect+"#error=account_exists" {
+		t.Fatalf("unexpected redirect %q", loc)
+	}
+}
+
+func TestOIDCLink_LinksSignedInUser(t *testing.T) {
+	uid := uuid.New()
+	m := &mocks.OIDCServiceMock{}
+	cfg, router := setupRouter(t, func() {
+		handlers.InitOIDC(m, []*oidc.Provider{stubProvider(t)}, []string{appRedirect})
+	})
+
+	start := httptest.NewRequest(http.MethodGet, "/auth/oidc/stub/link", nil)
+	start.AddCookie(&http.Cookie{Name: "auth_token", Value: sessionToken(t, cfg, uid, "admin", nil)})
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, oidcFlow(t, router, start))
+
+	if rr.Code != http.StatusFound || rr.Header().Get("Location") != "/dashboard" {
+		t.Fatalf("expected redirect to the dashboard, got %d %q", rr.Code, rr.Header().Get("Location"))
+	}
+	if m.LinkedTo != uid || m.Identity.Subject != "stub-user" || m.AskRole != "" {
+		t.Fatalf("expected stub-user linked to %s without a sign-in, got %+v to %s", uid, m.Identity, m.LinkedTo)
+	}
+}
+
+func TestOIDCLink_RefusesImpersonatedSession(t *testing.T) {
+	cfg, router := setupRouter(t, func() {
+		handlers.InitOIDC(&mocks.OIDCServiceMock{}, []*oidc.Provider{stubProvider(t)}, []string{appRedirect})
+	})
+
+	start := httptest.NewRequest(http.MethodGet, "/auth/oidc/stub/link", nil)
+	token := sessionToken(t, cfg, uuid.New(), "patient", jwt.MapClaims{"act": map[string]string{"sub": uuid.NewString()}})
+	start.AddCookie(&http.Cookie{Name: "auth_token", Value: token})
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, start)
+
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", rr.Code)
+	}
+}
+
+func TestOIDCCallback_RejectsStateMismatch(t *testing.T) {
+	_, router := setupRouter(t, func() {
+		handlers.InitOIDC(&mocks.OIDCServiceMock{UserID: uuid.New()}, []*oidc.Provider{stubProvider(t)}, []string{appRedirect})
+	})
+
+	req := oidcLogin(t, router, "")
+	q := req.URL.Query()
+	q.Set("state", "forged")
+	req.URL.RawQuery = q.Encode()
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rr.Code)
+	}
+}
+
+func TestOIDCStart_RejectsUnlistedAppRedirectAndRole(t *testing.T) {
+	_, router := setupRouter(t, func() {
+		handlers.InitOIDC(&mocks.OIDCServiceMock{}, []*oidc.Provider{stubProvider(t)}, []string{appRedirect})
+	})
+
+	for _, q := range []string{"app_redirect=https://evil.example", "role=admin"} {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/auth/oidc/stub?"+q, nil))
+		if rr.Code != http.StatusBadRequest {
+			t.Fatalf("%s: expected 400, got %d", q, rr.Code)
+		}
+	}
+}
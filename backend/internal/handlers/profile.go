@@ -3,19 +3,21 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/google/uuid"
 
 	"github.com/divijg19/physiolink/backend/internal/middleware"
+	"github.com/divijg19/physiolink/backend/internal/openapi"
 	"github.com/divijg19/physiolink/backend/internal/service"
 )
 
 // ProfileService interface to enable testing mocks.
 type ProfileService interface {
 	UpsertProfile(ctx context.Context, userID uuid.UUID, p service.NodeProfileUpdate) (uuid.UUID, error)
-	GetProfile(ctx context.Context, userID uuid.UUID) (map[string]interface{}, error)
-	CreateEmptyProfile(ctx context.Context, userID uuid.UUID) (map[string]interface{}, error)
+	GetProfile(ctx context.Context, userID uuid.UUID) (openapi.Profile, error)
+	CreateEmptyProfile(ctx context.Context, userID uuid.UUID) (openapi.Profile, error)
 }
 
 var profileService ProfileService
@@ -63,6 +65,11 @@ func UpsertMyProfile(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	_, err = profileService.UpsertProfile(r.Context(), userID, p)
+	if errors.Is(err, service.ErrInvalidCoordinates) || errors.Is(err, service.ErrInvalidAge) || errors.Is(err, service.ErrInvalidExperience) ||
+		errors.Is(err, service.ErrInvalidPrice) || errors.Is(err, service.ErrInvalidCurrency) {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
 	if err != nil {
 		http.Error(w, err.Error(), http.StatusInternalServerError)
 		return
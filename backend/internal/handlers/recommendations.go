@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/middleware"
+	"github.com/divijg19/physiolink/backend/internal/openapi"
+	"github.com/divijg19/physiolink/backend/internal/service"
+)
+
+// RecommendationService interface for handler tests.
+type RecommendationService interface {
+	Recommend(ctx context.Context, patientID uuid.UUID, p service.RecommendationParams) ([]openapi.Recommendation, error)
+}
+
+var recommendationService RecommendationService
+
+func InitRecommendations(s RecommendationService) { recommendationService = s }
+
+type recommendationsResponse struct {
+	Data []openapi.Recommendation `json:"data"`
+}
+
+// GetRecommendedTherapists ranks therapists for the calling patient by their
+// profile's condition, goals and location; lat and lng override the location.
+func GetRecommendedTherapists(w http.ResponseWriter, r *http.Request) {
+	sub, _ := r.Context().Value(middleware.UserIDKey).(string)
+	uid, err := uuid.Parse(sub)
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Msg: "unauthorized"})
+		return
+	}
+	if role, _ := r.Context().Value(middleware.UserRoleKey).(string); service.IsTherapistRole(role) {
+		writeJSON(w, http.StatusForbidden, errorResponse{Msg: "recommendations are for patients"})
+		return
+	}
+	q := r.URL.Query()
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	lat, ok1 := queryFloat(q.Get("lat"))
+	lng, ok2 := queryFloat(q.Get("lng"))
+	if !ok1 || !ok2 {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "lat and lng must be numbers"})
+		return
+	}
+	list, err := recommendationService.Recommend(r.Context(), uid, service.RecommendationParams{Limit: limit, Lat: lat, Lng: lng})
+	switch {
+	case errors.Is(err, service.ErrInvalidCoordinates):
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: err.Error()})
+		return
+	case err != nil:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
+		return
+	}
+	summaries := make([]openapi.Therapist, len(list))
+	for i := range list {
+		summaries[i].Id = list[i].Id
+	}
+	markFavourites(r, summaries)
+	for i := range list {
+		list[i].IsFavourite = summaries[i].IsFavourite
+	}
+	writeJSON(w, http.StatusOK, recommendationsResponse{Data: list})
+}
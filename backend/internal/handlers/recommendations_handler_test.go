@@ -0,0 +1,69 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/handlers"
+	mocks "github.com/divijg19/physiolink/backend/internal/mocks"
+	"github.com/divijg19/physiolink/backend/internal/openapi"
+	"github.com/divijg19/physiolink/backend/internal/service"
+)
+
+func TestGetRecommendedTherapists(t *testing.T) {
+	tid := uuid.NewString()
+	score, matched := 0.82, []string{"Sports"}
+	m := &mocks.RecommendationServiceMock{Resp: []openapi.Recommendation{
+		{Id: &tid, Profile: &openapi.Profile{}, Score: &score, MatchedSpecialties: &matched},
+	}}
+	cfg, router := setupRouter(t, func() { handlers.InitRecommendations(m) })
+	patient := uuid.New()
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, authedRequest(http.MethodGet, "/api/therapists/recommended?limit=5&lat=51.5&lng=-0.12", sessionToken(t, cfg, patient, "patient", nil), ""))
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if m.PatientID != patient || m.Params.Limit != 5 || m.Params.Lat == nil || *m.Params.Lng != -0.12 {
+		t.Fatalf("unexpected call %+v", m)
+	}
+	var res struct {
+		Data []openapi.Recommendation `json:"data"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&res); err != nil || len(res.Data) != 1 {
+		t.Fatalf("unexpected body %v %+v", err, res)
+	}
+	if got := res.Data[0]; *got.Id != tid || *got.Score != 0.82 || len(*got.MatchedSpecialties) != 1 {
+		t.Fatalf("unexpected recommendation %+v", got)
+	}
+}
+
+func TestGetRecommendedTherapists_Errors(t *testing.T) {
+	m := &mocks.RecommendationServiceMock{}
+	cfg, router := setupRouter(t, func() { handlers.InitRecommendations(m) })
+	patient := sessionToken(t, cfg, uuid.New(), "patient", nil)
+
+	cases := []struct {
+		path  string
+		token string
+		err   error
+		want  int
+	}{
+		{"/api/therapists/recommended", sessionToken(t, cfg, uuid.New(), "pt", nil), nil, http.StatusForbidden},
+		{"/api/therapists/recommended?lat=north", patient, nil, http.StatusBadRequest},
+		{"/api/therapists/recommended?lat=91&lng=0", patient, service.ErrInvalidCoordinates, http.StatusBadRequest},
+		{"/api/therapists/recommended", "", nil, http.StatusUnauthorized},
+	}
+	for _, c := range cases {
+		m.Err = c.err
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, authedRequest(http.MethodGet, c.path, c.token, ""))
+		if rr.Code != c.want {
+			t.Errorf("%s: expected %d, got %d", c.path, c.want, rr.Code)
+		}
+	}
+}
@@ -3,19 +3,26 @@ package handlers
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 
 	"github.com/divijg19/physiolink/backend/internal/middleware"
+	"github.com/divijg19/physiolink/backend/internal/openapi"
 	"github.com/divijg19/physiolink/backend/internal/service"
 )
 
 // ReviewService interface for handler tests.
 type ReviewService interface {
-	CreateReview(ctx context.Context, patientID, therapistID uuid.UUID, rating int, comment string) (map[string]interface{}, error)
-	GetReviewsForTherapist(ctx context.Context, therapistID uuid.UUID) ([]map[string]interface{}, error)
+	CreateReview(ctx context.Context, patientID uuid.UUID, in service.NewReview) (openapi.Review, error)
+	GetReviewsForTherapist(ctx context.Context, therapistID uuid.UUID) ([]openapi.Review, error)
+	ListReviewsForTherapist(ctx context.Context, therapistID uuid.UUID, cursor string, limit int) (service.ReviewPage, error)
+	UpdateReview(ctx context.Context, patientID, reviewID uuid.UUID, in service.ReviewEdit) (openapi.Review, error)
+	DeleteReview(ctx context.Context, patientID, reviewID uuid.UUID) error
+	ReportReview(ctx context.Context, reporterID, reviewID uuid.UUID, reason string) error
+	ReplyToReview(ctx context.Context, therapistID, reviewID uuid.UUID, body string) (openapi.ReviewReply, error)
 }
 
 var reviewService ReviewService
@@ -23,9 +30,11 @@ var reviewService ReviewService
 func InitReviews(s ReviewService) { reviewService = s }
 
 type createReviewReq struct {
-	TherapistID string `json:"therapistId"`
-	Rating      int    `json:"rating"`
-	Comment     string `json:"comment"`
+	AppointmentID string             `json:"appointmentId"`
+	TherapistID   string             `json:"therapistId"`
+	Rating        int                `json:"rating"`
+	Comment       string             `json:"comment"`
+	SubRatings    service.SubRatings `json:"subRatings"`
 }
 
 func CreateReview(w http.ResponseWriter, r *http.Request) {
@@ -44,30 +53,45 @@ func CreateReview(w http.ResponseWriter, r *http.Request) {
 		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "invalid request"})
 		return
 	}
-	tid, err := uuid.Parse(req.TherapistID)
-	if err != nil {
-		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "invalid therapistId"})
-		return
+	in := service.NewReview{Rating: req.Rating, Comment: req.Comment, SubRatings: req.SubRatings}
+	if req.AppointmentID != "" {
+		aid, err := uuid.Parse(req.AppointmentID)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "invalid appointmentId"})
+			return
+		}
+		in.AppointmentID = &aid
 	}
-	res, err := reviewService.CreateReview(r.Context(), pid, tid, req.Rating, req.Comment)
-	if err != nil {
-		if fe, ok := err.(*service.ForbiddenError); ok {
-			writeJSON(w, http.StatusForbidden, errorResponse{Msg: fe.Msg})
+	if req.TherapistID != "" || in.AppointmentID == nil {
+		if in.TherapistID, err = uuid.Parse(req.TherapistID); err != nil {
+			writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "invalid therapistId"})
 			return
 		}
-		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
+	}
+	res, err := reviewService.CreateReview(r.Context(), pid, in)
+	if err != nil {
+		writeReviewError(w, err)
 		return
 	}
 	writeJSON(w, http.StatusCreated, res)
 }
 
 func GetReviewsForTherapist(w http.ResponseWriter, r *http.Request) {
-	id := chi.URLParam(r, "therapistId")
+	id := chi.URLParam(r, "id")
 	tid, err := uuid.Parse(id)
 	if err != nil {
 		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "invalid therapistId"})
 		return
 	}
+	if cursor, limit, ok := pageRequest(r); ok {
+		page, err := reviewService.ListReviewsForTherapist(r.Context(), tid, cursor, limit)
+		if err != nil {
+			writePageError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, page)
+		return
+	}
 	res, err := reviewService.GetReviewsForTherapist(r.Context(), tid)
 	if err != nil {
 		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
@@ -75,3 +99,127 @@ func GetReviewsForTherapist(w http.ResponseWriter, r *http.Request) {
 	}
 	writeJSON(w, http.StatusOK, res)
 }
+
+type replyReviewReq struct {
+	Body string `json:"body"`
+}
+
+type reportReviewReq struct {
+	Reason string `json:"reason"`
+}
+
+type updateReviewReq struct {
+	Rating     int                `json:"rating"`
+	Comment    string             `json:"comment"`
+	SubRatings service.SubRatings `json:"subRatings"`
+}
+
+// UpdateReview lets a patient correct their review within the edit window.
+func UpdateReview(w http.ResponseWriter, r *http.Request) {
+	pid, rid, ok := reviewCallerRequest(w, r)
+	if !ok {
+		return
+	}
+	var req updateReviewReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "invalid request"})
+		return
+	}
+	res, err := reviewService.UpdateReview(r.Context(), pid, rid, service.ReviewEdit{Rating: req.Rating, Comment: req.Comment, SubRatings: req.SubRatings})
+	if err != nil {
+		writeReviewError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, res)
+}
+
+// DeleteReview withdraws a patient's review within the edit window.
+func DeleteReview(w http.ResponseWriter, r *http.Request) {
+	pid, rid, ok := reviewCallerRequest(w, r)
+	if !ok {
+		return
+	}
+	if err := reviewService.DeleteReview(r.Context(), pid, rid); err != nil {
+		writeReviewError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, errorResponse{Msg: "Review withdrawn"})
+}
+
+// ReplyToReview posts a therapist's public reply to a review of them.
+func ReplyToReview(w http.ResponseWriter, r *http.Request) {
+	tid, rid, ok := reviewCallerRequest(w, r)
+	if !ok {
+		return
+	}
+	var req replyReviewReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "invalid request"})
+		return
+	}
+	res, err := reviewService.ReplyToReview(r.Context(), tid, rid, req.Body)
+	if err != nil {
+		writeReviewError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusCreated, res)
+}
+
+// ReportReview flags a review for the admins' moderation queue.
+func ReportReview(w http.ResponseWriter, r *http.Request) {
+	uid, rid, ok := reviewCallerRequest(w, r)
+	if !ok {
+		return
+	}
+	var req reportReviewReq
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "invalid request"})
+		return
+	}
+	if err := reviewService.ReportReview(r.Context(), uid, rid, req.Reason); err != nil {
+		writeReviewError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, errorResponse{Msg: "Review reported"})
+}
+
+// reviewCallerRequest reads the caller and the review id, writing the error
+// response when either is missing.
+func reviewCallerRequest(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	sub, _ := r.Context().Value(middleware.UserIDKey).(string)
+	pid, err := uuid.Parse(sub)
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Msg: "unauthorized"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	rid, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, errorResponse{Msg: "Review not found"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	return pid, rid, true
+}
+
+func writeReviewError(w http.ResponseWriter, err error) {
+	var fe *service.ForbiddenError
+	switch {
+	case errors.As(err, &fe):
+		writeJSON(w, http.StatusForbidden, errorResponse{Msg: fe.Msg})
+	case errors.Is(err, service.ErrInvalidRating):
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "Rating must be between 1 and 5"})
+	case errors.Is(err, service.ErrReportReasonMissing):
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "Please say why you are reporting this review"})
+	case errors.Is(err, service.ErrReplyEmpty):
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "Please write a reply"})
+	case errors.Is(err, service.ErrAppointmentNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Msg: "Appointment not found"})
+	case errors.Is(err, service.ErrReviewNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Msg: "Review not found"})
+	case errors.Is(err, service.ErrAlreadyReviewed):
+		writeJSON(w, http.StatusConflict, errorResponse{Msg: "You have already reviewed this appointment"})
+	case errors.Is(err, service.ErrAlreadyReplied):
+		writeJSON(w, http.StatusConflict, errorResponse{Msg: "You have already replied to this review"})
+	default:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
+	}
+}
@@ -6,13 +6,16 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 
 	"github.com/divijg19/physiolink/backend/internal/handlers"
 	"github.com/divijg19/physiolink/backend/internal/middleware"
+	"github.com/divijg19/physiolink/backend/internal/openapi"
 	"github.com/divijg19/physiolink/backend/internal/service"
 	"github.com/divijg19/physiolink/backend/internal/mocks"
 )
@@ -23,7 +26,11 @@ func setupReviewsRouter(service *__mocks__.ReviewServiceMock) *chi.Mux {
 	r.Route("/reviews", func(r chi.Router) {
 		r.Use(mockAuth)
 		r.Post("/", handlers.CreateReview)
-		r.Get("/{therapistId}", handlers.GetReviewsForTherapist)
+		r.Get("/{id}", handlers.GetReviewsForTherapist)
+		r.Put("/{id}", handlers.UpdateReview)
+		r.Delete("/{id}", handlers.DeleteReview)
+		r.Post("/{id}/reply", handlers.ReplyToReview)
+		r.Post("/{id}/report", handlers.ReportReview)
 	})
 	return r
 }
@@ -39,11 +46,14 @@ func mockAuth(next http.Handler) http.Handler {
 	})
 }
 
+func review(rating int, comment string) openapi.Review {
+	id := uuid.NewString()
+	return openapi.Review{Id: &id, Rating: &rating, Comment: &comment}
+}
+
 func TestCreateReview_Success(t *testing.T) {
 	m := &__mocks__.ReviewServiceMock{
-		CreateResp: map[string]interface{}{
-			"id": uuid.New().String(),
-		},
+		CreateResp: review(5, "great"),
 	}
 	r := chi.NewRouter()
 	handlers.InitReviews(m)
@@ -67,10 +77,7 @@ func TestCreateReview_Success(t *testing.T) {
 
 func TestGetReviewsForTherapist_Success(t *testing.T) {
 	m := &__mocks__.ReviewServiceMock{
-		ListResp: []map[string]interface{}{
-			{"rating": 4, "comment": "good"},
-			{"rating": 5, "comment": "great"},
-		},
+		ListResp: []openapi.Review{review(4, "good"), review(5, "great")},
 	}
 	r := setupReviewsRouter(m)
 
@@ -86,7 +93,6 @@ func TestGetReviewsForTherapist_Success(t *testing.T) {
 
 func TestCreateReview_ForbiddenForTherapistRole(t *testing.T) {
 	m := &__mocks__.ReviewServiceMock{
-		CreateResp: map[string]interface{}{"id": uuid.New().String()},
 		CreateErr:  &service.ForbiddenError{Msg: "forbidden"},
 	}
 	r := chi.NewRouter()
@@ -115,3 +121,245 @@ func TestCreateReview_ForbiddenForTherapistRole(t *testing.T) {
 		t.Fatalf("expected 403/401, got %d: %s", w.Code, w.Body.String())
 	}
 }
+
+func TestGetReviewsForTherapist_CursorPage(t *testing.T) {
+	m := &__mocks__.ReviewServiceMock{
+		PageResp: service.ReviewPage{Data: []openapi.Review{review(5, "")}, NextCursor: "next"},
+	}
+	r := setupReviewsRouter(m)
+
+	req := httptest.NewRequest(http.MethodGet, "/reviews/"+uuid.New().String()+"?limit=1&cursor=abc", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if m.Cursor != "abc" || m.Limit != 1 {
+		t.Fatalf("unexpected page request cursor=%q limit=%d", m.Cursor, m.Limit)
+	}
+	var page service.ReviewPage
+	if err := json.Unmarshal(w.Body.Bytes(), &page); err != nil || page.NextCursor != "next" || len(page.Data) != 1 {
+		t.Fatalf("unexpected body %s", w.Body.String())
+	}
+}
+
+func TestGetReviewsForTherapist_InvalidCursor(t *testing.T) {
+	m := &__mocks__.ReviewServiceMock{ListErr: service.ErrInvalidCursor}
+	r := setupReviewsRouter(m)
+
+	req := httptest.NewRequest(http.MethodGet, "/reviews/"+uuid.New().String()+"?cursor=garbage", nil)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", w.Code)
+	}
+}
+
+func TestGetReviewsWeb_RendersRatingAndDate(t *testing.T) {
+	rev := review(4, "Helped my knee")
+	name, createdAt := "Sam", time.Date(2026, 3, 5, 10, 0, 0, 0, time.UTC)
+	rev.Patient = &openapi.UserRef{Profile: &openapi.Profile{FirstName: &name}}
+	rev.CreatedAt = &createdAt
+	reply, repliedAt := "Glad it helped, see you next week", time.Date(2026, 3, 7, 9, 0, 0, 0, time.UTC)
+	rev.Reply = &openapi.ReviewReply{Body: &reply, CreatedAt: &repliedAt}
+	handlers.InitReviews(&__mocks__.ReviewServiceMock{ListResp: []openapi.Review{rev}})
+	r := chi.NewRouter()
+	r.Get("/web/reviews/{therapistId}", handlers.GetReviewsWeb)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/web/reviews/"+uuid.NewString(), nil))
+
+	body := w.Body.String()
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, body)
+	}
+	for _, want := range []string{"Sam", "Helped my knee", "Mar 05, 2026", "Reply from the therapist", reply, "Mar 07, 2026"} {
+		if !strings.Contains(body, want) {
+			t.Errorf("expected %q in reviews list", want)
+		}
+	}
+	if got := strings.Count(body, "text-yellow-400"); got != 4 {
+		t.Errorf("expected 4 filled stars, got %d", got)
+	}
+}
+
+func postReview(r http.Handler, body map[string]interface{}) *httptest.ResponseRecorder {
+	b, _ := json.Marshal(body)
+	req := httptest.NewRequest(http.MethodPost, "/reviews", bytes.NewReader(b))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestCreateReview_ForAppointment(t *testing.T) {
+	m := &__mocks__.ReviewServiceMock{CreateResp: review(4, "")}
+	r := setupReviewsRouter(m)
+
+	appointmentID := uuid.New()
+	w := postReview(r, map[string]interface{}{"appointmentId": appointmentID.String(), "rating": 4})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if m.Created.AppointmentID == nil || *m.Created.AppointmentID != appointmentID || m.Created.TherapistID != uuid.Nil {
+		t.Fatalf("unexpected review request %+v", m.Created)
+	}
+
+	w = postReview(r, map[string]interface{}{"appointmentId": "not-a-uuid", "rating": 4})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a malformed appointmentId, got %d", w.Code)
+	}
+	w = postReview(r, map[string]interface{}{"rating": 4})
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 without appointmentId or therapistId, got %d", w.Code)
+	}
+}
+
+func TestCreateReview_SubRatings(t *testing.T) {
+	m := &__mocks__.ReviewServiceMock{CreateResp: review(4, "")}
+	r := setupReviewsRouter(m)
+
+	w := postReview(r, map[string]interface{}{
+		"therapistId": uuid.NewString(),
+		"rating":      4,
+		"subRatings":  map[string]int{"communication": 5, "facility": 3},
+	})
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if m.Created.SubRatings != (service.SubRatings{Communication: 5, Facility: 3}) {
+		t.Fatalf("unexpected sub-ratings %+v", m.Created.SubRatings)
+	}
+}
+
+func TestCreateReview_ErrorStatuses(t *testing.T) {
+	cases := []struct {
+		err  error
+		want int
+	}{
+		{service.ErrInvalidRating, http.StatusBadRequest},
+		{service.ErrAppointmentNotFound, http.StatusNotFound},
+		{service.ErrAlreadyReviewed, http.StatusConflict},
+		{&service.ForbiddenError{Msg: "Only completed appointments can be reviewed"}, http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		r := setupReviewsRouter(&__mocks__.ReviewServiceMock{CreateErr: tc.err})
+		w := postReview(r, map[string]interface{}{"therapistId": uuid.NewString(), "rating": 9})
+		if w.Code != tc.want {
+			t.Errorf("%v: expected %d, got %d", tc.err, tc.want, w.Code)
+		}
+	}
+}
+
+func TestUpdateReview_Success(t *testing.T) {
+	m := &__mocks__.ReviewServiceMock{UpdateResp: review(3, "Better now")}
+	r := setupReviewsRouter(m)
+
+	b, _ := json.Marshal(map[string]interface{}{"rating": 3, "comment": "Better now", "subRatings": map[string]int{"punctuality": 2}})
+	req := httptest.NewRequest(http.MethodPut, "/reviews/"+uuid.NewString(), bytes.NewReader(b))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if m.Updated != (service.ReviewEdit{Rating: 3, Comment: "Better now", SubRatings: service.SubRatings{Punctuality: 2}}) {
+		t.Fatalf("unexpected edit %+v", m.Updated)
+	}
+}
+
+func TestDeleteReview_ErrorStatuses(t *testing.T) {
+	cases := []struct {
+		err  error
+		want int
+	}{
+		{nil, http.StatusOK},
+		{service.ErrReviewNotFound, http.StatusNotFound},
+		{&service.ForbiddenError{Msg: "This review can no longer be changed"}, http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		m := &__mocks__.ReviewServiceMock{DeleteErr: tc.err}
+		r := setupReviewsRouter(m)
+		id := uuid.New()
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/reviews/"+id.String(), nil))
+		if w.Code != tc.want {
+			t.Errorf("%v: expected %d, got %d", tc.err, tc.want, w.Code)
+		}
+		if m.Deleted != id {
+			t.Errorf("%v: expected review %s to be withdrawn, got %s", tc.err, id, m.Deleted)
+		}
+	}
+
+	w := httptest.NewRecorder()
+	setupReviewsRouter(&__mocks__.ReviewServiceMock{}).ServeHTTP(w, httptest.NewRequest(http.MethodDelete, "/reviews/not-a-uuid", nil))
+	if w.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 for a malformed id, got %d", w.Code)
+	}
+}
+
+func TestReplyToReview(t *testing.T) {
+	body := "Thanks for the feedback"
+	m := &__mocks__.ReviewServiceMock{ReplyResp: openapi.ReviewReply{Body: &body}}
+	r := setupReviewsRouter(m)
+	id := uuid.New()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/reviews/"+id.String()+"/reply", strings.NewReader(`{"body":"Thanks for the feedback"}`)))
+	if w.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", w.Code, w.Body.String())
+	}
+	if m.RepliedTo != id || m.ReplyBody != body {
+		t.Fatalf("unexpected reply %s %q", m.RepliedTo, m.ReplyBody)
+	}
+
+	cases := []struct {
+		err  error
+		want int
+	}{
+		{service.ErrReplyEmpty, http.StatusBadRequest},
+		{service.ErrReviewNotFound, http.StatusNotFound},
+		{&service.ForbiddenError{Msg: "Only the reviewed therapist can reply"}, http.StatusForbidden},
+		{service.ErrAlreadyReplied, http.StatusConflict},
+	}
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		setupReviewsRouter(&__mocks__.ReviewServiceMock{ReplyErr: tc.err}).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/reviews/"+id.String()+"/reply", strings.NewReader(`{}`)))
+		if w.Code != tc.want {
+			t.Errorf("%v: expected %d, got %d", tc.err, tc.want, w.Code)
+		}
+	}
+}
+
+func TestReportReview(t *testing.T) {
+	m := &__mocks__.ReviewServiceMock{}
+	r := setupReviewsRouter(m)
+	id := uuid.New()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/reviews/"+id.String()+"/report", strings.NewReader(`{"reason":"Not about the session"}`)))
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+	if m.Reported != id || m.Reason != "Not about the session" {
+		t.Fatalf("unexpected report %s %q", m.Reported, m.Reason)
+	}
+
+	cases := []struct {
+		err  error
+		want int
+	}{
+		{service.ErrReportReasonMissing, http.StatusBadRequest},
+		{service.ErrReviewNotFound, http.StatusNotFound},
+		{&service.ForbiddenError{Msg: "You can't report your own review"}, http.StatusForbidden},
+	}
+	for _, tc := range cases {
+		w := httptest.NewRecorder()
+		setupReviewsRouter(&__mocks__.ReviewServiceMock{ReportErr: tc.err}).ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/reviews/"+id.String()+"/report", strings.NewReader(`{}`)))
+		if w.Code != tc.want {
+			t.Errorf("%v: expected %d, got %d", tc.err, tc.want, w.Code)
+		}
+	}
+}
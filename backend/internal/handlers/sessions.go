@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/middleware"
+	"github.com/divijg19/physiolink/backend/internal/service"
+	"github.com/divijg19/physiolink/backend/internal/views"
+)
+
+// SessionService interface for handler tests.
+type SessionService interface {
+	Create(ctx context.Context, userID uuid.UUID, impersonator uuid.NullUUID, userAgent, ip string, expiresAt time.Time) (uuid.UUID, error)
+	List(ctx context.Context, userID, current uuid.UUID) ([]service.Session, error)
+	Revoke(ctx context.Context, userID, sessionID uuid.UUID) error
+	RevokeOthers(ctx context.Context, userID, keep uuid.UUID) ([]uuid.UUID, error)
+}
+
+var sessionService SessionService
+
+// InitSessions enables device session tracking. Without it tokens are issued
+// without a session id and can't be revoked.
+func InitSessions(s SessionService) { sessionService = s }
+
+// signSession signs claims for a new session of userID and, when sessions are
+// tracked, records the device behind it as the token's "sid". impersonator is
+// the admin acting as userID, if any; it goes in the "act" claim and on the
+// session, so support sessions are listed and revoked like the user's own.
+func signSession(r *http.Request, userID uuid.UUID, impersonator uuid.NullUUID, claims jwt.MapClaims) (string, error) {
+	if impersonator.Valid {
+		claims["act"] = map[string]interface{}{"sub": impersonator.UUID.String()}
+	}
+	if sessionService != nil {
+		exp, ok := claims["exp"].(int64)
+		if !ok {
+			return "", errors.New("session claims need an expiry")
+		}
+		sid, err := sessionService.Create(r.Context(), userID, impersonator, r.UserAgent(), clientIP(r), time.Unix(exp, 0))
+		if err != nil {
+			return "", err
+		}
+		claims["sid"] = sid.String()
+	}
+	return signClaims(claims)
+}
+
+type sessionsResponse struct {
+	Data []service.Session `json:"data"`
+}
+
+// ListSessions returns the caller's signed-in devices.
+func ListSessions(w http.ResponseWriter, r *http.Request) {
+	uid, sid, ok := sessionUser(w, r)
+	if !ok {
+		return
+	}
+	list, err := sessionService.List(r.Context(), uid, sid)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, sessionsResponse{Data: list})
+}
+
+// RevokeSession signs out one of the caller's devices, including the current one.
+func RevokeSession(w http.ResponseWriter, r *http.Request) {
+	uid, _, ok := sessionUser(w, r)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, errorResponse{Msg: "Session not found"})
+		return
+	}
+	if err := revokeSession(r.Context(), uid, id); err != nil {
+		if errors.Is(err, service.ErrSessionNotFound) {
+			writeJSON(w, http.StatusNotFound, errorResponse{Msg: "Session not found"})
+			return
+		}
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, errorResponse{Msg: "Session revoked"})
+}
+
+// RevokeOtherSessions signs out every device except the caller's.
+func RevokeOtherSessions(w http.ResponseWriter, r *http.Request) {
+	uid, sid, ok := sessionUser(w, r)
+	if !ok {
+		return
+	}
+	revoked, err := sessionService.RevokeOthers(r.Context(), uid, sid)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
+		return
+	}
+	for _, id := range revoked {
+		middleware.ForgetSession(id)
+	}
+	writeJSON(w, http.StatusOK, errorResponse{Msg: "Other sessions revoked"})
+}
+
+// GetSessionsWeb renders the dashboard's device list.
+func GetSessionsWeb(w http.ResponseWriter, r *http.Request) {
+	uid, sid, ok := sessionUser(w, r)
+	if !ok {
+		return
+	}
+	renderSessionsWeb(w, r, uid, sid)
+}
+
+// RevokeSessionWeb signs out a device from the dashboard. Revoking the
+// current device logs the browser out.
+func RevokeSessionWeb(w http.ResponseWriter, r *http.Request) {
+	uid, sid, ok := sessionUser(w, r)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	if err := revokeSession(r.Context(), uid, id); err != nil && !errors.Is(err, service.ErrSessionNotFound) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Error revoking session"))
+		return
+	}
+	if id == sid {
+		clearAuthCookie(w)
+		w.Header().Set("HX-Redirect", "/login")
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	renderSessionsWeb(w, r, uid, sid)
+}
+
+func renderSessionsWeb(w http.ResponseWriter, r *http.Request, uid, sid uuid.UUID) {
+	list, err := sessionService.List(r.Context(), uid, sid)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("Error loading sessions"))
+		return
+	}
+	out := make([]views.SessionView, 0, len(list))
+	for _, s := range list {
+		out = append(out, views.SessionView{
+			ID:         s.ID.String(),
+			UserAgent:  s.UserAgent,
+			IPAddress:  s.IPAddress,
+			LastSeenAt: s.LastSeenAt,
+			Current:    s.Current,
+		})
+	}
+	views.SessionsList(out).Render(r.Context(), w)
+}
+
+func revokeSession(ctx context.Context, uid, id uuid.UUID) error {
+	if err := sessionService.Revoke(ctx, uid, id); err != nil {
+		return err
+	}
+	middleware.ForgetSession(id)
+	return nil
+}
+
+// endCurrentSession revokes the session the request was made with, if any.
+func endCurrentSession(r *http.Request) {
+	if sessionService == nil {
+		return
+	}
+	sub, _ := r.Context().Value(middleware.UserIDKey).(string)
+	sidStr, _ := r.Context().Value(middleware.SessionIDKey).(string)
+	uid, err1 := uuid.Parse(sub)
+	sid, err2 := uuid.Parse(sidStr)
+	if err1 != nil || err2 != nil {
+		return
+	}
+	_ = revokeSession(r.Context(), uid, sid)
+}
+
+// sessionUser returns the caller and the id of the session they are using,
+// which is uuid.Nil for tokens issued before sessions were tracked.
+func sessionUser(w http.ResponseWriter, r *http.Request) (uuid.UUID, uuid.UUID, bool) {
+	sub, _ := r.Context().Value(middleware.UserIDKey).(string)
+	uid, err := uuid.Parse(sub)
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Msg: "unauthorized"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	if sessionService == nil {
+		writeJSON(w, http.StatusNotFound, errorResponse{Msg: "Session tracking is not enabled"})
+		return uuid.Nil, uuid.Nil, false
+	}
+	sidStr, _ := r.Context().Value(middleware.SessionIDKey).(string)
+	sid, _ := uuid.Parse(sidStr)
+	return uid, sid, true
+}
+
+// clientIP is the peer address. Deployments behind a proxy should mount a
+// trusted RealIP middleware in front of the router.
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
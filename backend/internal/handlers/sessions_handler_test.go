@@ -0,0 +1,121 @@
+package handlers_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/config"
+	"github.com/divijg19/physiolink/backend/internal/handlers"
+	mware "github.com/divijg19/physiolink/backend/internal/middleware"
+	mocks "github.com/divijg19/physiolink/backend/internal/mocks"
+	"github.com/divijg19/physiolink/backend/internal/server"
+	"github.com/divijg19/physiolink/backend/internal/service"
+)
+
+func setupSessions(t *testing.T) (*mocks.SessionServiceMock, http.Handler) {
+	t.Helper()
+	auth := mocks.NewAuthServiceMock()
+	for _, email := range []string{"pt@example.com", "other@example.com"} {
+		if _, _, err := auth.Register(context.Background(), email, "pw", "pt"); err != nil {
+			t.Fatalf("register: %v", err)
+		}
+	}
+	cfg := config.New()
+	handlers.InitAuth(auth, cfg)
+	handlers.InitMFA(nil)
+	m := mocks.NewSessionServiceMock()
+	handlers.InitSessions(m)
+	mware.InitSessions(m, time.Minute)
+	t.Cleanup(func() {
+		handlers.InitSessions(nil)
+		mware.InitSessions(nil, 0)
+	})
+	return m, server.NewRouter(cfg)
+}
+
+func login(t *testing.T, router http.Handler, email, agent string) string {
+	t.Helper()
+	req := loginRequest(t, email, "pw")
+	req.Header.Set("User-Agent", agent)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	var resp struct {
+		Token string `json:"token"`
+	}
+	if err := json.NewDecoder(rr.Body).Decode(&resp); err != nil || resp.Token == "" {
+		t.Fatalf("login: %d %v", rr.Code, err)
+	}
+	return resp.Token
+}
+
+func listSessions(t *testing.T, router http.Handler, token string) (int, []service.Session) {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/api/sessions", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	var resp struct {
+		Data []service.Session `json:"data"`
+	}
+	_ = json.NewDecoder(rr.Body).Decode(&resp)
+	return rr.Code, resp.Data
+}
+
+func TestSessions_ListAndRevokeOtherDevice(t *testing.T) {
+	_, router := setupSessions(t)
+	phone := login(t, router, "pt@example.com", "PhysiolinkApp/2.1 (Android)")
+	laptop := login(t, router, "pt@example.com", "Mozilla/5.0")
+
+	code, list := listSessions(t, router, laptop)
+	if code != http.StatusOK || len(list) != 2 {
+		t.Fatalf("expected 2 sessions, got %d %+v", code, list)
+	}
+	var phoneID uuid.UUID
+	for _, s := range list {
+		if s.UserAgent == "PhysiolinkApp/2.1 (Android)" {
+			phoneID = s.ID
+			if s.Current {
+				t.Fatalf("phone session marked current")
+			}
+		}
+	}
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/sessions/"+phoneID.String(), nil)
+	req.Header.Set("Authorization", "Bearer "+laptop)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+
+	if code, _ := listSessions(t, router, phone); code != http.StatusUnauthorized {
+		t.Fatalf("expected revoked token to be rejected, got %d", code)
+	}
+	if code, list := listSessions(t, router, laptop); code != http.StatusOK || len(list) != 1 || !list[0].Current {
+		t.Fatalf("expected only the current session, got %d %+v", code, list)
+	}
+}
+
+func TestSessions_CannotRevokeAnotherUsersSession(t *testing.T) {
+	_, router := setupSessions(t)
+	mine := login(t, router, "pt@example.com", "a")
+	theirs := login(t, router, "other@example.com", "b")
+	_, list := listSessions(t, router, theirs)
+
+	req := httptest.NewRequest(http.MethodDelete, "/api/sessions/"+list[0].ID.String(), nil)
+	req.Header.Set("Authorization", "Bearer "+mine)
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, req)
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404, got %d", rr.Code)
+	}
+	if code, _ := listSessions(t, router, theirs); code != http.StatusOK {
+		t.Fatalf("expected other user's session to survive, got %d", code)
+	}
+}
@@ -2,17 +2,20 @@ package handlers
 
 import (
 	"context"
+	"errors"
+	"math"
 	"net/http"
 	"strconv"
 
+	"github.com/divijg19/physiolink/backend/internal/openapi"
 	"github.com/divijg19/physiolink/backend/internal/service"
 	"github.com/go-chi/chi/v5"
 )
 
 // TherapistService interface for handler tests.
 type TherapistService interface {
-	GetAllTherapists(ctx context.Context, params service.TherapistQueryParams) (service.TherapistListResult, error)
-	GetTherapistByID(ctx context.Context, id, date string) (map[string]interface{}, error)
+	GetAllTherapists(ctx context.Context, params service.TherapistQueryParams) (openapi.TherapistList, error)
+	GetTherapistByID(ctx context.Context, id, date string) (openapi.Therapist, error)
 }
 
 var therapistService TherapistService
@@ -24,7 +27,21 @@ func GetAllTherapists(w http.ResponseWriter, r *http.Request) {
 	page, _ := strconv.Atoi(q.Get("page"))
 	limit, _ := strconv.Atoi(q.Get("limit"))
 	available := q.Get("available") == "true"
+	lat, ok1 := queryFloat(q.Get("lat"))
+	lng, ok2 := queryFloat(q.Get("lng"))
+	radius, ok3 := queryFloat(q.Get("radiusKm"))
+	if !ok1 || !ok2 || !ok3 {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "lat, lng and radiusKm must be numbers"})
+		return
+	}
+	minPrice, ok1 := queryFloat(q.Get("minPrice"))
+	maxPrice, ok2 := queryFloat(q.Get("maxPrice"))
+	if !ok1 || !ok2 {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "minPrice and maxPrice must be numbers"})
+		return
+	}
 	params := service.TherapistQueryParams{
+		Query:     q.Get("q"),
 		Specialty: q.Get("specialty"),
 		Location:  q.Get("location"),
 		Page:      page,
@@ -32,15 +49,68 @@ func GetAllTherapists(w http.ResponseWriter, r *http.Request) {
 		Sort:      q.Get("sort"),
 		Date:      q.Get("date"),
 		Available: available,
+		Verified:  q.Get("verified") == "true",
+		Insurer:   q.Get("insurer"),
+		Language:  q.Get("language"),
+		MinPrice:  minPrice,
+		MaxPrice:  maxPrice,
+		Currency:  q.Get("currency"),
+		Lat:       lat,
+		Lng:       lng,
+		Cursor:    q.Get("cursor"),
+	}
+	if radius != nil {
+		params.RadiusKm = *radius
 	}
 	res, err := therapistService.GetAllTherapists(r.Context(), params)
-	if err != nil {
+	switch {
+	case errors.Is(err, service.ErrInvalidSort), errors.Is(err, service.ErrInvalidDate), errors.Is(err, service.ErrInvalidCursor),
+		errors.Is(err, service.ErrInvalidCoordinates), errors.Is(err, service.ErrInvalidRadius),
+		errors.Is(err, service.ErrLocationRequired), errors.Is(err, service.ErrInvalidPrice),
+		errors.Is(err, service.ErrInvalidPriceRange), errors.Is(err, service.ErrInvalidCurrency):
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: err.Error()})
+		return
+	case err != nil:
 		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
 		return
 	}
+	if res.Data != nil {
+		markFavourites(r, *res.Data)
+	}
 	writeJSON(w, http.StatusOK, res)
 }
 
+// pageRequest reports whether a listing that returns everything by default was
+// asked for a cursor page, and with what cursor and limit.
+func pageRequest(r *http.Request) (string, int, bool) {
+	q := r.URL.Query()
+	if !q.Has("cursor") && !q.Has("limit") {
+		return "", 0, false
+	}
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	return q.Get("cursor"), limit, true
+}
+
+func writePageError(w http.ResponseWriter, err error) {
+	if errors.Is(err, service.ErrInvalidCursor) {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: err.Error()})
+		return
+	}
+	writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
+}
+
+// queryFloat parses an optional numeric query parameter.
+func queryFloat(v string) (*float64, bool) {
+	if v == "" {
+		return nil, true
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return nil, false
+	}
+	return &f, true
+}
+
 func GetTherapistByID(w http.ResponseWriter, r *http.Request) {
 	id := chi.URLParam(r, "id")
 	date := r.URL.Query().Get("date")
@@ -49,5 +119,10 @@ func GetTherapistByID(w http.ResponseWriter, r *http.Request) {
 		writeJSON(w, http.StatusNotFound, errorResponse{Msg: "Therapist not found"})
 		return
 	}
+	recordView(r, id)
+	if _, ok := favouriteViewer(r); ok {
+		fav := isFavourite(r, id)
+		res.IsFavourite = &fav
+	}
 	writeJSON(w, http.StatusOK, res)
 }
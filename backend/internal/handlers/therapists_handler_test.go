@@ -3,10 +3,13 @@ package handlers_test
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/divijg19/physiolink/backend/internal/handlers"
 	mocks "github.com/divijg19/physiolink/backend/internal/mocks"
+	"github.com/divijg19/physiolink/backend/internal/openapi"
+	"github.com/divijg19/physiolink/backend/internal/service"
 )
 
 func TestGetAllTherapists_OK(t *testing.T) {
@@ -24,7 +27,8 @@ func TestGetAllTherapists_OK(t *testing.T) {
 }
 
 func TestGetTherapistByID_OK(t *testing.T) {
-	mocksrv := &mocks.TherapistServiceMock{DetailResp: map[string]interface{}{"_id": "t2"}}
+	id := "t2"
+	mocksrv := &mocks.TherapistServiceMock{DetailResp: &openapi.Therapist{Id: &id}}
 	handlers.InitTherapists(mocksrv)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/therapists/t2", nil)
@@ -40,3 +44,139 @@ func TestGetTherapistByID_OK(t *testing.T) {
 		t.Fatalf("expected a status code to be written")
 	}
 }
+
+func TestGetAllTherapists_PassesSortAndAvailability(t *testing.T) {
+	mocksrv := &mocks.TherapistServiceMock{ListResp: mocks.MakeTherapistListResult(nil)}
+	handlers.InitTherapists(mocksrv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/therapists?q=sports+knee&sort=rating&available=true&date=2025-03-01", nil)
+	rr := httptest.NewRecorder()
+
+	handlers.GetAllTherapists(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	p := mocksrv.Params
+	if p.Query != "sports knee" || p.Sort != service.SortRating || !p.Available || p.Date != "2025-03-01" {
+		t.Fatalf("unexpected params %+v", p)
+	}
+}
+
+func TestGetAllTherapists_InvalidSort(t *testing.T) {
+	mocksrv := &mocks.TherapistServiceMock{ListErr: service.ErrInvalidSort}
+	handlers.InitTherapists(mocksrv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/therapists?sort=price", nil)
+	rr := httptest.NewRecorder()
+
+	handlers.GetAllTherapists(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rr.Code)
+	}
+}
+
+func TestGetAllTherapists_NearMe(t *testing.T) {
+	mocksrv := &mocks.TherapistServiceMock{ListResp: mocks.MakeTherapistListResult(nil)}
+	handlers.InitTherapists(mocksrv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/therapists?lat=12.97&lng=77.59&radiusKm=5&sort=distance", nil)
+	rr := httptest.NewRecorder()
+
+	handlers.GetAllTherapists(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	p := mocksrv.Params
+	if p.Lat == nil || *p.Lat != 12.97 || p.Lng == nil || *p.Lng != 77.59 || p.RadiusKm != 5 || p.Sort != service.SortDistance {
+		t.Fatalf("unexpected params %+v", p)
+	}
+}
+
+func TestGetAllTherapists_BadCoordinates(t *testing.T) {
+	handlers.InitTherapists(&mocks.TherapistServiceMock{})
+
+	req := httptest.NewRequest(http.MethodGet, "/api/therapists?lat=north&lng=77.59", nil)
+	rr := httptest.NewRecorder()
+
+	handlers.GetAllTherapists(rr, req)
+
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400, got %d", rr.Code)
+	}
+}
+
+func TestGetAllTherapists_CursorPageOmitsTotals(t *testing.T) {
+	res := mocks.MakeTherapistListResult([]string{"t1"})
+	next := "next"
+	res.NextCursor, res.Total, res.Page, res.TotalPages = &next, nil, nil, nil
+	mocksrv := &mocks.TherapistServiceMock{ListResp: res}
+	handlers.InitTherapists(mocksrv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/therapists?cursor=abc", nil)
+	rr := httptest.NewRecorder()
+
+	handlers.GetAllTherapists(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	if mocksrv.Params.Cursor != "abc" {
+		t.Fatalf("cursor not passed through: %+v", mocksrv.Params)
+	}
+	if body := rr.Body.String(); strings.Contains(body, "total") || !strings.Contains(body, `"nextCursor":"next"`) {
+		t.Fatalf("unexpected body %s", body)
+	}
+}
+
+func TestGetAllTherapists_VerifiedFilter(t *testing.T) {
+	mocksrv := &mocks.TherapistServiceMock{ListResp: mocks.MakeTherapistListResult([]string{"t1"})}
+	handlers.InitTherapists(mocksrv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/therapists?verified=true", nil)
+	rr := httptest.NewRecorder()
+
+	handlers.GetAllTherapists(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	if !mocksrv.Params.Verified {
+		t.Fatalf("verified filter not passed through: %+v", mocksrv.Params)
+	}
+}
+
+func TestGetAllTherapists_PriceInsurerLanguageFilters(t *testing.T) {
+	mocksrv := &mocks.TherapistServiceMock{ListResp: mocks.MakeTherapistListResult([]string{"t1"})}
+	handlers.InitTherapists(mocksrv)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/therapists?insurer=Bupa&language=hindi&minPrice=40&maxPrice=80.5&currency=gbp", nil)
+	rr := httptest.NewRecorder()
+	handlers.GetAllTherapists(rr, req)
+
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+	p := mocksrv.Params
+	if p.Insurer != "Bupa" || p.Language != "hindi" || p.Currency != "gbp" ||
+		p.MinPrice == nil || *p.MinPrice != 40 || p.MaxPrice == nil || *p.MaxPrice != 80.5 {
+		t.Fatalf("filters not passed through: %+v", p)
+	}
+
+	rr = httptest.NewRecorder()
+	handlers.GetAllTherapists(rr, httptest.NewRequest(http.MethodGet, "/api/therapists?maxPrice=cheap", nil))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a non-numeric price, got %d", rr.Code)
+	}
+
+	for _, err := range []error{service.ErrInvalidPrice, service.ErrInvalidPriceRange, service.ErrInvalidCurrency} {
+		handlers.InitTherapists(&mocks.TherapistServiceMock{ListErr: err})
+		rr = httptest.NewRecorder()
+		handlers.GetAllTherapists(rr, httptest.NewRequest(http.MethodGet, "/api/therapists", nil))
+		if rr.Code != http.StatusBadRequest {
+			t.Errorf("%v: expected 400, got %d", err, rr.Code)
+		}
+	}
+}
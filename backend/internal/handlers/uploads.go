@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/middleware"
+	"github.com/divijg19/physiolink/backend/internal/service"
+)
+
+// UploadService interface for handler tests.
+type UploadService interface {
+	UploadAvatar(ctx context.Context, ownerID uuid.UUID, filename string, body io.Reader) (service.Upload, error)
+	UploadDocument(ctx context.Context, ownerID uuid.UUID, filename string, body io.Reader) (service.Upload, error)
+	DownloadURL(ctx context.Context, requesterID, id uuid.UUID, size string) (string, error)
+}
+
+var (
+	uploadService UploadService
+	// blobFiles serves signed links for the local blob store; nil with S3.
+	blobFiles http.Handler
+)
+
+func InitUploads(s UploadService, files http.Handler) {
+	uploadService = s
+	blobFiles = files
+}
+
+// multipartOverhead allows for the multipart framing around the file.
+const multipartOverhead = 64 << 10
+
+// UploadAvatar stores a new profile picture from the "file" form field.
+func UploadAvatar(w http.ResponseWriter, r *http.Request) {
+	handleUpload(w, r, service.MaxAvatarBytes, uploadService.UploadAvatar)
+}
+
+// UploadDocument stores a credential document from the "file" form field.
+func UploadDocument(w http.ResponseWriter, r *http.Request) {
+	handleUpload(w, r, service.MaxDocumentBytes, uploadService.UploadDocument)
+}
+
+// GetUpload redirects to a short-lived signed link for an upload or, with
+// ?size=, one of its thumbnails.
+func GetUpload(w http.ResponseWriter, r *http.Request) {
+	uid, ok := uploadUser(w, r)
+	if !ok {
+		return
+	}
+	id, err := uuid.Parse(chi.URLParam(r, "id"))
+	if err != nil {
+		writeJSON(w, http.StatusNotFound, errorResponse{Msg: service.ErrUploadNotFound.Error()})
+		return
+	}
+	link, err := uploadService.DownloadURL(r.Context(), uid, id, r.URL.Query().Get("size"))
+	switch {
+	case errors.Is(err, service.ErrUploadNotFound):
+		writeJSON(w, http.StatusNotFound, errorResponse{Msg: err.Error()})
+	case errors.Is(err, service.ErrUnknownUploadSize):
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: err.Error()})
+	case err != nil:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
+	default:
+		w.Header().Set("Cache-Control", "private, no-store")
+		http.Redirect(w, r, link, http.StatusFound)
+	}
+}
+
+// ServeFiles serves the local blob store's signed links under /files.
+func ServeFiles(w http.ResponseWriter, r *http.Request) {
+	if blobFiles == nil {
+		http.NotFound(w, r)
+		return
+	}
+	http.StripPrefix("/files", blobFiles).ServeHTTP(w, r)
+}
+
+type uploadFunc func(ctx context.Context, ownerID uuid.UUID, filename string, body io.Reader) (service.Upload, error)
+
+// handleUpload streams the "file" part of a multipart request into store
+// without buffering the rest of the form.
+func handleUpload(w http.ResponseWriter, r *http.Request, max int64, store uploadFunc) {
+	uid, ok := uploadUser(w, r)
+	if !ok {
+		return
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, max+multipartOverhead)
+	mr, err := r.MultipartReader()
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "expected a multipart/form-data body"})
+		return
+	}
+	for {
+		part, err := mr.NextPart()
+		if err != nil {
+			var tooBig *http.MaxBytesError
+			if errors.As(err, &tooBig) {
+				writeJSON(w, http.StatusRequestEntityTooLarge, errorResponse{Msg: service.ErrFileTooLarge.Error()})
+				return
+			}
+			writeJSON(w, http.StatusBadRequest, errorResponse{Msg: `missing "file" field`})
+			return
+		}
+		if part.FormName() != "file" {
+			part.Close()
+			continue
+		}
+		up, err := store(r.Context(), uid, part.FileName(), part)
+		part.Close()
+		var tooBig *http.MaxBytesError
+		switch {
+		case errors.Is(err, service.ErrFileTooLarge), errors.As(err, &tooBig):
+			writeJSON(w, http.StatusRequestEntityTooLarge, errorResponse{Msg: service.ErrFileTooLarge.Error()})
+		case errors.Is(err, service.ErrUnsupportedFile):
+			writeJSON(w, http.StatusUnsupportedMediaType, errorResponse{Msg: err.Error()})
+		case errors.Is(err, service.ErrEmptyFile), errors.Is(err, service.ErrInvalidImage):
+			writeJSON(w, http.StatusBadRequest, errorResponse{Msg: err.Error()})
+		case errors.Is(err, service.ErrNotTherapist):
+			writeJSON(w, http.StatusForbidden, errorResponse{Msg: "only therapists can upload credential documents"})
+		case err != nil:
+			writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
+		default:
+			writeJSON(w, http.StatusCreated, up)
+		}
+		return
+	}
+}
+
+func uploadUser(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	sub, _ := r.Context().Value(middleware.UserIDKey).(string)
+	uid, err := uuid.Parse(sub)
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Msg: "unauthorized"})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
@@ -0,0 +1,140 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/handlers"
+	mocks "github.com/divijg19/physiolink/backend/internal/mocks"
+	"github.com/divijg19/physiolink/backend/internal/service"
+)
+
+func multipartRequest(t *testing.T, path, token, field, filename string, data []byte) *http.Request {
+	t.Helper()
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	_ = mw.WriteField("note", "ignored")
+	fw, err := mw.CreateFormFile(field, filename)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fw.Write(data)
+	mw.Close()
+	req := httptest.NewRequest(http.MethodPost, path, &buf)
+	req.Header.Set("Content-Type", mw.FormDataContentType())
+	req.Header.Set("Authorization", "Bearer "+token)
+	return req
+}
+
+func TestUploadAvatar_StreamsFilePart(t *testing.T) {
+	m := &mocks.UploadServiceMock{}
+	cfg, router := setupRouter(t, func() { handlers.InitUploads(m, nil) })
+	user := uuid.New()
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, multipartRequest(t, "/api/uploads/avatar", sessionToken(t, cfg, user, "patient", nil), "file", "me.png", []byte("picture")))
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if m.OwnerID != user || m.Kind != service.UploadAvatar || m.Filename != "me.png" || string(m.Body) != "picture" {
+		t.Fatalf("unexpected call %+v", m)
+	}
+	var up service.Upload
+	if err := json.NewDecoder(rr.Body).Decode(&up); err != nil || up.Size != 7 {
+		t.Fatalf("unexpected body %v %+v", err, up)
+	}
+}
+
+func TestUploadDocument_RequiresFileField(t *testing.T) {
+	cfg, router := setupRouter(t, func() { handlers.InitUploads(&mocks.UploadServiceMock{}, nil) })
+	token := sessionToken(t, cfg, uuid.New(), "pt", nil)
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, multipartRequest(t, "/api/uploads/documents", token, "attachment", "x.pdf", []byte("%PDF")))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 without a file field, got %d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, authedRequest(http.MethodPost, "/api/uploads/documents", token, `{"file":"x"}`))
+	if rr.Code != http.StatusBadRequest {
+		t.Fatalf("expected 400 for a JSON body, got %d", rr.Code)
+	}
+}
+
+func TestUploadAvatar_RejectsOversizedBody(t *testing.T) {
+	cfg, router := setupRouter(t, func() { handlers.InitUploads(&mocks.UploadServiceMock{}, nil) })
+	rr := httptest.NewRecorder()
+	big := make([]byte, service.MaxAvatarBytes+128<<10)
+	router.ServeHTTP(rr, multipartRequest(t, "/api/uploads/avatar", sessionToken(t, cfg, uuid.New(), "patient", nil), "file", "big.png", big))
+	if rr.Code != http.StatusRequestEntityTooLarge {
+		t.Fatalf("expected 413, got %d", rr.Code)
+	}
+}
+
+func TestUpload_MapsErrors(t *testing.T) {
+	cases := map[error]int{
+		service.ErrFileTooLarge:    http.StatusRequestEntityTooLarge,
+		service.ErrUnsupportedFile: http.StatusUnsupportedMediaType,
+		service.ErrInvalidImage:    http.StatusBadRequest,
+		service.ErrEmptyFile:       http.StatusBadRequest,
+		service.ErrNotTherapist:    http.StatusForbidden,
+	}
+	for err, want := range cases {
+		cfg, router := setupRouter(t, func() { handlers.InitUploads(&mocks.UploadServiceMock{Err: err}, nil) })
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, multipartRequest(t, "/api/uploads/documents", sessionToken(t, cfg, uuid.New(), "patient", nil), "file", "x", []byte("x")))
+		if rr.Code != want {
+			t.Errorf("%v: expected %d, got %d", err, want, rr.Code)
+		}
+	}
+}
+
+func TestGetUpload_RedirectsToSignedLink(t *testing.T) {
+	m := &mocks.UploadServiceMock{Link: "/files/avatars/a/b/128.png?exp=1&sig=x"}
+	cfg, router := setupRouter(t, func() { handlers.InitUploads(m, nil) })
+	user := uuid.New()
+
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, authedRequest(http.MethodGet, "/api/uploads/"+uuid.NewString()+"?size=128", sessionToken(t, cfg, user, "patient", nil), ""))
+	if rr.Code != http.StatusFound || rr.Header().Get("Location") != m.Link {
+		t.Fatalf("expected redirect to signed link, got %d %q", rr.Code, rr.Header().Get("Location"))
+	}
+	if m.OwnerID != user || m.Size != "128" {
+		t.Fatalf("unexpected call %+v", m)
+	}
+
+	for err, want := range map[error]int{service.ErrUploadNotFound: http.StatusNotFound, service.ErrUnknownUploadSize: http.StatusBadRequest} {
+		cfg, router := setupRouter(t, func() { handlers.InitUploads(&mocks.UploadServiceMock{Err: err}, nil) })
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, authedRequest(http.MethodGet, "/api/uploads/"+uuid.NewString(), sessionToken(t, cfg, uuid.New(), "patient", nil), ""))
+		if rr.Code != want {
+			t.Errorf("%v: expected %d, got %d", err, want, rr.Code)
+		}
+	}
+}
+
+func TestServeFiles_StripsPrefix(t *testing.T) {
+	var path string
+	files := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { path = r.URL.Path })
+	_, router := setupRouter(t, func() { handlers.InitUploads(&mocks.UploadServiceMock{}, files) })
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/files/avatars/a/original.png?exp=1&sig=x", nil))
+	if rr.Code != http.StatusOK || path != "/avatars/a/original.png" {
+		t.Fatalf("expected blob handler to see the key, got %d %q", rr.Code, path)
+	}
+
+	_, router = setupRouter(t, func() { handlers.InitUploads(&mocks.UploadServiceMock{}, nil) })
+	rr = httptest.NewRecorder()
+	router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/files/x", nil))
+	if rr.Code != http.StatusNotFound {
+		t.Fatalf("expected 404 without a local store, got %d", rr.Code)
+	}
+}
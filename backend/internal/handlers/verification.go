@@ -0,0 +1,74 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/middleware"
+	"github.com/divijg19/physiolink/backend/internal/service"
+)
+
+// VerificationService interface for handler tests.
+type VerificationService interface {
+	SubmitCredentials(ctx context.Context, therapistID uuid.UUID, in service.CredentialSubmissionInput) (service.CredentialSubmission, error)
+	ListMySubmissions(ctx context.Context, therapistID uuid.UUID) ([]service.CredentialSubmission, error)
+}
+
+var verificationService VerificationService
+
+func InitVerification(s VerificationService) { verificationService = s }
+
+// SubmitCredentials sends the caller's licence details and documents for
+// admin review.
+func SubmitCredentials(w http.ResponseWriter, r *http.Request) {
+	uid, ok := verificationUser(w, r)
+	if !ok {
+		return
+	}
+	var in service.CredentialSubmissionInput
+	if err := json.NewDecoder(r.Body).Decode(&in); err != nil {
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: "invalid request"})
+		return
+	}
+	sub, err := verificationService.SubmitCredentials(r.Context(), uid, in)
+	switch {
+	case errors.Is(err, service.ErrCredentialsIncomplete), errors.Is(err, service.ErrInvalidDocument):
+		writeJSON(w, http.StatusBadRequest, errorResponse{Msg: err.Error()})
+	case errors.Is(err, service.ErrNotTherapist):
+		writeJSON(w, http.StatusForbidden, errorResponse{Msg: err.Error()})
+	case errors.Is(err, service.ErrSubmissionPending):
+		writeJSON(w, http.StatusConflict, errorResponse{Msg: err.Error()})
+	case err != nil:
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
+	default:
+		writeJSON(w, http.StatusCreated, sub)
+	}
+}
+
+// GetMyVerification lists the caller's submissions with their review outcome.
+func GetMyVerification(w http.ResponseWriter, r *http.Request) {
+	uid, ok := verificationUser(w, r)
+	if !ok {
+		return
+	}
+	list, err := verificationService.ListMySubmissions(r.Context(), uid)
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, errorResponse{Msg: "Server Error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, list)
+}
+
+func verificationUser(w http.ResponseWriter, r *http.Request) (uuid.UUID, bool) {
+	sub, _ := r.Context().Value(middleware.UserIDKey).(string)
+	uid, err := uuid.Parse(sub)
+	if err != nil {
+		writeJSON(w, http.StatusUnauthorized, errorResponse{Msg: "unauthorized"})
+		return uuid.Nil, false
+	}
+	return uid, true
+}
@@ -0,0 +1,52 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/handlers"
+	mocks "github.com/divijg19/physiolink/backend/internal/mocks"
+	"github.com/divijg19/physiolink/backend/internal/service"
+)
+
+func TestSubmitCredentials_Created(t *testing.T) {
+	m := &mocks.VerificationServiceMock{}
+	cfg, router := setupRouter(t, func() { handlers.InitVerification(m) })
+	therapist := uuid.New()
+
+	body := `{"licenceNumber":"PT-1234","issuingBody":"HCPC","documents":[{"name":"licence.pdf","url":"https://files.example.com/licence.pdf"}]}`
+	rr := httptest.NewRecorder()
+	router.ServeHTTP(rr, authedRequest(http.MethodPost, "/api/profile/verification", sessionToken(t, cfg, therapist, "pt", nil), body))
+
+	if rr.Code != http.StatusCreated {
+		t.Fatalf("expected 201, got %d: %s", rr.Code, rr.Body.String())
+	}
+	if m.TherapistID != therapist || m.Input.LicenceNumber != "PT-1234" || len(m.Input.Documents) != 1 {
+		t.Fatalf("unexpected call %+v", m)
+	}
+	var sub service.CredentialSubmission
+	if err := json.NewDecoder(rr.Body).Decode(&sub); err != nil || sub.Status != service.CredentialPending {
+		t.Fatalf("unexpected body %v %+v", err, sub)
+	}
+}
+
+func TestSubmitCredentials_MapsErrors(t *testing.T) {
+	cases := map[error]int{
+		service.ErrCredentialsIncomplete: http.StatusBadRequest,
+		service.ErrInvalidDocument:       http.StatusBadRequest,
+		service.ErrNotTherapist:          http.StatusForbidden,
+		service.ErrSubmissionPending:     http.StatusConflict,
+	}
+	for err, want := range cases {
+		cfg, router := setupRouter(t, func() { handlers.InitVerification(&mocks.VerificationServiceMock{Err: err}) })
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, authedRequest(http.MethodPost, "/api/profile/verification", sessionToken(t, cfg, uuid.New(), "pt", nil), `{}`))
+		if rr.Code != want {
+			t.Errorf("%v: expected %d, got %d", err, want, rr.Code)
+		}
+	}
+}
@@ -3,19 +3,21 @@ package handlers
 import (
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/go-chi/chi/v5"
 	"github.com/google/uuid"
 
 	"github.com/divijg19/physiolink/backend/internal/middleware"
+	"github.com/divijg19/physiolink/backend/internal/openapi"
 	"github.com/divijg19/physiolink/backend/internal/service"
 	"github.com/divijg19/physiolink/backend/internal/views"
 )
 
 // Render views
 func LoginPage(w http.ResponseWriter, r *http.Request) {
-	views.Login().Render(r.Context(), w)
+	views.Login(oidcProviderNames()).Render(r.Context(), w)
 }
 
 func RegisterPage(w http.ResponseWriter, r *http.Request) {
@@ -26,7 +28,9 @@ func TherapistsPage(w http.ResponseWriter, r *http.Request) {
 	// Fetch therapists
 	// We can reuse GetAllTherapists logic or call service directly
 	// Calling service directly is better
+	query := r.URL.Query().Get("q")
 	result, err := therapistService.GetAllTherapists(r.Context(), service.TherapistQueryParams{
+		Query: query,
 		Page:  1,
 		Limit: 20,
 	})
@@ -35,8 +39,22 @@ func TherapistsPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	therapists := deref(result.Data)
+	markFavourites(r, therapists)
+	cards := make([]views.TherapistCardView, len(therapists))
+	for i, t := range therapists {
+		cards[i] = views.TherapistCardView{
+			ID:             deref(t.Id),
+			Email:          deref(t.Email),
+			Snippet:        deref(t.Snippet),
+			AvailableSlots: deref(t.AvailableSlotsCount),
+			ReviewCount:    deref(t.ReviewCount),
+			Favourite:      deref(t.IsFavourite),
+		}
+	}
 	_, ok := r.Context().Value(middleware.UserIDKey).(string)
-	views.TherapistsList(result.Data, ok).Render(r.Context(), w)
+	_, canFavourite := favouriteViewer(r)
+	views.TherapistsList(cards, query, ok, canFavourite).Render(r.Context(), w)
 }
 
 func TherapistDetailPage(w http.ResponseWriter, r *http.Request) {
@@ -47,6 +65,7 @@ func TherapistDetailPage(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Therapist not found", http.StatusNotFound)
 		return
 	}
+	recordView(r, idStr)
 
 	tid, _ := uuid.Parse(idStr)
 	slots, err := apptService.GetTherapistAvailability(r.Context(), tid)
@@ -54,12 +73,7 @@ func TherapistDetailPage(w http.ResponseWriter, r *http.Request) {
 		slots = []service.Slot{}
 	}
 
-	profile, _ := tData["profile"].(map[string]interface{})
-	firstName, _ := profile["firstName"].(string)
-	lastName, _ := profile["lastName"].(string)
-	specialty, _ := profile["specialty"].(string)
-	bio, _ := profile["bio"].(string)
-	email, _ := tData["email"].(string)
+	profile := deref(tData.Profile)
 
 	var slotViews []views.SlotView
 	for _, s := range slots {
@@ -73,18 +87,46 @@ func TherapistDetailPage(w http.ResponseWriter, r *http.Request) {
 
 	detail := views.TherapistDetailView{
 		ID:        idStr,
-		Email:     email,
-		FirstName: firstName,
-		LastName:  lastName,
-		Specialty: specialty,
-		Bio:       bio,
+		Email:     deref(tData.Email),
+		FirstName: deref(profile.FirstName),
+		LastName:  deref(profile.LastName),
+		Specialty: deref(profile.Specialty),
+		Bio:       deref(profile.Bio),
 		Slots:     slotViews,
+		Aspects:   aspectRatings(tData.Ratings),
+	}
+	if _, ok := favouriteViewer(r); ok {
+		detail.CanFavourite = true
+		detail.Favourite = isFavourite(r, idStr)
 	}
 
 	_, isLoggedIn := r.Context().Value(middleware.UserIDKey).(string)
 	views.TherapistDetail(detail, isLoggedIn).Render(r.Context(), w)
 }
 
+// aspectRatings lists the sub-rating averages a therapist has, in the order
+// the review form asks for them.
+func aspectRatings(s *openapi.RatingSummary) []views.AspectRatingView {
+	if s == nil || s.SubRatings == nil {
+		return nil
+	}
+	var out []views.AspectRatingView
+	for _, a := range []struct {
+		label   string
+		average *float64
+	}{
+		{"Communication", s.SubRatings.Communication},
+		{"Punctuality", s.SubRatings.Punctuality},
+		{"Effectiveness", s.SubRatings.Effectiveness},
+		{"Facility", s.SubRatings.Facility},
+	} {
+		if a.average != nil {
+			out = append(out, views.AspectRatingView{Label: a.label, Average: *a.average})
+		}
+	}
+	return out
+}
+
 func BookAppointmentWeb(w http.ResponseWriter, r *http.Request) {
 	slotIDStr := chi.URLParam(r, "id")
 	slotID, _ := uuid.Parse(slotIDStr)
@@ -107,26 +149,21 @@ func GetReviewsWeb(w http.ResponseWriter, r *http.Request) {
 
 	rawReviews, err := reviewService.GetReviewsForTherapist(r.Context(), tid)
 	if err != nil {
-		rawReviews = []map[string]interface{}{}
+		rawReviews = []openapi.Review{}
 	}
 
 	var reviewViews []views.ReviewView
 	for _, r := range rawReviews {
-		patient, _ := r["patient"].(map[string]interface{})
-		profile, _ := patient["profile"].(map[string]interface{})
-		firstName, _ := profile["firstName"].(string)
-
-		rating, _ := r["rating"].(float64) // JSON numbers are float64
-		comment, _ := r["comment"].(string)
-		createdAtStr, _ := r["createdAt"].(string)
-		createdAt, _ := time.Parse(time.RFC3339, createdAtStr)
-
+		patient := deref(r.Patient)
+		reply := deref(r.Reply)
 		reviewViews = append(reviewViews, views.ReviewView{
-			ID:          r["_id"].(string),
-			PatientName: firstName,
-			Rating:      int(rating),
-			Comment:     comment,
-			CreatedAt:   createdAt,
+			ID:          deref(r.Id),
+			PatientName: deref(deref(patient.Profile).FirstName),
+			Rating:      deref(r.Rating),
+			Comment:     deref(r.Comment),
+			CreatedAt:   deref(r.CreatedAt),
+			Reply:       deref(reply.Body),
+			RepliedAt:   deref(reply.CreatedAt),
 		})
 	}
 
@@ -146,31 +183,25 @@ func PostReviewWeb(w http.ResponseWriter, r *http.Request) {
 	userIDStr, _ := r.Context().Value(middleware.UserIDKey).(string)
 	userID, _ := uuid.Parse(userIDStr)
 
-	ratingStr := r.FormValue("rating")
+	rating, _ := strconv.Atoi(r.FormValue("rating"))
 	comment := r.FormValue("comment")
-
-	// Simple conversion
-	rating := 5
-	if ratingStr == "1" {
-		rating = 1
-	}
-	if ratingStr == "2" {
-		rating = 2
-	}
-	if ratingStr == "3" {
-		rating = 3
-	}
-	if ratingStr == "4" {
-		rating = 4
-	}
-
-	_, err := reviewService.CreateReview(r.Context(), userID, tid, rating, comment)
+	// Sub-ratings left on "Not rated" come through empty and parse as 0.
+	var sub service.SubRatings
+	sub.Communication, _ = strconv.Atoi(r.FormValue("communication"))
+	sub.Punctuality, _ = strconv.Atoi(r.FormValue("punctuality"))
+	sub.Effectiveness, _ = strconv.Atoi(r.FormValue("effectiveness"))
+	sub.Facility, _ = strconv.Atoi(r.FormValue("facility"))
+
+	rev, err := reviewService.CreateReview(r.Context(), userID, service.NewReview{TherapistID: tid, Rating: rating, Comment: comment, SubRatings: sub})
 	if err != nil {
 		// In a real app, return the form with error
 		w.WriteHeader(http.StatusBadRequest)
 		w.Write([]byte(fmt.Sprintf("<div class='bg-red-100 text-red-700 p-4 rounded mb-4'>Error: %s</div>", err.Error())))
 		return
 	}
+	if rev.Status != nil && *rev.Status == openapi.ReviewStatusPending {
+		w.Write([]byte("<div class='bg-yellow-100 text-yellow-800 p-4 rounded mb-4'>Thanks! Your review will appear once a moderator has approved it.</div>"))
+	}
 
 	// Return the updated list
 	GetReviewsWeb(w, r)
@@ -222,22 +253,33 @@ func LoginSubmit(w http.ResponseWriter, r *http.Request) {
 	email := r.FormValue("email")
 	password := r.FormValue("password")
 
-	_, token, err := authService.Authenticate(r.Context(), email, password)
+	id, role, err := authService.Authenticate(r.Context(), email, password)
 	if err != nil {
-		// In a real HTMX app, we'd return a partial with the error message
 		w.WriteHeader(http.StatusUnauthorized)
-		w.Write([]byte("<div class='bg-red-100 border border-red-400 text-red-700 px-4 py-3 rounded relative' role='alert'><strong class='font-bold'>Error!</strong> <span class='block sm:inline'>" + err.Error() + "</span></div>"))
+		views.FormError(err.Error()).Render(r.Context(), w)
 		return
 	}
 
-	// Set cookie
-	http.SetCookie(w, &http.Cookie{
-		Name:     "auth_token",
-		Value:    token,
-		Expires:  time.Now().Add(24 * time.Hour),
-		HttpOnly: true,
-		Path:     "/",
-	})
+	mfaReq, token, err := startSession(r, id, role)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		views.FormError("Server error").Render(r.Context(), w)
+		return
+	}
+	switch mfaReq {
+	case service.MFAChallenge:
+		// Swap the password form for the code step
+		w.Header().Set("HX-Retarget", "#login-form")
+		w.Header().Set("HX-Reswap", "outerHTML")
+		views.LoginMFAForm(token).Render(r.Context(), w)
+		return
+	case service.MFASetupRequired:
+		w.WriteHeader(http.StatusForbidden)
+		views.FormError("Your clinic requires two-factor authentication. Set it up in the Physiolink app, then sign in again.").Render(r.Context(), w)
+		return
+	}
+
+	setAuthCookie(w, token)
 
 	// HTMX redirect via header
 	w.Header().Set("HX-Redirect", "/")
@@ -249,21 +291,55 @@ func RegisterSubmit(w http.ResponseWriter, r *http.Request) {
 	password := r.FormValue("password")
 	role := r.FormValue("role")
 
-	_, token, err := authService.Register(r.Context(), email, password, role)
+	id, role, err := authService.Register(r.Context(), email, password, role)
 	if err != nil {
 		w.WriteHeader(http.StatusBadRequest)
-		w.Write([]byte("<div class='bg-red-100 border border-red-400 text-red-700 px-4 py-3 rounded relative' role='alert'><strong class='font-bold'>Error!</strong> <span class='block sm:inline'>" + err.Error() + "</span></div>"))
+		views.FormError(err.Error()).Render(r.Context(), w)
 		return
 	}
 
+	token, err := signToken(r, id, role, false)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		views.FormError("Server error").Render(r.Context(), w)
+		return
+	}
+	setAuthCookie(w, token)
+
+	w.Header().Set("HX-Redirect", "/")
+	w.WriteHeader(http.StatusOK)
+}
+
+func setAuthCookie(w http.ResponseWriter, token string) {
 	http.SetCookie(w, &http.Cookie{
 		Name:     "auth_token",
 		Value:    token,
 		Expires:  time.Now().Add(24 * time.Hour),
 		HttpOnly: true,
+		Secure:   cfg.SecureCookies(),
+		SameSite: http.SameSiteLaxMode,
 		Path:     "/",
 	})
+}
 
-	w.Header().Set("HX-Redirect", "/")
-	w.WriteHeader(http.StatusOK)
+// deref reads an optional field of a generated model, giving the zero value
+// when it is unset.
+func deref[T any](p *T) T {
+	if p == nil {
+		var zero T
+		return zero
+	}
+	return *p
+}
+
+func clearAuthCookie(w http.ResponseWriter) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     "auth_token",
+		Value:    "",
+		Expires:  time.Now().Add(-1 * time.Hour),
+		HttpOnly: true,
+		Secure:   cfg.SecureCookies(),
+		SameSite: http.SameSiteLaxMode,
+		Path:     "/",
+	})
 }
@@ -0,0 +1,97 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"testing"
+)
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestFitKeepsAspectRatio(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2000, 500))
+	if got := Fit(img, 1024).Bounds().Size(); got != image.Pt(1024, 256) {
+		t.Fatalf("Fit = %v, want 1024x256", got)
+	}
+	small := image.NewRGBA(image.Rect(0, 0, 300, 200))
+	if got := Fit(small, 1024).Bounds().Size(); got != image.Pt(300, 200) {
+		t.Fatalf("Fit should not enlarge, got %v", got)
+	}
+}
+
+func TestThumbnailCropsCentre(t *testing.T) {
+	// Red bars left and right of a blue 100x100 centre
+	img := image.NewRGBA(image.Rect(0, 0, 300, 100))
+	for y := 0; y < 100; y++ {
+		for x := 0; x < 300; x++ {
+			c := color.RGBA{255, 0, 0, 255}
+			if x >= 100 && x < 200 {
+				c = color.RGBA{0, 0, 255, 255}
+			}
+			img.Set(x, y, c)
+		}
+	}
+	th := Thumbnail(img, 64)
+	if th.Bounds().Size() != image.Pt(64, 64) {
+		t.Fatalf("unexpected size %v", th.Bounds())
+	}
+	for _, p := range []image.Point{{0, 0}, {63, 63}, {32, 32}} {
+		if c := th.RGBAAt(p.X, p.Y); c != (color.RGBA{0, 0, 255, 255}) {
+			t.Fatalf("pixel %v = %v, want blue", p, c)
+		}
+	}
+}
+
+func TestResizeAverages(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.RGBA{200, 0, 0, 255})
+	img.Set(1, 0, color.RGBA{0, 0, 0, 255})
+	img.Set(0, 1, color.RGBA{0, 0, 0, 255})
+	img.Set(1, 1, color.RGBA{200, 0, 0, 255})
+	if c := Thumbnail(img, 1).RGBAAt(0, 0); c != (color.RGBA{100, 0, 0, 255}) {
+		t.Fatalf("got %v, want the average", c)
+	}
+}
+
+func TestDecodeRejectsGarbageAndHugeImages(t *testing.T) {
+	if _, _, err := Decode([]byte("not an image")); !errors.Is(err, ErrInvalidImage) {
+		t.Fatalf("expected ErrInvalidImage, got %v", err)
+	}
+	// A PNG header claiming 10000x10000 pixels is refused before decoding
+	data := encodePNG(t, image.NewGray(image.Rect(0, 0, 1, 1)))
+	huge := append([]byte(nil), data...)
+	copy(huge[16:24], []byte{0, 0, 0x27, 0x10, 0, 0, 0x27, 0x10})
+	binary.BigEndian.PutUint32(huge[29:33], crc32.ChecksumIEEE(huge[12:29]))
+	if cfg, err := png.DecodeConfig(bytes.NewReader(huge)); err != nil || cfg.Width != 10000 {
+		t.Fatalf("test image header is broken: %v %v", cfg, err)
+	}
+	if _, _, err := Decode(huge); !errors.Is(err, ErrInvalidImage) {
+		t.Fatalf("expected oversized image to be refused, got %v", err)
+	}
+	img, format, err := Decode(data)
+	if err != nil || format != "png" || img.Bounds().Dx() != 1 {
+		t.Fatalf("Decode = %v %q %v", img, format, err)
+	}
+}
+
+func TestEncodeFormats(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 4, 4))
+	for format, want := range map[string]string{"jpeg": "image/jpeg", "png": "image/png", "gif": "image/png"} {
+		data, ctype, err := Encode(img, format)
+		if err != nil || ctype != want || len(data) == 0 {
+			t.Fatalf("Encode(%s) = %d bytes %q %v", format, len(data), ctype, err)
+		}
+	}
+}
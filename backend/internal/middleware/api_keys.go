@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/service"
+)
+
+// ClinicIDKey holds the clinic an API key acts for.
+const ClinicIDKey ctxKey = "clinic_id"
+
+// APIKeyIDKey holds the id of the API key that authenticated the request.
+const APIKeyIDKey ctxKey = "api_key_id"
+
+// APIKeyStore resolves presented keys to their clinic and scopes.
+type APIKeyStore interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (keyID, clinicID uuid.UUID, scopes []string, err error)
+}
+
+var apiKeyStore APIKeyStore
+
+// InitAPIKeys enables API key authentication for routes mounted with APIKeyAuth.
+func InitAPIKeys(s APIKeyStore) { apiKeyStore = s }
+
+// APIKeyAuth authenticates clinic integrations. The key is read from the same
+// Authorization bearer or x-auth-token header as session tokens, and must hold
+// every scope listed. User sessions are not accepted.
+func APIKeyAuth(scopes ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := requestToken(r)
+			if apiKeyStore == nil || key == "" {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+			keyID, clinicID, granted, err := apiKeyStore.AuthenticateAPIKey(r.Context(), key)
+			if err != nil {
+				if errors.Is(err, service.ErrInvalidAPIKey) {
+					http.Error(w, "unauthorized", http.StatusUnauthorized)
+					return
+				}
+				http.Error(w, "service unavailable", http.StatusServiceUnavailable)
+				return
+			}
+			for _, want := range scopes {
+				if !hasScope(granted, want) {
+					http.Error(w, "insufficient scope", http.StatusForbidden)
+					return
+				}
+			}
+			ctx := context.WithValue(r.Context(), APIKeyIDKey, keyID.String())
+			ctx = context.WithValue(ctx, ClinicIDKey, clinicID.String())
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func hasScope(granted []string, want string) bool {
+	for _, s := range granted {
+		if s == want {
+			return true
+		}
+	}
+	return false
+}
@@ -15,51 +15,42 @@ type ctxKey string
 const UserIDKey ctxKey = "user_id"
 const UserRoleKey ctxKey = "user_role"
 
+// MFAVerifiedKey is true when the session completed a second factor.
+const MFAVerifiedKey ctxKey = "mfa_verified"
+
+// ImpersonatorKey holds the admin's user id when a session was issued through
+// admin impersonation.
+const ImpersonatorKey ctxKey = "impersonator_id"
+
+// MFASetupRequiredKey is true when the user's clinic enforces MFA and the
+// user has not enrolled yet.
+const MFASetupRequiredKey ctxKey = "mfa_setup_required"
+
 func JWTAuth(cfg *config.Config) func(http.Handler) http.Handler {
+	keys := cfg.KeySet()
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			auth := r.Header.Get("Authorization")
-			var tokenStr string
-			if auth != "" && strings.HasPrefix(auth, "Bearer ") {
-				tokenStr = strings.TrimPrefix(auth, "Bearer ")
-			} else {
-				// Support React Native client using x-auth-token
-				tokenStr = r.Header.Get("x-auth-token")
-			}
+			tokenStr := requestToken(r)
 			if tokenStr == "" {
 				http.Error(w, "unauthorized", http.StatusUnauthorized)
 				return
 			}
-			token, err := jwt.NewParser(jwt.WithValidMethods([]string{"HS256"})).Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-				return []byte(cfg.JWTSecret), nil
-			})
-			if err != nil || !token.Valid {
+			claims, err := keys.Parse(tokenStr)
+			if err != nil {
 				http.Error(w, "unauthorized", http.StatusUnauthorized)
 				return
 			}
-			claims, ok := token.Claims.(jwt.MapClaims)
+			ctx, ok := withClaims(r.Context(), claims)
 			if !ok {
 				http.Error(w, "unauthorized", http.StatusUnauthorized)
 				return
 			}
-			// Our JWT payload: { user: { id, role }, exp }
-			var sub string
-			var role string
-			if u, ok := claims["user"].(map[string]interface{}); ok && u != nil {
-				if v, ok := u["id"].(string); ok {
-					sub = v
-				}
-				if v, ok := u["role"].(string); ok {
-					role = v
-				}
-			}
-			if sub == "" {
-				http.Error(w, "unauthorized", http.StatusUnauthorized)
+			if active, err := sessionActive(ctx); err != nil {
+				http.Error(w, "service unavailable", http.StatusServiceUnavailable)
+				return
+			} else if !active {
+				http.Error(w, "session revoked", http.StatusUnauthorized)
 				return
-			}
-			ctx := context.WithValue(r.Context(), UserIDKey, sub)
-			if role != "" {
-				ctx = context.WithValue(ctx, UserRoleKey, role)
 			}
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
@@ -67,6 +58,7 @@ func JWTAuth(cfg *config.Config) func(http.Handler) http.Handler {
 }
 
 func CookieAuth(cfg *config.Config) func(http.Handler) http.Handler {
+	keys := cfg.KeySet()
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			cookie, err := r.Cookie("auth_token")
@@ -75,42 +67,30 @@ func CookieAuth(cfg *config.Config) func(http.Handler) http.Handler {
 				return
 			}
 			tokenStr := cookie.Value
-			token, err := jwt.NewParser(jwt.WithValidMethods([]string{"HS256"})).Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-				return []byte(cfg.JWTSecret), nil
-			})
-			if err != nil || !token.Valid {
+			claims, err := keys.Parse(tokenStr)
+			if err != nil {
 				http.Redirect(w, r, "/login", http.StatusFound)
 				return
 			}
-			claims, ok := token.Claims.(jwt.MapClaims)
+			ctx, ok := withClaims(r.Context(), claims)
 			if !ok {
 				http.Redirect(w, r, "/login", http.StatusFound)
 				return
 			}
-			var sub string
-			var role string
-			if u, ok := claims["user"].(map[string]interface{}); ok && u != nil {
-				if v, ok := u["id"].(string); ok {
-					sub = v
-				}
-				if v, ok := u["role"].(string); ok {
-					role = v
-				}
-			}
-			if sub == "" {
+			if active, err := sessionActive(ctx); err != nil {
+				http.Error(w, "service unavailable", http.StatusServiceUnavailable)
+				return
+			} else if !active {
 				http.Redirect(w, r, "/login", http.StatusFound)
 				return
 			}
-			ctx := context.WithValue(r.Context(), UserIDKey, sub)
-			if role != "" {
-				ctx = context.WithValue(ctx, UserRoleKey, role)
-			}
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
 func OptionalCookieAuth(cfg *config.Config) func(http.Handler) http.Handler {
+	keys := cfg.KeySet()
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			cookie, err := r.Cookie("auth_token")
@@ -119,37 +99,106 @@ func OptionalCookieAuth(cfg *config.Config) func(http.Handler) http.Handler {
 				return
 			}
 			tokenStr := cookie.Value
-			token, err := jwt.NewParser(jwt.WithValidMethods([]string{"HS256"})).Parse(tokenStr, func(token *jwt.Token) (interface{}, error) {
-				return []byte(cfg.JWTSecret), nil
-			})
-			if err != nil || !token.Valid {
-				next.ServeHTTP(w, r)
-				return
-			}
-			claims, ok := token.Claims.(jwt.MapClaims)
-			if !ok {
+			claims, err := keys.Parse(tokenStr)
+			if err != nil {
 				next.ServeHTTP(w, r)
 				return
 			}
-			var sub string
-			var role string
-			if u, ok := claims["user"].(map[string]interface{}); ok && u != nil {
-				if v, ok := u["id"].(string); ok {
-					sub = v
-				}
-				if v, ok := u["role"].(string); ok {
-					role = v
+			if ctx, ok := withClaims(r.Context(), claims); ok {
+				if active, err := sessionActive(ctx); err == nil && active {
+					next.ServeHTTP(w, r.WithContext(ctx))
+					return
 				}
 			}
-			if sub != "" {
-				ctx := context.WithValue(r.Context(), UserIDKey, sub)
-				if role != "" {
-					ctx = context.WithValue(ctx, UserRoleKey, role)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// EnforceMFAEnrollment rejects sessions that were issued before a required
+// MFA enrollment. Mount it after JWTAuth on every group except the MFA
+// enrollment endpoints themselves.
+func EnforceMFAEnrollment(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if pending, _ := r.Context().Value(MFASetupRequiredKey).(bool); pending {
+			http.Error(w, "mfa enrollment required", http.StatusForbidden)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// RequireRole rejects requests whose session role is not one of roles.
+// Mount it after JWTAuth. Impersonated sessions never pass, so an admin can't
+// reach privileged routes through someone else's identity.
+func RequireRole(roles ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			role, _ := r.Context().Value(UserRoleKey).(string)
+			if _, impersonated := r.Context().Value(ImpersonatorKey).(string); !impersonated {
+				for _, want := range roles {
+					if role == want {
+						next.ServeHTTP(w, r)
+						return
+					}
 				}
-				next.ServeHTTP(w, r.WithContext(ctx))
-				return
 			}
-			next.ServeHTTP(w, r)
+			http.Error(w, "forbidden", http.StatusForbidden)
 		})
 	}
 }
+
+// withClaims copies the authenticated user from our JWT payload
+// ({ user: { id, role }, sid, mfa, act, exp }) into ctx. It reports false when the
+// token carries no user, e.g. a pending MFA challenge token.
+func withClaims(ctx context.Context, claims jwt.MapClaims) (context.Context, bool) {
+	var sub string
+	var role string
+	if u, ok := claims["user"].(map[string]interface{}); ok && u != nil {
+		if v, ok := u["id"].(string); ok {
+			sub = v
+		}
+		if v, ok := u["role"].(string); ok {
+			role = v
+		}
+	}
+	if sub == "" {
+		return ctx, false
+	}
+	ctx = context.WithValue(ctx, UserIDKey, sub)
+	if role != "" {
+		ctx = context.WithValue(ctx, UserRoleKey, role)
+	}
+	if sid, _ := claims["sid"].(string); sid != "" {
+		ctx = context.WithValue(ctx, SessionIDKey, sid)
+	}
+	mfa, _ := claims["mfa"].(bool)
+	ctx = context.WithValue(ctx, MFAVerifiedKey, mfa)
+	if setup, _ := claims["mfa_setup_required"].(bool); setup {
+		ctx = context.WithValue(ctx, MFASetupRequiredKey, true)
+	}
+	// "act" names the party acting on the user's behalf (RFC 8693)
+	if act, ok := claims["act"].(map[string]interface{}); ok {
+		if admin, _ := act["sub"].(string); admin != "" {
+			ctx = context.WithValue(ctx, ImpersonatorKey, admin)
+		}
+	}
+	return ctx, true
+}
+
+// requestToken reads the credential from the Authorization bearer header or,
+// for the React Native client, x-auth-token.
+func requestToken(r *http.Request) string {
+	if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") {
+		return strings.TrimPrefix(auth, "Bearer ")
+	}
+	return r.Header.Get("x-auth-token")
+}
+
+// sessionActive checks the request's device session against the revocation
+// cache.
+func sessionActive(ctx context.Context) (bool, error) {
+	sid, _ := ctx.Value(SessionIDKey).(string)
+	_, impersonated := ctx.Value(ImpersonatorKey).(string)
+	return sessions.active(ctx, sid, impersonated)
+}
@@ -0,0 +1,91 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"net/http"
+
+	"github.com/divijg19/physiolink/backend/internal/config"
+)
+
+const (
+	// CSRFCookieName holds the per-browser token.
+	CSRFCookieName = "csrf_token"
+	// CSRFHeader is set on every HTMX request by the layout's hx-headers.
+	CSRFHeader = "X-CSRF-Token"
+	// CSRFFormField is accepted instead of the header for plain form posts.
+	CSRFFormField = "csrf_token"
+)
+
+// CSRFTokenKey holds the current request's token for rendering into pages.
+const CSRFTokenKey ctxKey = "csrf_token"
+
+// CSRF protects the cookie-authenticated web UI with a double-submit token:
+// unsafe requests must echo the csrf_token cookie in the X-CSRF-Token header
+// (or csrf_token form field). A cross-site page can make the browser send the
+// cookie but cannot read it to fill in the header. Safe requests get a token
+// issued so the next page can render it.
+func CSRF(cfg *config.Config) func(http.Handler) http.Handler {
+	secure := cfg.SecureCookies()
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var token string
+			if c, err := r.Cookie(CSRFCookieName); err == nil && len(c.Value) == csrfTokenLen {
+				token = c.Value
+			}
+			if !isSafeMethod(r.Method) {
+				sent := r.Header.Get(CSRFHeader)
+				if sent == "" {
+					sent = r.PostFormValue(CSRFFormField)
+				}
+				if token == "" || subtle.ConstantTimeCompare([]byte(sent), []byte(token)) != 1 {
+					http.Error(w, "invalid csrf token", http.StatusForbidden)
+					return
+				}
+			}
+			if token == "" {
+				var err error
+				if token, err = newCSRFToken(); err != nil {
+					http.Error(w, "server error", http.StatusInternalServerError)
+					return
+				}
+				http.SetCookie(w, &http.Cookie{
+					Name:     CSRFCookieName,
+					Value:    token,
+					Path:     "/",
+					HttpOnly: true,
+					Secure:   secure,
+					SameSite: http.SameSiteLaxMode,
+				})
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), CSRFTokenKey, token)))
+		})
+	}
+}
+
+// CSRFToken returns the token to embed in pages rendered for this request.
+func CSRFToken(ctx context.Context) string {
+	token, _ := ctx.Value(CSRFTokenKey).(string)
+	return token
+}
+
+// csrfTokenLen is the encoded length of a 32-byte token.
+var csrfTokenLen = base64.RawURLEncoding.EncodedLen(32)
+
+func newCSRFToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+func isSafeMethod(m string) bool {
+	switch m {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodTrace:
+		return true
+	}
+	return false
+}
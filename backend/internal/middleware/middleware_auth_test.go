@@ -1,6 +1,8 @@
 package middleware_test
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -10,6 +12,7 @@ import (
 
 	"github.com/divijg19/physiolink/backend/internal/config"
 	mware "github.com/divijg19/physiolink/backend/internal/middleware"
+	"github.com/divijg19/physiolink/backend/internal/tokens"
 )
 
 // nextHandler echoes 200 if it sees user id in context
@@ -68,6 +71,60 @@ func TestJWTAuth_AllowsXAuthTokenHeader(t *testing.T) {
 	}
 }
 
+func TestJWTAuth_RejectsMFAChallengeToken(t *testing.T) {
+	cfg := config.New()
+	claims := jwt.MapClaims{
+		"mfa_pending": map[string]string{"id": "11111111-1111-1111-1111-111111111111", "role": "pt"},
+		"exp":         time.Now().Add(5 * time.Minute).Unix(),
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(cfg.JWTSecret))
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+
+	mw := mware.JWTAuth(cfg)
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	mw(http.HandlerFunc(nextHandler)).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401, got %d", rr.Code)
+	}
+}
+
+func TestEnforceMFAEnrollment_BlocksPendingSetup(t *testing.T) {
+	cfg := config.New()
+	claims := jwt.MapClaims{
+		"user":               map[string]string{"id": "11111111-1111-1111-1111-111111111111", "role": "pt"},
+		"mfa":                false,
+		"mfa_setup_required": true,
+		"exp":                time.Now().Add(5 * time.Minute).Unix(),
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(cfg.JWTSecret))
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+
+	h := mware.JWTAuth(cfg)(mware.EnforceMFAEnrollment(http.HandlerFunc(nextHandler)))
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	h.ServeHTTP(rr, req)
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", rr.Code)
+	}
+
+	rr = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+makeToken(t, cfg.JWTSecret, "11111111-1111-1111-1111-111111111111", "pt"))
+	h.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", rr.Code)
+	}
+}
+
 func TestJWTAuth_RejectsMissingToken(t *testing.T) {
 	cfg := config.New()
 	mw := mware.JWTAuth(cfg)
@@ -80,3 +137,52 @@ func TestJWTAuth_RejectsMissingToken(t *testing.T) {
 		t.Fatalf("expected 401, got %d", rr.Code)
 	}
 }
+
+func TestJWTAuth_VerifiesRotatedAsymmetricKeys(t *testing.T) {
+	_, oldPriv, _ := ed25519.GenerateKey(rand.Reader)
+	_, newPriv, _ := ed25519.GenerateKey(rand.Reader)
+	oldKey, _ := tokens.NewKey("old", oldPriv)
+	newKey, _ := tokens.NewKey("new", newPriv)
+	keys := tokens.NewHMAC("")
+	if err := keys.Add(oldKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := keys.Add(newKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := keys.SetActive("old"); err != nil {
+		t.Fatal(err)
+	}
+	claims := jwt.MapClaims{
+		"user": map[string]string{"id": "11111111-1111-1111-1111-111111111111", "role": "patient"},
+		"exp":  time.Now().Add(5 * time.Minute).Unix(),
+	}
+	oldToken, err := keys.Sign(claims)
+	if err != nil {
+		t.Fatalf("sign token: %v", err)
+	}
+	if err := keys.SetActive("new"); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := &config.Config{JWTKeys: keys}
+	mw := mware.JWTAuth(cfg)
+	cases := []struct {
+		name  string
+		token string
+		want  int
+	}{
+		{"pre-rotation", oldToken, http.StatusOK},
+		// No JWT secret is configured, so HS256 tokens must not verify.
+		{"legacy HS256", makeToken(t, config.DefaultJWTSecret, "11111111-1111-1111-1111-111111111111", "patient"), http.StatusUnauthorized},
+	}
+	for _, tc := range cases {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+tc.token)
+		mw(http.HandlerFunc(nextHandler)).ServeHTTP(rr, req)
+		if rr.Code != tc.want {
+			t.Fatalf("%s: expected %d, got %d", tc.name, tc.want, rr.Code)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package middleware_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/divijg19/physiolink/backend/internal/config"
+	mware "github.com/divijg19/physiolink/backend/internal/middleware"
+)
+
+func csrfHandler() http.Handler {
+	cfg := &config.Config{Env: "production"}
+	return mware.CSRF(cfg)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(mware.CSRFToken(r.Context())))
+	}))
+}
+
+// issueCSRF performs a GET and returns the cookie the middleware sets.
+func issueCSRF(t *testing.T, h http.Handler) *http.Cookie {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/", nil))
+	for _, c := range rr.Result().Cookies() {
+		if c.Name == mware.CSRFCookieName {
+			if !c.HttpOnly || !c.Secure || c.SameSite != http.SameSiteLaxMode {
+				t.Fatalf("unexpected cookie attributes %+v", c)
+			}
+			if rr.Body.String() != c.Value {
+				t.Fatalf("context token %q does not match cookie", rr.Body.String())
+			}
+			return c
+		}
+	}
+	t.Fatal("expected csrf cookie")
+	return nil
+}
+
+func TestCSRF_RejectsUnsafeRequestWithoutToken(t *testing.T) {
+	h := csrfHandler()
+	cookie := issueCSRF(t, h)
+
+	for name, header := range map[string]string{"missing": "", "mismatched": strings.Repeat("x", len(cookie.Value))} {
+		req := httptest.NewRequest(http.MethodPost, "/auth/logout", nil)
+		req.AddCookie(cookie)
+		if header != "" {
+			req.Header.Set(mware.CSRFHeader, header)
+		}
+		rr := httptest.NewRecorder()
+		h.ServeHTTP(rr, req)
+		if rr.Code != http.StatusForbidden {
+			t.Fatalf("%s: expected 403, got %d", name, rr.Code)
+		}
+	}
+}
+
+func TestCSRF_AcceptsHeaderOrFormField(t *testing.T) {
+	h := csrfHandler()
+	cookie := issueCSRF(t, h)
+
+	req := httptest.NewRequest(http.MethodPut, "/web/profile", nil)
+	req.AddCookie(cookie)
+	req.Header.Set(mware.CSRFHeader, cookie.Value)
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("header: expected 200, got %d", rr.Code)
+	}
+
+	form := url.Values{mware.CSRFFormField: {cookie.Value}}
+	req = httptest.NewRequest(http.MethodPost, "/auth/login-form", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.AddCookie(cookie)
+	rr = httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+	if rr.Code != http.StatusOK {
+		t.Fatalf("form field: expected 200, got %d", rr.Code)
+	}
+}
+
+func TestCSRF_RejectsUnsafeRequestWithoutCookie(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/auth/logout", nil)
+	req.Header.Set(mware.CSRFHeader, "anything")
+	rr := httptest.NewRecorder()
+	csrfHandler().ServeHTTP(rr, req)
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", rr.Code)
+	}
+}
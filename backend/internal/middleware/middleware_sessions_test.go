@@ -0,0 +1,113 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/config"
+	mware "github.com/divijg19/physiolink/backend/internal/middleware"
+)
+
+// fakeSessions counts lookups so tests can see the cache at work.
+type fakeSessions struct {
+	revoked map[uuid.UUID]bool
+	calls   int
+}
+
+func (f *fakeSessions) Touch(ctx context.Context, id uuid.UUID) (bool, error) {
+	f.calls++
+	return !f.revoked[id], nil
+}
+
+func sessionToken(t *testing.T, cfg *config.Config, sid uuid.UUID) string {
+	t.Helper()
+	token, err := cfg.KeySet().Sign(jwt.MapClaims{
+		"user": map[string]string{"id": uuid.NewString(), "role": "patient"},
+		"sid":  sid.String(),
+		"exp":  time.Now().Add(5 * time.Minute).Unix(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return token
+}
+
+func TestJWTAuth_RejectsRevokedSessionAfterForget(t *testing.T) {
+	cfg := config.New()
+	store := &fakeSessions{revoked: map[uuid.UUID]bool{}}
+	mware.InitSessions(store, time.Minute)
+	t.Cleanup(func() { mware.InitSessions(nil, 0) })
+
+	sid := uuid.New()
+	token := sessionToken(t, cfg, sid)
+	call := func() int {
+		rr := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		mware.JWTAuth(cfg)(http.HandlerFunc(nextHandler)).ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	for i := 0; i < 3; i++ {
+		if code := call(); code != http.StatusOK {
+			t.Fatalf("expected 200, got %d", code)
+		}
+	}
+	if store.calls != 1 {
+		t.Fatalf("expected a single store lookup, got %d", store.calls)
+	}
+
+	store.revoked[sid] = true
+	if code := call(); code != http.StatusOK {
+		t.Fatalf("expected cached answer until forgotten, got %d", code)
+	}
+	mware.ForgetSession(sid)
+	if code := call(); code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for revoked session, got %d", code)
+	}
+}
+
+func TestJWTAuth_RejectsImpersonationWithoutSession(t *testing.T) {
+	cfg := config.New()
+	mware.InitSessions(&fakeSessions{revoked: map[uuid.UUID]bool{}}, time.Minute)
+	t.Cleanup(func() { mware.InitSessions(nil, 0) })
+
+	token, err := cfg.KeySet().Sign(jwt.MapClaims{
+		"user": map[string]string{"id": uuid.NewString(), "role": "patient"},
+		"act":  map[string]string{"sub": uuid.NewString()},
+		"exp":  time.Now().Add(5 * time.Minute).Unix(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/protected", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	mware.JWTAuth(cfg)(http.HandlerFunc(nextHandler)).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusUnauthorized {
+		t.Fatalf("expected 401 for an impersonation token that can't be revoked, got %d", rr.Code)
+	}
+}
+
+func TestCookieAuth_RedirectsRevokedSession(t *testing.T) {
+	cfg := config.New()
+	sid := uuid.New()
+	mware.InitSessions(&fakeSessions{revoked: map[uuid.UUID]bool{sid: true}}, time.Minute)
+	t.Cleanup(func() { mware.InitSessions(nil, 0) })
+
+	rr := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/dashboard", nil)
+	req.AddCookie(&http.Cookie{Name: "auth_token", Value: sessionToken(t, cfg, sid)})
+	mware.CookieAuth(cfg)(http.HandlerFunc(nextHandler)).ServeHTTP(rr, req)
+
+	if rr.Code != http.StatusFound || rr.Header().Get("Location") != "/login" {
+		t.Fatalf("expected redirect to /login, got %d %q", rr.Code, rr.Header().Get("Location"))
+	}
+}
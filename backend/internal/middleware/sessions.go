@@ -0,0 +1,104 @@
+package middleware
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// SessionIDKey holds the id of the device session behind the request's token.
+const SessionIDKey ctxKey = "session_id"
+
+// SessionStore reports whether a session is still active, updating its
+// last-seen time as a side effect.
+type SessionStore interface {
+	Touch(ctx context.Context, sessionID uuid.UUID) (bool, error)
+}
+
+// DefaultSessionCacheTTL bounds how long a revoked session keeps working on
+// instances other than the one that revoked it.
+const DefaultSessionCacheTTL = 30 * time.Second
+
+var sessions = &sessionCache{entries: map[uuid.UUID]sessionEntry{}}
+
+// InitSessions enables revocation checks in the auth middleware. Without a
+// store (as in unit tests) every validly signed token is accepted.
+func InitSessions(s SessionStore, ttl time.Duration) {
+	sessions.mu.Lock()
+	defer sessions.mu.Unlock()
+	sessions.store = s
+	sessions.ttl = ttl
+	sessions.entries = map[uuid.UUID]sessionEntry{}
+}
+
+// ForgetSession drops a cached check so a revocation takes effect on this
+// instance immediately.
+func ForgetSession(id uuid.UUID) {
+	sessions.mu.Lock()
+	delete(sessions.entries, id)
+	sessions.mu.Unlock()
+}
+
+type sessionEntry struct {
+	active  bool
+	expires time.Time
+}
+
+// sessionCache remembers store answers for ttl so that authenticated requests
+// don't each cost a database round trip.
+type sessionCache struct {
+	mu      sync.Mutex
+	store   SessionStore
+	ttl     time.Duration
+	entries map[uuid.UUID]sessionEntry
+}
+
+// active reports whether the session named by sid may be used. Tokens issued
+// before sessions were tracked carry no sid and are accepted until they expire,
+// except impersonation tokens, which must always be revocable.
+func (c *sessionCache) active(ctx context.Context, sid string, impersonated bool) (bool, error) {
+	c.mu.Lock()
+	store, ttl := c.store, c.ttl
+	c.mu.Unlock()
+	if store == nil {
+		return true, nil
+	}
+	if sid == "" {
+		return !impersonated, nil
+	}
+	id, err := uuid.Parse(sid)
+	if err != nil {
+		return false, nil
+	}
+	now := time.Now()
+	c.mu.Lock()
+	e, ok := c.entries[id]
+	c.mu.Unlock()
+	if ok && now.Before(e.expires) {
+		return e.active, nil
+	}
+	active, err := store.Touch(ctx, id)
+	if err != nil {
+		return false, err
+	}
+	c.mu.Lock()
+	if len(c.entries) > maxCachedSessions {
+		c.prune(now)
+	}
+	c.entries[id] = sessionEntry{active: active, expires: now.Add(ttl)}
+	c.mu.Unlock()
+	return active, nil
+}
+
+// maxCachedSessions triggers a sweep of stale entries; it is not a hard cap.
+const maxCachedSessions = 10000
+
+func (c *sessionCache) prune(now time.Time) {
+	for id, e := range c.entries {
+		if !now.Before(e.expires) {
+			delete(c.entries, id)
+		}
+	}
+}
@@ -0,0 +1,102 @@
+package __mocks__
+
+import (
+	"context"
+
+	"github.com/divijg19/physiolink/backend/internal/service"
+	"github.com/google/uuid"
+)
+
+// AdminServiceMock records the last action an admin performed.
+type AdminServiceMock struct {
+	Err         error
+	Users       service.AdminUserList
+	Target      service.AdminUser
+	Fingerprint string
+	Events      []service.AuditEvent
+	NewKey      service.NewAPIKey
+	Keys        []service.APIKey
+	Submissions []service.CredentialSubmission
+	Reviewed    service.CredentialSubmission
+	Queue       []service.ModeratedReview
+	Moderated   service.ModeratedReview
+
+	ActorID  uuid.UUID
+	UserID   uuid.UUID
+	Disabled bool
+	Role     string
+	Reason   string
+	ClinicID uuid.UUID
+	Scopes   []string
+	Status   string
+	Approved bool
+	Notes    string
+	Reported bool
+	ReviewID uuid.UUID
+	Decision string
+}
+
+func (m *AdminServiceMock) ListUsers(ctx context.Context, actorID uuid.UUID, q service.AdminUserQuery) (service.AdminUserList, error) {
+	m.ActorID = actorID
+	return m.Users, m.Err
+}
+
+func (m *AdminServiceMock) SetDisabled(ctx context.Context, actorID, userID uuid.UUID, disabled bool) error {
+	m.ActorID, m.UserID, m.Disabled = actorID, userID, disabled
+	return m.Err
+}
+
+func (m *AdminServiceMock) ChangeRole(ctx context.Context, actorID, userID uuid.UUID, role string) error {
+	m.ActorID, m.UserID, m.Role = actorID, userID, role
+	return m.Err
+}
+
+func (m *AdminServiceMock) ForcePasswordReset(ctx context.Context, actorID, userID uuid.UUID) (string, error) {
+	m.ActorID, m.UserID = actorID, userID
+	return m.Fingerprint, m.Err
+}
+
+func (m *AdminServiceMock) Impersonate(ctx context.Context, actorID, userID uuid.UUID, reason string) (service.AdminUser, error) {
+	m.ActorID, m.UserID, m.Reason = actorID, userID, reason
+	return m.Target, m.Err
+}
+
+func (m *AdminServiceMock) AuditLog(ctx context.Context, actorID uuid.UUID, target uuid.NullUUID, limit int) ([]service.AuditEvent, error) {
+	m.ActorID = actorID
+	return m.Events, m.Err
+}
+
+func (m *AdminServiceMock) CreateAPIKey(ctx context.Context, actorID, clinicID uuid.UUID, name string, scopes []string) (service.NewAPIKey, error) {
+	m.ActorID, m.ClinicID, m.Scopes = actorID, clinicID, scopes
+	return m.NewKey, m.Err
+}
+
+func (m *AdminServiceMock) ListAPIKeys(ctx context.Context, actorID, clinicID uuid.UUID) ([]service.APIKey, error) {
+	m.ActorID, m.ClinicID = actorID, clinicID
+	return m.Keys, m.Err
+}
+
+func (m *AdminServiceMock) RevokeAPIKey(ctx context.Context, actorID, clinicID, keyID uuid.UUID) error {
+	m.ActorID, m.ClinicID = actorID, clinicID
+	return m.Err
+}
+
+func (m *AdminServiceMock) ListCredentialSubmissions(ctx context.Context, actorID uuid.UUID, status string, limit int) ([]service.CredentialSubmission, error) {
+	m.ActorID, m.Status = actorID, status
+	return m.Submissions, m.Err
+}
+
+func (m *AdminServiceMock) ReviewCredentials(ctx context.Context, actorID, submissionID uuid.UUID, approve bool, notes string) (service.CredentialSubmission, error) {
+	m.ActorID, m.Approved, m.Notes = actorID, approve, notes
+	return m.Reviewed, m.Err
+}
+
+func (m *AdminServiceMock) ListReviewModerationQueue(ctx context.Context, actorID uuid.UUID, status string, reported bool, limit int) ([]service.ModeratedReview, error) {
+	m.ActorID, m.Status, m.Reported = actorID, status, reported
+	return m.Queue, m.Err
+}
+
+func (m *AdminServiceMock) ModerateReview(ctx context.Context, actorID, reviewID uuid.UUID, decision, reason string) (service.ModeratedReview, error) {
+	m.ActorID, m.ReviewID, m.Decision, m.Reason = actorID, reviewID, decision, reason
+	return m.Moderated, m.Err
+}
@@ -15,6 +15,9 @@ type AppointmentServiceMock struct {
 	BookErr    error
 	ListResp   []service.AppointmentBrief
 	ListErr    error
+	PageResp   service.AppointmentPage
+	Cursor     string
+	Limit      int
 	UpdateResp service.AppointmentBrief
 	UpdateErr  error
 }
@@ -38,3 +41,8 @@ func (m *AppointmentServiceMock) ListMyAppointments(ctx context.Context, userID
 func (m *AppointmentServiceMock) UpdateAppointmentStatus(ctx context.Context, appointmentID, therapistID uuid.UUID, status string) (service.AppointmentBrief, error) {
 	return m.UpdateResp, m.UpdateErr
 }
+
+func (m *AppointmentServiceMock) ListMyAppointmentsPage(ctx context.Context, userID uuid.UUID, role, cursor string, limit int) (service.AppointmentPage, error) {
+	m.Cursor, m.Limit = cursor, limit
+	return m.PageResp, m.ListErr
+}
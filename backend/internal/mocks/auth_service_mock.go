@@ -17,6 +17,7 @@ type AuthServiceMock struct {
 
 var ErrInvalidCredentials = errors.New("invalid credentials")
 var ErrUserExists = errors.New("user already exists")
+var ErrInvalidResetToken = errors.New("invalid or expired reset token")
 
 func NewAuthServiceMock() *AuthServiceMock {
 	return &AuthServiceMock{Users: make(map[string]struct {
@@ -46,3 +47,14 @@ func (m *AuthServiceMock) Authenticate(ctx context.Context, email, password stri
 	}
 	return u.ID, u.Role, nil
 }
+
+func (m *AuthServiceMock) ResetPassword(ctx context.Context, userID uuid.UUID, fingerprint, password string) error {
+	for email, u := range m.Users {
+		if u.ID == userID {
+			u.Hash = password
+			m.Users[email] = u
+			return nil
+		}
+	}
+	return ErrInvalidResetToken
+}
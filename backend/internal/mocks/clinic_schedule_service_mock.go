@@ -0,0 +1,52 @@
+package __mocks__
+
+import (
+	"context"
+	"time"
+
+	"github.com/divijg19/physiolink/backend/internal/service"
+	"github.com/google/uuid"
+)
+
+// ClinicScheduleServiceMock records the clinic each call was scoped to.
+type ClinicScheduleServiceMock struct {
+	Err          error
+	Appts        []service.ClinicAppointment
+	Slots        []service.ClinicSlot
+	ClinicID     uuid.UUID
+	TherapistID  uuid.UUID
+	From, To     time.Time
+	CreatedSlots []struct{ StartTs, EndTs string }
+}
+
+func (m *ClinicScheduleServiceMock) Appointments(ctx context.Context, clinicID uuid.UUID, from, to time.Time) ([]service.ClinicAppointment, error) {
+	m.ClinicID, m.From, m.To = clinicID, from, to
+	return m.Appts, m.Err
+}
+
+func (m *ClinicScheduleServiceMock) OpenSlots(ctx context.Context, clinicID uuid.UUID, from, to time.Time) ([]service.ClinicSlot, error) {
+	m.ClinicID, m.From, m.To = clinicID, from, to
+	return m.Slots, m.Err
+}
+
+func (m *ClinicScheduleServiceMock) CreateSlots(ctx context.Context, clinicID, therapistID uuid.UUID, slots []struct{ StartTs, EndTs string }) error {
+	m.ClinicID, m.TherapistID, m.CreatedSlots = clinicID, therapistID, slots
+	return m.Err
+}
+
+// APIKeyStoreMock accepts the keys in Keys.
+type APIKeyStoreMock struct {
+	Keys map[string]struct {
+		ID       uuid.UUID
+		ClinicID uuid.UUID
+		Scopes   []string
+	}
+}
+
+func (m *APIKeyStoreMock) AuthenticateAPIKey(ctx context.Context, key string) (uuid.UUID, uuid.UUID, []string, error) {
+	k, ok := m.Keys[key]
+	if !ok {
+		return uuid.Nil, uuid.Nil, nil, service.ErrInvalidAPIKey
+	}
+	return k.ID, k.ClinicID, k.Scopes, nil
+}
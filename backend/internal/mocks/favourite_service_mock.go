@@ -0,0 +1,73 @@
+package __mocks__
+
+import (
+	"context"
+	"time"
+
+	"github.com/divijg19/physiolink/backend/internal/openapi"
+	"github.com/divijg19/physiolink/backend/internal/service"
+	"github.com/google/uuid"
+)
+
+// FavouriteServiceMock keeps favourites and views in memory.
+type FavouriteServiceMock struct {
+	Err     error
+	Starred map[uuid.UUID]bool
+	Viewed  []uuid.UUID
+
+	PatientID uuid.UUID
+}
+
+func NewFavouriteServiceMock() *FavouriteServiceMock {
+	return &FavouriteServiceMock{Starred: map[uuid.UUID]bool{}}
+}
+
+func (m *FavouriteServiceMock) AddFavourite(ctx context.Context, patientID, therapistID uuid.UUID) (time.Time, error) {
+	m.PatientID = patientID
+	if m.Err != nil {
+		return time.Time{}, m.Err
+	}
+	m.Starred[therapistID] = true
+	return time.Now().UTC(), nil
+}
+
+func (m *FavouriteServiceMock) RemoveFavourite(ctx context.Context, patientID, therapistID uuid.UUID) error {
+	m.PatientID = patientID
+	if m.Err != nil {
+		return m.Err
+	}
+	delete(m.Starred, therapistID)
+	return nil
+}
+
+func (m *FavouriteServiceMock) ListFavourites(ctx context.Context, patientID uuid.UUID) ([]service.SavedTherapist, error) {
+	m.PatientID = patientID
+	out := []service.SavedTherapist{}
+	for id := range m.Starred {
+		out = append(out, service.SavedTherapist{ID: id.String(), Profile: openapi.Profile{}})
+	}
+	return out, m.Err
+}
+
+func (m *FavouriteServiceMock) FavouriteIDs(ctx context.Context, patientID uuid.UUID, therapistIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	set := map[uuid.UUID]bool{}
+	for _, id := range therapistIDs {
+		if m.Starred[id] {
+			set[id] = true
+		}
+	}
+	return set, m.Err
+}
+
+func (m *FavouriteServiceMock) RecordView(ctx context.Context, userID, therapistID uuid.UUID) error {
+	m.Viewed = append(m.Viewed, therapistID)
+	return m.Err
+}
+
+func (m *FavouriteServiceMock) RecentlyViewed(ctx context.Context, userID uuid.UUID) ([]service.SavedTherapist, error) {
+	out := []service.SavedTherapist{}
+	for i := len(m.Viewed) - 1; i >= 0; i-- {
+		out = append(out, service.SavedTherapist{ID: m.Viewed[i].String(), Profile: openapi.Profile{}})
+	}
+	return out, m.Err
+}
@@ -0,0 +1,65 @@
+package __mocks__
+
+import (
+	"context"
+	"time"
+
+	"github.com/divijg19/physiolink/backend/internal/service"
+	"github.com/google/uuid"
+)
+
+type MFAServiceMock struct {
+	Req           service.MFARequirement
+	ReqErr        error
+	StatusResp    service.MFAStatus
+	EnrollResp    service.MFAEnrollment
+	EnrollErr     error
+	RecoveryCodes []string
+	ConfirmErr    error
+	VerifyErr     error
+	DisableErr    error
+
+	// Challenges maps issued challenge ids to whether they were spent.
+	Challenges map[uuid.UUID]bool
+}
+
+func (m *MFAServiceMock) Requirement(ctx context.Context, userID uuid.UUID, role string) (service.MFARequirement, error) {
+	return m.Req, m.ReqErr
+}
+
+func (m *MFAServiceMock) Status(ctx context.Context, userID uuid.UUID, role string) (service.MFAStatus, error) {
+	return m.StatusResp, nil
+}
+
+func (m *MFAServiceMock) BeginEnrollment(ctx context.Context, userID uuid.UUID) (service.MFAEnrollment, error) {
+	return m.EnrollResp, m.EnrollErr
+}
+
+func (m *MFAServiceMock) ConfirmEnrollment(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	return m.RecoveryCodes, m.ConfirmErr
+}
+
+func (m *MFAServiceMock) Verify(ctx context.Context, userID uuid.UUID, code string) error {
+	return m.VerifyErr
+}
+
+func (m *MFAServiceMock) IssueChallenge(ctx context.Context, userID uuid.UUID, expiresAt time.Time) (uuid.UUID, error) {
+	if m.Challenges == nil {
+		m.Challenges = map[uuid.UUID]bool{}
+	}
+	id := uuid.New()
+	m.Challenges[id] = false
+	return id, nil
+}
+
+func (m *MFAServiceMock) ConsumeChallenge(ctx context.Context, challengeID, userID uuid.UUID) error {
+	if used, ok := m.Challenges[challengeID]; !ok || used {
+		return service.ErrMFAChallengeUsed
+	}
+	m.Challenges[challengeID] = true
+	return nil
+}
+
+func (m *MFAServiceMock) Disable(ctx context.Context, userID uuid.UUID, role, code string) error {
+	return m.DisableErr
+}
@@ -0,0 +1,37 @@
+package __mocks__
+
+import (
+	"context"
+
+	"github.com/divijg19/physiolink/backend/internal/service"
+	"github.com/google/uuid"
+)
+
+// OIDCServiceMock records the identity it was asked to sign in.
+type OIDCServiceMock struct {
+	UserID   uuid.UUID
+	Role     string
+	Err      error
+	Identity service.ExternalIdentity
+	AskRole  string
+	LinkErr  error
+	LinkedTo uuid.UUID
+}
+
+func (m *OIDCServiceMock) SignIn(ctx context.Context, ident service.ExternalIdentity, role string) (uuid.UUID, string, error) {
+	m.Identity = ident
+	m.AskRole = role
+	if m.Err != nil {
+		return uuid.Nil, "", m.Err
+	}
+	if m.Role != "" {
+		role = m.Role
+	}
+	return m.UserID, role, nil
+}
+
+func (m *OIDCServiceMock) LinkIdentity(ctx context.Context, userID uuid.UUID, ident service.ExternalIdentity) error {
+	m.Identity = ident
+	m.LinkedTo = userID
+	return m.LinkErr
+}
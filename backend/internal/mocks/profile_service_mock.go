@@ -3,6 +3,7 @@ package __mocks__
 import (
 	"context"
 
+	"github.com/divijg19/physiolink/backend/internal/openapi"
 	"github.com/divijg19/physiolink/backend/internal/service"
 	"github.com/google/uuid"
 )
@@ -14,28 +15,24 @@ func (m *ProfileServiceMock) UpsertProfile(ctx context.Context, userID uuid.UUID
 	return userID, nil
 }
 
-func (m *ProfileServiceMock) GetProfile(ctx context.Context, userID uuid.UUID) (map[string]interface{}, error) {
-	return map[string]interface{}{
-		"id":         userID.String(),
-		"firstName":  "",
-		"lastName":   "",
-		"bio":        "",
-		"specialty":  "",
-		"rating":     0,
-		"user":       map[string]interface{}{"email": "", "role": ""},
-		"isVerified": false,
-	}, nil
+func (m *ProfileServiceMock) GetProfile(ctx context.Context, userID uuid.UUID) (openapi.Profile, error) {
+	return emptyProfile(userID), nil
 }
 
-func (m *ProfileServiceMock) CreateEmptyProfile(ctx context.Context, userID uuid.UUID) (map[string]interface{}, error) {
-	return map[string]interface{}{
-		"id":         userID.String(),
-		"firstName":  "",
-		"lastName":   "",
-		"bio":        "",
-		"specialty":  "",
-		"rating":     0,
-		"user":       map[string]interface{}{"email": "", "role": ""},
-		"isVerified": false,
-	}, nil
+func (m *ProfileServiceMock) CreateEmptyProfile(ctx context.Context, userID uuid.UUID) (openapi.Profile, error) {
+	return emptyProfile(userID), nil
+}
+
+func emptyProfile(userID uuid.UUID) openapi.Profile {
+	id, empty, verified, rating := userID.String(), "", false, 0.0
+	return openapi.Profile{
+		Id:         &id,
+		FirstName:  &empty,
+		LastName:   &empty,
+		Bio:        &empty,
+		Specialty:  &empty,
+		Rating:     &rating,
+		User:       &openapi.User{Email: &empty, Role: &empty},
+		IsVerified: &verified,
+	}
 }
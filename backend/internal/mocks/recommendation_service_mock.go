@@ -0,0 +1,23 @@
+package __mocks__
+
+import (
+	"context"
+
+	"github.com/divijg19/physiolink/backend/internal/openapi"
+	"github.com/divijg19/physiolink/backend/internal/service"
+	"github.com/google/uuid"
+)
+
+// RecommendationServiceMock records the last request.
+type RecommendationServiceMock struct {
+	Resp []openapi.Recommendation
+	Err  error
+
+	PatientID uuid.UUID
+	Params    service.RecommendationParams
+}
+
+func (m *RecommendationServiceMock) Recommend(ctx context.Context, patientID uuid.UUID, p service.RecommendationParams) ([]openapi.Recommendation, error) {
+	m.PatientID, m.Params = patientID, p
+	return m.Resp, m.Err
+}
@@ -4,19 +4,64 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/openapi"
+	"github.com/divijg19/physiolink/backend/internal/service"
 )
 
 type ReviewServiceMock struct {
-	CreateResp map[string]interface{}
+	CreateResp openapi.Review
 	CreateErr  error
-	ListResp   []map[string]interface{}
+	Created    service.NewReview
+	ListResp   []openapi.Review
 	ListErr    error
+	PageResp   service.ReviewPage
+	Cursor     string
+	Limit      int
+	UpdateResp openapi.Review
+	UpdateErr  error
+	Updated    service.ReviewEdit
+	DeleteErr  error
+	Deleted    uuid.UUID
+	ReportErr  error
+	Reported   uuid.UUID
+	Reason     string
+	ReplyResp  openapi.ReviewReply
+	ReplyErr   error
+	RepliedTo  uuid.UUID
+	ReplyBody  string
 }
 
-func (m *ReviewServiceMock) CreateReview(ctx context.Context, patientID, therapistID uuid.UUID, rating int, comment string) (map[string]interface{}, error) {
+func (m *ReviewServiceMock) CreateReview(ctx context.Context, patientID uuid.UUID, in service.NewReview) (openapi.Review, error) {
+	m.Created = in
 	return m.CreateResp, m.CreateErr
 }
 
-func (m *ReviewServiceMock) GetReviewsForTherapist(ctx context.Context, therapistID uuid.UUID) ([]map[string]interface{}, error) {
+func (m *ReviewServiceMock) GetReviewsForTherapist(ctx context.Context, therapistID uuid.UUID) ([]openapi.Review, error) {
 	return m.ListResp, m.ListErr
 }
+
+func (m *ReviewServiceMock) ListReviewsForTherapist(ctx context.Context, therapistID uuid.UUID, cursor string, limit int) (service.ReviewPage, error) {
+	m.Cursor, m.Limit = cursor, limit
+	return m.PageResp, m.ListErr
+}
+
+func (m *ReviewServiceMock) UpdateReview(ctx context.Context, patientID, reviewID uuid.UUID, in service.ReviewEdit) (openapi.Review, error) {
+	m.Updated = in
+	return m.UpdateResp, m.UpdateErr
+}
+
+func (m *ReviewServiceMock) DeleteReview(ctx context.Context, patientID, reviewID uuid.UUID) error {
+	m.Deleted = reviewID
+	return m.DeleteErr
+}
+
+func (m *ReviewServiceMock) ReportReview(ctx context.Context, reporterID, reviewID uuid.UUID, reason string) error {
+	m.Reported, m.Reason = reviewID, reason
+	return m.ReportErr
+}
+
+func (m *ReviewServiceMock) ReplyToReview(ctx context.Context, therapistID, reviewID uuid.UUID, body string) (openapi.ReviewReply, error) {
+	m.RepliedTo, m.ReplyBody = reviewID, body
+	return m.ReplyResp, m.ReplyErr
+}
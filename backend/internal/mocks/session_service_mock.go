@@ -0,0 +1,68 @@
+package __mocks__
+
+import (
+	"context"
+	"time"
+
+	"github.com/divijg19/physiolink/backend/internal/service"
+	"github.com/google/uuid"
+)
+
+// SessionServiceMock keeps sessions in memory.
+type SessionServiceMock struct {
+	Sessions map[uuid.UUID]service.Session
+	Owners   map[uuid.UUID]uuid.UUID
+}
+
+func NewSessionServiceMock() *SessionServiceMock {
+	return &SessionServiceMock{Sessions: map[uuid.UUID]service.Session{}, Owners: map[uuid.UUID]uuid.UUID{}}
+}
+
+func (m *SessionServiceMock) Create(ctx context.Context, userID uuid.UUID, impersonator uuid.NullUUID, userAgent, ip string, expiresAt time.Time) (uuid.UUID, error) {
+	id := uuid.New()
+	s := service.Session{ID: id, UserAgent: userAgent, IPAddress: ip, ExpiresAt: expiresAt}
+	if impersonator.Valid {
+		s.ImpersonatedBy = &impersonator.UUID
+	}
+	m.Sessions[id] = s
+	m.Owners[id] = userID
+	return id, nil
+}
+
+func (m *SessionServiceMock) List(ctx context.Context, userID, current uuid.UUID) ([]service.Session, error) {
+	var out []service.Session
+	for id, s := range m.Sessions {
+		if m.Owners[id] == userID {
+			s.Current = id == current
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+func (m *SessionServiceMock) Revoke(ctx context.Context, userID, sessionID uuid.UUID) error {
+	if m.Owners[sessionID] != userID {
+		return service.ErrSessionNotFound
+	}
+	delete(m.Sessions, sessionID)
+	delete(m.Owners, sessionID)
+	return nil
+}
+
+func (m *SessionServiceMock) RevokeOthers(ctx context.Context, userID, keep uuid.UUID) ([]uuid.UUID, error) {
+	var out []uuid.UUID
+	for id, owner := range m.Owners {
+		if owner == userID && id != keep {
+			delete(m.Sessions, id)
+			delete(m.Owners, id)
+			out = append(out, id)
+		}
+	}
+	return out, nil
+}
+
+// Touch lets the mock back the auth middleware's revocation check.
+func (m *SessionServiceMock) Touch(ctx context.Context, sessionID uuid.UUID) (bool, error) {
+	_, ok := m.Sessions[sessionID]
+	return ok, nil
+}
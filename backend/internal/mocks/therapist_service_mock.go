@@ -3,30 +3,38 @@ package __mocks__
 import (
 	"context"
 
+	"github.com/divijg19/physiolink/backend/internal/openapi"
 	"github.com/divijg19/physiolink/backend/internal/service"
 )
 
 type TherapistServiceMock struct {
-	ListResp   service.TherapistListResult
-	DetailResp map[string]interface{}
+	ListResp   openapi.TherapistList
+	ListErr    error
+	DetailResp *openapi.Therapist
+	Params     service.TherapistQueryParams
 }
 
-func (m *TherapistServiceMock) GetAllTherapists(ctx context.Context, params service.TherapistQueryParams) (service.TherapistListResult, error) {
+func (m *TherapistServiceMock) GetAllTherapists(ctx context.Context, params service.TherapistQueryParams) (openapi.TherapistList, error) {
+	m.Params = params
+	if m.ListErr != nil {
+		return openapi.TherapistList{}, m.ListErr
+	}
 	return m.ListResp, nil
 }
 
-func (m *TherapistServiceMock) GetTherapistByID(ctx context.Context, id, date string) (map[string]interface{}, error) {
+func (m *TherapistServiceMock) GetTherapistByID(ctx context.Context, id, date string) (openapi.Therapist, error) {
 	if m.DetailResp != nil {
-		return m.DetailResp, nil
+		return *m.DetailResp, nil
 	}
-	return map[string]interface{}{"_id": id}, nil
+	return openapi.Therapist{Id: &id}, nil
 }
 
 // MakeTherapistListResult is a helper to create a minimal list result.
-func MakeTherapistListResult(ids []string) service.TherapistListResult {
-	out := make([]service.TherapistSummary, 0, len(ids))
+func MakeTherapistListResult(ids []string) openapi.TherapistList {
+	out := make([]openapi.Therapist, 0, len(ids))
 	for _, id := range ids {
-		out = append(out, service.TherapistSummary{ID: id, Email: "", Profile: map[string]interface{}{}, AvailableSlots: 0, ReviewCount: 0})
+		out = append(out, openapi.Therapist{Id: &id, Profile: &openapi.Profile{}})
 	}
-	return service.TherapistListResult{Data: out, Total: len(out), Page: 1, TotalPages: 1}
+	total, page := len(out), 1
+	return openapi.TherapistList{Data: &out, Total: &total, Page: &page, TotalPages: &page}
 }
@@ -0,0 +1,48 @@
+package __mocks__
+
+import (
+	"context"
+	"io"
+
+	"github.com/divijg19/physiolink/backend/internal/service"
+	"github.com/google/uuid"
+)
+
+// UploadServiceMock records the last upload and the bytes it was sent.
+type UploadServiceMock struct {
+	Err  error
+	Link string
+
+	OwnerID  uuid.UUID
+	Kind     string
+	Filename string
+	Body     []byte
+	Size     string
+}
+
+func (m *UploadServiceMock) UploadAvatar(ctx context.Context, ownerID uuid.UUID, filename string, body io.Reader) (service.Upload, error) {
+	return m.upload(service.UploadAvatar, ownerID, filename, body)
+}
+
+func (m *UploadServiceMock) UploadDocument(ctx context.Context, ownerID uuid.UUID, filename string, body io.Reader) (service.Upload, error) {
+	return m.upload(service.UploadDocument, ownerID, filename, body)
+}
+
+func (m *UploadServiceMock) DownloadURL(ctx context.Context, requesterID, id uuid.UUID, size string) (string, error) {
+	m.OwnerID, m.Size = requesterID, size
+	return m.Link, m.Err
+}
+
+func (m *UploadServiceMock) upload(kind string, ownerID uuid.UUID, filename string, body io.Reader) (service.Upload, error) {
+	m.OwnerID, m.Kind, m.Filename = ownerID, kind, filename
+	if m.Err != nil {
+		return service.Upload{}, m.Err
+	}
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return service.Upload{}, err
+	}
+	m.Body = data
+	id := uuid.New()
+	return service.Upload{ID: id, Kind: kind, Filename: filename, Size: int64(len(data)), URL: "/api/uploads/" + id.String()}, nil
+}
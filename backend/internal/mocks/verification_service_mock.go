@@ -0,0 +1,37 @@
+package __mocks__
+
+import (
+	"context"
+
+	"github.com/divijg19/physiolink/backend/internal/service"
+	"github.com/google/uuid"
+)
+
+// VerificationServiceMock records the last submission.
+type VerificationServiceMock struct {
+	Err         error
+	Submissions []service.CredentialSubmission
+
+	TherapistID uuid.UUID
+	Input       service.CredentialSubmissionInput
+}
+
+func (m *VerificationServiceMock) SubmitCredentials(ctx context.Context, therapistID uuid.UUID, in service.CredentialSubmissionInput) (service.CredentialSubmission, error) {
+	m.TherapistID, m.Input = therapistID, in
+	if m.Err != nil {
+		return service.CredentialSubmission{}, m.Err
+	}
+	return service.CredentialSubmission{
+		ID:            uuid.New(),
+		TherapistID:   therapistID,
+		LicenceNumber: in.LicenceNumber,
+		IssuingBody:   in.IssuingBody,
+		Documents:     in.Documents,
+		Status:        service.CredentialPending,
+	}, nil
+}
+
+func (m *VerificationServiceMock) ListMySubmissions(ctx context.Context, therapistID uuid.UUID) ([]service.CredentialSubmission, error) {
+	m.TherapistID = therapistID
+	return m.Submissions, m.Err
+}
@@ -0,0 +1,167 @@
+// Package oidctest runs a local OpenID Connect provider for tests. Its
+// authorize endpoint signs the configured user in without a login page.
+package oidctest
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/divijg19/physiolink/backend/internal/oidc"
+	"github.com/divijg19/physiolink/backend/internal/tokens"
+)
+
+const ClientID = "physiolink-test"
+
+// IdP is a stub identity provider. Set Subject, Email and EmailVerified
+// before starting a login to choose who signs in.
+type IdP struct {
+	*httptest.Server
+	Subject       string
+	Email         string
+	EmailVerified bool
+
+	keys  *tokens.KeySet
+	mu    sync.Mutex
+	codes map[string]grant
+}
+
+type grant struct {
+	redirectURI string
+	challenge   string
+	nonce       string
+	subject     string
+	email       string
+	verified    bool
+}
+
+// NewIdP starts a provider; stop it with Close.
+func NewIdP() *IdP {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	key, err := tokens.NewKey("stub-1", priv)
+	if err != nil {
+		panic(err)
+	}
+	keys := tokens.NewHMAC("")
+	if err := keys.Add(key); err != nil {
+		panic(err)
+	}
+	if err := keys.SetActive("stub-1"); err != nil {
+		panic(err)
+	}
+	idp := &IdP{
+		Subject:       "stub-user",
+		Email:         "stub@example.com",
+		EmailVerified: true,
+		keys:          keys,
+		codes:         map[string]grant{},
+	}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", idp.discovery)
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, idp.keys.JWKS())
+	})
+	mux.HandleFunc("/authorize", idp.authorize)
+	mux.HandleFunc("/token", idp.token)
+	idp.Server = httptest.NewServer(mux)
+	return idp
+}
+
+// Issuer is the value to configure as the provider's issuer.
+func (idp *IdP) Issuer() string { return idp.URL }
+
+func (idp *IdP) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]string{
+		"issuer":                 idp.URL,
+		"authorization_endpoint": idp.URL + "/authorize",
+		"token_endpoint":         idp.URL + "/token",
+		"jwks_uri":               idp.URL + "/jwks",
+	})
+}
+
+// authorize immediately redirects back to the client with a code, as if the
+// user had signed in and consented.
+func (idp *IdP) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("client_id") != ClientID || q.Get("code_challenge_method") != "S256" || q.Get("code_challenge") == "" {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+		return
+	}
+	code, err := oidc.RandomString()
+	if err != nil {
+		http.Error(w, "server_error", http.StatusInternalServerError)
+		return
+	}
+	idp.mu.Lock()
+	idp.codes[code] = grant{
+		redirectURI: q.Get("redirect_uri"),
+		challenge:   q.Get("code_challenge"),
+		nonce:       q.Get("nonce"),
+		subject:     idp.Subject,
+		email:       idp.Email,
+		verified:    idp.EmailVerified,
+	}
+	idp.mu.Unlock()
+	u, err := url.Parse(q.Get("redirect_uri"))
+	if err != nil {
+		http.Error(w, "invalid_request", http.StatusBadRequest)
+		return
+	}
+	back := u.Query()
+	back.Set("code", code)
+	back.Set("state", q.Get("state"))
+	u.RawQuery = back.Encode()
+	http.Redirect(w, r, u.String(), http.StatusFound)
+}
+
+func (idp *IdP) token(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+	idp.mu.Lock()
+	g, ok := idp.codes[r.PostForm.Get("code")]
+	delete(idp.codes, r.PostForm.Get("code"))
+	idp.mu.Unlock()
+	if !ok || r.PostForm.Get("grant_type") != "authorization_code" ||
+		r.PostForm.Get("redirect_uri") != g.redirectURI ||
+		oidc.Challenge(r.PostForm.Get("code_verifier")) != g.challenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+	idToken, err := idp.keys.Sign(jwt.MapClaims{
+		"iss":            idp.URL,
+		"aud":            ClientID,
+		"sub":            g.subject,
+		"email":          g.email,
+		"email_verified": g.verified,
+		"nonce":          g.nonce,
+		"iat":            time.Now().Unix(),
+		"exp":            time.Now().Add(5 * time.Minute).Unix(),
+	})
+	if err != nil {
+		writeJSON(w, http.StatusInternalServerError, map[string]string{"error": "server_error"})
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]string{
+		"access_token": "stub",
+		"token_type":   "Bearer",
+		"id_token":     idToken,
+	})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
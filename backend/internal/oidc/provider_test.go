@@ -0,0 +1,96 @@
+package oidc_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/divijg19/physiolink/backend/internal/config"
+	"github.com/divijg19/physiolink/backend/internal/oidc"
+	"github.com/divijg19/physiolink/backend/internal/oidc/oidctest"
+)
+
+const redirectURL = "http://app.test/auth/oidc/stub/callback"
+
+// authorize follows the provider's redirect and returns the code it issued.
+func authorize(t *testing.T, p *oidc.Provider, state, nonce, verifier string) string {
+	t.Helper()
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }}
+	resp, err := client.Get(p.AuthCodeURL(state, nonce, verifier))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("expected redirect, got %d", resp.StatusCode)
+	}
+	loc, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loc.Query().Get("state") != state {
+		t.Fatalf("state not echoed: %s", loc)
+	}
+	return loc.Query().Get("code")
+}
+
+func discover(t *testing.T, idp *oidctest.IdP) *oidc.Provider {
+	t.Helper()
+	p, err := oidc.Discover(context.Background(), nil, config.OIDCProvider{
+		Name:        "stub",
+		Issuer:      idp.Issuer(),
+		ClientID:    oidctest.ClientID,
+		RedirectURL: redirectURL,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return p
+}
+
+func TestExchange_ReturnsVerifiedIdentity(t *testing.T) {
+	idp := oidctest.NewIdP()
+	defer idp.Close()
+	idp.Subject, idp.Email = "abc123", "pt@clinic.test"
+	p := discover(t, idp)
+
+	code := authorize(t, p, "state-1", "nonce-1", "verifier-1")
+	id, err := p.Exchange(context.Background(), code, "verifier-1", "nonce-1")
+	if err != nil {
+		t.Fatalf("exchange: %v", err)
+	}
+	if id.Subject != "abc123" || id.Email != "pt@clinic.test" || !id.EmailVerified {
+		t.Fatalf("unexpected identity %+v", id)
+	}
+}
+
+func TestExchange_RejectsWrongVerifierAndNonce(t *testing.T) {
+	idp := oidctest.NewIdP()
+	defer idp.Close()
+	p := discover(t, idp)
+
+	code := authorize(t, p, "s", "n", "right-verifier")
+	if _, err := p.Exchange(context.Background(), code, "wrong-verifier", "n"); !errors.Is(err, oidc.ErrExchangeFailed) {
+		t.Fatalf("expected exchange failure, got %v", err)
+	}
+
+	code = authorize(t, p, "s", "n", "v")
+	if _, err := p.Exchange(context.Background(), code, "v", "other-nonce"); !errors.Is(err, oidc.ErrInvalidIDToken) {
+		t.Fatalf("expected nonce mismatch, got %v", err)
+	}
+}
+
+func TestDiscover_RejectsIssuerMismatch(t *testing.T) {
+	idp := oidctest.NewIdP()
+	defer idp.Close()
+	_, err := oidc.Discover(context.Background(), nil, config.OIDCProvider{
+		Name:     "stub",
+		Issuer:   idp.Issuer() + "/",
+		ClientID: oidctest.ClientID,
+	})
+	if err == nil {
+		t.Fatal("expected issuer mismatch")
+	}
+}
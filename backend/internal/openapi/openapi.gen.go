@@ -11,33 +11,173 @@ import (
 
 	"github.com/go-chi/chi/v5"
 	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 const (
+	ApiKeyScopes     = "apiKey.Scopes"
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for CredentialSubmissionStatus.
+const (
+	CredentialSubmissionStatusApproved CredentialSubmissionStatus = "approved"
+	CredentialSubmissionStatusPending  CredentialSubmissionStatus = "pending"
+	CredentialSubmissionStatusRejected CredentialSubmissionStatus = "rejected"
+)
+
+// Defines values for ModeratedReviewStatus.
+const (
+	ModeratedReviewStatusHidden    ModeratedReviewStatus = "hidden"
+	ModeratedReviewStatusPending   ModeratedReviewStatus = "pending"
+	ModeratedReviewStatusPublished ModeratedReviewStatus = "published"
+)
+
+// Defines values for ReminderKind.
+const (
+	ReminderKindAppointment ReminderKind = "appointment"
+	ReminderKindOpening     ReminderKind = "opening"
+	ReminderKindReply       ReminderKind = "reply"
+)
+
+// Defines values for ReviewStatus.
+const (
+	ReviewStatusHidden    ReviewStatus = "hidden"
+	ReviewStatusPending   ReviewStatus = "pending"
+	ReviewStatusPublished ReviewStatus = "published"
+)
+
+// Defines values for UploadKind.
+const (
+	Avatar   UploadKind = "avatar"
+	Document UploadKind = "document"
+)
+
+// Defines values for PostAdminClinicsClinicIdApiKeysJSONBodyScopes.
+const (
+	AppointmentsRead  PostAdminClinicsClinicIdApiKeysJSONBodyScopes = "appointments:read"
+	AvailabilityRead  PostAdminClinicsClinicIdApiKeysJSONBodyScopes = "availability:read"
+	AvailabilityWrite PostAdminClinicsClinicIdApiKeysJSONBodyScopes = "availability:write"
+)
+
+// Defines values for GetAdminReviewsParamsStatus.
+const (
+	GetAdminReviewsParamsStatusHidden    GetAdminReviewsParamsStatus = "hidden"
+	GetAdminReviewsParamsStatusPending   GetAdminReviewsParamsStatus = "pending"
+	GetAdminReviewsParamsStatusPublished GetAdminReviewsParamsStatus = "published"
+)
+
+// Defines values for PutAdminUsersIdRoleJSONBodyRole.
+const (
+	PutAdminUsersIdRoleJSONBodyRoleAdmin     PutAdminUsersIdRoleJSONBodyRole = "admin"
+	PutAdminUsersIdRoleJSONBodyRolePatient   PutAdminUsersIdRoleJSONBodyRole = "patient"
+	PutAdminUsersIdRoleJSONBodyRolePt        PutAdminUsersIdRoleJSONBodyRole = "pt"
+	PutAdminUsersIdRoleJSONBodyRoleTherapist PutAdminUsersIdRoleJSONBodyRole = "therapist"
+)
+
+// Defines values for GetAdminVerificationsParamsStatus.
+const (
+	GetAdminVerificationsParamsStatusApproved GetAdminVerificationsParamsStatus = "approved"
+	GetAdminVerificationsParamsStatusPending  GetAdminVerificationsParamsStatus = "pending"
+	GetAdminVerificationsParamsStatusRejected GetAdminVerificationsParamsStatus = "rejected"
+)
+
+// Defines values for GetAuthOidcProviderParamsRole.
+const (
+	GetAuthOidcProviderParamsRolePatient   GetAuthOidcProviderParamsRole = "patient"
+	GetAuthOidcProviderParamsRolePt        GetAuthOidcProviderParamsRole = "pt"
+	GetAuthOidcProviderParamsRoleTherapist GetAuthOidcProviderParamsRole = "therapist"
+)
+
+// Defines values for GetTherapistsParamsSort.
+const (
+	Availability GetTherapistsParamsSort = "availability"
+	Distance     GetTherapistsParamsSort = "distance"
+	Name         GetTherapistsParamsSort = "name"
+	Newest       GetTherapistsParamsSort = "newest"
+	Rating       GetTherapistsParamsSort = "rating"
+	Relevance    GetTherapistsParamsSort = "relevance"
+	Reviews      GetTherapistsParamsSort = "reviews"
+)
+
+// Defines values for GetUploadsIdParamsSize.
+const (
+	N128 GetUploadsIdParamsSize = "128"
+	N256 GetUploadsIdParamsSize = "256"
+	N64  GetUploadsIdParamsSize = "64"
+)
+
+// APIKey defines model for APIKey.
+type APIKey struct {
+	Id         *string    `json:"_id,omitempty"`
+	ClinicId   *string    `json:"clinicId,omitempty"`
+	CreatedAt  *time.Time `json:"createdAt,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	Name       *string    `json:"name,omitempty"`
+	Prefix     *string    `json:"prefix,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+	Scopes     *[]string  `json:"scopes,omitempty"`
+}
+
+// Address defines model for Address.
+type Address struct {
+	City       *string  `json:"city,omitempty"`
+	Country    *string  `json:"country,omitempty"`
+	Latitude   *float64 `json:"latitude,omitempty"`
+	Line1      *string  `json:"line1,omitempty"`
+	Line2      *string  `json:"line2,omitempty"`
+	Longitude  *float64 `json:"longitude,omitempty"`
+	PostalCode *string  `json:"postalCode,omitempty"`
+	Region     *string  `json:"region,omitempty"`
+}
+
+// AdminUser defines model for AdminUser.
+type AdminUser struct {
+	Id                    *string    `json:"_id,omitempty"`
+	CreatedAt             *time.Time `json:"createdAt,omitempty"`
+	Disabled              *bool      `json:"disabled,omitempty"`
+	DisabledAt            *time.Time `json:"disabledAt,omitempty"`
+	Email                 *string    `json:"email,omitempty"`
+	PasswordResetRequired *bool      `json:"passwordResetRequired,omitempty"`
+	Role                  *string    `json:"role,omitempty"`
+}
+
+// AdminUserList defines model for AdminUserList.
+type AdminUserList struct {
+	Data  *[]AdminUser `json:"data,omitempty"`
+	Limit *int         `json:"limit,omitempty"`
+	Page  *int         `json:"page,omitempty"`
+	Total *int         `json:"total,omitempty"`
+}
+
 // Appointment defines model for Appointment.
 type Appointment struct {
 	Id        *string    `json:"_id,omitempty"`
 	CreatedAt *time.Time `json:"createdAt,omitempty"`
 	EndTime   *time.Time `json:"endTime,omitempty"`
-	Patient   *struct {
-		Id      *string  `json:"_id,omitempty"`
-		Profile *Profile `json:"profile,omitempty"`
-	} `json:"patient,omitempty"`
-	Pt *struct {
-		Id      *string  `json:"_id,omitempty"`
-		Profile *Profile `json:"profile,omitempty"`
-	} `json:"pt,omitempty"`
+	Patient   *UserRef   `json:"patient,omitempty"`
+	Pt        *UserRef   `json:"pt,omitempty"`
 	StartTime *time.Time `json:"startTime,omitempty"`
 	Status    *string    `json:"status,omitempty"`
 	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
 }
 
+// AuditEvent defines model for AuditEvent.
+type AuditEvent struct {
+	Id           *string                 `json:"_id,omitempty"`
+	Action       *string                 `json:"action,omitempty"`
+	ActorId      *string                 `json:"actorId,omitempty"`
+	CreatedAt    *time.Time              `json:"createdAt,omitempty"`
+	Details      *map[string]interface{} `json:"details,omitempty"`
+	TargetUserId *string                 `json:"targetUserId,omitempty"`
+}
+
 // AuthResponse defines model for AuthResponse.
 type AuthResponse struct {
-	Token *string `json:"token,omitempty"`
+	MfaRequired      *bool   `json:"mfaRequired,omitempty"`
+	MfaSetupRequired *bool   `json:"mfaSetupRequired,omitempty"`
+	MfaToken         *string `json:"mfaToken,omitempty"`
+	Token            *string `json:"token,omitempty"`
 }
 
 // AvailabilityRequest defines model for AvailabilityRequest.
@@ -48,23 +188,225 @@ type AvailabilityRequest struct {
 	} `json:"slots,omitempty"`
 }
 
+// ClinicAppointment defines model for ClinicAppointment.
+type ClinicAppointment struct {
+	Id            *string    `json:"_id,omitempty"`
+	EndTime       *time.Time `json:"endTime,omitempty"`
+	PatientId     *string    `json:"patientId,omitempty"`
+	PatientName   *string    `json:"patientName,omitempty"`
+	StartTime     *time.Time `json:"startTime,omitempty"`
+	Status        *string    `json:"status,omitempty"`
+	TherapistId   *string    `json:"therapistId,omitempty"`
+	TherapistName *string    `json:"therapistName,omitempty"`
+}
+
+// ClinicSlot defines model for ClinicSlot.
+type ClinicSlot struct {
+	Id          *string    `json:"_id,omitempty"`
+	EndTime     *time.Time `json:"endTime,omitempty"`
+	StartTime   *time.Time `json:"startTime,omitempty"`
+	Status      *string    `json:"status,omitempty"`
+	TherapistId *string    `json:"therapistId,omitempty"`
+}
+
+// CredentialDocument defines model for CredentialDocument.
+type CredentialDocument struct {
+	Name *string `json:"name,omitempty"`
+
+	// UploadId A document uploaded with POST /uploads/documents
+	UploadId *openapi_types.UUID `json:"uploadId,omitempty"`
+
+	// Url Filled in from uploadId when one is given
+	Url *string `json:"url,omitempty"`
+}
+
+// CredentialSubmission defines model for CredentialSubmission.
+type CredentialSubmission struct {
+	Id            *string                     `json:"_id,omitempty"`
+	CreatedAt     *time.Time                  `json:"createdAt,omitempty"`
+	Documents     *[]CredentialDocument       `json:"documents,omitempty"`
+	IssuingBody   *string                     `json:"issuingBody,omitempty"`
+	LicenceNumber *string                     `json:"licenceNumber,omitempty"`
+	ReviewNotes   *string                     `json:"reviewNotes,omitempty"`
+	ReviewedAt    *time.Time                  `json:"reviewedAt,omitempty"`
+	ReviewedBy    *string                     `json:"reviewedBy,omitempty"`
+	Status        *CredentialSubmissionStatus `json:"status,omitempty"`
+
+	// TherapistEmail Admin queue only
+	TherapistEmail *string `json:"therapistEmail,omitempty"`
+	TherapistId    *string `json:"therapistId,omitempty"`
+
+	// TherapistName Admin queue only
+	TherapistName *string `json:"therapistName,omitempty"`
+}
+
+// CredentialSubmissionStatus defines model for CredentialSubmission.status.
+type CredentialSubmissionStatus string
+
+// JWKS defines model for JWKS.
+type JWKS struct {
+	Keys *[]struct {
+		Alg *string `json:"alg,omitempty"`
+		Crv *string `json:"crv,omitempty"`
+		E   *string `json:"e,omitempty"`
+		Kid *string `json:"kid,omitempty"`
+		Kty *string `json:"kty,omitempty"`
+		N   *string `json:"n,omitempty"`
+		Use *string `json:"use,omitempty"`
+		X   *string `json:"x,omitempty"`
+	} `json:"keys,omitempty"`
+}
+
+// LoginMFARequest defines model for LoginMFARequest.
+type LoginMFARequest struct {
+	Code     *string `json:"code,omitempty"`
+	MfaToken *string `json:"mfaToken,omitempty"`
+}
+
 // LoginRequest defines model for LoginRequest.
 type LoginRequest struct {
 	Email    *string `json:"email,omitempty"`
 	Password *string `json:"password,omitempty"`
 }
 
+// MFACodeRequest defines model for MFACodeRequest.
+type MFACodeRequest struct {
+	Code *string `json:"code,omitempty"`
+}
+
+// MFAConfirmResponse defines model for MFAConfirmResponse.
+type MFAConfirmResponse struct {
+	RecoveryCodes *[]string `json:"recoveryCodes,omitempty"`
+	Token         *string   `json:"token,omitempty"`
+}
+
+// MFAEnrollment defines model for MFAEnrollment.
+type MFAEnrollment struct {
+	OtpauthUrl *string `json:"otpauthUrl,omitempty"`
+	Secret     *string `json:"secret,omitempty"`
+}
+
+// MFAStatus defines model for MFAStatus.
+type MFAStatus struct {
+	Enabled                *bool `json:"enabled,omitempty"`
+	RecoveryCodesRemaining *int  `json:"recoveryCodesRemaining,omitempty"`
+	Required               *bool `json:"required,omitempty"`
+}
+
+// ModeratedReview defines model for ModeratedReview.
+type ModeratedReview struct {
+	Id            *string    `json:"_id,omitempty"`
+	AppointmentId *string    `json:"appointmentId,omitempty"`
+	Comment       *string    `json:"comment,omitempty"`
+	CreatedAt     *time.Time `json:"createdAt,omitempty"`
+	ModeratedAt   *time.Time `json:"moderatedAt,omitempty"`
+	ModeratedBy   *string    `json:"moderatedBy,omitempty"`
+
+	// ModerationReason Why the content filter held the review, or the admin's reason for the last decision
+	ModerationReason *string `json:"moderationReason,omitempty"`
+	PatientId        *string `json:"patientId,omitempty"`
+	Rating           *int    `json:"rating,omitempty"`
+
+	// Reports Open reports; resolved by any decision
+	Reports     *[]ReviewReport        `json:"reports,omitempty"`
+	Status      *ModeratedReviewStatus `json:"status,omitempty"`
+	TherapistId *string                `json:"therapistId,omitempty"`
+	UpdatedAt   *time.Time             `json:"updatedAt,omitempty"`
+}
+
+// ModeratedReviewStatus defines model for ModeratedReview.status.
+type ModeratedReviewStatus string
+
+// NewAPIKey defines model for NewAPIKey.
+type NewAPIKey struct {
+	Id         *string    `json:"_id,omitempty"`
+	ClinicId   *string    `json:"clinicId,omitempty"`
+	CreatedAt  *time.Time `json:"createdAt,omitempty"`
+	Key        *string    `json:"key,omitempty"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	Name       *string    `json:"name,omitempty"`
+	Prefix     *string    `json:"prefix,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+	Scopes     *[]string  `json:"scopes,omitempty"`
+}
+
+// OIDCProviders defines model for OIDCProviders.
+type OIDCProviders struct {
+	Providers *[]string `json:"providers,omitempty"`
+}
+
 // Profile defines model for Profile.
 type Profile struct {
-	Id              *string  `json:"_id,omitempty"`
-	Bio             *string  `json:"bio,omitempty"`
-	Credentials     *string  `json:"credentials,omitempty"`
-	FirstName       *string  `json:"firstName,omitempty"`
-	LastName        *string  `json:"lastName,omitempty"`
-	Location        *string  `json:"location,omitempty"`
+	Id          *string  `json:"_id,omitempty"`
+	Address     *Address `json:"address,omitempty"`
+	Age         *int     `json:"age,omitempty"`
+	Bio         *string  `json:"bio,omitempty"`
+	Condition   *string  `json:"condition,omitempty"`
+	Credentials *string  `json:"credentials,omitempty"`
+
+	// Currency ISO 4217 code, required with the first sessionPrice and returned upper-case
+	Currency  *string `json:"currency,omitempty"`
+	FirstName *string `json:"firstName,omitempty"`
+	Gender    *string `json:"gender,omitempty"`
+	Goals     *string `json:"goals,omitempty"`
+
+	// Insurers Insurers the therapist accepts; kept when omitted from an update
+	Insurers   *[]string `json:"insurers,omitempty"`
+	IsVerified *bool     `json:"isVerified,omitempty"`
+	Languages  *[]string `json:"languages,omitempty"`
+	LastName   *string   `json:"lastName,omitempty"`
+	Location   *string   `json:"location,omitempty"`
+
+	// ProfileImageUrl Set by POST /uploads/avatar; kept when omitted from an update
 	ProfileImageUrl *string  `json:"profileImageUrl,omitempty"`
-	Rating          *float32 `json:"rating,omitempty"`
-	Specialty       *string  `json:"specialty,omitempty"`
+	Rating          *float64 `json:"rating,omitempty"`
+
+	// SessionPrice Price of one session in major units of currency; kept when omitted from an update
+	SessionPrice *float64  `json:"sessionPrice,omitempty"`
+	Specialties  *[]string `json:"specialties,omitempty"`
+
+	// Specialty First of specialties; older clients send only this
+	Specialty         *string    `json:"specialty,omitempty"`
+	User              *User      `json:"user,omitempty"`
+	VerifiedAt        *time.Time `json:"verifiedAt,omitempty"`
+	YearsOfExperience *int       `json:"yearsOfExperience,omitempty"`
+}
+
+// RatingSummary Set on a single therapist
+type RatingSummary struct {
+	// Average Omitted without reviews
+	Average *float64 `json:"average,omitempty"`
+	Count   *int     `json:"count,omitempty"`
+
+	// Histogram Number of reviews with 1 to 5 stars, in that order
+	Histogram  *[]int             `json:"histogram,omitempty"`
+	SubRatings *SubRatingAverages `json:"subRatings,omitempty"`
+}
+
+// Recommendation defines model for Recommendation.
+type Recommendation struct {
+	Id                  *string        `json:"_id,omitempty"`
+	AvailableSlots      *[]Appointment `json:"availableSlots,omitempty"`
+	AvailableSlotsCount *int           `json:"availableSlotsCount,omitempty"`
+
+	// DistanceKm Set when searching near lat/lng
+	DistanceKm *float64 `json:"distanceKm,omitempty"`
+	Email      *string  `json:"email,omitempty"`
+
+	// IsFavourite Set for patients who have the therapist in their favourites
+	IsFavourite        *bool          `json:"isFavourite,omitempty"`
+	MatchedSpecialties *[]string      `json:"matchedSpecialties,omitempty"`
+	NextAvailableAt    *time.Time     `json:"nextAvailableAt,omitempty"`
+	Profile            *Profile       `json:"profile,omitempty"`
+	Rating             *float64       `json:"rating,omitempty"`
+	Ratings            *RatingSummary `json:"ratings,omitempty"`
+	ReviewCount        *int           `json:"reviewCount,omitempty"`
+
+	// Score Between 0 and 1; only comparable within one response
+	Score *float64 `json:"score,omitempty"`
+
+	// Snippet HTML-escaped profile excerpt with search matches wrapped in <mark>
+	Snippet *string `json:"snippet,omitempty"`
 }
 
 // RegisterRequest defines model for RegisterRequest.
@@ -76,32 +418,124 @@ type RegisterRequest struct {
 
 // Reminder defines model for Reminder.
 type Reminder struct {
-	Id       *string    `json:"_id,omitempty"`
-	Message  *string    `json:"message,omitempty"`
-	RemindAt *time.Time `json:"remindAt,omitempty"`
+	// Id The reminder's id, the open slot's for an opening, or the reply's for a reply
+	Id *string `json:"_id,omitempty"`
+
+	// Kind opening announces a slot published by a favourite therapist in the last 7 days; reply a therapist's reply to one of my reviews in the same period
+	Kind     *ReminderKind `json:"kind,omitempty"`
+	Message  *string       `json:"message,omitempty"`
+	RemindAt *time.Time    `json:"remindAt,omitempty"`
+
+	// ReviewId Set for replies
+	ReviewId *string `json:"reviewId,omitempty"`
+
+	// TherapistId Set for openings and replies
+	TherapistId *string `json:"therapistId,omitempty"`
+}
+
+// ReminderKind opening announces a slot published by a favourite therapist in the last 7 days; reply a therapist's reply to one of my reviews in the same period
+type ReminderKind string
+
+// ResetPasswordRequest defines model for ResetPasswordRequest.
+type ResetPasswordRequest struct {
+	Password *string `json:"password,omitempty"`
+	Token    *string `json:"token,omitempty"`
 }
 
 // Review defines model for Review.
 type Review struct {
-	Id        *string    `json:"_id,omitempty"`
-	Comment   *string    `json:"comment,omitempty"`
+	Id            *string      `json:"_id,omitempty"`
+	AppointmentId *string      `json:"appointmentId,omitempty"`
+	Comment       *string      `json:"comment,omitempty"`
+	CreatedAt     *time.Time   `json:"createdAt,omitempty"`
+	Patient       *UserRef     `json:"patient,omitempty"`
+	Rating        *int         `json:"rating,omitempty"`
+	Reply         *ReviewReply `json:"reply,omitempty"`
+
+	// Status Set for the author; pending reviews are held for moderation and not yet listed
+	Status     *ReviewStatus `json:"status,omitempty"`
+	SubRatings *SubRatings   `json:"subRatings,omitempty"`
+	Therapist  *UserRef      `json:"therapist,omitempty"`
+
+	// UpdatedAt When the author last edited the review
+	UpdatedAt *time.Time `json:"updatedAt,omitempty"`
+}
+
+// ReviewStatus Set for the author; pending reviews are held for moderation and not yet listed
+type ReviewStatus string
+
+// ReviewReply defines model for ReviewReply.
+type ReviewReply struct {
+	Body      *string    `json:"body,omitempty"`
 	CreatedAt *time.Time `json:"createdAt,omitempty"`
-	Patient   *struct {
-		Id      *string  `json:"_id,omitempty"`
-		Profile *Profile `json:"profile,omitempty"`
-	} `json:"patient,omitempty"`
-	Rating    *float32 `json:"rating,omitempty"`
-	Therapist *struct {
-		Id      *string  `json:"_id,omitempty"`
-		Profile *Profile `json:"profile,omitempty"`
-	} `json:"therapist,omitempty"`
+}
+
+// ReviewReport defines model for ReviewReport.
+type ReviewReport struct {
+	CreatedAt  *time.Time `json:"createdAt,omitempty"`
+	Reason     *string    `json:"reason,omitempty"`
+	ReporterId *string    `json:"reporterId,omitempty"`
 }
 
 // ReviewRequest defines model for ReviewRequest.
 type ReviewRequest struct {
-	Comment     *string  `json:"comment,omitempty"`
-	Rating      *float32 `json:"rating,omitempty"`
-	TherapistId *string  `json:"therapistId,omitempty"`
+	// AppointmentId The appointment being reviewed; when left out, the patient's latest unreviewed past appointment with therapistId
+	AppointmentId *string     `json:"appointmentId,omitempty"`
+	Comment       *string     `json:"comment,omitempty"`
+	Rating        int         `json:"rating"`
+	SubRatings    *SubRatings `json:"subRatings,omitempty"`
+	TherapistId   *string     `json:"therapistId,omitempty"`
+}
+
+// ReviewUpdate defines model for ReviewUpdate.
+type ReviewUpdate struct {
+	Comment    *string     `json:"comment,omitempty"`
+	Rating     int         `json:"rating"`
+	SubRatings *SubRatings `json:"subRatings,omitempty"`
+}
+
+// SavedTherapist defines model for SavedTherapist.
+type SavedTherapist struct {
+	Id           *string    `json:"_id,omitempty"`
+	Email        *string    `json:"email,omitempty"`
+	FavouritedAt *time.Time `json:"favouritedAt,omitempty"`
+	Profile      *Profile   `json:"profile,omitempty"`
+	ViewedAt     *time.Time `json:"viewedAt,omitempty"`
+}
+
+// SavedTherapistList defines model for SavedTherapistList.
+type SavedTherapistList struct {
+	Data *[]SavedTherapist `json:"data,omitempty"`
+}
+
+// Session defines model for Session.
+type Session struct {
+	Id        *string    `json:"_id,omitempty"`
+	CreatedAt *time.Time `json:"createdAt,omitempty"`
+	Current   *bool      `json:"current,omitempty"`
+	ExpiresAt *time.Time `json:"expiresAt,omitempty"`
+
+	// ImpersonatedBy Set when an admin is using the session on the user's behalf
+	ImpersonatedBy *openapi_types.UUID `json:"impersonatedBy,omitempty"`
+	IpAddress      *string             `json:"ipAddress,omitempty"`
+	LastSeenAt     *time.Time          `json:"lastSeenAt,omitempty"`
+	UserAgent      *string             `json:"userAgent,omitempty"`
+}
+
+// SubRatingAverages Average of each aspect over the reviews that rated it; aspects nobody rated are left out
+type SubRatingAverages struct {
+	Communication *float64 `json:"communication,omitempty"`
+	Effectiveness *float64 `json:"effectiveness,omitempty"`
+	Facility      *float64 `json:"facility,omitempty"`
+	Punctuality   *float64 `json:"punctuality,omitempty"`
+}
+
+// SubRatings Optional 1 to 5 ratings of aspects of the session, alongside the overall rating
+type SubRatings struct {
+	Communication *int `json:"communication,omitempty"`
+	Effectiveness *int `json:"effectiveness,omitempty"`
+	Facility      *int `json:"facility,omitempty"`
+	Punctuality   *int `json:"punctuality,omitempty"`
 }
 
 // Therapist defines model for Therapist.
@@ -109,10 +543,169 @@ type Therapist struct {
 	Id                  *string        `json:"_id,omitempty"`
 	AvailableSlots      *[]Appointment `json:"availableSlots,omitempty"`
 	AvailableSlotsCount *int           `json:"availableSlotsCount,omitempty"`
-	Email               *string        `json:"email,omitempty"`
-	Profile             *Profile       `json:"profile,omitempty"`
-	Rating              *float32       `json:"rating,omitempty"`
-	ReviewCount         *int           `json:"reviewCount,omitempty"`
+
+	// DistanceKm Set when searching near lat/lng
+	DistanceKm *float64 `json:"distanceKm,omitempty"`
+	Email      *string  `json:"email,omitempty"`
+
+	// IsFavourite Set for patients who have the therapist in their favourites
+	IsFavourite     *bool          `json:"isFavourite,omitempty"`
+	NextAvailableAt *time.Time     `json:"nextAvailableAt,omitempty"`
+	Profile         *Profile       `json:"profile,omitempty"`
+	Rating          *float64       `json:"rating,omitempty"`
+	Ratings         *RatingSummary `json:"ratings,omitempty"`
+	ReviewCount     *int           `json:"reviewCount,omitempty"`
+
+	// Snippet HTML-escaped profile excerpt with search matches wrapped in <mark>
+	Snippet *string `json:"snippet,omitempty"`
+}
+
+// TherapistList defines model for TherapistList.
+type TherapistList struct {
+	Data *[]Therapist `json:"data,omitempty"`
+
+	// NextCursor Pass as cursor for the next page; page, total and totalPages are omitted in cursor mode
+	NextCursor *string `json:"nextCursor,omitempty"`
+	Page       *int    `json:"page,omitempty"`
+	Total      *int    `json:"total,omitempty"`
+	TotalPages *int    `json:"totalPages,omitempty"`
+}
+
+// Upload defines model for Upload.
+type Upload struct {
+	Id          *openapi_types.UUID `json:"_id,omitempty"`
+	ContentType *string             `json:"contentType,omitempty"`
+	CreatedAt   *time.Time          `json:"createdAt,omitempty"`
+	Filename    *string             `json:"filename,omitempty"`
+	Kind        *UploadKind         `json:"kind,omitempty"`
+	Size        *int64              `json:"size,omitempty"`
+
+	// Thumbnails Thumbnail paths keyed by edge length (avatars only)
+	Thumbnails *map[string]string `json:"thumbnails,omitempty"`
+
+	// Url API path that redirects to a signed link
+	Url *string `json:"url,omitempty"`
+}
+
+// UploadKind defines model for Upload.kind.
+type UploadKind string
+
+// UploadForm defines model for UploadForm.
+type UploadForm struct {
+	File openapi_types.File `json:"file"`
+}
+
+// User defines model for User.
+type User struct {
+	Id    *string `json:"_id,omitempty"`
+	Email *string `json:"email,omitempty"`
+	Role  *string `json:"role,omitempty"`
+}
+
+// UserRef The other party to an appointment or review
+type UserRef struct {
+	Id      *string  `json:"_id,omitempty"`
+	Profile *Profile `json:"profile,omitempty"`
+}
+
+// GetAdminAuditParams defines parameters for GetAdminAudit.
+type GetAdminAuditParams struct {
+	UserId *string `form:"userId,omitempty" json:"userId,omitempty"`
+	Limit  *int    `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PostAdminClinicsClinicIdApiKeysJSONBody defines parameters for PostAdminClinicsClinicIdApiKeys.
+type PostAdminClinicsClinicIdApiKeysJSONBody struct {
+	Name   *string                                          `json:"name,omitempty"`
+	Scopes *[]PostAdminClinicsClinicIdApiKeysJSONBodyScopes `json:"scopes,omitempty"`
+}
+
+// PostAdminClinicsClinicIdApiKeysJSONBodyScopes defines parameters for PostAdminClinicsClinicIdApiKeys.
+type PostAdminClinicsClinicIdApiKeysJSONBodyScopes string
+
+// GetAdminReviewsParams defines parameters for GetAdminReviews.
+type GetAdminReviewsParams struct {
+	Status *GetAdminReviewsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+
+	// Reported Only reviews with open reports
+	Reported *bool `form:"reported,omitempty" json:"reported,omitempty"`
+	Limit    *int  `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetAdminReviewsParamsStatus defines parameters for GetAdminReviews.
+type GetAdminReviewsParamsStatus string
+
+// PostAdminReviewsIdApproveJSONBody defines parameters for PostAdminReviewsIdApprove.
+type PostAdminReviewsIdApproveJSONBody struct {
+	// Reason Optional note kept with the review
+	Reason *string `json:"reason,omitempty"`
+}
+
+// PostAdminReviewsIdHideJSONBody defines parameters for PostAdminReviewsIdHide.
+type PostAdminReviewsIdHideJSONBody struct {
+	// Reason Required
+	Reason *string `json:"reason,omitempty"`
+}
+
+// PostAdminReviewsIdRestoreJSONBody defines parameters for PostAdminReviewsIdRestore.
+type PostAdminReviewsIdRestoreJSONBody struct {
+	// Reason Optional note kept with the review
+	Reason *string `json:"reason,omitempty"`
+}
+
+// GetAdminUsersParams defines parameters for GetAdminUsers.
+type GetAdminUsersParams struct {
+	// Q Matches email
+	Q     *string `form:"q,omitempty" json:"q,omitempty"`
+	Role  *string `form:"role,omitempty" json:"role,omitempty"`
+	Page  *int    `form:"page,omitempty" json:"page,omitempty"`
+	Limit *int    `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// PostAdminUsersIdImpersonateJSONBody defines parameters for PostAdminUsersIdImpersonate.
+type PostAdminUsersIdImpersonateJSONBody struct {
+	Reason string `json:"reason"`
+}
+
+// PutAdminUsersIdRoleJSONBody defines parameters for PutAdminUsersIdRole.
+type PutAdminUsersIdRoleJSONBody struct {
+	Role *PutAdminUsersIdRoleJSONBodyRole `json:"role,omitempty"`
+}
+
+// PutAdminUsersIdRoleJSONBodyRole defines parameters for PutAdminUsersIdRole.
+type PutAdminUsersIdRoleJSONBodyRole string
+
+// GetAdminVerificationsParams defines parameters for GetAdminVerifications.
+type GetAdminVerificationsParams struct {
+	Status *GetAdminVerificationsParamsStatus `form:"status,omitempty" json:"status,omitempty"`
+	Limit  *int                               `form:"limit,omitempty" json:"limit,omitempty"`
+}
+
+// GetAdminVerificationsParamsStatus defines parameters for GetAdminVerifications.
+type GetAdminVerificationsParamsStatus string
+
+// PostAdminVerificationsIdApproveJSONBody defines parameters for PostAdminVerificationsIdApprove.
+type PostAdminVerificationsIdApproveJSONBody struct {
+	Notes *string `json:"notes,omitempty"`
+}
+
+// PostAdminVerificationsIdRejectJSONBody defines parameters for PostAdminVerificationsIdReject.
+type PostAdminVerificationsIdRejectJSONBody struct {
+	Notes *string `json:"notes,omitempty"`
+}
+
+// GetAppointmentsAvailabilityParams defines parameters for GetAppointmentsAvailability.
+type GetAppointmentsAvailabilityParams struct {
+	PtId string `form:"ptId" json:"ptId"`
+}
+
+// GetAppointmentsMeParams defines parameters for GetAppointmentsMe.
+type GetAppointmentsMeParams struct {
+	// Limit Page size (default 20, at most 100); setting limit or cursor returns a page object
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque nextCursor from the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
 }
 
 // PutAppointmentsIdStatusJSONBody defines parameters for PutAppointmentsIdStatus.
@@ -120,12 +713,157 @@ type PutAppointmentsIdStatusJSONBody struct {
 	Status *string `json:"status,omitempty"`
 }
 
+// GetAuthOidcProviderParams defines parameters for GetAuthOidcProvider.
+type GetAuthOidcProviderParams struct {
+	// Role Role for an account provisioned on first login
+	Role        *GetAuthOidcProviderParamsRole `form:"role,omitempty" json:"role,omitempty"`
+	AppRedirect *string                        `form:"app_redirect,omitempty" json:"app_redirect,omitempty"`
+}
+
+// GetAuthOidcProviderParamsRole defines parameters for GetAuthOidcProvider.
+type GetAuthOidcProviderParamsRole string
+
+// GetAuthOidcProviderCallbackParams defines parameters for GetAuthOidcProviderCallback.
+type GetAuthOidcProviderCallbackParams struct {
+	Code  *string `form:"code,omitempty" json:"code,omitempty"`
+	State *string `form:"state,omitempty" json:"state,omitempty"`
+}
+
+// GetIntegrationsAppointmentsParams defines parameters for GetIntegrationsAppointments.
+type GetIntegrationsAppointmentsParams struct {
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+	To   *time.Time `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// GetIntegrationsAvailabilityParams defines parameters for GetIntegrationsAvailability.
+type GetIntegrationsAvailabilityParams struct {
+	From *time.Time `form:"from,omitempty" json:"from,omitempty"`
+	To   *time.Time `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// PostIntegrationsAvailabilityJSONBody defines parameters for PostIntegrationsAvailability.
+type PostIntegrationsAvailabilityJSONBody struct {
+	Slots *[]struct {
+		EndTime   *time.Time `json:"endTime,omitempty"`
+		StartTime *time.Time `json:"startTime,omitempty"`
+	} `json:"slots,omitempty"`
+	TherapistId *string `json:"therapistId,omitempty"`
+}
+
+// PostProfileVerificationJSONBody defines parameters for PostProfileVerification.
+type PostProfileVerificationJSONBody struct {
+	Documents     *[]CredentialDocument `json:"documents,omitempty"`
+	IssuingBody   *string               `json:"issuingBody,omitempty"`
+	LicenceNumber *string               `json:"licenceNumber,omitempty"`
+}
+
+// GetReviewsIdParams defines parameters for GetReviewsId.
+type GetReviewsIdParams struct {
+	// Limit Page size (default 20, at most 100); setting limit or cursor returns a page object
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor Opaque nextCursor from the previous page
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// PostReviewsIdReplyJSONBody defines parameters for PostReviewsIdReply.
+type PostReviewsIdReplyJSONBody struct {
+	Body string `json:"body"`
+}
+
+// PostReviewsIdReportJSONBody defines parameters for PostReviewsIdReport.
+type PostReviewsIdReportJSONBody struct {
+	Reason string `json:"reason"`
+}
+
 // GetTherapistsParams defines parameters for GetTherapists.
 type GetTherapistsParams struct {
-	Page      *int    `form:"page,omitempty" json:"page,omitempty"`
+	// Q Full-text search over name, specialties, credentials and bio (web search syntax)
+	Q    *string `form:"q,omitempty" json:"q,omitempty"`
+	Page *int    `form:"page,omitempty" json:"page,omitempty"`
+
+	// Cursor Opaque nextCursor from the previous page
+	Cursor    *string `form:"cursor,omitempty" json:"cursor,omitempty"`
 	Specialty *string `form:"specialty,omitempty" json:"specialty,omitempty"`
+	Location  *string `form:"location,omitempty" json:"location,omitempty"`
+	Limit     *int    `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Sort Defaults to relevance when q is set, distance when lat/lng are, newest first otherwise. rating orders by the average rating smoothed toward the mean of all reviews, so therapists with few reviews don't outrank established ones
+	Sort *GetTherapistsParamsSort `form:"sort,omitempty" json:"sort,omitempty"`
+	Lat  *float64                 `form:"lat,omitempty" json:"lat,omitempty"`
+	Lng  *float64                 `form:"lng,omitempty" json:"lng,omitempty"`
+
+	// RadiusKm Only therapists within this distance of lat/lng (at most 500)
+	RadiusKm *float64 `form:"radiusKm,omitempty" json:"radiusKm,omitempty"`
+
+	// Available Only therapists with an upcoming open slot (on date, when given)
+	Available *bool `form:"available,omitempty" json:"available,omitempty"`
+
+	// Verified Only therapists whose credentials an admin approved
+	Verified *bool `form:"verified,omitempty" json:"verified,omitempty"`
+
+	// Insurer Only therapists who accept this insurer (case-insensitive)
+	Insurer *string `form:"insurer,omitempty" json:"insurer,omitempty"`
+
+	// Language Only therapists who speak this language (case-insensitive)
+	Language *string `form:"language,omitempty" json:"language,omitempty"`
+
+	// MinPrice Lowest session price in major units; drops therapists without a price
+	MinPrice *float64 `form:"minPrice,omitempty" json:"minPrice,omitempty"`
+
+	// MaxPrice Highest session price in major units; drops therapists without a price
+	MaxPrice *float64 `form:"maxPrice,omitempty" json:"maxPrice,omitempty"`
+
+	// Currency Only prices in this ISO 4217 currency
+	Currency *string             `form:"currency,omitempty" json:"currency,omitempty"`
+	Date     *openapi_types.Date `form:"date,omitempty" json:"date,omitempty"`
+}
+
+// GetTherapistsParamsSort defines parameters for GetTherapists.
+type GetTherapistsParamsSort string
+
+// GetTherapistsRecommendedParams defines parameters for GetTherapistsRecommended.
+type GetTherapistsRecommendedParams struct {
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Lat Overrides the location on my profile
+	Lat *float64 `form:"lat,omitempty" json:"lat,omitempty"`
+	Lng *float64 `form:"lng,omitempty" json:"lng,omitempty"`
+}
+
+// GetUploadsIdParams defines parameters for GetUploadsId.
+type GetUploadsIdParams struct {
+	// Size Thumbnail edge length, one of the upload's `thumbnails` keys
+	Size *GetUploadsIdParamsSize `form:"size,omitempty" json:"size,omitempty"`
 }
 
+// GetUploadsIdParamsSize defines parameters for GetUploadsId.
+type GetUploadsIdParamsSize string
+
+// PostAdminClinicsClinicIdApiKeysJSONRequestBody defines body for PostAdminClinicsClinicIdApiKeys for application/json ContentType.
+type PostAdminClinicsClinicIdApiKeysJSONRequestBody PostAdminClinicsClinicIdApiKeysJSONBody
+
+// PostAdminReviewsIdApproveJSONRequestBody defines body for PostAdminReviewsIdApprove for application/json ContentType.
+type PostAdminReviewsIdApproveJSONRequestBody PostAdminReviewsIdApproveJSONBody
+
+// PostAdminReviewsIdHideJSONRequestBody defines body for PostAdminReviewsIdHide for application/json ContentType.
+type PostAdminReviewsIdHideJSONRequestBody PostAdminReviewsIdHideJSONBody
+
+// PostAdminReviewsIdRestoreJSONRequestBody defines body for PostAdminReviewsIdRestore for application/json ContentType.
+type PostAdminReviewsIdRestoreJSONRequestBody PostAdminReviewsIdRestoreJSONBody
+
+// PostAdminUsersIdImpersonateJSONRequestBody defines body for PostAdminUsersIdImpersonate for application/json ContentType.
+type PostAdminUsersIdImpersonateJSONRequestBody PostAdminUsersIdImpersonateJSONBody
+
+// PutAdminUsersIdRoleJSONRequestBody defines body for PutAdminUsersIdRole for application/json ContentType.
+type PutAdminUsersIdRoleJSONRequestBody PutAdminUsersIdRoleJSONBody
+
+// PostAdminVerificationsIdApproveJSONRequestBody defines body for PostAdminVerificationsIdApprove for application/json ContentType.
+type PostAdminVerificationsIdApproveJSONRequestBody PostAdminVerificationsIdApproveJSONBody
+
+// PostAdminVerificationsIdRejectJSONRequestBody defines body for PostAdminVerificationsIdReject for application/json ContentType.
+type PostAdminVerificationsIdRejectJSONRequestBody PostAdminVerificationsIdRejectJSONBody
+
 // PostAppointmentsAvailabilityJSONRequestBody defines body for PostAppointmentsAvailability for application/json ContentType.
 type PostAppointmentsAvailabilityJSONRequestBody = AvailabilityRequest
 
@@ -135,17 +873,110 @@ type PutAppointmentsIdStatusJSONRequestBody PutAppointmentsIdStatusJSONBody
 // PostAuthLoginJSONRequestBody defines body for PostAuthLogin for application/json ContentType.
 type PostAuthLoginJSONRequestBody = LoginRequest
 
+// PostAuthLoginMfaJSONRequestBody defines body for PostAuthLoginMfa for application/json ContentType.
+type PostAuthLoginMfaJSONRequestBody = LoginMFARequest
+
+// PostAuthMfaConfirmJSONRequestBody defines body for PostAuthMfaConfirm for application/json ContentType.
+type PostAuthMfaConfirmJSONRequestBody = MFACodeRequest
+
+// PostAuthMfaDisableJSONRequestBody defines body for PostAuthMfaDisable for application/json ContentType.
+type PostAuthMfaDisableJSONRequestBody = MFACodeRequest
+
+// PostAuthPasswordResetJSONRequestBody defines body for PostAuthPasswordReset for application/json ContentType.
+type PostAuthPasswordResetJSONRequestBody = ResetPasswordRequest
+
 // PostAuthRegisterJSONRequestBody defines body for PostAuthRegister for application/json ContentType.
 type PostAuthRegisterJSONRequestBody = RegisterRequest
 
+// PostIntegrationsAvailabilityJSONRequestBody defines body for PostIntegrationsAvailability for application/json ContentType.
+type PostIntegrationsAvailabilityJSONRequestBody PostIntegrationsAvailabilityJSONBody
+
 // PostProfileJSONRequestBody defines body for PostProfile for application/json ContentType.
 type PostProfileJSONRequestBody = Profile
 
+// PutProfileMeJSONRequestBody defines body for PutProfileMe for application/json ContentType.
+type PutProfileMeJSONRequestBody = Profile
+
+// PostProfileVerificationJSONRequestBody defines body for PostProfileVerification for application/json ContentType.
+type PostProfileVerificationJSONRequestBody PostProfileVerificationJSONBody
+
 // PostReviewsJSONRequestBody defines body for PostReviews for application/json ContentType.
 type PostReviewsJSONRequestBody = ReviewRequest
 
+// PutReviewsIdJSONRequestBody defines body for PutReviewsId for application/json ContentType.
+type PutReviewsIdJSONRequestBody = ReviewUpdate
+
+// PostReviewsIdReplyJSONRequestBody defines body for PostReviewsIdReply for application/json ContentType.
+type PostReviewsIdReplyJSONRequestBody PostReviewsIdReplyJSONBody
+
+// PostReviewsIdReportJSONRequestBody defines body for PostReviewsIdReport for application/json ContentType.
+type PostReviewsIdReportJSONRequestBody PostReviewsIdReportJSONBody
+
+// PostUploadsAvatarMultipartRequestBody defines body for PostUploadsAvatar for multipart/form-data ContentType.
+type PostUploadsAvatarMultipartRequestBody = UploadForm
+
+// PostUploadsDocumentsMultipartRequestBody defines body for PostUploadsDocuments for multipart/form-data ContentType.
+type PostUploadsDocumentsMultipartRequestBody = UploadForm
+
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
+	// Public keys for verifying issued tokens
+	// (GET /.well-known/jwks.json)
+	GetWellKnownJwksJson(w http.ResponseWriter, r *http.Request)
+	// List audit events (admin)
+	// (GET /admin/audit)
+	GetAdminAudit(w http.ResponseWriter, r *http.Request, params GetAdminAuditParams)
+	// List a clinic's API keys (admin)
+	// (GET /admin/clinics/{clinicId}/api-keys)
+	GetAdminClinicsClinicIdApiKeys(w http.ResponseWriter, r *http.Request, clinicId string)
+	// Create a scoped API key for a clinic (admin)
+	// (POST /admin/clinics/{clinicId}/api-keys)
+	PostAdminClinicsClinicIdApiKeys(w http.ResponseWriter, r *http.Request, clinicId string)
+	// Revoke an API key (admin)
+	// (DELETE /admin/clinics/{clinicId}/api-keys/{keyId})
+	DeleteAdminClinicsClinicIdApiKeysKeyId(w http.ResponseWriter, r *http.Request, clinicId string, keyId string)
+	// Review moderation queue, oldest first (admin)
+	// (GET /admin/reviews)
+	GetAdminReviews(w http.ResponseWriter, r *http.Request, params GetAdminReviewsParams)
+	// Publish a pending review, or dismiss the reports on a published one (admin)
+	// (POST /admin/reviews/{id}/approve)
+	PostAdminReviewsIdApprove(w http.ResponseWriter, r *http.Request, id string)
+	// Hide a review from listings and ratings (admin)
+	// (POST /admin/reviews/{id}/hide)
+	PostAdminReviewsIdHide(w http.ResponseWriter, r *http.Request, id string)
+	// Publish a hidden review again (admin)
+	// (POST /admin/reviews/{id}/restore)
+	PostAdminReviewsIdRestore(w http.ResponseWriter, r *http.Request, id string)
+	// List and search users (admin)
+	// (GET /admin/users)
+	GetAdminUsers(w http.ResponseWriter, r *http.Request, params GetAdminUsersParams)
+	// Disable an account (admin)
+	// (POST /admin/users/{id}/disable)
+	PostAdminUsersIdDisable(w http.ResponseWriter, r *http.Request, id string)
+	// Re-enable an account (admin)
+	// (POST /admin/users/{id}/enable)
+	PostAdminUsersIdEnable(w http.ResponseWriter, r *http.Request, id string)
+	// Issue a short-lived session as the user (admin)
+	// (POST /admin/users/{id}/impersonate)
+	PostAdminUsersIdImpersonate(w http.ResponseWriter, r *http.Request, id string)
+	// Force a password reset (admin)
+	// (POST /admin/users/{id}/password-reset)
+	PostAdminUsersIdPasswordReset(w http.ResponseWriter, r *http.Request, id string)
+	// Change a user's role (admin)
+	// (PUT /admin/users/{id}/role)
+	PutAdminUsersIdRole(w http.ResponseWriter, r *http.Request, id string)
+	// Therapist verification queue, oldest first (admin)
+	// (GET /admin/verifications)
+	GetAdminVerifications(w http.ResponseWriter, r *http.Request, params GetAdminVerificationsParams)
+	// Approve a submission and mark the therapist verified (admin)
+	// (POST /admin/verifications/{id}/approve)
+	PostAdminVerificationsIdApprove(w http.ResponseWriter, r *http.Request, id string)
+	// Reject a submission with notes for the therapist (admin)
+	// (POST /admin/verifications/{id}/reject)
+	PostAdminVerificationsIdReject(w http.ResponseWriter, r *http.Request, id string)
+	// Get a PT's available slots (same as /appointments/availability/{ptId})
+	// (GET /appointments/availability)
+	GetAppointmentsAvailability(w http.ResponseWriter, r *http.Request, params GetAppointmentsAvailabilityParams)
 	// Create availability (PT only)
 	// (POST /appointments/availability)
 	PostAppointmentsAvailability(w http.ResponseWriter, r *http.Request)
@@ -154,7 +985,7 @@ type ServerInterface interface {
 	GetAppointmentsAvailabilityPtId(w http.ResponseWriter, r *http.Request, ptId string)
 	// Get my schedule (PT or patient)
 	// (GET /appointments/me)
-	GetAppointmentsMe(w http.ResponseWriter, r *http.Request)
+	GetAppointmentsMe(w http.ResponseWriter, r *http.Request, params GetAppointmentsMeParams)
 	// Book an available appointment
 	// (PUT /appointments/{id}/book)
 	PutAppointmentsIdBook(w http.ResponseWriter, r *http.Request, id string)
@@ -164,105 +995,459 @@ type ServerInterface interface {
 	// Login
 	// (POST /auth/login)
 	PostAuthLogin(w http.ResponseWriter, r *http.Request)
+	// Complete login with a TOTP or recovery code
+	// (POST /auth/login/mfa)
+	PostAuthLoginMfa(w http.ResponseWriter, r *http.Request)
+	// Current user's MFA status
+	// (GET /auth/mfa)
+	GetAuthMfa(w http.ResponseWriter, r *http.Request)
+	// Confirm TOTP enrollment with a first code
+	// (POST /auth/mfa/confirm)
+	PostAuthMfaConfirm(w http.ResponseWriter, r *http.Request)
+	// Disable TOTP
+	// (POST /auth/mfa/disable)
+	PostAuthMfaDisable(w http.ResponseWriter, r *http.Request)
+	// Start TOTP enrollment
+	// (POST /auth/mfa/enroll)
+	PostAuthMfaEnroll(w http.ResponseWriter, r *http.Request)
+	// Identity providers available for single sign-on
+	// (GET /auth/oidc/providers)
+	GetAuthOidcProviders(w http.ResponseWriter, r *http.Request)
+	// Start an OpenID Connect login (authorization code + PKCE)
+	// (GET /auth/oidc/{provider})
+	GetAuthOidcProvider(w http.ResponseWriter, r *http.Request, provider string, params GetAuthOidcProviderParams)
+	// OpenID Connect redirect URI
+	// (GET /auth/oidc/{provider}/callback)
+	GetAuthOidcProviderCallback(w http.ResponseWriter, r *http.Request, provider string, params GetAuthOidcProviderCallbackParams)
+	// Set a new password with an admin-issued reset token
+	// (POST /auth/password/reset)
+	PostAuthPasswordReset(w http.ResponseWriter, r *http.Request)
 	// Register a new user
 	// (POST /auth/register)
 	PostAuthRegister(w http.ResponseWriter, r *http.Request)
+	// List my favourite therapists (patient)
+	// (GET /favourites)
+	GetFavourites(w http.ResponseWriter, r *http.Request)
+	// Remove a therapist from my favourites (patient)
+	// (DELETE /favourites/{therapistId})
+	DeleteFavouritesTherapistId(w http.ResponseWriter, r *http.Request, therapistId string)
+	// Add a therapist to my favourites (patient)
+	// (PUT /favourites/{therapistId})
+	PutFavouritesTherapistId(w http.ResponseWriter, r *http.Request, therapistId string)
+	// Export the clinic's appointments (API key with appointments:read)
+	// (GET /integrations/appointments)
+	GetIntegrationsAppointments(w http.ResponseWriter, r *http.Request, params GetIntegrationsAppointmentsParams)
+	// List open slots of the clinic's therapists (API key with availability:read)
+	// (GET /integrations/availability)
+	GetIntegrationsAvailability(w http.ResponseWriter, r *http.Request, params GetIntegrationsAvailabilityParams)
+	// Publish slots for one of the clinic's therapists (API key with availability:write)
+	// (POST /integrations/availability)
+	PostIntegrationsAvailability(w http.ResponseWriter, r *http.Request)
 	// Create or update profile
 	// (POST /profile)
 	PostProfile(w http.ResponseWriter, r *http.Request)
 	// Current user's profile
 	// (GET /profile/me)
 	GetProfileMe(w http.ResponseWriter, r *http.Request)
+	// Create or update my profile (same as POST /profile)
+	// (PUT /profile/me)
+	PutProfileMe(w http.ResponseWriter, r *http.Request)
+	// List my credential submissions and their outcome (therapist)
+	// (GET /profile/verification)
+	GetProfileVerification(w http.ResponseWriter, r *http.Request)
+	// Submit licence details and documents for verification (therapist)
+	// (POST /profile/verification)
+	PostProfileVerification(w http.ResponseWriter, r *http.Request)
 	// Get my reminders (patient)
 	// (GET /reminders/me)
 	GetRemindersMe(w http.ResponseWriter, r *http.Request)
-	// Create a review
+	// Review a completed appointment (patient)
 	// (POST /reviews)
 	PostReviews(w http.ResponseWriter, r *http.Request)
+	// Withdraw my review (patient)
+	// (DELETE /reviews/{id})
+	DeleteReviewsId(w http.ResponseWriter, r *http.Request, id string)
 	// List reviews for therapist
-	// (GET /reviews/{therapistId})
-	GetReviewsTherapistId(w http.ResponseWriter, r *http.Request, therapistId string)
+	// (GET /reviews/{id})
+	GetReviewsId(w http.ResponseWriter, r *http.Request, id string, params GetReviewsIdParams)
+	// Edit my review (patient)
+	// (PUT /reviews/{id})
+	PutReviewsId(w http.ResponseWriter, r *http.Request, id string)
+	// Reply publicly to a review of me (therapist)
+	// (POST /reviews/{id}/reply)
+	PostReviewsIdReply(w http.ResponseWriter, r *http.Request, id string)
+	// Report a review for moderation
+	// (POST /reviews/{id}/report)
+	PostReviewsIdReport(w http.ResponseWriter, r *http.Request, id string)
+	// Sign out all my other devices
+	// (DELETE /sessions)
+	DeleteSessions(w http.ResponseWriter, r *http.Request)
+	// List my signed-in devices
+	// (GET /sessions)
+	GetSessions(w http.ResponseWriter, r *http.Request)
+	// Sign out one of my devices
+	// (DELETE /sessions/{id})
+	DeleteSessionsId(w http.ResponseWriter, r *http.Request, id string)
 	// List therapists (with filters)
 	// (GET /therapists)
 	GetTherapists(w http.ResponseWriter, r *http.Request, params GetTherapistsParams)
+	// List the therapists I viewed most recently
+	// (GET /therapists/recent)
+	GetTherapistsRecent(w http.ResponseWriter, r *http.Request)
+	// Recommend therapists for my condition and goals
+	// (GET /therapists/recommended)
+	GetTherapistsRecommended(w http.ResponseWriter, r *http.Request, params GetTherapistsRecommendedParams)
 	// Get therapist detail
 	// (GET /therapists/{id})
 	GetTherapistsId(w http.ResponseWriter, r *http.Request, id string)
+	// Upload a profile picture and make it the caller's profileImageUrl
+	// (POST /uploads/avatar)
+	PostUploadsAvatar(w http.ResponseWriter, r *http.Request)
+	// Upload a credential document (therapist)
+	// (POST /uploads/documents)
+	PostUploadsDocuments(w http.ResponseWriter, r *http.Request)
+	// Redirect to a signed, expiring download link
+	// (GET /uploads/{id})
+	GetUploadsId(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, params GetUploadsIdParams)
 }
 
 // Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
 
 type Unimplemented struct{}
 
-// Create availability (PT only)
-// (POST /appointments/availability)
-func (_ Unimplemented) PostAppointmentsAvailability(w http.ResponseWriter, r *http.Request) {
+// Public keys for verifying issued tokens
+// (GET /.well-known/jwks.json)
+func (_ Unimplemented) GetWellKnownJwksJson(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Get a specific PT's available slots
-// (GET /appointments/availability/{ptId})
-func (_ Unimplemented) GetAppointmentsAvailabilityPtId(w http.ResponseWriter, r *http.Request, ptId string) {
+// List audit events (admin)
+// (GET /admin/audit)
+func (_ Unimplemented) GetAdminAudit(w http.ResponseWriter, r *http.Request, params GetAdminAuditParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Get my schedule (PT or patient)
-// (GET /appointments/me)
-func (_ Unimplemented) GetAppointmentsMe(w http.ResponseWriter, r *http.Request) {
+// List a clinic's API keys (admin)
+// (GET /admin/clinics/{clinicId}/api-keys)
+func (_ Unimplemented) GetAdminClinicsClinicIdApiKeys(w http.ResponseWriter, r *http.Request, clinicId string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Book an available appointment
-// (PUT /appointments/{id}/book)
-func (_ Unimplemented) PutAppointmentsIdBook(w http.ResponseWriter, r *http.Request, id string) {
+// Create a scoped API key for a clinic (admin)
+// (POST /admin/clinics/{clinicId}/api-keys)
+func (_ Unimplemented) PostAdminClinicsClinicIdApiKeys(w http.ResponseWriter, r *http.Request, clinicId string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Update appointment status (PT)
-// (PUT /appointments/{id}/status)
-func (_ Unimplemented) PutAppointmentsIdStatus(w http.ResponseWriter, r *http.Request, id string) {
+// Revoke an API key (admin)
+// (DELETE /admin/clinics/{clinicId}/api-keys/{keyId})
+func (_ Unimplemented) DeleteAdminClinicsClinicIdApiKeysKeyId(w http.ResponseWriter, r *http.Request, clinicId string, keyId string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Login
-// (POST /auth/login)
-func (_ Unimplemented) PostAuthLogin(w http.ResponseWriter, r *http.Request) {
+// Review moderation queue, oldest first (admin)
+// (GET /admin/reviews)
+func (_ Unimplemented) GetAdminReviews(w http.ResponseWriter, r *http.Request, params GetAdminReviewsParams) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Register a new user
-// (POST /auth/register)
-func (_ Unimplemented) PostAuthRegister(w http.ResponseWriter, r *http.Request) {
+// Publish a pending review, or dismiss the reports on a published one (admin)
+// (POST /admin/reviews/{id}/approve)
+func (_ Unimplemented) PostAdminReviewsIdApprove(w http.ResponseWriter, r *http.Request, id string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Create or update profile
-// (POST /profile)
-func (_ Unimplemented) PostProfile(w http.ResponseWriter, r *http.Request) {
+// Hide a review from listings and ratings (admin)
+// (POST /admin/reviews/{id}/hide)
+func (_ Unimplemented) PostAdminReviewsIdHide(w http.ResponseWriter, r *http.Request, id string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Current user's profile
-// (GET /profile/me)
-func (_ Unimplemented) GetProfileMe(w http.ResponseWriter, r *http.Request) {
+// Publish a hidden review again (admin)
+// (POST /admin/reviews/{id}/restore)
+func (_ Unimplemented) PostAdminReviewsIdRestore(w http.ResponseWriter, r *http.Request, id string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Get my reminders (patient)
-// (GET /reminders/me)
+// List and search users (admin)
+// (GET /admin/users)
+func (_ Unimplemented) GetAdminUsers(w http.ResponseWriter, r *http.Request, params GetAdminUsersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Disable an account (admin)
+// (POST /admin/users/{id}/disable)
+func (_ Unimplemented) PostAdminUsersIdDisable(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Re-enable an account (admin)
+// (POST /admin/users/{id}/enable)
+func (_ Unimplemented) PostAdminUsersIdEnable(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Issue a short-lived session as the user (admin)
+// (POST /admin/users/{id}/impersonate)
+func (_ Unimplemented) PostAdminUsersIdImpersonate(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Force a password reset (admin)
+// (POST /admin/users/{id}/password-reset)
+func (_ Unimplemented) PostAdminUsersIdPasswordReset(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Change a user's role (admin)
+// (PUT /admin/users/{id}/role)
+func (_ Unimplemented) PutAdminUsersIdRole(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Therapist verification queue, oldest first (admin)
+// (GET /admin/verifications)
+func (_ Unimplemented) GetAdminVerifications(w http.ResponseWriter, r *http.Request, params GetAdminVerificationsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Approve a submission and mark the therapist verified (admin)
+// (POST /admin/verifications/{id}/approve)
+func (_ Unimplemented) PostAdminVerificationsIdApprove(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reject a submission with notes for the therapist (admin)
+// (POST /admin/verifications/{id}/reject)
+func (_ Unimplemented) PostAdminVerificationsIdReject(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a PT's available slots (same as /appointments/availability/{ptId})
+// (GET /appointments/availability)
+func (_ Unimplemented) GetAppointmentsAvailability(w http.ResponseWriter, r *http.Request, params GetAppointmentsAvailabilityParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create availability (PT only)
+// (POST /appointments/availability)
+func (_ Unimplemented) PostAppointmentsAvailability(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a specific PT's available slots
+// (GET /appointments/availability/{ptId})
+func (_ Unimplemented) GetAppointmentsAvailabilityPtId(w http.ResponseWriter, r *http.Request, ptId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get my schedule (PT or patient)
+// (GET /appointments/me)
+func (_ Unimplemented) GetAppointmentsMe(w http.ResponseWriter, r *http.Request, params GetAppointmentsMeParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Book an available appointment
+// (PUT /appointments/{id}/book)
+func (_ Unimplemented) PutAppointmentsIdBook(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update appointment status (PT)
+// (PUT /appointments/{id}/status)
+func (_ Unimplemented) PutAppointmentsIdStatus(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Login
+// (POST /auth/login)
+func (_ Unimplemented) PostAuthLogin(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Complete login with a TOTP or recovery code
+// (POST /auth/login/mfa)
+func (_ Unimplemented) PostAuthLoginMfa(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Current user's MFA status
+// (GET /auth/mfa)
+func (_ Unimplemented) GetAuthMfa(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Confirm TOTP enrollment with a first code
+// (POST /auth/mfa/confirm)
+func (_ Unimplemented) PostAuthMfaConfirm(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Disable TOTP
+// (POST /auth/mfa/disable)
+func (_ Unimplemented) PostAuthMfaDisable(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Start TOTP enrollment
+// (POST /auth/mfa/enroll)
+func (_ Unimplemented) PostAuthMfaEnroll(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Identity providers available for single sign-on
+// (GET /auth/oidc/providers)
+func (_ Unimplemented) GetAuthOidcProviders(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Start an OpenID Connect login (authorization code + PKCE)
+// (GET /auth/oidc/{provider})
+func (_ Unimplemented) GetAuthOidcProvider(w http.ResponseWriter, r *http.Request, provider string, params GetAuthOidcProviderParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// OpenID Connect redirect URI
+// (GET /auth/oidc/{provider}/callback)
+func (_ Unimplemented) GetAuthOidcProviderCallback(w http.ResponseWriter, r *http.Request, provider string, params GetAuthOidcProviderCallbackParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set a new password with an admin-issued reset token
+// (POST /auth/password/reset)
+func (_ Unimplemented) PostAuthPasswordReset(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Register a new user
+// (POST /auth/register)
+func (_ Unimplemented) PostAuthRegister(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List my favourite therapists (patient)
+// (GET /favourites)
+func (_ Unimplemented) GetFavourites(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Remove a therapist from my favourites (patient)
+// (DELETE /favourites/{therapistId})
+func (_ Unimplemented) DeleteFavouritesTherapistId(w http.ResponseWriter, r *http.Request, therapistId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Add a therapist to my favourites (patient)
+// (PUT /favourites/{therapistId})
+func (_ Unimplemented) PutFavouritesTherapistId(w http.ResponseWriter, r *http.Request, therapistId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Export the clinic's appointments (API key with appointments:read)
+// (GET /integrations/appointments)
+func (_ Unimplemented) GetIntegrationsAppointments(w http.ResponseWriter, r *http.Request, params GetIntegrationsAppointmentsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List open slots of the clinic's therapists (API key with availability:read)
+// (GET /integrations/availability)
+func (_ Unimplemented) GetIntegrationsAvailability(w http.ResponseWriter, r *http.Request, params GetIntegrationsAvailabilityParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Publish slots for one of the clinic's therapists (API key with availability:write)
+// (POST /integrations/availability)
+func (_ Unimplemented) PostIntegrationsAvailability(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create or update profile
+// (POST /profile)
+func (_ Unimplemented) PostProfile(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Current user's profile
+// (GET /profile/me)
+func (_ Unimplemented) GetProfileMe(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create or update my profile (same as POST /profile)
+// (PUT /profile/me)
+func (_ Unimplemented) PutProfileMe(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List my credential submissions and their outcome (therapist)
+// (GET /profile/verification)
+func (_ Unimplemented) GetProfileVerification(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Submit licence details and documents for verification (therapist)
+// (POST /profile/verification)
+func (_ Unimplemented) PostProfileVerification(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get my reminders (patient)
+// (GET /reminders/me)
 func (_ Unimplemented) GetRemindersMe(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
-// Create a review
+// Review a completed appointment (patient)
 // (POST /reviews)
 func (_ Unimplemented) PostReviews(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Withdraw my review (patient)
+// (DELETE /reviews/{id})
+func (_ Unimplemented) DeleteReviewsId(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // List reviews for therapist
-// (GET /reviews/{therapistId})
-func (_ Unimplemented) GetReviewsTherapistId(w http.ResponseWriter, r *http.Request, therapistId string) {
+// (GET /reviews/{id})
+func (_ Unimplemented) GetReviewsId(w http.ResponseWriter, r *http.Request, id string, params GetReviewsIdParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Edit my review (patient)
+// (PUT /reviews/{id})
+func (_ Unimplemented) PutReviewsId(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reply publicly to a review of me (therapist)
+// (POST /reviews/{id}/reply)
+func (_ Unimplemented) PostReviewsIdReply(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Report a review for moderation
+// (POST /reviews/{id}/report)
+func (_ Unimplemented) PostReviewsIdReport(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Sign out all my other devices
+// (DELETE /sessions)
+func (_ Unimplemented) DeleteSessions(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List my signed-in devices
+// (GET /sessions)
+func (_ Unimplemented) GetSessions(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Sign out one of my devices
+// (DELETE /sessions/{id})
+func (_ Unimplemented) DeleteSessionsId(w http.ResponseWriter, r *http.Request, id string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
@@ -272,12 +1457,42 @@ func (_ Unimplemented) GetTherapists(w http.ResponseWriter, r *http.Request, par
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// List the therapists I viewed most recently
+// (GET /therapists/recent)
+func (_ Unimplemented) GetTherapistsRecent(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Recommend therapists for my condition and goals
+// (GET /therapists/recommended)
+func (_ Unimplemented) GetTherapistsRecommended(w http.ResponseWriter, r *http.Request, params GetTherapistsRecommendedParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // Get therapist detail
 // (GET /therapists/{id})
 func (_ Unimplemented) GetTherapistsId(w http.ResponseWriter, r *http.Request, id string) {
 	w.WriteHeader(http.StatusNotImplemented)
 }
 
+// Upload a profile picture and make it the caller's profileImageUrl
+// (POST /uploads/avatar)
+func (_ Unimplemented) PostUploadsAvatar(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Upload a credential document (therapist)
+// (POST /uploads/documents)
+func (_ Unimplemented) PostUploadsDocuments(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Redirect to a signed, expiring download link
+// (GET /uploads/{id})
+func (_ Unimplemented) GetUploadsId(w http.ResponseWriter, r *http.Request, id openapi_types.UUID, params GetUploadsIdParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
 // ServerInterfaceWrapper converts contexts to parameters.
 type ServerInterfaceWrapper struct {
 	Handler            ServerInterface
@@ -287,14 +1502,50 @@ type ServerInterfaceWrapper struct {
 
 type MiddlewareFunc func(http.Handler) http.Handler
 
-// PostAppointmentsAvailability operation middleware
-func (siw *ServerInterfaceWrapper) PostAppointmentsAvailability(w http.ResponseWriter, r *http.Request) {
+// GetWellKnownJwksJson operation middleware
+func (siw *ServerInterfaceWrapper) GetWellKnownJwksJson(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetWellKnownJwksJson(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetAdminAudit operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminAudit(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdminAuditParams
+
+	// ------------- Optional query parameter "userId" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "userId", r.URL.Query(), &params.UserId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "userId", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostAppointmentsAvailability(w, r)
+		siw.Handler.GetAdminAudit(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -304,25 +1555,25 @@ func (siw *ServerInterfaceWrapper) PostAppointmentsAvailability(w http.ResponseW
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GetAppointmentsAvailabilityPtId operation middleware
-func (siw *ServerInterfaceWrapper) GetAppointmentsAvailabilityPtId(w http.ResponseWriter, r *http.Request) {
+// GetAdminClinicsClinicIdApiKeys operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminClinicsClinicIdApiKeys(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "ptId" -------------
-	var ptId string
+	// ------------- Path parameter "clinicId" -------------
+	var clinicId string
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "ptId", runtime.ParamLocationPath, chi.URLParam(r, "ptId"), &ptId)
+	err = runtime.BindStyledParameterWithLocation("simple", false, "clinicId", runtime.ParamLocationPath, chi.URLParam(r, "clinicId"), &clinicId)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ptId", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "clinicId", Err: err})
 		return
 	}
 
-	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetAppointmentsAvailabilityPtId(w, r, ptId)
+		siw.Handler.GetAdminClinicsClinicIdApiKeys(w, r, clinicId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -332,14 +1583,25 @@ func (siw *ServerInterfaceWrapper) GetAppointmentsAvailabilityPtId(w http.Respon
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GetAppointmentsMe operation middleware
-func (siw *ServerInterfaceWrapper) GetAppointmentsMe(w http.ResponseWriter, r *http.Request) {
+// PostAdminClinicsClinicIdApiKeys operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminClinicsClinicIdApiKeys(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+	var err error
+
+	// ------------- Path parameter "clinicId" -------------
+	var clinicId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "clinicId", runtime.ParamLocationPath, chi.URLParam(r, "clinicId"), &clinicId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "clinicId", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetAppointmentsMe(w, r)
+		siw.Handler.PostAdminClinicsClinicIdApiKeys(w, r, clinicId)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -349,70 +1611,1529 @@ func (siw *ServerInterfaceWrapper) GetAppointmentsMe(w http.ResponseWriter, r *h
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// PutAppointmentsIdBook operation middleware
-func (siw *ServerInterfaceWrapper) PutAppointmentsIdBook(w http.ResponseWriter, r *http.Request) {
+// DeleteAdminClinicsClinicIdApiKeysKeyId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteAdminClinicsClinicIdApiKeysKeyId(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
-	// ------------- Path parameter "id" -------------
-	var id string
+	// ------------- Path parameter "clinicId" -------------
+	var clinicId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "clinicId", runtime.ParamLocationPath, chi.URLParam(r, "clinicId"), &clinicId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "clinicId", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "keyId" -------------
+	var keyId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "keyId", runtime.ParamLocationPath, chi.URLParam(r, "keyId"), &keyId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "keyId", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteAdminClinicsClinicIdApiKeysKeyId(w, r, clinicId, keyId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetAdminReviews operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminReviews(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdminReviewsParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "reported" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "reported", r.URL.Query(), &params.Reported)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "reported", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAdminReviews(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostAdminReviewsIdApprove operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminReviewsIdApprove(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAdminReviewsIdApprove(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostAdminReviewsIdHide operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminReviewsIdHide(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAdminReviewsIdHide(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostAdminReviewsIdRestore operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminReviewsIdRestore(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAdminReviewsIdRestore(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetAdminUsers operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminUsers(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdminUsersParams
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "role" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "role", r.URL.Query(), &params.Role)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAdminUsers(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostAdminUsersIdDisable operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminUsersIdDisable(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAdminUsersIdDisable(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostAdminUsersIdEnable operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminUsersIdEnable(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAdminUsersIdEnable(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostAdminUsersIdImpersonate operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminUsersIdImpersonate(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAdminUsersIdImpersonate(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostAdminUsersIdPasswordReset operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminUsersIdPasswordReset(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAdminUsersIdPasswordReset(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PutAdminUsersIdRole operation middleware
+func (siw *ServerInterfaceWrapper) PutAdminUsersIdRole(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutAdminUsersIdRole(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetAdminVerifications operation middleware
+func (siw *ServerInterfaceWrapper) GetAdminVerifications(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAdminVerificationsParams
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "status", r.URL.Query(), &params.Status)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAdminVerifications(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostAdminVerificationsIdApprove operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminVerificationsIdApprove(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAdminVerificationsIdApprove(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostAdminVerificationsIdReject operation middleware
+func (siw *ServerInterfaceWrapper) PostAdminVerificationsIdReject(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{"admin"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAdminVerificationsIdReject(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetAppointmentsAvailability operation middleware
+func (siw *ServerInterfaceWrapper) GetAppointmentsAvailability(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAppointmentsAvailabilityParams
+
+	// ------------- Required query parameter "ptId" -------------
+
+	if paramValue := r.URL.Query().Get("ptId"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "ptId"})
+		return
+	}
+
+	err = runtime.BindQueryParameter("form", true, true, "ptId", r.URL.Query(), &params.PtId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ptId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAppointmentsAvailability(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostAppointmentsAvailability operation middleware
+func (siw *ServerInterfaceWrapper) PostAppointmentsAvailability(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAppointmentsAvailability(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetAppointmentsAvailabilityPtId operation middleware
+func (siw *ServerInterfaceWrapper) GetAppointmentsAvailabilityPtId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "ptId" -------------
+	var ptId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "ptId", runtime.ParamLocationPath, chi.URLParam(r, "ptId"), &ptId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "ptId", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAppointmentsAvailabilityPtId(w, r, ptId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetAppointmentsMe operation middleware
+func (siw *ServerInterfaceWrapper) GetAppointmentsMe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAppointmentsMeParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAppointmentsMe(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PutAppointmentsIdBook operation middleware
+func (siw *ServerInterfaceWrapper) PutAppointmentsIdBook(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutAppointmentsIdBook(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PutAppointmentsIdStatus operation middleware
+func (siw *ServerInterfaceWrapper) PutAppointmentsIdStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutAppointmentsIdStatus(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostAuthLogin operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthLogin(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAuthLogin(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostAuthLoginMfa operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthLoginMfa(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAuthLoginMfa(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetAuthMfa operation middleware
+func (siw *ServerInterfaceWrapper) GetAuthMfa(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAuthMfa(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostAuthMfaConfirm operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthMfaConfirm(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAuthMfaConfirm(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostAuthMfaDisable operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthMfaDisable(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAuthMfaDisable(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostAuthMfaEnroll operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthMfaEnroll(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAuthMfaEnroll(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetAuthOidcProviders operation middleware
+func (siw *ServerInterfaceWrapper) GetAuthOidcProviders(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAuthOidcProviders(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetAuthOidcProvider operation middleware
+func (siw *ServerInterfaceWrapper) GetAuthOidcProvider(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "provider", runtime.ParamLocationPath, chi.URLParam(r, "provider"), &provider)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "provider", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAuthOidcProviderParams
+
+	// ------------- Optional query parameter "role" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "role", r.URL.Query(), &params.Role)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "app_redirect" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "app_redirect", r.URL.Query(), &params.AppRedirect)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "app_redirect", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAuthOidcProvider(w, r, provider, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetAuthOidcProviderCallback operation middleware
+func (siw *ServerInterfaceWrapper) GetAuthOidcProviderCallback(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "provider" -------------
+	var provider string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "provider", runtime.ParamLocationPath, chi.URLParam(r, "provider"), &provider)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "provider", Err: err})
+		return
+	}
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetAuthOidcProviderCallbackParams
+
+	// ------------- Optional query parameter "code" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "code", r.URL.Query(), &params.Code)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "code", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "state" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "state", r.URL.Query(), &params.State)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "state", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetAuthOidcProviderCallback(w, r, provider, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostAuthPasswordReset operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthPasswordReset(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAuthPasswordReset(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostAuthRegister operation middleware
+func (siw *ServerInterfaceWrapper) PostAuthRegister(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostAuthRegister(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetFavourites operation middleware
+func (siw *ServerInterfaceWrapper) GetFavourites(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetFavourites(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteFavouritesTherapistId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteFavouritesTherapistId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "therapistId" -------------
+	var therapistId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "therapistId", runtime.ParamLocationPath, chi.URLParam(r, "therapistId"), &therapistId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "therapistId", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteFavouritesTherapistId(w, r, therapistId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PutFavouritesTherapistId operation middleware
+func (siw *ServerInterfaceWrapper) PutFavouritesTherapistId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "therapistId" -------------
+	var therapistId string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "therapistId", runtime.ParamLocationPath, chi.URLParam(r, "therapistId"), &therapistId)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "therapistId", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutFavouritesTherapistId(w, r, therapistId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetIntegrationsAppointments operation middleware
+func (siw *ServerInterfaceWrapper) GetIntegrationsAppointments(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"appointments:read"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetIntegrationsAppointmentsParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetIntegrationsAppointments(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetIntegrationsAvailability operation middleware
+func (siw *ServerInterfaceWrapper) GetIntegrationsAvailability(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"availability:read"})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetIntegrationsAvailabilityParams
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "from", r.URL.Query(), &params.From)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "to", r.URL.Query(), &params.To)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetIntegrationsAvailability(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostIntegrationsAvailability operation middleware
+func (siw *ServerInterfaceWrapper) PostIntegrationsAvailability(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{"availability:write"})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostIntegrationsAvailability(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostProfile operation middleware
+func (siw *ServerInterfaceWrapper) PostProfile(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostProfile(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetProfileMe operation middleware
+func (siw *ServerInterfaceWrapper) GetProfileMe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProfileMe(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PutProfileMe operation middleware
+func (siw *ServerInterfaceWrapper) PutProfileMe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutProfileMe(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetProfileVerification operation middleware
+func (siw *ServerInterfaceWrapper) GetProfileVerification(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetProfileVerification(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostProfileVerification operation middleware
+func (siw *ServerInterfaceWrapper) PostProfileVerification(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostProfileVerification(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetRemindersMe operation middleware
+func (siw *ServerInterfaceWrapper) GetRemindersMe(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetRemindersMe(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostReviews operation middleware
+func (siw *ServerInterfaceWrapper) PostReviews(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostReviews(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteReviewsId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteReviewsId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteReviewsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetReviewsId operation middleware
+func (siw *ServerInterfaceWrapper) GetReviewsId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetReviewsIdParams
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetReviewsId(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PutReviewsId operation middleware
+func (siw *ServerInterfaceWrapper) PutReviewsId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutReviewsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostReviewsIdReply operation middleware
+func (siw *ServerInterfaceWrapper) PostReviewsIdReply(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostReviewsIdReply(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// PostReviewsIdReport operation middleware
+func (siw *ServerInterfaceWrapper) PostReviewsIdReport(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostReviewsIdReport(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteSessions operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteSessions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetSessions operation middleware
+func (siw *ServerInterfaceWrapper) GetSessions(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetSessions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// DeleteSessionsId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteSessionsId(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteSessionsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// GetTherapists operation middleware
+func (siw *ServerInterfaceWrapper) GetTherapists(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	var err error
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTherapistsParams
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "q", r.URL.Query(), &params.Q)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "cursor", r.URL.Query(), &params.Cursor)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "specialty" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "specialty", r.URL.Query(), &params.Specialty)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "specialty", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "location" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "location", r.URL.Query(), &params.Location)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "location", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "sort", r.URL.Query(), &params.Sort)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "lat" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "lat", r.URL.Query(), &params.Lat)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "lat", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "lng" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "lng", r.URL.Query(), &params.Lng)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "lng", Err: err})
+		return
+	}
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	// ------------- Optional query parameter "radiusKm" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "radiusKm", r.URL.Query(), &params.RadiusKm)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "radiusKm", Err: err})
 		return
 	}
 
-	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
-
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PutAppointmentsIdBook(w, r, id)
-	}))
+	// ------------- Optional query parameter "available" -------------
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	err = runtime.BindQueryParameter("form", true, false, "available", r.URL.Query(), &params.Available)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "available", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
-}
+	// ------------- Optional query parameter "verified" -------------
 
-// PutAppointmentsIdStatus operation middleware
-func (siw *ServerInterfaceWrapper) PutAppointmentsIdStatus(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+	err = runtime.BindQueryParameter("form", true, false, "verified", r.URL.Query(), &params.Verified)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "verified", Err: err})
+		return
+	}
 
-	var err error
+	// ------------- Optional query parameter "insurer" -------------
 
-	// ------------- Path parameter "id" -------------
-	var id string
+	err = runtime.BindQueryParameter("form", true, false, "insurer", r.URL.Query(), &params.Insurer)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "insurer", Err: err})
+		return
+	}
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	// ------------- Optional query parameter "language" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "language", r.URL.Query(), &params.Language)
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "language", Err: err})
 		return
 	}
 
-	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+	// ------------- Optional query parameter "minPrice" -------------
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PutAppointmentsIdStatus(w, r, id)
-	}))
+	err = runtime.BindQueryParameter("form", true, false, "minPrice", r.URL.Query(), &params.MinPrice)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "minPrice", Err: err})
+		return
+	}
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	// ------------- Optional query parameter "maxPrice" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "maxPrice", r.URL.Query(), &params.MaxPrice)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "maxPrice", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
-}
+	// ------------- Optional query parameter "currency" -------------
 
-// PostAuthLogin operation middleware
-func (siw *ServerInterfaceWrapper) PostAuthLogin(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+	err = runtime.BindQueryParameter("form", true, false, "currency", r.URL.Query(), &params.Currency)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency", Err: err})
+		return
+	}
 
-	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+	// ------------- Optional query parameter "date" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "date", r.URL.Query(), &params.Date)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "date", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostAuthLogin(w, r)
+		siw.Handler.GetTherapists(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -422,14 +3143,14 @@ func (siw *ServerInterfaceWrapper) PostAuthLogin(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// PostAuthRegister operation middleware
-func (siw *ServerInterfaceWrapper) PostAuthRegister(w http.ResponseWriter, r *http.Request) {
+// GetTherapistsRecent operation middleware
+func (siw *ServerInterfaceWrapper) GetTherapistsRecent(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostAuthRegister(w, r)
+		siw.Handler.GetTherapistsRecent(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -439,31 +3160,43 @@ func (siw *ServerInterfaceWrapper) PostAuthRegister(w http.ResponseWriter, r *ht
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// PostProfile operation middleware
-func (siw *ServerInterfaceWrapper) PostProfile(w http.ResponseWriter, r *http.Request) {
+// GetTherapistsRecommended operation middleware
+func (siw *ServerInterfaceWrapper) GetTherapistsRecommended(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	var err error
+
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostProfile(w, r)
-	}))
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTherapistsRecommendedParams
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", r.URL.Query(), &params.Limit)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
-}
+	// ------------- Optional query parameter "lat" -------------
 
-// GetProfileMe operation middleware
-func (siw *ServerInterfaceWrapper) GetProfileMe(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
+	err = runtime.BindQueryParameter("form", true, false, "lat", r.URL.Query(), &params.Lat)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "lat", Err: err})
+		return
+	}
 
-	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+	// ------------- Optional query parameter "lng" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "lng", r.URL.Query(), &params.Lng)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "lng", Err: err})
+		return
+	}
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetProfileMe(w, r)
+		siw.Handler.GetTherapistsRecommended(w, r, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -473,31 +3206,25 @@ func (siw *ServerInterfaceWrapper) GetProfileMe(w http.ResponseWriter, r *http.R
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GetRemindersMe operation middleware
-func (siw *ServerInterfaceWrapper) GetRemindersMe(w http.ResponseWriter, r *http.Request) {
+// GetTherapistsId operation middleware
+func (siw *ServerInterfaceWrapper) GetTherapistsId(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+	var err error
 
-	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetRemindersMe(w, r)
-	}))
+	// ------------- Path parameter "id" -------------
+	var id string
 
-	for _, middleware := range siw.HandlerMiddlewares {
-		handler = middleware(handler)
+	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
 	}
 
-	handler.ServeHTTP(w, r.WithContext(ctx))
-}
-
-// PostReviews operation middleware
-func (siw *ServerInterfaceWrapper) PostReviews(w http.ResponseWriter, r *http.Request) {
-	ctx := r.Context()
-
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostReviews(w, r)
+		siw.Handler.GetTherapistsId(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -507,25 +3234,14 @@ func (siw *ServerInterfaceWrapper) PostReviews(w http.ResponseWriter, r *http.Re
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GetReviewsTherapistId operation middleware
-func (siw *ServerInterfaceWrapper) GetReviewsTherapistId(w http.ResponseWriter, r *http.Request) {
+// PostUploadsAvatar operation middleware
+func (siw *ServerInterfaceWrapper) PostUploadsAvatar(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	var err error
-
-	// ------------- Path parameter "therapistId" -------------
-	var therapistId string
-
-	err = runtime.BindStyledParameterWithLocation("simple", false, "therapistId", runtime.ParamLocationPath, chi.URLParam(r, "therapistId"), &therapistId)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "therapistId", Err: err})
-		return
-	}
-
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetReviewsTherapistId(w, r, therapistId)
+		siw.Handler.PostUploadsAvatar(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -535,35 +3251,14 @@ func (siw *ServerInterfaceWrapper) GetReviewsTherapistId(w http.ResponseWriter,
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GetTherapists operation middleware
-func (siw *ServerInterfaceWrapper) GetTherapists(w http.ResponseWriter, r *http.Request) {
+// PostUploadsDocuments operation middleware
+func (siw *ServerInterfaceWrapper) PostUploadsDocuments(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
-	var err error
-
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetTherapistsParams
-
-	// ------------- Optional query parameter "page" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "page", r.URL.Query(), &params.Page)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
-		return
-	}
-
-	// ------------- Optional query parameter "specialty" -------------
-
-	err = runtime.BindQueryParameter("form", true, false, "specialty", r.URL.Query(), &params.Specialty)
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "specialty", Err: err})
-		return
-	}
-
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetTherapists(w, r, params)
+		siw.Handler.PostUploadsDocuments(w, r)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -573,14 +3268,14 @@ func (siw *ServerInterfaceWrapper) GetTherapists(w http.ResponseWriter, r *http.
 	handler.ServeHTTP(w, r.WithContext(ctx))
 }
 
-// GetTherapistsId operation middleware
-func (siw *ServerInterfaceWrapper) GetTherapistsId(w http.ResponseWriter, r *http.Request) {
+// GetUploadsId operation middleware
+func (siw *ServerInterfaceWrapper) GetUploadsId(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
 	var err error
 
 	// ------------- Path parameter "id" -------------
-	var id string
+	var id openapi_types.UUID
 
 	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, chi.URLParam(r, "id"), &id)
 	if err != nil {
@@ -590,8 +3285,19 @@ func (siw *ServerInterfaceWrapper) GetTherapistsId(w http.ResponseWriter, r *htt
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUploadsIdParams
+
+	// ------------- Optional query parameter "size" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "size", r.URL.Query(), &params.Size)
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "size", Err: err})
+		return
+	}
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetTherapistsId(w, r, id)
+		siw.Handler.GetUploadsId(w, r, id, params)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -714,6 +3420,63 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/.well-known/jwks.json", wrapper.GetWellKnownJwksJson)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/audit", wrapper.GetAdminAudit)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/clinics/{clinicId}/api-keys", wrapper.GetAdminClinicsClinicIdApiKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/clinics/{clinicId}/api-keys", wrapper.PostAdminClinicsClinicIdApiKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/admin/clinics/{clinicId}/api-keys/{keyId}", wrapper.DeleteAdminClinicsClinicIdApiKeysKeyId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/reviews", wrapper.GetAdminReviews)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/reviews/{id}/approve", wrapper.PostAdminReviewsIdApprove)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/reviews/{id}/hide", wrapper.PostAdminReviewsIdHide)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/reviews/{id}/restore", wrapper.PostAdminReviewsIdRestore)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/users", wrapper.GetAdminUsers)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/users/{id}/disable", wrapper.PostAdminUsersIdDisable)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/users/{id}/enable", wrapper.PostAdminUsersIdEnable)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/users/{id}/impersonate", wrapper.PostAdminUsersIdImpersonate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/users/{id}/password-reset", wrapper.PostAdminUsersIdPasswordReset)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/admin/users/{id}/role", wrapper.PutAdminUsersIdRole)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/admin/verifications", wrapper.GetAdminVerifications)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/verifications/{id}/approve", wrapper.PostAdminVerificationsIdApprove)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/admin/verifications/{id}/reject", wrapper.PostAdminVerificationsIdReject)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/appointments/availability", wrapper.GetAppointmentsAvailability)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/appointments/availability", wrapper.PostAppointmentsAvailability)
 	})
@@ -732,15 +3495,69 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/auth/login", wrapper.PostAuthLogin)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/login/mfa", wrapper.PostAuthLoginMfa)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/mfa", wrapper.GetAuthMfa)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/mfa/confirm", wrapper.PostAuthMfaConfirm)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/mfa/disable", wrapper.PostAuthMfaDisable)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/mfa/enroll", wrapper.PostAuthMfaEnroll)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/oidc/providers", wrapper.GetAuthOidcProviders)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/oidc/{provider}", wrapper.GetAuthOidcProvider)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/auth/oidc/{provider}/callback", wrapper.GetAuthOidcProviderCallback)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/auth/password/reset", wrapper.PostAuthPasswordReset)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/auth/register", wrapper.PostAuthRegister)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/favourites", wrapper.GetFavourites)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/favourites/{therapistId}", wrapper.DeleteFavouritesTherapistId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/favourites/{therapistId}", wrapper.PutFavouritesTherapistId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/integrations/appointments", wrapper.GetIntegrationsAppointments)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/integrations/availability", wrapper.GetIntegrationsAvailability)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/integrations/availability", wrapper.PostIntegrationsAvailability)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/profile", wrapper.PostProfile)
 	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/profile/me", wrapper.GetProfileMe)
 	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/profile/me", wrapper.PutProfileMe)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/profile/verification", wrapper.GetProfileVerification)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/profile/verification", wrapper.PostProfileVerification)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/reminders/me", wrapper.GetRemindersMe)
 	})
@@ -748,14 +3565,50 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		r.Post(options.BaseURL+"/reviews", wrapper.PostReviews)
 	})
 	r.Group(func(r chi.Router) {
-		r.Get(options.BaseURL+"/reviews/{therapistId}", wrapper.GetReviewsTherapistId)
+		r.Delete(options.BaseURL+"/reviews/{id}", wrapper.DeleteReviewsId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/reviews/{id}", wrapper.GetReviewsId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/reviews/{id}", wrapper.PutReviewsId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/reviews/{id}/reply", wrapper.PostReviewsIdReply)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/reviews/{id}/report", wrapper.PostReviewsIdReport)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/sessions", wrapper.DeleteSessions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/sessions", wrapper.GetSessions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/sessions/{id}", wrapper.DeleteSessionsId)
 	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/therapists", wrapper.GetTherapists)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/therapists/recent", wrapper.GetTherapistsRecent)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/therapists/recommended", wrapper.GetTherapistsRecommended)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/therapists/{id}", wrapper.GetTherapistsId)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/uploads/avatar", wrapper.PostUploadsAvatar)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/uploads/documents", wrapper.PostUploadsDocuments)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/uploads/{id}", wrapper.GetUploadsId)
+	})
 
 	return r
 }
@@ -0,0 +1,187 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/golang-jwt/jwt/v5"
+	"gopkg.in/yaml.v3"
+
+	"github.com/divijg19/physiolink/backend/internal/config"
+	"github.com/divijg19/physiolink/backend/internal/handlers"
+	mocks "github.com/divijg19/physiolink/backend/internal/mocks"
+)
+
+type specPathItem struct {
+	Servers []struct {
+		URL string `yaml:"url"`
+	} `yaml:"servers"`
+	Operations map[string]yaml.Node `yaml:",inline"`
+}
+
+// specOperations returns "METHOD /path" for every operation in openapi.yaml,
+// with the path as served: under /api unless the path names its own server.
+func specOperations(t *testing.T) map[string]bool {
+	t.Helper()
+	raw, err := os.ReadFile("../../openapi.yaml")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var spec struct {
+		Paths map[string]specPathItem `yaml:"paths"`
+	}
+	if err := yaml.Unmarshal(raw, &spec); err != nil {
+		t.Fatal(err)
+	}
+	ops := map[string]bool{}
+	for path, item := range spec.Paths {
+		base := "/api"
+		if len(item.Servers) > 0 {
+			u, err := url.Parse(item.Servers[0].URL)
+			if err != nil {
+				t.Fatal(err)
+			}
+			base = strings.TrimSuffix(u.Path, "/")
+		}
+		for method := range item.Operations {
+			switch method {
+			case "get", "put", "post", "delete", "patch", "head", "options":
+				ops[strings.ToUpper(method)+" "+base+path] = true
+			}
+		}
+	}
+	return ops
+}
+
+func TestRoutes_MatchOpenAPISpec(t *testing.T) {
+	cfg := &config.Config{BindAddr: ":8080"}
+	served := map[string]bool{}
+	err := chi.Walk(NewRouter(cfg).(chi.Routes), func(method, route string, _ http.Handler, _ ...func(http.Handler) http.Handler) error {
+		served[method+" "+route] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	spec := specOperations(t)
+
+	var missing, undocumented, root []string
+	for op := range spec {
+		if !served[op] {
+			missing = append(missing, op)
+		}
+		if _, path, _ := strings.Cut(op, " "); !strings.HasPrefix(path, "/api/") && !rootOperations[op] {
+			root = append(root, op)
+		}
+	}
+	for op := range served {
+		if _, path, _ := strings.Cut(op, " "); strings.HasPrefix(path, "/api/") && !spec[op] {
+			undocumented = append(undocumented, op)
+		}
+	}
+	sort.Strings(missing)
+	sort.Strings(undocumented)
+	sort.Strings(root)
+	if len(missing) > 0 {
+		t.Errorf("operations in openapi.yaml that are not served: %v", missing)
+	}
+	if len(undocumented) > 0 {
+		t.Errorf("API routes missing from openapi.yaml: %v", undocumented)
+	}
+	if len(root) > 0 {
+		t.Errorf("root-served operations missing from rootOperations: %v", root)
+	}
+}
+
+// OpenAPI treats paths that differ only in parameter names as the same path,
+// which it forbids.
+func TestSpec_PathTemplatesAreDistinct(t *testing.T) {
+	param := regexp.MustCompile(`\{[^}]*\}`)
+	paths := map[string]map[string]bool{}
+	for op := range specOperations(t) {
+		_, path, _ := strings.Cut(op, " ")
+		template := param.ReplaceAllString(path, "{}")
+		if paths[template] == nil {
+			paths[template] = map[string]bool{}
+		}
+		paths[template][path] = true
+	}
+	for _, same := range paths {
+		if len(same) > 1 {
+			var clash []string
+			for path := range same {
+				clash = append(clash, path)
+			}
+			sort.Strings(clash)
+			t.Errorf("paths differ only in parameter names: %v", clash)
+		}
+	}
+}
+
+func TestRootOperations_NotServedUnderAPI(t *testing.T) {
+	router := NewRouter(config.New())
+	for _, path := range []string{"/api/.well-known/jwks.json", "/api/auth/oidc/google", "/api/auth/oidc/google/callback"} {
+		rr := httptest.NewRecorder()
+		router.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, path, nil))
+		if rr.Code != http.StatusNotFound {
+			t.Errorf("%s: expected 404, got %d", path, rr.Code)
+		}
+	}
+}
+
+func TestAPISecurity_FollowsSpec(t *testing.T) {
+	cfg := config.New()
+	handlers.InitAuth(nil, cfg)
+	handlers.InitMFA(&mocks.MFAServiceMock{})
+	t.Cleanup(func() { handlers.InitMFA(nil) })
+	router := NewRouter(cfg)
+
+	token := func(role string, extra jwt.MapClaims) string {
+		claims := jwt.MapClaims{
+			"user": map[string]interface{}{"id": "11111111-1111-1111-1111-111111111111", "role": role},
+			"exp":  time.Now().Add(time.Hour).Unix(),
+		}
+		for k, v := range extra {
+			claims[k] = v
+		}
+		signed, err := cfg.KeySet().Sign(claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return signed
+	}
+	pending := token("pt", jwt.MapClaims{"mfa_setup_required": true})
+
+	cases := []struct {
+		name, method, path, token string
+		want                      int
+	}{
+		{"bearer required", http.MethodGet, "/api/reminders/me", "", http.StatusUnauthorized},
+		{"public operation", http.MethodGet, "/api/appointments/availability", "", http.StatusBadRequest},
+		{"role scope", http.MethodGet, "/api/admin/users", token("patient", nil), http.StatusForbidden},
+		{"mfa enrollment pending", http.MethodGet, "/api/reminders/me", pending, http.StatusForbidden},
+		{"mfa enrollment route", http.MethodGet, "/api/auth/mfa", pending, http.StatusOK},
+		{"api key required", http.MethodGet, "/api/integrations/availability", token("admin", nil), http.StatusUnauthorized},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, tc.path, nil)
+			if tc.token != "" {
+				req.Header.Set("Authorization", "Bearer "+tc.token)
+			}
+			rr := httptest.NewRecorder()
+			router.ServeHTTP(rr, req)
+			if rr.Code != tc.want {
+				t.Fatalf("expected %d, got %d: %s", tc.want, rr.Code, rr.Body.String())
+			}
+		})
+	}
+}
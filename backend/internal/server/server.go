@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/go-chi/chi/v5"
@@ -11,6 +12,7 @@ import (
 	"github.com/divijg19/physiolink/backend/internal/config"
 	"github.com/divijg19/physiolink/backend/internal/handlers"
 	mware "github.com/divijg19/physiolink/backend/internal/middleware"
+	"github.com/divijg19/physiolink/backend/internal/openapi"
 )
 
 type Server struct {
@@ -26,84 +28,136 @@ func NewRouter(cfg *config.Config) http.Handler {
 
 	// health
 	r.Get("/health", handlers.Health)
+	r.Get("/.well-known/jwks.json", handlers.JWKS)
 
-	// Public routes with optional auth (for navbar state)
-	r.Group(func(r chi.Router) {
-		r.Use(mware.OptionalCookieAuth(cfg))
-		r.Get("/", handlers.Home)
-		r.Get("/login", handlers.LoginPage)
-		r.Get("/register", handlers.RegisterPage)
-		r.Get("/therapists", handlers.TherapistsPage)
-		r.Get("/therapists/{id}", handlers.TherapistDetailPage)
-		r.Get("/web/reviews/{therapistId}", handlers.GetReviewsWeb)
-	})
+	// Signed download links from the local blob store
+	r.Get("/files/*", handlers.ServeFiles)
+	r.Head("/files/*", handlers.ServeFiles)
 
-	// Protected routes (Web)
+	// Web UI: cookie-authenticated, so every state-changing request must
+	// carry the CSRF token the layout hands to HTMX
 	r.Group(func(r chi.Router) {
-		r.Use(mware.CookieAuth(cfg))
-		r.Get("/dashboard", handlers.DashboardPage)
-		r.Get("/dashboard/appointments", handlers.DashboardAppointments)
-		r.Put("/web/appointments/{id}/book", handlers.BookAppointmentWeb)
-		r.Get("/web/reviews/{therapistId}/form", handlers.GetReviewFormWeb)
-		r.Post("/web/reviews/{therapistId}", handlers.PostReviewWeb)
-		r.Get("/web/profile", handlers.GetProfileWeb)
-		r.Get("/web/profile/edit", handlers.GetProfileFormWeb)
-		r.Put("/web/profile", handlers.PutProfileWeb)
-		r.Post("/auth/logout", handlers.Logout)
-	})
-
-	// HTMX Form submissions
-	r.Post("/auth/login-form", handlers.LoginSubmit)
-	r.Post("/auth/register-form", handlers.RegisterSubmit)
+		r.Use(mware.CSRF(cfg))
 
-	// API routes
-	r.Route("/api", func(r chi.Router) {
-		// auth
-		r.Post("/auth/register", handlers.Register)
-		r.Post("/auth/login", handlers.Login)
-
-		// therapists (private)
+		// Public routes with optional auth (for navbar state)
 		r.Group(func(r chi.Router) {
-			r.Use(mware.JWTAuth(cfg))
-			r.Get("/therapists", handlers.GetAllTherapists)
-			r.Get("/therapists/{id}", handlers.GetTherapistByID)
+			r.Use(mware.OptionalCookieAuth(cfg))
+			r.Get("/", handlers.Home)
+			r.Get("/login", handlers.LoginPage)
+			r.Get("/register", handlers.RegisterPage)
+			r.Get("/therapists", handlers.TherapistsPage)
+			r.Get("/therapists/{id}", handlers.TherapistDetailPage)
+			r.Get("/web/reviews/{therapistId}", handlers.GetReviewsWeb)
 		})
 
-		// reviews (private)
+		// Protected routes (Web)
 		r.Group(func(r chi.Router) {
-			r.Use(mware.JWTAuth(cfg))
-			r.Post("/reviews", handlers.CreateReview)
-			r.Get("/reviews/{therapistId}", handlers.GetReviewsForTherapist)
+			r.Use(mware.CookieAuth(cfg))
+			r.Use(mware.EnforceMFAEnrollment)
+			r.Get("/dashboard", handlers.DashboardPage)
+			r.Get("/dashboard/appointments", handlers.DashboardAppointments)
+			r.Put("/web/appointments/{id}/book", handlers.BookAppointmentWeb)
+			r.Get("/web/reviews/{therapistId}/form", handlers.GetReviewFormWeb)
+			r.Post("/web/reviews/{therapistId}", handlers.PostReviewWeb)
+			r.Put("/web/favourites/{therapistId}", handlers.AddFavouriteWeb)
+			r.Delete("/web/favourites/{therapistId}", handlers.RemoveFavouriteWeb)
+			r.Get("/web/profile", handlers.GetProfileWeb)
+			r.Get("/web/profile/edit", handlers.GetProfileFormWeb)
+			r.Put("/web/profile", handlers.PutProfileWeb)
+			r.Get("/web/sessions", handlers.GetSessionsWeb)
+			r.Delete("/web/sessions/{id}", handlers.RevokeSessionWeb)
+			r.Get("/auth/oidc/{provider}/link", handlers.OIDCLinkStart)
+			r.Post("/auth/logout", handlers.Logout)
 		})
 
-		// Public — therapist availability (no auth)
-		r.Get("/appointments/availability", handlers.GetTherapistAvailability)
-		r.Get("/appointments/availability/{ptId}", handlers.GetTherapistAvailability)
+		// HTMX Form submissions
+		r.Post("/auth/login-form", handlers.LoginSubmit)
+		r.Post("/auth/login-mfa-form", handlers.LoginMFASubmit)
+		r.Post("/auth/register-form", handlers.RegisterSubmit)
 
-		// profile
-		r.Group(func(r chi.Router) {
-			r.Use(mware.JWTAuth(cfg))
-			r.Get("/profile/me", handlers.GetMyProfile)
-			r.Put("/profile/me", handlers.UpsertMyProfile)
-			r.Post("/profile", handlers.UpsertMyProfile)
-
-			// appointments (protected)
-			r.Post("/appointments/availability", handlers.CreateAvailability)
-			r.Get("/appointments/me", handlers.GetMyAppointments)
-			r.Put("/appointments/{id}/book", handlers.BookAppointment)
-			r.Put("/appointments/{id}/status", handlers.UpdateAppointmentStatus)
-		})
+		// OpenID Connect login, shared by the web UI and the mobile app
+		r.Get("/auth/oidc/{provider}", handlers.OIDCStart)
+		r.Get("/auth/oidc/{provider}/callback", handlers.OIDCCallback)
+	})
 
-		// reminders (private)
-		r.Group(func(r chi.Router) {
-			r.Use(mware.JWTAuth(cfg))
-			r.Get("/reminders/me", handlers.GetMyReminders)
-		})
+	// API routes, as described by openapi.yaml. Operations the spec serves
+	// from the root (JWKS, OpenID Connect) are mounted above instead.
+	openapi.HandlerWithOptions(handlers.API{}, openapi.ChiServerOptions{
+		BaseURL:          "/api",
+		BaseRouter:       apiRouter{r},
+		Middlewares:      []openapi.MiddlewareFunc{apiSecurity(cfg)},
+		ErrorHandlerFunc: handlers.APIParamError,
 	})
 
 	return r
 }
 
+// rootOperations are the operations openapi.yaml serves from the root, via
+// a path-level servers entry, rather than under /api.
+var rootOperations = map[string]bool{
+	"GET /.well-known/jwks.json":         true,
+	"GET /auth/oidc/{provider}":          true,
+	"GET /auth/oidc/{provider}/callback": true,
+}
+
+// apiRouter keeps the generated router, which ignores path-level servers,
+// from mounting rootOperations under /api as well.
+type apiRouter struct {
+	chi.Router
+}
+
+func (a apiRouter) Group(fn func(r chi.Router)) chi.Router {
+	return a.Router.Group(func(r chi.Router) { fn(apiRouter{r}) })
+}
+
+func (a apiRouter) Get(pattern string, h http.HandlerFunc) {
+	if rootOperations["GET "+strings.TrimPrefix(pattern, "/api")] {
+		return
+	}
+	a.Router.Get(pattern, h)
+}
+
+// mfaEnrollmentRoutes stay reachable while a required MFA enrollment is
+// pending, so the user can enroll and can always sign out a lost device.
+var mfaEnrollmentRoutes = map[string]bool{
+	"/api/auth/mfa":         true,
+	"/api/auth/mfa/enroll":  true,
+	"/api/auth/mfa/confirm": true,
+	"/api/auth/mfa/disable": true,
+	"/api/sessions":         true,
+	"/api/sessions/{id}":    true,
+}
+
+// apiSecurity authenticates each operation as its security requirement in
+// openapi.yaml says: bearerAuth scopes are the roles allowed (any when
+// empty), apiKey scopes the ones the clinic key must hold. Operations with
+// no requirement are public.
+func apiSecurity(cfg *config.Config) openapi.MiddlewareFunc {
+	session := mware.JWTAuth(cfg)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+			if scopes, ok := ctx.Value(openapi.ApiKeyScopes).([]string); ok {
+				mware.APIKeyAuth(scopes...)(next).ServeHTTP(w, r)
+				return
+			}
+			roles, ok := ctx.Value(openapi.BearerAuthScopes).([]string)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			h := next
+			if len(roles) > 0 {
+				h = mware.RequireRole(roles...)(h)
+			}
+			if !mfaEnrollmentRoutes[chi.RouteContext(ctx).RoutePattern()] {
+				h = mware.EnforceMFAEnrollment(h)
+			}
+			session(h).ServeHTTP(w, r)
+		})
+	}
+}
+
 // New returns a Server that wraps the configured router and listens on cfg.BindAddr.
 func New(cfg *config.Config) *Server {
 	srv := &http.Server{
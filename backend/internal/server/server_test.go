@@ -4,10 +4,13 @@ import (
 	"context"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/divijg19/physiolink/backend/internal/config"
+	"github.com/divijg19/physiolink/backend/internal/handlers"
+	mware "github.com/divijg19/physiolink/backend/internal/middleware"
 )
 
 func TestNewRouter_ReturnsHandler(t *testing.T) {
@@ -78,3 +81,41 @@ func TestListenAndServe_Shutdown(t *testing.T) {
 		t.Fatal("timed out waiting for server to stop")
 	}
 }
+
+func TestWebRoutes_RequireCSRFToken(t *testing.T) {
+	cfg := &config.Config{BindAddr: ":8080", Env: "development", JWTSecret: "secret"}
+	handlers.InitAuth(nil, cfg)
+	handler := NewRouter(cfg)
+
+	// The login page issues a token and renders it for HTMX
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodGet, "/login", nil))
+	var csrf *http.Cookie
+	for _, c := range rr.Result().Cookies() {
+		if c.Name == mware.CSRFCookieName {
+			csrf = c
+		}
+	}
+	if csrf == nil {
+		t.Fatal("expected csrf cookie")
+	}
+	if !strings.Contains(rr.Body.String(), csrf.Value) || !strings.Contains(rr.Body.String(), "hx-headers") {
+		t.Fatal("expected layout to render the csrf token into hx-headers")
+	}
+
+	// A cross-site logout without the header is refused before auth runs
+	req := httptest.NewRequest(http.MethodPost, "/auth/logout", nil)
+	req.AddCookie(csrf)
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+	if rr.Code != http.StatusForbidden {
+		t.Fatalf("expected 403, got %d", rr.Code)
+	}
+
+	// The API uses bearer tokens and is not affected
+	rr = httptest.NewRecorder()
+	handler.ServeHTTP(rr, httptest.NewRequest(http.MethodPost, "/api/auth/login/mfa", strings.NewReader("{}")))
+	if rr.Code == http.StatusForbidden {
+		t.Fatal("API routes must not require a csrf token")
+	}
+}
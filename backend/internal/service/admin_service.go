@@ -0,0 +1,662 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sqlc-dev/pqtype"
+
+	"github.com/divijg19/physiolink/backend/internal/db"
+)
+
+// RoleAdmin is granted only by another admin, never at signup.
+const RoleAdmin = "admin"
+
+var (
+	ErrNotAdmin         = errors.New("admin role required")
+	ErrUserNotFound     = errors.New("user not found")
+	ErrSelfAdminAction  = errors.New("admins cannot do this to their own account")
+	ErrImpersonateAdmin = errors.New("admins cannot be impersonated")
+)
+
+// Audit actions recorded in audit_log.
+const (
+	AuditUserDisabled  = "user.disabled"
+	AuditUserEnabled   = "user.enabled"
+	AuditRoleChanged   = "user.role_changed"
+	AuditPasswordReset = "user.password_reset_forced"
+	AuditImpersonation = "user.impersonated"
+	AuditAPIKeyCreated = "api_key.created"
+	AuditAPIKeyRevoked = "api_key.revoked"
+
+	AuditCredentialsApproved = "credentials.approved"
+	AuditCredentialsRejected = "credentials.rejected"
+
+	AuditReviewApproved = "review.approved"
+	AuditReviewHidden   = "review.hidden"
+	AuditReviewRestored = "review.restored"
+)
+
+// Moderation decisions on a review.
+const (
+	ReviewApprove = "approve"
+	ReviewHide    = "hide"
+	ReviewRestore = "restore"
+)
+
+var (
+	ErrInvalidReviewStatus      = errors.New("invalid review status")
+	ErrModerationReasonRequired = errors.New("a reason is required to hide a review")
+	ErrReviewDecision           = errors.New("review is not in a state this decision applies to")
+)
+
+// reviewDecisions lists, for each decision, the states it applies to, the
+// state it leads to and how it is audited. Approving a published review
+// dismisses its reports.
+var reviewDecisions = map[string]struct {
+	from  []string
+	to    string
+	audit string
+}{
+	ReviewApprove: {[]string{ReviewPending, ReviewPublished}, ReviewPublished, AuditReviewApproved},
+	ReviewHide:    {[]string{ReviewPending, ReviewPublished}, ReviewHidden, AuditReviewHidden},
+	ReviewRestore: {[]string{ReviewHidden}, ReviewPublished, AuditReviewRestored},
+}
+
+const (
+	defaultAdminPageLimit  = 20
+	maxAdminPageLimit      = 100
+	defaultAuditEventLimit = 50
+)
+
+type AdminUser struct {
+	ID                    uuid.UUID  `json:"_id"`
+	Email                 string     `json:"email"`
+	Role                  string     `json:"role"`
+	Disabled              bool       `json:"disabled"`
+	DisabledAt            *time.Time `json:"disabledAt,omitempty"`
+	PasswordResetRequired bool       `json:"passwordResetRequired"`
+	CreatedAt             time.Time  `json:"createdAt"`
+}
+
+type AdminUserQuery struct {
+	Query string
+	Role  string
+	Page  int
+	Limit int
+}
+
+type AdminUserList struct {
+	Data  []AdminUser `json:"data"`
+	Total int64       `json:"total"`
+	Page  int         `json:"page"`
+	Limit int         `json:"limit"`
+}
+
+type AuditEvent struct {
+	ID           uuid.UUID       `json:"_id"`
+	ActorID      *uuid.UUID      `json:"actorId"`
+	Action       string          `json:"action"`
+	TargetUserID *uuid.UUID      `json:"targetUserId"`
+	Details      json.RawMessage `json:"details,omitempty"`
+	CreatedAt    time.Time       `json:"createdAt"`
+}
+
+// ReviewReport is an open report against a review.
+type ReviewReport struct {
+	ReporterID uuid.UUID `json:"reporterId"`
+	Reason     string    `json:"reason"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+// ModeratedReview is a review as the moderation queue shows it.
+type ModeratedReview struct {
+	ID               uuid.UUID      `json:"_id"`
+	AppointmentID    uuid.UUID      `json:"appointmentId"`
+	TherapistID      uuid.UUID      `json:"therapistId"`
+	PatientID        uuid.UUID      `json:"patientId"`
+	Rating           int            `json:"rating"`
+	Comment          string         `json:"comment,omitempty"`
+	Status           string         `json:"status"`
+	ModerationReason string         `json:"moderationReason,omitempty"`
+	ModeratedBy      *uuid.UUID     `json:"moderatedBy,omitempty"`
+	ModeratedAt      *time.Time     `json:"moderatedAt,omitempty"`
+	Reports          []ReviewReport `json:"reports"`
+	CreatedAt        time.Time      `json:"createdAt"`
+	UpdatedAt        *time.Time     `json:"updatedAt,omitempty"`
+}
+
+type AdminService struct {
+	db *db.DB
+}
+
+func NewAdminService(d *db.DB) *AdminService {
+	return &AdminService{db: d}
+}
+
+// AdminRole validates a role an admin may assign.
+func AdminRole(role string) (string, error) {
+	if role == RoleAdmin {
+		return role, nil
+	}
+	if role == "" {
+		return "", ErrInvalidRole
+	}
+	return SignupRole(role)
+}
+
+func (s *AdminService) ListUsers(ctx context.Context, actorID uuid.UUID, q AdminUserQuery) (AdminUserList, error) {
+	var out AdminUserList
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return out, err
+	}
+	if q.Page < 1 {
+		q.Page = 1
+	}
+	if q.Limit < 1 {
+		q.Limit = defaultAdminPageLimit
+	}
+	if q.Limit > maxAdminPageLimit {
+		q.Limit = maxAdminPageLimit
+	}
+	search := strings.TrimSpace(q.Query)
+	total, err := s.db.Queries.CountUsers(ctx, db.CountUsersParams{Column1: search, Column2: q.Role})
+	if err != nil {
+		return out, err
+	}
+	rows, err := s.db.Queries.ListUsers(ctx, db.ListUsersParams{
+		Column1: search,
+		Column2: q.Role,
+		Limit:   int32(q.Limit),
+		Offset:  int32((q.Page - 1) * q.Limit),
+	})
+	if err != nil {
+		return out, err
+	}
+	out.Data = make([]AdminUser, 0, len(rows))
+	for _, u := range rows {
+		out.Data = append(out.Data, toAdminUser(u))
+	}
+	out.Total, out.Page, out.Limit = total, q.Page, q.Limit
+	return out, nil
+}
+
+// SetDisabled disables or re-enables an account. Disabled users cannot sign
+// in with a password or an identity provider, and their sessions are revoked.
+func (s *AdminService) SetDisabled(ctx context.Context, actorID, userID uuid.UUID, disabled bool) error {
+	if actorID == userID {
+		return ErrSelfAdminAction
+	}
+	return s.mutate(ctx, actorID, userTarget(userID), func(q *db.Queries) (string, interface{}, error) {
+		if disabled {
+			n, err := q.DisableUser(ctx, userID)
+			if err = rowsOrNotFound(n, err); err != nil {
+				return "", nil, err
+			}
+			return AuditUserDisabled, nil, q.RevokeUserSessions(ctx, userID)
+		}
+		n, err := q.EnableUser(ctx, userID)
+		return AuditUserEnabled, nil, rowsOrNotFound(n, err)
+	})
+}
+
+// ChangeRole sets the user's role and revokes their sessions, whose tokens
+// still carry the old one.
+func (s *AdminService) ChangeRole(ctx context.Context, actorID, userID uuid.UUID, role string) error {
+	role, err := AdminRole(role)
+	if err != nil {
+		return err
+	}
+	if actorID == userID {
+		return ErrSelfAdminAction
+	}
+	return s.mutate(ctx, actorID, userTarget(userID), func(q *db.Queries) (string, interface{}, error) {
+		u, err := q.GetUserByID(ctx, userID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return "", nil, ErrUserNotFound
+			}
+			return "", nil, err
+		}
+		n, err := q.UpdateUserRole(ctx, db.UpdateUserRoleParams{ID: userID, Role: role})
+		if err = rowsOrNotFound(n, err); err != nil {
+			return "", nil, err
+		}
+		return AuditRoleChanged, map[string]string{"from": u.Role, "to": role}, q.RevokeUserSessions(ctx, userID)
+	})
+}
+
+// ForcePasswordReset revokes the user's sessions and blocks password sign-in
+// until they set a new password. It returns the fingerprint to embed in the
+// reset token.
+func (s *AdminService) ForcePasswordReset(ctx context.Context, actorID, userID uuid.UUID) (string, error) {
+	var fingerprint string
+	err := s.mutate(ctx, actorID, userTarget(userID), func(q *db.Queries) (string, interface{}, error) {
+		u, err := q.GetUserByID(ctx, userID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return "", nil, ErrUserNotFound
+			}
+			return "", nil, err
+		}
+		fingerprint = PasswordFingerprint(u.PasswordHash)
+		n, err := q.RequirePasswordReset(ctx, userID)
+		if err = rowsOrNotFound(n, err); err != nil {
+			return "", nil, err
+		}
+		return AuditPasswordReset, nil, q.RevokeUserSessions(ctx, userID)
+	})
+	return fingerprint, err
+}
+
+// Impersonate records that an admin is about to act as userID and returns
+// the user to issue the session for. reason is kept in the audit trail.
+func (s *AdminService) Impersonate(ctx context.Context, actorID, userID uuid.UUID, reason string) (AdminUser, error) {
+	var target AdminUser
+	if actorID == userID {
+		return target, ErrSelfAdminAction
+	}
+	err := s.mutate(ctx, actorID, userTarget(userID), func(q *db.Queries) (string, interface{}, error) {
+		u, err := q.GetUserByID(ctx, userID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return "", nil, ErrUserNotFound
+			}
+			return "", nil, err
+		}
+		if u.Role == RoleAdmin {
+			return "", nil, ErrImpersonateAdmin
+		}
+		if u.DisabledAt.Valid {
+			return "", nil, ErrAccountDisabled
+		}
+		target = toAdminUser(u)
+		return AuditImpersonation, map[string]string{"reason": reason}, nil
+	})
+	return target, err
+}
+
+// AuditLog lists recent admin actions, optionally for a single user.
+func (s *AdminService) AuditLog(ctx context.Context, actorID uuid.UUID, target uuid.NullUUID, limit int) ([]AuditEvent, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+	if limit < 1 || limit > maxAdminPageLimit {
+		limit = defaultAuditEventLimit
+	}
+	rows, err := s.db.Queries.ListAuditEvents(ctx, db.ListAuditEventsParams{TargetUserID: target, Limit: int32(limit)})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]AuditEvent, 0, len(rows))
+	for _, r := range rows {
+		e := AuditEvent{ID: r.ID, Action: r.Action, CreatedAt: r.CreatedAt}
+		if r.ActorID.Valid {
+			e.ActorID = &r.ActorID.UUID
+		}
+		if r.TargetUserID.Valid {
+			e.TargetUserID = &r.TargetUserID.UUID
+		}
+		if r.Details.Valid {
+			e.Details = r.Details.RawMessage
+		}
+		out = append(out, e)
+	}
+	return out, nil
+}
+
+// CreateAPIKey issues a key for a clinic's integrations. The returned key is
+// shown once; only its hash is kept.
+func (s *AdminService) CreateAPIKey(ctx context.Context, actorID, clinicID uuid.UUID, name string, scopes []string) (NewAPIKey, error) {
+	var out NewAPIKey
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return out, ErrAPIKeyNameEmpty
+	}
+	scopes, err := APIKeyScopes(scopes)
+	if err != nil {
+		return out, err
+	}
+	key, prefix, err := generateAPIKey()
+	if err != nil {
+		return out, err
+	}
+	err = s.mutate(ctx, actorID, uuid.NullUUID{}, func(q *db.Queries) (string, interface{}, error) {
+		ok, err := q.ClinicExists(ctx, clinicID)
+		if err != nil {
+			return "", nil, err
+		}
+		if !ok {
+			return "", nil, ErrClinicNotFound
+		}
+		k, err := q.CreateAPIKey(ctx, db.CreateAPIKeyParams{
+			ClinicID:  clinicID,
+			Name:      name,
+			Prefix:    prefix,
+			KeyHash:   hashAPIKey(key),
+			Scopes:    scopes,
+			CreatedBy: uuid.NullUUID{UUID: actorID, Valid: true},
+		})
+		if err != nil {
+			return "", nil, err
+		}
+		out = NewAPIKey{APIKey: toAPIKey(k), Key: key}
+		return AuditAPIKeyCreated, map[string]interface{}{
+			"clinicId": clinicID,
+			"keyId":    k.ID,
+			"prefix":   out.Prefix,
+			"scopes":   scopes,
+		}, nil
+	})
+	return out, err
+}
+
+func (s *AdminService) ListAPIKeys(ctx context.Context, actorID, clinicID uuid.UUID) ([]APIKey, error) {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+	rows, err := s.db.Queries.ListClinicAPIKeys(ctx, clinicID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]APIKey, 0, len(rows))
+	for _, k := range rows {
+		out = append(out, toAPIKey(k))
+	}
+	return out, nil
+}
+
+func (s *AdminService) RevokeAPIKey(ctx context.Context, actorID, clinicID, keyID uuid.UUID) error {
+	return s.mutate(ctx, actorID, uuid.NullUUID{}, func(q *db.Queries) (string, interface{}, error) {
+		n, err := q.RevokeAPIKey(ctx, db.RevokeAPIKeyParams{ID: keyID, ClinicID: clinicID})
+		if err != nil {
+			return "", nil, err
+		}
+		if n == 0 {
+			return "", nil, ErrAPIKeyNotFound
+		}
+		return AuditAPIKeyRevoked, map[string]interface{}{"clinicId": clinicID, "keyId": keyID}, nil
+	})
+}
+
+// ListCredentialSubmissions is the verification queue, oldest first. status
+// narrows it to one state; empty lists every submission.
+func (s *AdminService) ListCredentialSubmissions(ctx context.Context, actorID uuid.UUID, status string, limit int) ([]CredentialSubmission, error) {
+	switch status {
+	case "", CredentialPending, CredentialApproved, CredentialRejected:
+	default:
+		return nil, ErrInvalidSubmissionStatus
+	}
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+	if limit < 1 || limit > maxAdminPageLimit {
+		limit = defaultAdminPageLimit
+	}
+	rows, err := s.db.Queries.ListCredentialSubmissions(ctx, db.ListCredentialSubmissionsParams{Column1: status, Limit: int32(limit)})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]CredentialSubmission, 0, len(rows))
+	for _, r := range rows {
+		c := toCredentialSubmission(db.CredentialSubmission{
+			ID:            r.ID,
+			TherapistID:   r.TherapistID,
+			LicenceNumber: r.LicenceNumber,
+			IssuingBody:   r.IssuingBody,
+			Documents:     r.Documents,
+			Status:        r.Status,
+			ReviewNotes:   r.ReviewNotes,
+			ReviewedBy:    r.ReviewedBy,
+			ReviewedAt:    r.ReviewedAt,
+			CreatedAt:     r.CreatedAt,
+		})
+		c.TherapistName, c.TherapistEmail = r.DisplayName, r.Email
+		out = append(out, c)
+	}
+	return out, nil
+}
+
+// ReviewCredentials approves or rejects a pending submission. Approval marks
+// the therapist's profile verified; a rejection must say why, and the
+// therapist sees the notes.
+func (s *AdminService) ReviewCredentials(ctx context.Context, actorID, submissionID uuid.UUID, approve bool, notes string) (CredentialSubmission, error) {
+	var out CredentialSubmission
+	notes = strings.TrimSpace(notes)
+	status, action := CredentialApproved, AuditCredentialsApproved
+	if !approve {
+		if notes == "" {
+			return out, ErrRejectionReasonRequired
+		}
+		status, action = CredentialRejected, AuditCredentialsRejected
+	}
+	existing, err := s.db.Queries.GetCredentialSubmission(ctx, submissionID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return out, ErrSubmissionNotFound
+		}
+		return out, err
+	}
+	err = s.mutate(ctx, actorID, userTarget(existing.TherapistID), func(q *db.Queries) (string, interface{}, error) {
+		c, err := q.ReviewCredentialSubmission(ctx, db.ReviewCredentialSubmissionParams{
+			ID:          submissionID,
+			Status:      status,
+			ReviewNotes: sql.NullString{String: notes, Valid: notes != ""},
+			ReviewedBy:  uuid.NullUUID{UUID: actorID, Valid: true},
+		})
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return "", nil, ErrSubmissionReviewed
+			}
+			return "", nil, err
+		}
+		if approve {
+			if err := q.SetProfileVerified(ctx, c.TherapistID); err != nil {
+				return "", nil, err
+			}
+		}
+		out = toCredentialSubmission(c)
+		return action, map[string]interface{}{"submissionId": submissionID, "notes": notes}, nil
+	})
+	return out, err
+}
+
+// ListReviewModerationQueue lists reviews oldest first. status narrows it to
+// one state; reported keeps only reviews with open reports.
+func (s *AdminService) ListReviewModerationQueue(ctx context.Context, actorID uuid.UUID, status string, reported bool, limit int) ([]ModeratedReview, error) {
+	switch status {
+	case "", ReviewPending, ReviewPublished, ReviewHidden:
+	default:
+		return nil, ErrInvalidReviewStatus
+	}
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return nil, err
+	}
+	if limit < 1 || limit > maxAdminPageLimit {
+		limit = defaultAdminPageLimit
+	}
+	rows, err := s.db.Queries.ListReviewModerationQueue(ctx, db.ListReviewModerationQueueParams{Column1: status, Column2: reported, Limit: int32(limit)})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]ModeratedReview, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, toModeratedReview(db.GetModeratedReviewRow(r)))
+	}
+	return out, nil
+}
+
+// ModerateReview approves, hides or restores a review and resolves its open
+// reports. Hiding needs a reason; the therapist's rating follows the change.
+func (s *AdminService) ModerateReview(ctx context.Context, actorID, reviewID uuid.UUID, decision, reason string) (ModeratedReview, error) {
+	var out ModeratedReview
+	d, ok := reviewDecisions[decision]
+	if !ok {
+		return out, ErrReviewDecision
+	}
+	reason = strings.TrimSpace(reason)
+	if decision == ReviewHide && reason == "" {
+		return out, ErrModerationReasonRequired
+	}
+	existing, err := s.db.Queries.GetReviewStatus(ctx, reviewID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return out, ErrReviewNotFound
+		}
+		return out, err
+	}
+	err = s.mutate(ctx, actorID, userTarget(existing.PatientID), func(q *db.Queries) (string, interface{}, error) {
+		rev, err := q.GetReviewForUpdate(ctx, reviewID)
+		if err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return "", nil, ErrReviewNotFound
+			}
+			return "", nil, err
+		}
+		if !slices.Contains(d.from, rev.Status) {
+			return "", nil, ErrReviewDecision
+		}
+		if err := q.ModerateReview(ctx, db.ModerateReviewParams{
+			ID:               reviewID,
+			Status:           d.to,
+			ModerationReason: sql.NullString{String: reason, Valid: reason != ""},
+			ModeratedBy:      uuid.NullUUID{UUID: actorID, Valid: true},
+		}); err != nil {
+			return "", nil, err
+		}
+		if err := q.ResolveReviewReports(ctx, reviewID); err != nil {
+			return "", nil, err
+		}
+		switch {
+		case rev.Status == ReviewPublished && d.to != ReviewPublished:
+			err = countRating(ctx, q, rev.TherapistID, rev.Rating, reviewSubRatings(rev), -1)
+		case rev.Status != ReviewPublished && d.to == ReviewPublished:
+			err = countRating(ctx, q, rev.TherapistID, rev.Rating, reviewSubRatings(rev), 1)
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		m, err := q.GetModeratedReview(ctx, reviewID)
+		if err != nil {
+			return "", nil, err
+		}
+		out = toModeratedReview(m)
+		return d.audit, map[string]interface{}{"reviewId": reviewID, "from": rev.Status, "reason": reason}, nil
+	})
+	return out, err
+}
+
+func toModeratedReview(r db.GetModeratedReviewRow) ModeratedReview {
+	out := ModeratedReview{
+		ID:               r.ID,
+		AppointmentID:    r.AppointmentID,
+		TherapistID:      r.TherapistID,
+		PatientID:        r.PatientID,
+		Rating:           int(r.Rating),
+		Comment:          r.Comment.String,
+		Status:           r.Status,
+		ModerationReason: r.ModerationReason.String,
+		Reports:          []ReviewReport{},
+		CreatedAt:        r.CreatedAt,
+	}
+	_ = json.Unmarshal(r.Reports, &out.Reports)
+	if r.ModeratedBy.Valid {
+		out.ModeratedBy = &r.ModeratedBy.UUID
+	}
+	if r.ModeratedAt.Valid {
+		out.ModeratedAt = &r.ModeratedAt.Time
+	}
+	if r.UpdatedAt.Valid {
+		out.UpdatedAt = &r.UpdatedAt.Time
+	}
+	return out
+}
+
+// requireAdmin re-checks the actor against the database: the role in their
+// token may predate a demotion or a disabled account.
+func (s *AdminService) requireAdmin(ctx context.Context, actorID uuid.UUID) error {
+	u, err := s.db.Queries.GetUserByID(ctx, actorID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrNotAdmin
+		}
+		return err
+	}
+	if u.Role != RoleAdmin || u.DisabledAt.Valid {
+		return ErrNotAdmin
+	}
+	return nil
+}
+
+// mutate runs fn and records its audit event in the same transaction, so an
+// action is never applied without its audit entry.
+func (s *AdminService) mutate(ctx context.Context, actorID uuid.UUID, target uuid.NullUUID, fn func(q *db.Queries) (string, interface{}, error)) error {
+	if err := s.requireAdmin(ctx, actorID); err != nil {
+		return err
+	}
+	tx, err := s.db.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	qtx := s.db.Queries.WithTx(tx)
+
+	action, details, err := fn(qtx)
+	if err != nil {
+		return err
+	}
+	var raw pqtype.NullRawMessage
+	if details != nil {
+		b, err := json.Marshal(details)
+		if err != nil {
+			return err
+		}
+		raw = pqtype.NullRawMessage{RawMessage: b, Valid: true}
+	}
+	if err := qtx.InsertAuditEvent(ctx, db.InsertAuditEventParams{
+		ActorID:      uuid.NullUUID{UUID: actorID, Valid: true},
+		Action:       action,
+		TargetUserID: target,
+		Details:      raw,
+	}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func userTarget(id uuid.UUID) uuid.NullUUID {
+	return uuid.NullUUID{UUID: id, Valid: true}
+}
+
+func rowsOrNotFound(n int64, err error) error {
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+func toAdminUser(u db.User) AdminUser {
+	out := AdminUser{
+		ID:                    u.ID,
+		Email:                 u.Email,
+		Role:                  u.Role,
+		Disabled:              u.DisabledAt.Valid,
+		PasswordResetRequired: u.PasswordResetRequired,
+		CreatedAt:             u.CreatedAt,
+	}
+	if u.DisabledAt.Valid {
+		out.DisabledAt = &u.DisabledAt.Time
+	}
+	return out
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"database/sql"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/db"
+)
+
+// APIKeyPrefix starts every key so it can be told apart from a session JWT
+// and spotted by secret scanners.
+const APIKeyPrefix = "plk_"
+
+// Scopes an API key can be granted.
+const (
+	ScopeAppointmentsRead  = "appointments:read"
+	ScopeAvailabilityRead  = "availability:read"
+	ScopeAvailabilityWrite = "availability:write"
+)
+
+var apiKeyScopes = map[string]bool{
+	ScopeAppointmentsRead:  true,
+	ScopeAvailabilityRead:  true,
+	ScopeAvailabilityWrite: true,
+}
+
+var (
+	ErrInvalidAPIKey   = errors.New("invalid api key")
+	ErrInvalidScope    = errors.New("invalid api key scope")
+	ErrClinicNotFound  = errors.New("clinic not found")
+	ErrAPIKeyNotFound  = errors.New("api key not found")
+	ErrAPIKeyNameEmpty = errors.New("api key name required")
+)
+
+// APIKey describes a key without its secret.
+type APIKey struct {
+	ID         uuid.UUID  `json:"_id"`
+	ClinicID   uuid.UUID  `json:"clinicId"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix"`
+	Scopes     []string   `json:"scopes"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+	RevokedAt  *time.Time `json:"revokedAt,omitempty"`
+}
+
+// NewAPIKey is returned once, at creation; only its hash is stored.
+type NewAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+type APIKeyService struct {
+	db *db.DB
+}
+
+func NewAPIKeyService(d *db.DB) *APIKeyService {
+	return &APIKeyService{db: d}
+}
+
+// AuthenticateAPIKey resolves a presented key to its clinic and scopes and
+// records that it was used.
+func (s *APIKeyService) AuthenticateAPIKey(ctx context.Context, key string) (uuid.UUID, uuid.UUID, []string, error) {
+	prefix, ok := apiKeyLookupPrefix(key)
+	if !ok {
+		return uuid.Nil, uuid.Nil, nil, ErrInvalidAPIKey
+	}
+	k, err := s.db.Queries.GetAPIKeyByPrefix(ctx, prefix)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, uuid.Nil, nil, ErrInvalidAPIKey
+		}
+		return uuid.Nil, uuid.Nil, nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(hashAPIKey(key)), []byte(k.KeyHash)) != 1 || k.RevokedAt.Valid {
+		return uuid.Nil, uuid.Nil, nil, ErrInvalidAPIKey
+	}
+	if err := s.db.Queries.TouchAPIKey(ctx, k.ID); err != nil {
+		return uuid.Nil, uuid.Nil, nil, err
+	}
+	return k.ID, k.ClinicID, k.Scopes, nil
+}
+
+// APIKeyScopes validates and normalises requested scopes.
+func APIKeyScopes(scopes []string) ([]string, error) {
+	seen := map[string]bool{}
+	out := make([]string, 0, len(scopes))
+	for _, sc := range scopes {
+		if !apiKeyScopes[sc] {
+			return nil, ErrInvalidScope
+		}
+		if !seen[sc] {
+			seen[sc] = true
+			out = append(out, sc)
+		}
+	}
+	if len(out) == 0 {
+		return nil, ErrInvalidScope
+	}
+	sort.Strings(out)
+	return out, nil
+}
+
+// generateAPIKey returns a key of the form plk_<prefix>_<secret> and its
+// lookup prefix.
+func generateAPIKey() (key, prefix string, err error) {
+	p := make([]byte, 5)
+	secret := make([]byte, 32)
+	if _, err := rand.Read(p); err != nil {
+		return "", "", err
+	}
+	if _, err := rand.Read(secret); err != nil {
+		return "", "", err
+	}
+	prefix = strings.ToLower(base32.StdEncoding.EncodeToString(p))
+	return APIKeyPrefix + prefix + "_" + base64.RawURLEncoding.EncodeToString(secret), prefix, nil
+}
+
+func apiKeyLookupPrefix(key string) (string, bool) {
+	rest, ok := strings.CutPrefix(key, APIKeyPrefix)
+	if !ok {
+		return "", false
+	}
+	prefix, secret, ok := strings.Cut(rest, "_")
+	if !ok || prefix == "" || secret == "" {
+		return "", false
+	}
+	return prefix, true
+}
+
+func hashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func toAPIKey(k db.ApiKey) APIKey {
+	out := APIKey{
+		ID:        k.ID,
+		ClinicID:  k.ClinicID,
+		Name:      k.Name,
+		Prefix:    APIKeyPrefix + k.Prefix,
+		Scopes:    k.Scopes,
+		CreatedAt: k.CreatedAt,
+	}
+	if k.LastUsedAt.Valid {
+		out.LastUsedAt = &k.LastUsedAt.Time
+	}
+	if k.RevokedAt.Valid {
+		out.RevokedAt = &k.RevokedAt.Time
+	}
+	return out
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestGenerateAPIKey_PrefixIdentifiesKey(t *testing.T) {
+	key, prefix, err := generateAPIKey()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(key, APIKeyPrefix+prefix+"_") {
+		t.Fatalf("key %q does not start with its prefix %q", key, prefix)
+	}
+	got, ok := apiKeyLookupPrefix(key)
+	if !ok || got != prefix {
+		t.Fatalf("lookup prefix = %q, %v; want %q", got, ok, prefix)
+	}
+	other, _, _ := generateAPIKey()
+	if hashAPIKey(key) == hashAPIKey(other) {
+		t.Fatalf("distinct keys must not share a hash")
+	}
+	for _, bad := range []string{"", "plk_", "plk_abc", "plk__secret", "eyJhbGciOi.x.y"} {
+		if _, ok := apiKeyLookupPrefix(bad); ok {
+			t.Fatalf("expected %q to be rejected", bad)
+		}
+	}
+}
+
+func TestAPIKeyScopes_ValidatesAndNormalises(t *testing.T) {
+	got, err := APIKeyScopes([]string{ScopeAvailabilityWrite, ScopeAppointmentsRead, ScopeAvailabilityWrite})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := []string{ScopeAppointmentsRead, ScopeAvailabilityWrite}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for _, bad := range [][]string{nil, {"admin"}, {ScopeAppointmentsRead, "appointments:write"}} {
+		if _, err := APIKeyScopes(bad); !errors.Is(err, ErrInvalidScope) {
+			t.Fatalf("%v: expected ErrInvalidScope, got %v", bad, err)
+		}
+	}
+}
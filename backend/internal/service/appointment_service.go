@@ -12,6 +12,7 @@ import (
 	"go.temporal.io/sdk/client"
 
 	"github.com/divijg19/physiolink/backend/internal/db"
+	"github.com/divijg19/physiolink/backend/internal/openapi"
 	"github.com/divijg19/physiolink/backend/internal/workflows"
 )
 
@@ -150,42 +151,73 @@ func (s *AppointmentService) ListMyAppointments(ctx context.Context, userID uuid
 
 	var out []AppointmentBrief
 	for _, r := range rows {
-		// derive names
-		ptFirst, ptLast := splitDisplayName(r.PtDisplayName.String)
-		paFirst, paLast := splitDisplayName(r.PaDisplayName.String)
-
-		a := AppointmentBrief{
-			ID:     r.ID.String(),
-			Start:  r.StartTs.Format(time.RFC3339),
-			End:    r.EndTs.Format(time.RFC3339),
-			Status: r.Status,
-			PT: map[string]interface{}{
-				"_id": r.TherapistID.String(),
-				"profile": map[string]interface{}{
-					"firstName": ptFirst,
-					"lastName":  ptLast,
-				},
-			},
-			Patient: map[string]interface{}{
-				"_id": r.PatientID.String(),
-				"profile": map[string]interface{}{
-					"firstName": paFirst,
-					"lastName":  paLast,
-				},
-			},
-		}
-		out = append(out, a)
+		out = append(out, appointmentBrief(r))
 	}
 	return out, nil
 }
 
+// AppointmentPage is one page of a user's appointments, soonest first.
+type AppointmentPage struct {
+	Data       []AppointmentBrief `json:"data"`
+	NextCursor string             `json:"nextCursor,omitempty"`
+}
+
+type appointmentCursor struct {
+	StartTs time.Time `json:"s"`
+	ID      uuid.UUID `json:"i"`
+}
+
+// ListMyAppointmentsPage pages through ListMyAppointments with a keyset
+// cursor; pass "" for the first page.
+func (s *AppointmentService) ListMyAppointmentsPage(ctx context.Context, userID uuid.UUID, role, cursor string, limit int) (AppointmentPage, error) {
+	limit = pageLimit(limit)
+	var cur appointmentCursor
+	if cursor != "" {
+		if err := decodeCursor(cursor, &cur); err != nil {
+			return AppointmentPage{}, err
+		}
+	}
+	rows, err := s.db.Queries.ListMyAppointmentsPage(ctx, db.ListMyAppointmentsPageParams{
+		TherapistID: userID,
+		Column2:     role,
+		Column3:     cursor != "",
+		Column4:     cur.StartTs,
+		Column5:     cur.ID,
+		Limit:       int32(limit + 1),
+	})
+	if err != nil {
+		return AppointmentPage{}, err
+	}
+	page := AppointmentPage{Data: make([]AppointmentBrief, 0, len(rows))}
+	if len(rows) > limit {
+		rows = rows[:limit]
+		last := rows[limit-1]
+		page.NextCursor = encodeCursor(appointmentCursor{StartTs: last.StartTs, ID: last.ID})
+	}
+	for _, r := range rows {
+		page.Data = append(page.Data, appointmentBrief(db.ListMyAppointmentsWithDetailsRow(r)))
+	}
+	return page, nil
+}
+
+func appointmentBrief(r db.ListMyAppointmentsWithDetailsRow) AppointmentBrief {
+	return AppointmentBrief{
+		ID:      r.ID.String(),
+		Start:   r.StartTs.Format(time.RFC3339),
+		End:     r.EndTs.Format(time.RFC3339),
+		Status:  r.Status,
+		PT:      userRef(r.TherapistID, r.PtDisplayName.String),
+		Patient: userRef(r.PatientID, r.PaDisplayName.String),
+	}
+}
+
 type AppointmentBrief struct {
-	ID      string                 `json:"_id"`
-	PT      map[string]interface{} `json:"pt"`
-	Patient map[string]interface{} `json:"patient"`
-	Start   string                 `json:"startTime"`
-	End     string                 `json:"endTime"`
-	Status  string                 `json:"status"`
+	ID      string           `json:"_id"`
+	PT      *openapi.UserRef `json:"pt"`
+	Patient *openapi.UserRef `json:"patient"`
+	Start   string           `json:"startTime"`
+	End     string           `json:"endTime"`
+	Status  string           `json:"status"`
 }
 
 func splitDisplayName(s string) (string, string) {
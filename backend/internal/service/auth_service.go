@@ -2,7 +2,10 @@ package service
 
 import (
 	"context"
+	"crypto/sha256"
+	"crypto/subtle"
 	"database/sql"
+	"encoding/hex"
 	"errors"
 
 	"github.com/google/uuid"
@@ -22,14 +25,21 @@ func NewAuthService(d *db.DB, cfg *config.Config) *AuthService {
 }
 
 var (
-	ErrUserExists         = errors.New("user already exists")
-	ErrInvalidCredentials = errors.New("invalid credentials")
+	ErrUserExists            = errors.New("user already exists")
+	ErrInvalidCredentials    = errors.New("invalid credentials")
+	ErrAccountDisabled       = errors.New("account disabled")
+	ErrPasswordResetRequired = errors.New("password reset required")
+	ErrInvalidResetToken     = errors.New("invalid or expired reset token")
 )
 
 func (s *AuthService) Register(ctx context.Context, email, password, role string) (uuid.UUID, string, error) {
 	if email == "" || password == "" {
 		return uuid.Nil, "", errors.New("email and password required")
 	}
+	role, err := SignupRole(role)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
 	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
 	if err != nil {
 		return uuid.Nil, "", err
@@ -59,5 +69,48 @@ func (s *AuthService) Authenticate(ctx context.Context, email, password string)
 	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
 		return uuid.Nil, "", ErrInvalidCredentials
 	}
+	if user.DisabledAt.Valid {
+		return uuid.Nil, "", ErrAccountDisabled
+	}
+	if user.PasswordResetRequired {
+		return uuid.Nil, "", ErrPasswordResetRequired
+	}
 	return user.ID, user.Role, nil
 }
+
+// ResetPassword sets a new password using a reset token issued by an admin.
+// fingerprint ties the token to the password hash it was issued against, so
+// it stops working once any new password is set.
+func (s *AuthService) ResetPassword(ctx context.Context, userID uuid.UUID, fingerprint, password string) error {
+	if password == "" {
+		return errors.New("password required")
+	}
+	user, err := s.db.Queries.GetUserByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrInvalidResetToken
+		}
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(PasswordFingerprint(user.PasswordHash)), []byte(fingerprint)) != 1 {
+		return ErrInvalidResetToken
+	}
+	if user.DisabledAt.Valid {
+		return ErrAccountDisabled
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+	if _, err := s.db.Queries.UpdateUserPassword(ctx, db.UpdateUserPasswordParams{ID: userID, PasswordHash: string(hash)}); err != nil {
+		return err
+	}
+	// Whoever knew the old password may still hold a session
+	return s.db.Queries.RevokeUserSessions(ctx, userID)
+}
+
+// PasswordFingerprint identifies a password hash without revealing it.
+func PasswordFingerprint(hash string) string {
+	sum := sha256.Sum256([]byte("pwd-reset:" + hash))
+	return hex.EncodeToString(sum[:16])
+}
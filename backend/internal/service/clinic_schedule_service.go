@@ -0,0 +1,141 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/db"
+)
+
+// maxScheduleWindow bounds a single schedule export.
+const maxScheduleWindow = 93 * 24 * time.Hour
+
+var (
+	ErrInvalidWindow   = errors.New("invalid time window")
+	ErrNotClinicMember = errors.New("therapist does not belong to this clinic")
+	ErrInvalidSlot     = errors.New("invalid slot")
+)
+
+// ClinicAppointment is an appointment as exported to a clinic's systems.
+type ClinicAppointment struct {
+	ID            uuid.UUID `json:"_id"`
+	TherapistID   uuid.UUID `json:"therapistId"`
+	TherapistName string    `json:"therapistName"`
+	PatientID     uuid.UUID `json:"patientId"`
+	PatientName   string    `json:"patientName"`
+	Status        string    `json:"status"`
+	StartTime     time.Time `json:"startTime"`
+	EndTime       time.Time `json:"endTime"`
+}
+
+// ClinicSlot is an open availability slot of one of a clinic's therapists.
+type ClinicSlot struct {
+	ID          uuid.UUID `json:"_id"`
+	TherapistID uuid.UUID `json:"therapistId"`
+	StartTime   time.Time `json:"startTime"`
+	EndTime     time.Time `json:"endTime"`
+	Status      string    `json:"status"`
+}
+
+// ClinicScheduleService serves the clinic integration API. Every call is
+// scoped to the clinic of the API key making it.
+type ClinicScheduleService struct {
+	db *db.DB
+}
+
+func NewClinicScheduleService(d *db.DB) *ClinicScheduleService {
+	return &ClinicScheduleService{db: d}
+}
+
+func (s *ClinicScheduleService) Appointments(ctx context.Context, clinicID uuid.UUID, from, to time.Time) ([]ClinicAppointment, error) {
+	if err := checkWindow(from, to); err != nil {
+		return nil, err
+	}
+	rows, err := s.db.Queries.ListClinicAppointments(ctx, db.ListClinicAppointmentsParams{
+		ClinicID:  uuid.NullUUID{UUID: clinicID, Valid: true},
+		StartTs:   from,
+		StartTs_2: to,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]ClinicAppointment, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, ClinicAppointment{
+			ID:            r.ID,
+			TherapistID:   r.TherapistID,
+			TherapistName: r.PtDisplayName.String,
+			PatientID:     r.PatientID,
+			PatientName:   r.PaDisplayName.String,
+			Status:        r.Status,
+			StartTime:     r.StartTs,
+			EndTime:       r.EndTs,
+		})
+	}
+	return out, nil
+}
+
+func (s *ClinicScheduleService) OpenSlots(ctx context.Context, clinicID uuid.UUID, from, to time.Time) ([]ClinicSlot, error) {
+	if err := checkWindow(from, to); err != nil {
+		return nil, err
+	}
+	rows, err := s.db.Queries.ListClinicOpenSlots(ctx, db.ListClinicOpenSlotsParams{
+		ClinicID:  uuid.NullUUID{UUID: clinicID, Valid: true},
+		StartTs:   from,
+		StartTs_2: to,
+	})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]ClinicSlot, 0, len(rows))
+	for _, r := range rows {
+		out = append(out, ClinicSlot{
+			ID:          r.ID,
+			TherapistID: r.TherapistID,
+			StartTime:   r.StartTs,
+			EndTime:     r.EndTs,
+			Status:      r.Status,
+		})
+	}
+	return out, nil
+}
+
+// CreateSlots publishes availability for one of the clinic's therapists.
+func (s *ClinicScheduleService) CreateSlots(ctx context.Context, clinicID, therapistID uuid.UUID, slots []struct{ StartTs, EndTs string }) error {
+	ok, err := s.db.Queries.IsClinicTherapist(ctx, db.IsClinicTherapistParams{
+		ID:       therapistID,
+		ClinicID: uuid.NullUUID{UUID: clinicID, Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotClinicMember
+	}
+	// Validate the whole batch before writing any of it
+	params := make([]db.CreateAvailabilitySlotsParams, 0, len(slots))
+	for _, sl := range slots {
+		start, err1 := time.Parse(time.RFC3339, sl.StartTs)
+		end, err2 := time.Parse(time.RFC3339, sl.EndTs)
+		if err1 != nil || err2 != nil || !end.After(start) {
+			return ErrInvalidSlot
+		}
+		params = append(params, db.CreateAvailabilitySlotsParams{TherapistID: therapistID, StartTs: start, EndTs: end})
+	}
+	for _, p := range params {
+		if err := s.db.Queries.CreateAvailabilitySlots(ctx, p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func checkWindow(from, to time.Time) error {
+	if !to.After(from) || to.Sub(from) > maxScheduleWindow {
+		return ErrInvalidWindow
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// ContentFilter screens review comments as they are posted or edited. A
+// comment it flags is held for an admin instead of going live; the reason is
+// shown in the moderation queue.
+type ContentFilter interface {
+	// Flag returns why text should be held, or "" to publish it.
+	Flag(ctx context.Context, text string) (string, error)
+}
+
+// KeywordFilter holds comments that contain any of Words (matched as whole,
+// case-insensitive words), and comments with links, email addresses or phone
+// numbers, which reviews have no need for.
+type KeywordFilter struct {
+	Words []string
+}
+
+// DefaultContentFilter is used when NewReviewService is given no filter.
+var DefaultContentFilter = KeywordFilter{
+	Words: []string{"fuck", "fucking", "shit", "bitch", "asshole", "cunt", "bastard", "scam", "fraud"},
+}
+
+var (
+	linkPattern  = regexp.MustCompile(`(?i)\b(https?://|www\.)\S+|\b[a-z0-9-]+\.(com|net|org|io|co)\b`)
+	emailPattern = regexp.MustCompile(`(?i)[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}`)
+	phonePattern = regexp.MustCompile(`\+?\d[\d\s().-]{7,}\d`)
+)
+
+func (f KeywordFilter) Flag(_ context.Context, text string) (string, error) {
+	switch {
+	case linkPattern.MatchString(text):
+		return "Contains a link", nil
+	case emailPattern.MatchString(text), phonePattern.MatchString(text):
+		return "Contains contact details", nil
+	}
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && r != '\''
+	})
+	for _, w := range words {
+		for _, blocked := range f.Words {
+			if w == strings.ToLower(blocked) {
+				return "Contains the word \"" + blocked + "\"", nil
+			}
+		}
+	}
+	return "", nil
+}
@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"testing"
+)
+
+func TestKeywordFilter_Flag(t *testing.T) {
+	f := KeywordFilter{Words: []string{"scam"}}
+	cases := []struct {
+		text    string
+		flagged bool
+	}{
+		{"Helped my knee a lot, highly recommend", false},
+		{"Booked 3 sessions in 2025, all on time", false},
+		{"Total SCAM, avoid", true},
+		{"Scammed? No, just slow", false},
+		{"Cheaper at www.example.com", true},
+		{"See https://example.com/deals", true},
+		{"Email me at jo@example.org", true},
+		{"Call +44 20 7946 0958 instead", true},
+	}
+	for _, tc := range cases {
+		reason, err := f.Flag(context.Background(), tc.text)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if (reason != "") != tc.flagged {
+			t.Errorf("%q: expected flagged=%v, got reason %q", tc.text, tc.flagged, reason)
+		}
+	}
+}
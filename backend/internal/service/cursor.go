@@ -0,0 +1,43 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+)
+
+// Listing limits shared by paginated endpoints.
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+var ErrInvalidCursor = errors.New("invalid cursor")
+
+// encodeCursor turns the sort key of the last row on a page into an opaque
+// token. Cursors aren't signed: a tampered one only moves the page start.
+func encodeCursor(v interface{}) string {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return ""
+	}
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(s string, v interface{}) error {
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || json.Unmarshal(b, v) != nil {
+		return ErrInvalidCursor
+	}
+	return nil
+}
+
+func pageLimit(limit int) int {
+	if limit < 1 {
+		return defaultPageLimit
+	}
+	if limit > maxPageLimit {
+		return maxPageLimit
+	}
+	return limit
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/db"
+	"github.com/divijg19/physiolink/backend/internal/openapi"
+)
+
+// recentViewsKept is how many recently viewed therapists are remembered per
+// user.
+const recentViewsKept = 20
+
+var ErrTherapistNotFound = errors.New("therapist not found")
+
+// SavedTherapist is a therapist in a patient's favourites or viewing history.
+type SavedTherapist struct {
+	ID           string          `json:"_id"`
+	Email        string          `json:"email"`
+	Profile      openapi.Profile `json:"profile"`
+	FavouritedAt *time.Time      `json:"favouritedAt,omitempty"`
+	ViewedAt     *time.Time      `json:"viewedAt,omitempty"`
+}
+
+type FavouriteService struct {
+	db *db.DB
+}
+
+func NewFavouriteService(d *db.DB) *FavouriteService { return &FavouriteService{db: d} }
+
+// AddFavourite stars a therapist for patientID. Starring one twice keeps the
+// original time, so openings already announced stay announced.
+func (s *FavouriteService) AddFavourite(ctx context.Context, patientID, therapistID uuid.UUID) (time.Time, error) {
+	if _, err := s.db.Queries.GetTherapistByID(ctx, therapistID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return time.Time{}, ErrTherapistNotFound
+		}
+		return time.Time{}, err
+	}
+	return s.db.Queries.AddFavourite(ctx, db.AddFavouriteParams{PatientID: patientID, TherapistID: therapistID})
+}
+
+// RemoveFavourite unstars a therapist; removing one that isn't starred is not
+// an error.
+func (s *FavouriteService) RemoveFavourite(ctx context.Context, patientID, therapistID uuid.UUID) error {
+	return s.db.Queries.RemoveFavourite(ctx, db.RemoveFavouriteParams{PatientID: patientID, TherapistID: therapistID})
+}
+
+// ListFavourites returns patientID's starred therapists, newest first.
+func (s *FavouriteService) ListFavourites(ctx context.Context, patientID uuid.UUID) ([]SavedTherapist, error) {
+	rows, err := s.db.Queries.ListFavourites(ctx, patientID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]SavedTherapist, 0, len(rows))
+	for _, r := range rows {
+		t := savedTherapist(r.ID, r.Email, r.DisplayName, r.Specialties, r.Rating, r.IsVerified, r.ProfileImageUrl)
+		t.FavouritedAt = &r.CreatedAt
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+// FavouriteIDs reports which of therapistIDs patientID has starred.
+func (s *FavouriteService) FavouriteIDs(ctx context.Context, patientID uuid.UUID, therapistIDs []uuid.UUID) (map[uuid.UUID]bool, error) {
+	if len(therapistIDs) == 0 {
+		return map[uuid.UUID]bool{}, nil
+	}
+	ids, err := s.db.Queries.ListFavouriteIDs(ctx, db.ListFavouriteIDsParams{PatientID: patientID, Column2: therapistIDs})
+	if err != nil {
+		return nil, err
+	}
+	set := make(map[uuid.UUID]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set, nil
+}
+
+// RecordView moves a therapist to the top of userID's viewing history and
+// forgets views beyond the history length. Therapists viewing their own
+// profile are not recorded.
+func (s *FavouriteService) RecordView(ctx context.Context, userID, therapistID uuid.UUID) error {
+	if userID == therapistID {
+		return nil
+	}
+	tx, err := s.db.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	q := s.db.Queries.WithTx(tx)
+	if err := q.RecordTherapistView(ctx, db.RecordTherapistViewParams{UserID: userID, TherapistID: therapistID}); err != nil {
+		return err
+	}
+	if err := q.PruneTherapistViews(ctx, db.PruneTherapistViewsParams{UserID: userID, Limit: recentViewsKept}); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// RecentlyViewed returns the therapists userID opened most recently.
+func (s *FavouriteService) RecentlyViewed(ctx context.Context, userID uuid.UUID) ([]SavedTherapist, error) {
+	rows, err := s.db.Queries.ListRecentlyViewed(ctx, db.ListRecentlyViewedParams{UserID: userID, Limit: recentViewsKept})
+	if err != nil {
+		return nil, err
+	}
+	out := make([]SavedTherapist, 0, len(rows))
+	for _, r := range rows {
+		t := savedTherapist(r.ID, r.Email, r.DisplayName, r.Specialties, r.Rating, r.IsVerified, r.ProfileImageUrl)
+		t.ViewedAt = &r.ViewedAt
+		out = append(out, t)
+	}
+	return out, nil
+}
+
+func savedTherapist(id uuid.UUID, email, displayName string, specialties []string, rating sql.NullString, verified bool, image sql.NullString) SavedTherapist {
+	prof := therapistProfile(displayName, specialties, verified)
+	prof.Rating = profileRating(rating)
+	if image.Valid {
+		prof.ProfileImageUrl = &image.String
+	}
+	return SavedTherapist{ID: id.String(), Email: email, Profile: prof}
+}
@@ -0,0 +1,339 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/base32"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/clock"
+	"github.com/divijg19/physiolink/backend/internal/db"
+	"github.com/divijg19/physiolink/backend/internal/totp"
+)
+
+const (
+	mfaIssuer         = "Physiolink"
+	mfaSkewSteps      = 1
+	recoveryCodeCount = 10
+	// mfaMaxFailures wrong codes in a row lock the second factor for
+	// mfaLockout, correct codes included.
+	mfaMaxFailures = 5
+	mfaLockout     = 15 * time.Minute
+)
+
+var (
+	ErrMFANotEnrolled    = errors.New("mfa not enrolled")
+	ErrMFAAlreadyEnabled = errors.New("mfa already enabled")
+	ErrMFARequired       = errors.New("mfa required by clinic")
+	ErrInvalidMFACode    = errors.New("invalid mfa code")
+	ErrMFALocked         = errors.New("too many invalid mfa codes")
+	ErrMFAChallengeUsed  = errors.New("mfa challenge used or expired")
+)
+
+// MFARequirement describes what a user must do after a successful password check.
+type MFARequirement int
+
+const (
+	// MFANotRequired means a full session can be issued straight away.
+	MFANotRequired MFARequirement = iota
+	// MFAChallenge means the user has TOTP enabled and must present a code.
+	MFAChallenge
+	// MFASetupRequired means the user's clinic enforces MFA but they have not
+	// enrolled yet; the issued session may only be used to enroll.
+	MFASetupRequired
+)
+
+type MFAStatus struct {
+	Enabled                bool `json:"enabled"`
+	Required               bool `json:"required"`
+	RecoveryCodesRemaining int  `json:"recoveryCodesRemaining"`
+}
+
+type MFAEnrollment struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"otpauthUrl"`
+}
+
+type MFAService struct {
+	db  *db.DB
+	clk clock.Clock
+}
+
+func NewMFAService(d *db.DB, clk clock.Clock) *MFAService {
+	return &MFAService{db: d, clk: clk}
+}
+
+// IsTherapistRole reports whether role belongs to clinical staff. Older
+// clients register therapists as "therapist" while queries use "pt".
+func IsTherapistRole(role string) bool {
+	return role == "pt" || role == "therapist"
+}
+
+// Requirement decides whether a password-authenticated user needs a second factor.
+func (s *MFAService) Requirement(ctx context.Context, userID uuid.UUID, role string) (MFARequirement, error) {
+	m, err := s.db.Queries.GetUserMFA(ctx, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return MFANotRequired, err
+	}
+	if err == nil && m.Enabled {
+		return MFAChallenge, nil
+	}
+	required, err := s.clinicRequires(ctx, userID, role)
+	if err != nil {
+		return MFANotRequired, err
+	}
+	if required {
+		return MFASetupRequired, nil
+	}
+	return MFANotRequired, nil
+}
+
+func (s *MFAService) Status(ctx context.Context, userID uuid.UUID, role string) (MFAStatus, error) {
+	var out MFAStatus
+	m, err := s.db.Queries.GetUserMFA(ctx, userID)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return out, err
+	}
+	out.Enabled = err == nil && m.Enabled
+	if out.Required, err = s.clinicRequires(ctx, userID, role); err != nil {
+		return out, err
+	}
+	if out.Enabled {
+		n, err := s.db.Queries.CountUnusedRecoveryCodes(ctx, userID)
+		if err != nil {
+			return out, err
+		}
+		out.RecoveryCodesRemaining = int(n)
+	}
+	return out, nil
+}
+
+// BeginEnrollment generates a new secret for the user. It stays inactive until
+// ConfirmEnrollment succeeds, so an abandoned enrollment never locks anyone out.
+func (s *MFAService) BeginEnrollment(ctx context.Context, userID uuid.UUID) (MFAEnrollment, error) {
+	var out MFAEnrollment
+	if m, err := s.db.Queries.GetUserMFA(ctx, userID); err == nil && m.Enabled {
+		return out, ErrMFAAlreadyEnabled
+	} else if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return out, err
+	}
+	user, err := s.db.Queries.GetUserByID(ctx, userID)
+	if err != nil {
+		return out, err
+	}
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return out, err
+	}
+	if err := s.db.Queries.UpsertPendingMFA(ctx, db.UpsertPendingMFAParams{UserID: userID, Secret: secret}); err != nil {
+		return out, err
+	}
+	out.Secret = secret
+	out.ProvisioningURI = totp.ProvisioningURI(secret, mfaIssuer, user.Email)
+	return out, nil
+}
+
+// ConfirmEnrollment activates MFA once the user proves their authenticator
+// works, and returns a fresh set of recovery codes. The plain codes are only
+// ever returned here; the database keeps hashes.
+func (s *MFAService) ConfirmEnrollment(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	m, err := s.db.Queries.GetUserMFA(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrMFANotEnrolled
+		}
+		return nil, err
+	}
+	if m.Enabled {
+		return nil, ErrMFAAlreadyEnabled
+	}
+	step, ok := totp.Validate(m.Secret, code, s.clk.Now(), mfaSkewSteps)
+	if !ok {
+		return nil, ErrInvalidMFACode
+	}
+
+	codes, err := generateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.db.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	qtx := s.db.Queries.WithTx(tx)
+
+	if _, err := qtx.MarkMFAStepUsed(ctx, db.MarkMFAStepUsedParams{UserID: userID, LastUsedStep: step}); err != nil {
+		return nil, err
+	}
+	if err := qtx.EnableMFA(ctx, userID); err != nil {
+		return nil, err
+	}
+	if err := qtx.DeleteRecoveryCodes(ctx, userID); err != nil {
+		return nil, err
+	}
+	for _, c := range codes {
+		if err := qtx.InsertRecoveryCode(ctx, db.InsertRecoveryCodeParams{UserID: userID, CodeHash: hashRecoveryCode(c)}); err != nil {
+			return nil, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// IssueChallenge records a login challenge for the code step and returns its
+// id, which ConsumeChallenge accepts once.
+func (s *MFAService) IssueChallenge(ctx context.Context, userID uuid.UUID, expiresAt time.Time) (uuid.UUID, error) {
+	if err := s.db.Queries.DeleteExpiredMFAChallenges(ctx, s.clk.Now()); err != nil {
+		return uuid.Nil, err
+	}
+	id := uuid.New()
+	err := s.db.Queries.CreateMFAChallenge(ctx, db.CreateMFAChallengeParams{ID: id, UserID: userID, ExpiresAt: expiresAt})
+	return id, err
+}
+
+// ConsumeChallenge spends a login challenge. Each allows one code attempt,
+// right or wrong, so a challenge can't be replayed to guess codes.
+func (s *MFAService) ConsumeChallenge(ctx context.Context, challengeID, userID uuid.UUID) error {
+	n, err := s.db.Queries.ConsumeMFAChallenge(ctx, db.ConsumeMFAChallengeParams{ID: challengeID, UserID: userID})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrMFAChallengeUsed
+	}
+	return nil
+}
+
+// Verify checks a TOTP code, falling back to a one-time recovery code. A TOTP
+// code is accepted at most once to stop replays within its validity window.
+// After mfaMaxFailures wrong codes every code is refused with ErrMFALocked
+// until mfaLockout has passed.
+func (s *MFAService) Verify(ctx context.Context, userID uuid.UUID, code string) error {
+	m, err := s.db.Queries.GetUserMFA(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return ErrMFANotEnrolled
+		}
+		return err
+	}
+	if !m.Enabled {
+		return ErrMFANotEnrolled
+	}
+	now := s.clk.Now()
+	if m.LockedUntil.Valid && m.LockedUntil.Time.After(now) {
+		return ErrMFALocked
+	}
+	ok, err := s.checkCode(ctx, m, code, now)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		failures, err := s.db.Queries.RecordMFAFailure(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if failures >= mfaMaxFailures {
+			until := sql.NullTime{Time: now.Add(mfaLockout), Valid: true}
+			if err := s.db.Queries.LockMFA(ctx, db.LockMFAParams{UserID: userID, LockedUntil: until}); err != nil {
+				return err
+			}
+		}
+		return ErrInvalidMFACode
+	}
+	return s.db.Queries.ResetMFAFailures(ctx, userID)
+}
+
+// checkCode accepts an unused TOTP step or an unused recovery code, marking
+// it used.
+func (s *MFAService) checkCode(ctx context.Context, m db.UserMfa, code string, now time.Time) (bool, error) {
+	if step, ok := totp.Validate(m.Secret, code, now, mfaSkewSteps); ok {
+		n, err := s.db.Queries.MarkMFAStepUsed(ctx, db.MarkMFAStepUsedParams{UserID: m.UserID, LastUsedStep: step})
+		return n > 0, err
+	}
+	n, err := s.db.Queries.UseRecoveryCode(ctx, db.UseRecoveryCodeParams{UserID: m.UserID, CodeHash: hashRecoveryCode(code)})
+	return n > 0, err
+}
+
+// Disable turns MFA off after verifying a current code. Users whose clinic
+// enforces MFA cannot disable it.
+func (s *MFAService) Disable(ctx context.Context, userID uuid.UUID, role, code string) error {
+	required, err := s.clinicRequires(ctx, userID, role)
+	if err != nil {
+		return err
+	}
+	if required {
+		return ErrMFARequired
+	}
+	if err := s.Verify(ctx, userID, code); err != nil {
+		return err
+	}
+
+	tx, err := s.db.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	qtx := s.db.Queries.WithTx(tx)
+	if err := qtx.DeleteRecoveryCodes(ctx, userID); err != nil {
+		return err
+	}
+	if err := qtx.DeleteUserMFA(ctx, userID); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+func (s *MFAService) clinicRequires(ctx context.Context, userID uuid.UUID, role string) (bool, error) {
+	if !IsTherapistRole(role) {
+		return false, nil
+	}
+	required, err := s.db.Queries.GetClinicRequiresMFA(ctx, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return false, nil
+		}
+		return false, err
+	}
+	return required, nil
+}
+
+var recoveryEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// generateRecoveryCodes returns n random codes formatted as xxxxx-xxxxx.
+func generateRecoveryCodes(n int) ([]string, error) {
+	out := make([]string, 0, n)
+	buf := make([]byte, 7)
+	for i := 0; i < n; i++ {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, err
+		}
+		raw := strings.ToLower(recoveryEncoding.EncodeToString(buf))[:10]
+		out = append(out, raw[:5]+"-"+raw[5:])
+	}
+	return out, nil
+}
+
+// hashRecoveryCode normalises user input before hashing so codes can be typed
+// with or without the separator and in any case.
+func hashRecoveryCode(code string) string {
+	c := strings.ToLower(strings.TrimSpace(code))
+	c = strings.ReplaceAll(c, "-", "")
+	c = strings.ReplaceAll(c, " ", "")
+	sum := sha256.Sum256([]byte(c))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,39 @@
+package service
+
+import (
+	"regexp"
+	"testing"
+)
+
+func TestGenerateRecoveryCodes_FormatAndUniqueness(t *testing.T) {
+	codes, err := generateRecoveryCodes(recoveryCodeCount)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(codes) != recoveryCodeCount {
+		t.Fatalf("expected %d codes, got %d", recoveryCodeCount, len(codes))
+	}
+	format := regexp.MustCompile(`^[a-z2-7]{5}-[a-z2-7]{5}$`)
+	seen := map[string]bool{}
+	for _, c := range codes {
+		if !format.MatchString(c) {
+			t.Fatalf("unexpected code format: %q", c)
+		}
+		if seen[c] {
+			t.Fatalf("duplicate code: %q", c)
+		}
+		seen[c] = true
+	}
+}
+
+func TestHashRecoveryCode_NormalisesInput(t *testing.T) {
+	want := hashRecoveryCode("abcde-fghij")
+	for _, in := range []string{"ABCDE-FGHIJ", "abcdefghij", " abcde fghij "} {
+		if got := hashRecoveryCode(in); got != want {
+			t.Fatalf("hash of %q differs", in)
+		}
+	}
+	if hashRecoveryCode("abcde-fghik") == want {
+		t.Fatalf("different codes must not collide")
+	}
+}
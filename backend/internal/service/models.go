@@ -0,0 +1,66 @@
+package service
+
+import (
+	"database/sql"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/openapi"
+)
+
+// Responses use the models generated from openapi.yaml, so a field the spec
+// doesn't describe won't compile. Every field there is optional, hence the
+// pointers.
+
+func ptr[T any](v T) *T { return &v }
+
+// therapistProfile is the part of a therapist's profile shown wherever they
+// are listed.
+func therapistProfile(displayName string, specialties []string, verified bool) openapi.Profile {
+	firstName, lastName := splitDisplayName(displayName)
+	specialties = nonNil(specialties)
+	specialty := ""
+	if len(specialties) > 0 {
+		specialty = specialties[0]
+	}
+	return openapi.Profile{
+		FirstName:   &firstName,
+		LastName:    &lastName,
+		Specialty:   &specialty,
+		Specialties: &specialties,
+		IsVerified:  &verified,
+	}
+}
+
+// userRef names the other party to an appointment or review.
+func userRef(id uuid.UUID, displayName string) *openapi.UserRef {
+	firstName, lastName := splitDisplayName(displayName)
+	return &openapi.UserRef{
+		Id:      ptr(id.String()),
+		Profile: &openapi.Profile{FirstName: &firstName, LastName: &lastName},
+	}
+}
+
+// profileRating reads a NUMERIC rating column, which the driver returns as
+// text.
+func profileRating(rating sql.NullString) *float64 {
+	if !rating.Valid {
+		return nil
+	}
+	v, err := strconv.ParseFloat(rating.String, 64)
+	if err != nil {
+		return nil
+	}
+	return &v
+}
+
+// profilePrice sets sessionPrice (in major units) and currency when a price
+// is set.
+func profilePrice(prof *openapi.Profile, cents sql.NullInt32, currency sql.NullString) {
+	if !cents.Valid {
+		return
+	}
+	prof.SessionPrice = ptr(float64(cents.Int32) / 100)
+	prof.Currency = &currency.String
+}
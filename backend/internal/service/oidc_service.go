@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/db"
+)
+
+var (
+	ErrOIDCEmailRequired = errors.New("identity provider did not return an email")
+	// ErrOIDCAccountExists is returned when the email already belongs to a
+	// local account that can't be linked by email alone: the provider has
+	// not verified it, or the account has a password or admin rights. The
+	// owner links the provider from a signed-in session instead.
+	ErrOIDCAccountExists = errors.New("an account with this email already exists")
+	// ErrOIDCIdentityInUse is returned when linking an external identity that
+	// already signs in to a different account.
+	ErrOIDCIdentityInUse = errors.New("this sign-in is already linked to another account")
+	ErrInvalidRole       = errors.New("invalid role")
+)
+
+// ExternalIdentity is a user as asserted by an OpenID Connect provider.
+type ExternalIdentity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+}
+
+type OIDCService struct {
+	db *db.DB
+}
+
+func NewOIDCService(d *db.DB) *OIDCService {
+	return &OIDCService{db: d}
+}
+
+// SignupRole validates a role requested for a new account. Elevated roles are
+// never self-service.
+func SignupRole(role string) (string, error) {
+	switch role {
+	case "":
+		return "patient", nil
+	case "patient", "pt", "therapist":
+		return role, nil
+	default:
+		return "", ErrInvalidRole
+	}
+}
+
+// SignIn returns the local user for an external identity. Known identities
+// sign straight in; otherwise a verified email links to an existing account
+// with that address that has neither a password nor admin rights, and
+// unknown emails get a new account with role.
+func (s *OIDCService) SignIn(ctx context.Context, ident ExternalIdentity, role string) (uuid.UUID, string, error) {
+	key := db.GetIdentityUserParams{Provider: ident.Provider, Subject: ident.Subject}
+	u, err := s.db.Queries.GetIdentityUser(ctx, key)
+	if err == nil {
+		if u.Disabled {
+			return uuid.Nil, "", ErrAccountDisabled
+		}
+		if err := s.db.Queries.TouchUserIdentity(ctx, db.TouchUserIdentityParams(key)); err != nil {
+			return uuid.Nil, "", err
+		}
+		return u.ID, u.Role, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return uuid.Nil, "", err
+	}
+
+	email := strings.TrimSpace(ident.Email)
+	if email == "" {
+		return uuid.Nil, "", ErrOIDCEmailRequired
+	}
+	role, err = SignupRole(role)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+
+	tx, err := s.db.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return uuid.Nil, "", err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+	qtx := s.db.Queries.WithTx(tx)
+
+	existing, err := qtx.GetUserByEmail(ctx, email)
+	switch {
+	case err == nil:
+		// Whoever controls the address at the provider would otherwise take
+		// over the account, so only provider-only accounts link this way.
+		if !ident.EmailVerified || existing.PasswordHash != "" || existing.Role == "admin" {
+			return uuid.Nil, "", ErrOIDCAccountExists
+		}
+		if existing.DisabledAt.Valid {
+			return uuid.Nil, "", ErrAccountDisabled
+		}
+		u = db.GetIdentityUserRow{ID: existing.ID, Role: existing.Role}
+	case errors.Is(err, sql.ErrNoRows):
+		// Provisioned users have no password; bcrypt never matches an empty
+		// hash, so they can only sign in through their provider.
+		id, err := qtx.CreateUser(ctx, db.CreateUserParams{Email: email, PasswordHash: "", Role: role})
+		if err != nil {
+			return uuid.Nil, "", err
+		}
+		if err := qtx.CreateEmptyProfile(ctx, id); err != nil {
+			return uuid.Nil, "", err
+		}
+		u = db.GetIdentityUserRow{ID: id, Role: role}
+	default:
+		return uuid.Nil, "", err
+	}
+
+	if err := qtx.CreateUserIdentity(ctx, db.CreateUserIdentityParams{
+		UserID:   u.ID,
+		Provider: ident.Provider,
+		Subject:  ident.Subject,
+		Email:    sql.NullString{String: email, Valid: true},
+	}); err != nil {
+		return uuid.Nil, "", err
+	}
+	if err := tx.Commit(); err != nil {
+		return uuid.Nil, "", err
+	}
+	return u.ID, u.Role, nil
+}
+
+// LinkIdentity adds an external identity to userID's account, for a user who
+// started the link from a signed-in session. Linking an identity the account
+// already has is a no-op.
+func (s *OIDCService) LinkIdentity(ctx context.Context, userID uuid.UUID, ident ExternalIdentity) error {
+	u, err := s.db.Queries.GetIdentityUser(ctx, db.GetIdentityUserParams{Provider: ident.Provider, Subject: ident.Subject})
+	switch {
+	case err == nil && u.ID == userID:
+		return nil
+	case err == nil:
+		return ErrOIDCIdentityInUse
+	case !errors.Is(err, sql.ErrNoRows):
+		return err
+	}
+	email := strings.TrimSpace(ident.Email)
+	return s.db.Queries.CreateUserIdentity(ctx, db.CreateUserIdentityParams{
+		UserID:   userID,
+		Provider: ident.Provider,
+		Subject:  ident.Subject,
+		Email:    sql.NullString{String: email, Valid: email != ""},
+	})
+}
@@ -4,13 +4,46 @@ import (
 	"context"
 	"database/sql"
 	"encoding/json"
+	"errors"
+	"math"
+	"strings"
 
 	"github.com/divijg19/physiolink/backend/internal/config"
 	"github.com/divijg19/physiolink/backend/internal/db"
+	"github.com/divijg19/physiolink/backend/internal/openapi"
 	"github.com/google/uuid"
 	"github.com/sqlc-dev/pqtype"
 )
 
+var (
+	ErrInvalidCoordinates = errors.New("latitude and longitude must be given together and in range")
+	ErrInvalidAge         = errors.New("age must be between 0 and 130")
+	ErrInvalidExperience  = errors.New("years of experience must be between 0 and 80")
+	ErrInvalidPrice       = errors.New("sessionPrice must be between 0 and 100000")
+	ErrInvalidCurrency    = errors.New("currency must be a three-letter ISO 4217 code")
+)
+
+// Bounds matching the profiles table's check constraints.
+const (
+	maxAge             = 130
+	maxYearsExperience = 80
+	// maxSessionPrice is in major units; prices are stored in cents.
+	maxSessionPrice = 100000
+)
+
+// Address is a structured postal address. Its coordinates are stored in
+// their own columns and are what "near me" search works from.
+type Address struct {
+	Line1      string   `json:"line1,omitempty"`
+	Line2      string   `json:"line2,omitempty"`
+	City       string   `json:"city,omitempty"`
+	Region     string   `json:"region,omitempty"`
+	PostalCode string   `json:"postalCode,omitempty"`
+	Country    string   `json:"country,omitempty"`
+	Latitude   *float64 `json:"latitude,omitempty"`
+	Longitude  *float64 `json:"longitude,omitempty"`
+}
+
 type ProfileService struct {
 	db  *db.DB
 	cfg *config.Config
@@ -21,19 +54,30 @@ func NewProfileService(d *db.DB, cfg *config.Config) *ProfileService {
 }
 
 // NodeProfileUpdate mirrors the payload expected from the existing
-// React Native app (parity with the Node backend).
+// React Native app (parity with the Node backend). Specialties, Languages
+// and YearsExperience are newer; when they're left out the stored values are
+// kept, so older clients don't wipe them.
 type NodeProfileUpdate struct {
-	FirstName       string `json:"firstName"`
-	LastName        string `json:"lastName"`
-	Age             *int   `json:"age"`
-	Gender          string `json:"gender"`
-	Condition       string `json:"condition"`
-	Goals           string `json:"goals"`
-	Specialty       string `json:"specialty"`
-	Bio             string `json:"bio"`
-	Credentials     string `json:"credentials"`
-	Location        string `json:"location"`
-	ProfileImageURL string `json:"profileImageUrl"`
+	FirstName       string   `json:"firstName"`
+	LastName        string   `json:"lastName"`
+	Age             *int     `json:"age"`
+	Gender          string   `json:"gender"`
+	Condition       string   `json:"condition"`
+	Goals           string   `json:"goals"`
+	Specialty       string   `json:"specialty"`
+	Specialties     []string `json:"specialties"`
+	Languages       []string `json:"languages"`
+	YearsExperience *int     `json:"yearsOfExperience"`
+	Bio             string   `json:"bio"`
+	Credentials     string   `json:"credentials"`
+	Location        string   `json:"location"`
+	ProfileImageURL *string  `json:"profileImageUrl"`
+	Address         *Address `json:"address"`
+	// SessionPrice is what one session costs in Currency, e.g. 65.5.
+	SessionPrice *float64 `json:"sessionPrice"`
+	Currency     string   `json:"currency"`
+	// Insurers lists the insurers a therapist accepts.
+	Insurers []string `json:"insurers"`
 }
 
 func (s *ProfileService) UpsertProfile(ctx context.Context, userID uuid.UUID, p NodeProfileUpdate) (uuid.UUID, error) {
@@ -47,51 +91,110 @@ func (s *ProfileService) UpsertProfile(ctx context.Context, userID uuid.UUID, p
 			displayName = p.LastName
 		}
 	}
-
-	var specialties []string
-	if p.Specialty != "" {
-		specialties = []string{p.Specialty}
+	if p.Age != nil && (*p.Age < 0 || *p.Age > maxAge) {
+		return uuid.Nil, ErrInvalidAge
+	}
+	if p.YearsExperience != nil && (*p.YearsExperience < 0 || *p.YearsExperience > maxYearsExperience) {
+		return uuid.Nil, ErrInvalidExperience
 	}
 
-	extraMap := map[string]interface{}{}
+	// Build args for sqlc-generated query
+	arg := db.CreateOrUpdateProfileParams{
+		UserID:      userID,
+		DisplayName: nullString(displayName),
+		Bio:         nullString(p.Bio),
+		Phone:       sql.NullString{Valid: false},
+		Address:     pqtype.NullRawMessage{},
+		Specialties: cleanList(p.Specialties),
+		Gender:      nullString(p.Gender),
+		Condition:   nullString(p.Condition),
+		Goals:       nullString(p.Goals),
+		Credentials: nullString(p.Credentials),
+		Location:    nullString(p.Location),
+		Languages:   cleanList(p.Languages),
+		Insurers:    cleanList(p.Insurers),
+	}
 	if p.Age != nil {
-		extraMap["age"] = *p.Age
+		arg.Age = sql.NullInt32{Int32: int32(*p.Age), Valid: true}
+	}
+	if p.YearsExperience != nil {
+		arg.YearsExperience = sql.NullInt32{Int32: int32(*p.YearsExperience), Valid: true}
 	}
-	if p.Gender != "" {
-		extraMap["gender"] = p.Gender
+	if p.ProfileImageURL != nil {
+		arg.ProfileImageUrl = nullString(*p.ProfileImageURL)
 	}
-	if p.Condition != "" {
-		extraMap["condition"] = p.Condition
+	if p.SessionPrice != nil {
+		cents, err := priceCents(*p.SessionPrice)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		arg.SessionPriceCents = cents
 	}
-	if p.Goals != "" {
-		extraMap["goals"] = p.Goals
+	if p.Currency != "" {
+		currency, err := checkCurrency(p.Currency)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		arg.PriceCurrency = sql.NullString{String: currency, Valid: true}
 	}
-	if p.Credentials != "" {
-		extraMap["credentials"] = p.Credentials
+	if p.Specialties == nil || p.Languages == nil || p.YearsExperience == nil || p.ProfileImageURL == nil ||
+		p.SessionPrice == nil || p.Currency == "" || p.Insurers == nil || p.Address == nil {
+		cur, err := s.db.Queries.GetProfileByUserID(ctx, userID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, err
+		}
+		if p.Specialties == nil {
+			arg.Specialties = primarySpecialty(cur.Specialties, strings.TrimSpace(p.Specialty))
+		}
+		if p.Languages == nil {
+			arg.Languages = cur.Languages
+		}
+		if p.YearsExperience == nil {
+			arg.YearsExperience = cur.YearsExperience
+		}
+		if p.ProfileImageURL == nil {
+			// Usually set by an avatar upload
+			arg.ProfileImageUrl = cur.ProfileImageUrl
+		}
+		if p.SessionPrice == nil {
+			arg.SessionPriceCents = cur.SessionPriceCents
+		}
+		if p.Currency == "" {
+			arg.PriceCurrency = cur.PriceCurrency
+		}
+		if p.Insurers == nil {
+			arg.Insurers = cur.Insurers
+		}
+		if p.Address == nil {
+			// The web form and older apps don't send one; dropping the
+			// coordinates would take the therapist out of near-me search
+			arg.Address, arg.Latitude, arg.Longitude = cur.Address, cur.Latitude, cur.Longitude
+		}
 	}
-	if p.Location != "" {
-		extraMap["location"] = p.Location
+	if arg.SessionPriceCents.Valid && !arg.PriceCurrency.Valid {
+		return uuid.Nil, ErrInvalidCurrency
 	}
-	if p.ProfileImageURL != "" {
-		extraMap["profileImageUrl"] = p.ProfileImageURL
+	if arg.Languages == nil {
+		arg.Languages = []string{}
 	}
-	extra, err := json.Marshal(extraMap)
-	if err != nil {
-		return uuid.Nil, err
+	if arg.Insurers == nil {
+		arg.Insurers = []string{}
 	}
-
-	// Build args for sqlc-generated query
-	arg := db.CreateOrUpdateProfileParams{
-		UserID:      userID,
-		DisplayName: sql.NullString{String: displayName, Valid: displayName != ""},
-		Bio:         sql.NullString{String: p.Bio, Valid: p.Bio != ""},
-		Phone:       sql.NullString{Valid: false},
-		Address:     pqtype.NullRawMessage{},
-		Specialties: specialties,
-		ProfileExtra: pqtype.NullRawMessage{
-			RawMessage: extra,
-			Valid:      len(extra) > 0,
-		},
+	if p.Address != nil {
+		if err := checkCoordinates(p.Address.Latitude, p.Address.Longitude); err != nil {
+			return uuid.Nil, err
+		}
+		addr := *p.Address
+		if addr.Latitude != nil {
+			arg.Latitude = sql.NullFloat64{Float64: *addr.Latitude, Valid: true}
+			arg.Longitude = sql.NullFloat64{Float64: *addr.Longitude, Valid: true}
+		}
+		addr.Latitude, addr.Longitude = nil, nil
+		raw, err := json.Marshal(addr)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		arg.Address = pqtype.NullRawMessage{RawMessage: raw, Valid: true}
 	}
 
 	id, err := s.db.Queries.CreateOrUpdateProfile(ctx, arg)
@@ -101,96 +204,151 @@ func (s *ProfileService) UpsertProfile(ctx context.Context, userID uuid.UUID, p
 	return id, nil
 }
 
-func (s *ProfileService) GetProfile(ctx context.Context, userID uuid.UUID) (map[string]interface{}, error) {
+// GetProfile returns userID's own profile, with their email and role.
+func (s *ProfileService) GetProfile(ctx context.Context, userID uuid.UUID) (openapi.Profile, error) {
 	row, err := s.db.Queries.GetProfileByUserID(ctx, userID)
 	if err != nil {
-		return nil, err
+		return openapi.Profile{}, err
 	}
 
 	userInfo, err := s.db.Queries.GetProfileWithUserInfo(ctx, userID)
 	if err != nil {
-		return nil, err
+		return openapi.Profile{}, err
 	}
 
-	// derive first/last name
-	displayName := ""
-	if row.DisplayName.Valid {
-		displayName = row.DisplayName.String
+	firstName, lastName := splitDisplayName(row.DisplayName.String)
+	specialty := ""
+	if len(row.Specialties) > 0 {
+		specialty = row.Specialties[0]
 	}
-	firstName := ""
-	lastName := ""
-	if displayName != "" {
-		for i, ch := range displayName {
-			if ch == ' ' {
-				firstName = displayName[:i]
-				if i+1 < len(displayName) {
-					lastName = displayName[i+1:]
-				}
-				break
-			}
-		}
-		if firstName == "" {
-			firstName = displayName
+	out := openapi.Profile{
+		Id:          ptr(row.ID.String()),
+		User:        &openapi.User{Email: &userInfo.Email, Role: &userInfo.Role},
+		FirstName:   &firstName,
+		LastName:    &lastName,
+		Bio:         &row.Bio.String,
+		Specialty:   &specialty,
+		Specialties: ptr(nonNil(row.Specialties)),
+		Languages:   ptr(nonNil(row.Languages)),
+		Insurers:    ptr(nonNil(row.Insurers)),
+		Rating:      profileRating(sql.NullString{String: userInfo.Rating, Valid: true}),
+		IsVerified:  &row.IsVerified,
+	}
+	profilePrice(&out, row.SessionPriceCents, row.PriceCurrency)
+	if row.Age.Valid {
+		out.Age = ptr(int(row.Age.Int32))
+	}
+	if row.YearsExperience.Valid {
+		out.YearsOfExperience = ptr(int(row.YearsExperience.Int32))
+	}
+	for dst, v := range map[**string]sql.NullString{
+		&out.Gender:          row.Gender,
+		&out.Condition:       row.Condition,
+		&out.Goals:           row.Goals,
+		&out.Credentials:     row.Credentials,
+		&out.Location:        row.Location,
+		&out.ProfileImageUrl: row.ProfileImageUrl,
+	} {
+		if v.Valid {
+			*dst = ptr(v.String)
 		}
 	}
+	out.Address = profileAddress(row.Address, row.Latitude, row.Longitude)
+	if row.VerifiedAt.Valid {
+		out.VerifiedAt = &row.VerifiedAt.Time
+	}
+	return out, nil
+}
 
-	// parse extra
-	extra := map[string]interface{}{}
-	if row.ProfileExtra.Valid {
-		_ = json.Unmarshal(row.ProfileExtra.RawMessage, &extra)
+// CreateEmptyProfile creates a minimal profile row for a newly registered user
+// to mirror the Node behavior (firstName/lastName empty initially).
+func (s *ProfileService) CreateEmptyProfile(ctx context.Context, userID uuid.UUID) (openapi.Profile, error) {
+	if err := s.db.Queries.CreateEmptyProfile(ctx, userID); err != nil {
+		return openapi.Profile{}, err
 	}
+	return s.GetProfile(ctx, userID)
+}
 
-	out := make(map[string]interface{})
-	out["id"] = row.ID.String()
-	out["user"] = map[string]interface{}{"email": userInfo.Email, "role": userInfo.Role}
-	out["firstName"] = firstName
-	out["lastName"] = lastName
-	out["bio"] = ""
-	if row.Bio.Valid {
-		out["bio"] = row.Bio.String
+// profileAddress combines a stored address with its coordinates; it is nil
+// when there is no address.
+func profileAddress(raw pqtype.NullRawMessage, lat, lng sql.NullFloat64) *openapi.Address {
+	var addr openapi.Address
+	if !raw.Valid || json.Unmarshal(raw.RawMessage, &addr) != nil {
+		return nil
 	}
-	if len(row.Specialties) > 0 {
-		out["specialty"] = row.Specialties[0]
-	} else {
-		out["specialty"] = ""
+	if lat.Valid && lng.Valid {
+		addr.Latitude, addr.Longitude = &lat.Float64, &lng.Float64
 	}
-	out["rating"] = userInfo.Rating
+	return &addr
+}
 
-	if v, ok := extra["age"]; ok {
-		out["age"] = v
+// checkCoordinates accepts no coordinates or a valid latitude/longitude pair.
+func checkCoordinates(lat, lng *float64) error {
+	if lat == nil && lng == nil {
+		return nil
 	}
-	if v, ok := extra["gender"]; ok {
-		out["gender"] = v
+	if lat == nil || lng == nil || *lat < -90 || *lat > 90 || *lng < -180 || *lng > 180 {
+		return ErrInvalidCoordinates
 	}
-	if v, ok := extra["condition"]; ok {
-		out["condition"] = v
+	return nil
+}
+
+// cleanList trims values and drops blanks and case-insensitive repeats,
+// keeping the first spelling. A nil list stays nil.
+func cleanList(values []string) []string {
+	if values == nil {
+		return nil
 	}
-	if v, ok := extra["goals"]; ok {
-		out["goals"] = v
+	out := []string{}
+	seen := map[string]bool{}
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[strings.ToLower(v)] {
+			continue
+		}
+		seen[strings.ToLower(v)] = true
+		out = append(out, v)
 	}
-	if v, ok := extra["credentials"]; ok {
-		out["credentials"] = v
+	return out
+}
+
+// primarySpecialty applies a single-specialty update from older clients: it
+// replaces the first specialty and the others are kept. An empty one clears
+// the list, as it always has.
+func primarySpecialty(current []string, specialty string) []string {
+	if specialty == "" {
+		return nil
 	}
-	if v, ok := extra["location"]; ok {
-		out["location"] = v
+	if len(current) > 0 {
+		current = current[1:]
 	}
-	if v, ok := extra["profileImageUrl"]; ok {
-		out["profileImageUrl"] = v
+	return cleanList(append([]string{specialty}, current...))
+}
+
+// priceCents converts a price in major units to whole cents.
+func priceCents(price float64) (sql.NullInt32, error) {
+	if math.IsNaN(price) || price < 0 || price > maxSessionPrice {
+		return sql.NullInt32{}, ErrInvalidPrice
 	}
-	if v, ok := extra["isVerified"]; ok {
-		out["isVerified"] = v
-	} else {
-		out["isVerified"] = false
+	return sql.NullInt32{Int32: int32(math.Round(price * 100)), Valid: true}, nil
+}
+
+// checkCurrency upper-cases an ISO 4217 code such as "gbp".
+func checkCurrency(code string) (string, error) {
+	code = strings.ToUpper(strings.TrimSpace(code))
+	if len(code) != 3 || strings.Trim(code, "ABCDEFGHIJKLMNOPQRSTUVWXYZ") != "" {
+		return "", ErrInvalidCurrency
 	}
+	return code, nil
+}
 
-	return out, nil
+func nullString(s string) sql.NullString {
+	return sql.NullString{String: s, Valid: s != ""}
 }
 
-// CreateEmptyProfile creates a minimal profile row for a newly registered user
-// to mirror the Node behavior (firstName/lastName empty initially).
-func (s *ProfileService) CreateEmptyProfile(ctx context.Context, userID uuid.UUID) (map[string]interface{}, error) {
-	if err := s.db.Queries.CreateEmptyProfile(ctx, userID); err != nil {
-		return nil, err
+func nonNil(values []string) []string {
+	if values == nil {
+		return []string{}
 	}
-	return s.GetProfile(ctx, userID)
+	return values
 }
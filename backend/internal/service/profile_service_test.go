@@ -0,0 +1,58 @@
+package service
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestCleanList_TrimsAndDedupes(t *testing.T) {
+	got := cleanList([]string{" English", "english", "", "Hindi "})
+	if want := []string{"English", "Hindi"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("cleanList = %v, want %v", got, want)
+	}
+	if cleanList(nil) != nil {
+		t.Fatalf("expected nil list to stay nil")
+	}
+}
+
+func TestPrimarySpecialty_ReplacesFirstAndKeepsRest(t *testing.T) {
+	cases := []struct {
+		current   []string
+		specialty string
+		want      []string
+	}{
+		{nil, "Sports", []string{"Sports"}},
+		{[]string{"Sports", "Neuro"}, "Sports", []string{"Sports", "Neuro"}},
+		{[]string{"Sports", "Neuro"}, "Pediatric", []string{"Pediatric", "Neuro"}},
+		{[]string{"Sports", "Neuro"}, "neuro", []string{"neuro"}},
+		{[]string{"Sports", "Neuro"}, "", nil},
+	}
+	for _, c := range cases {
+		if got := primarySpecialty(c.current, c.specialty); !reflect.DeepEqual(got, c.want) {
+			t.Errorf("primarySpecialty(%v, %q) = %v, want %v", c.current, c.specialty, got, c.want)
+		}
+	}
+}
+
+func TestPriceCentsAndCurrency(t *testing.T) {
+	if c, err := priceCents(65.5); err != nil || c.Int32 != 6550 || !c.Valid {
+		t.Fatalf("priceCents(65.5) = %v, %v", c, err)
+	}
+	if c, _ := priceCents(19.99); c.Int32 != 1999 {
+		t.Fatalf("expected rounding to whole cents, got %d", c.Int32)
+	}
+	for _, bad := range []float64{-1, maxSessionPrice + 1, math.NaN()} {
+		if _, err := priceCents(bad); err != ErrInvalidPrice {
+			t.Errorf("priceCents(%v) = %v, want ErrInvalidPrice", bad, err)
+		}
+	}
+	if c, err := checkCurrency(" gbp "); err != nil || c != "GBP" {
+		t.Fatalf("checkCurrency = %q, %v", c, err)
+	}
+	for _, bad := range []string{"", "GB", "POUND", "G8P", "€"} {
+		if _, err := checkCurrency(bad); err != ErrInvalidCurrency {
+			t.Errorf("checkCurrency(%q) = %v, want ErrInvalidCurrency", bad, err)
+		}
+	}
+}
@@ -0,0 +1,341 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/clock"
+	"github.com/divijg19/physiolink/backend/internal/db"
+	"github.com/divijg19/physiolink/backend/internal/openapi"
+)
+
+const (
+	defaultRecommendations = 10
+	maxRecommendations     = 50
+	// recommendationPool bounds how many therapists are scored, best rated
+	// first.
+	recommendationPool = 500
+	// recommendationRadiusKm is how far to look first when the patient's
+	// location is known; the proximity signal is small beyond it.
+	recommendationRadiusKm = 50
+)
+
+// PatientNeeds is what a Scorer knows about the patient.
+type PatientNeeds struct {
+	Condition string
+	Goals     string
+	Now       time.Time
+}
+
+// Candidate is a therapist being scored. DistanceKm is nil when either side
+// has no location.
+type Candidate struct {
+	Specialties []string
+	Rating      *float64
+	ReviewCount int
+	DistanceKm  *float64
+	NextSlot    *time.Time
+}
+
+// Scorer ranks therapists for a patient; higher is better. Scores only need
+// to be comparable between candidates for the same patient.
+type Scorer interface {
+	Score(p PatientNeeds, c Candidate) float64
+}
+
+// WeightedScorer blends four signals, each between 0 and 1: how well the
+// therapist's specialties match the patient's condition and goals, their
+// rating, how close they are and how soon they have an open slot. The result
+// is normalised by the total weight, so it is also between 0 and 1.
+type WeightedScorer struct {
+	Specialty    float64
+	Rating       float64
+	Proximity    float64
+	Availability float64
+	// HalfDistanceKm is the distance at which the proximity signal halves.
+	HalfDistanceKm float64
+	// Horizon is how far ahead an open slot still counts; the sooner the
+	// slot, the higher the availability signal.
+	Horizon time.Duration
+}
+
+var DefaultScorer = WeightedScorer{
+	Specialty:      0.45,
+	Rating:         0.25,
+	Proximity:      0.15,
+	Availability:   0.15,
+	HalfDistanceKm: 10,
+	Horizon:        14 * 24 * time.Hour,
+}
+
+func (w WeightedScorer) Score(p PatientNeeds, c Candidate) float64 {
+	total := w.Specialty + w.Rating + w.Proximity + w.Availability
+	if total <= 0 {
+		return 0
+	}
+	score := w.Specialty*specialtyScore(p, c.Specialties) +
+		w.Rating*ratingScore(c.Rating, c.ReviewCount) +
+		w.Proximity*proximityScore(c.DistanceKm, w.HalfDistanceKm) +
+		w.Availability*availabilityScore(c.NextSlot, p.Now, w.Horizon)
+	return score / total
+}
+
+// specialtyScore is 1 when the therapist's primary specialty matches the
+// patient's needs and less when only another one does.
+func specialtyScore(p PatientNeeds, specialties []string) float64 {
+	matched := MatchSpecialties(p, specialties)
+	switch {
+	case len(matched) == 0:
+		return 0
+	case strings.EqualFold(matched[0], specialties[0]):
+		return 1
+	default:
+		return 0.7
+	}
+}
+
+// ratingScore trusts a rating more as reviews accumulate; without any it is
+// neutral.
+func ratingScore(rating *float64, reviews int) float64 {
+	if rating == nil {
+		return 0.5
+	}
+	confidence := math.Min(float64(reviews), 10) / 10
+	r := math.Max(0, math.Min(*rating, 5)) / 5
+	return 0.5*(1-confidence) + r*confidence
+}
+
+func proximityScore(distanceKm *float64, halfKm float64) float64 {
+	if distanceKm == nil || halfKm <= 0 {
+		return 0
+	}
+	return 1 / (1 + math.Max(*distanceKm, 0)/halfKm)
+}
+
+func availabilityScore(next *time.Time, now time.Time, horizon time.Duration) float64 {
+	if next == nil || horizon <= 0 {
+		return 0
+	}
+	wait := next.Sub(now)
+	if wait >= horizon {
+		return 0
+	}
+	return 1 - math.Max(float64(wait), 0)/float64(horizon)
+}
+
+// specialtyHints maps the start of a specialty name to words patients use
+// for conditions it treats. A specialty also matches when it shares a word
+// with the patient's condition or goals.
+var specialtyHints = map[string][]string{
+	"sport":     {"sport", "running", "runner", "athlete", "acl", "sprain", "strain", "tendon", "tendinitis", "tendonitis", "ligament", "marathon"},
+	"ortho":     {"back", "neck", "spine", "spinal", "shoulder", "knee", "hip", "ankle", "wrist", "elbow", "fracture", "joint", "arthritis", "disc", "sciatica", "posture", "surgery"},
+	"neuro":     {"stroke", "parkinson", "sclerosis", "concussion", "nerve", "neuropathy", "balance", "vertigo", "brain"},
+	"pediatric": {"child", "children", "kid", "infant", "baby", "toddler", "developmental"},
+	"geriatric": {"elderly", "senior", "fall", "osteoporosis", "aging", "ageing", "mobility"},
+	"cardio":    {"heart", "cardiac", "lung", "breathing", "copd", "asthma"},
+	"pelvic":    {"pelvic", "pregnancy", "postpartum", "prenatal", "incontinence"},
+}
+
+// MatchSpecialties returns the specialties, in their original order, that
+// fit the patient's condition and goals.
+func MatchSpecialties(p PatientNeeds, specialties []string) []string {
+	needs := map[string]bool{}
+	for _, t := range terms(p.Condition + " " + p.Goals) {
+		needs[t] = true
+	}
+	if len(needs) == 0 {
+		return nil
+	}
+	var out []string
+	for _, sp := range specialties {
+		if specialtyMatches(sp, needs) {
+			out = append(out, sp)
+		}
+	}
+	return out
+}
+
+func specialtyMatches(specialty string, needs map[string]bool) bool {
+	for _, t := range terms(specialty) {
+		if needs[t] {
+			return true
+		}
+		for prefix, hints := range specialtyHints {
+			if !strings.HasPrefix(t, prefix) {
+				continue
+			}
+			for _, h := range hints {
+				if needs[stem(h)] {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// genericTerms say nothing about which specialty fits.
+var genericTerms = map[string]bool{
+	"and": true, "the": true, "for": true, "with": true, "after": true, "from": true,
+	"pain": true, "injury": true, "therapy": true, "physical": true, "physio": true,
+	"physiotherapy": true, "rehab": true, "rehabilitation": true, "care": true,
+}
+
+// terms splits text into lower-case, roughly singular words, ignoring short
+// and generic ones and British spellings ("paediatric").
+func terms(text string) []string {
+	var out []string
+	for _, w := range strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}) {
+		if len(w) < 3 || genericTerms[w] {
+			continue
+		}
+		out = append(out, stem(w))
+	}
+	return out
+}
+
+func stem(w string) string {
+	w = strings.ReplaceAll(w, "ae", "e")
+	if len(w) > 3 && strings.HasSuffix(w, "s") && !strings.HasSuffix(w, "ss") {
+		w = w[:len(w)-1]
+	}
+	return w
+}
+
+type RecommendationParams struct {
+	Limit int
+	// Lat and Lng override the location on the patient's profile.
+	Lat *float64
+	Lng *float64
+}
+
+type RecommendationService struct {
+	db     *db.DB
+	scorer Scorer
+	clk    clock.Clock
+}
+
+// NewRecommendationService ranks with scorer, or DefaultScorer when nil.
+func NewRecommendationService(d *db.DB, scorer Scorer, clk clock.Clock) *RecommendationService {
+	if scorer == nil {
+		scorer = DefaultScorer
+	}
+	return &RecommendationService{db: d, scorer: scorer, clk: clk}
+}
+
+// Recommend ranks therapists for patientID using the condition, goals and
+// location on their profile.
+func (s *RecommendationService) Recommend(ctx context.Context, patientID uuid.UUID, p RecommendationParams) ([]openapi.Recommendation, error) {
+	if err := checkCoordinates(p.Lat, p.Lng); err != nil {
+		return nil, err
+	}
+	limit := p.Limit
+	if limit <= 0 {
+		limit = defaultRecommendations
+	}
+	if limit > maxRecommendations {
+		limit = maxRecommendations
+	}
+
+	needs := PatientNeeds{Now: s.clk.Now()}
+	lat, lng := p.Lat, p.Lng
+	prof, err := s.db.Queries.GetProfileByUserID(ctx, patientID)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return nil, err
+	default:
+		needs.Condition, needs.Goals = prof.Condition.String, prof.Goals.String
+		if lat == nil && prof.Latitude.Valid && prof.Longitude.Valid {
+			lat, lng = &prof.Latitude.Float64, &prof.Longitude.Float64
+		}
+	}
+
+	params := db.ListRecommendationCandidatesParams{StartTs: needs.Now, Limit: recommendationPool}
+	if lat != nil {
+		params.Column2, params.Column3, params.Column4 = true, *lat, *lng
+		box := boundingBox(*lat, *lng, recommendationRadiusKm)
+		params.Column6 = recommendationRadiusKm
+		params.Column7, params.Column8, params.Column9, params.Column10 = box.minLat, box.maxLat, box.minLng, box.maxLng
+	}
+	rows, err := s.db.Queries.ListRecommendationCandidates(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if params.Column6 > 0 && len(rows) < limit {
+		// Too few nearby; top up from everywhere
+		params.Column6 = 0
+		more, err := s.db.Queries.ListRecommendationCandidates(ctx, params)
+		if err != nil {
+			return nil, err
+		}
+		seen := make(map[uuid.UUID]bool, len(rows))
+		for _, r := range rows {
+			seen[r.ID] = true
+		}
+		for _, r := range more {
+			if !seen[r.ID] {
+				rows = append(rows, r)
+			}
+		}
+	}
+
+	out := make([]openapi.Recommendation, 0, len(rows))
+	for _, r := range rows {
+		c := Candidate{Specialties: r.Specialties, ReviewCount: int(r.ReviewCount)}
+		if r.Rating.Valid {
+			if v, err := strconv.ParseFloat(r.Rating.String, 64); err == nil {
+				c.Rating = &v
+			}
+		}
+		if r.DistanceKm.Valid {
+			d := math.Round(r.DistanceKm.Float64*100) / 100
+			c.DistanceKm = &d
+		}
+		if r.NextSlot.Valid {
+			c.NextSlot = &r.NextSlot.Time
+		}
+		rec := recommendedTherapist(r, c)
+		rec.Score = ptr(math.Round(s.scorer.Score(needs, c)*1000) / 1000)
+		if m := MatchSpecialties(needs, r.Specialties); len(m) > 0 {
+			rec.MatchedSpecialties = &m
+		}
+		out = append(out, rec)
+	}
+	sort.SliceStable(out, func(i, j int) bool {
+		if *out[i].Score != *out[j].Score {
+			return *out[i].Score > *out[j].Score
+		}
+		return *out[i].Id < *out[j].Id
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out, nil
+}
+
+func recommendedTherapist(r db.ListRecommendationCandidatesRow, c Candidate) openapi.Recommendation {
+	prof := therapistProfile(r.DisplayName, r.Specialties, r.IsVerified)
+	prof.Address = profileAddress(r.Address, r.Latitude, r.Longitude)
+	prof.Rating = profileRating(r.Rating)
+	return openapi.Recommendation{
+		Id:                  ptr(r.ID.String()),
+		Email:               ptr(r.Email),
+		Profile:             &prof,
+		AvailableSlotsCount: ptr(int(r.OpenSlots)),
+		ReviewCount:         ptr(int(r.ReviewCount)),
+		NextAvailableAt:     c.NextSlot,
+		DistanceKm:          c.DistanceKm,
+	}
+}
@@ -0,0 +1,102 @@
+package service
+
+import (
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestMatchSpecialties(t *testing.T) {
+	specialties := []string{"Sports Physiotherapy", "Orthopedic", "Pediatric"}
+	cases := []struct {
+		condition, goals string
+		want             []string
+	}{
+		{"Lower back pain", "", []string{"Orthopedic"}},
+		{"ACL tear", "return to running", []string{"Sports Physiotherapy"}},
+		{"", "help my paediatric patient", []string{"Pediatric"}},
+		{"Knee pain after marathons", "", []string{"Sports Physiotherapy", "Orthopedic"}},
+		{"Physical therapy pain", "rehab", nil},
+		{"", "", nil},
+	}
+	for _, c := range cases {
+		got := MatchSpecialties(PatientNeeds{Condition: c.condition, Goals: c.goals}, specialties)
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%q/%q: got %v, want %v", c.condition, c.goals, got, c.want)
+		}
+	}
+}
+
+func TestWeightedScorer_Signals(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	p := PatientNeeds{Condition: "neck pain", Now: now}
+	five, near, far := 5.0, 0.0, 10.0
+	soon := now.Add(time.Hour)
+
+	if got := specialtyScore(p, []string{"Orthopedic", "Sports"}); got != 1 {
+		t.Errorf("primary match: got %v", got)
+	}
+	if got := specialtyScore(p, []string{"Sports", "Orthopedic"}); got != 0.7 {
+		t.Errorf("secondary match: got %v", got)
+	}
+	if got := ratingScore(nil, 0); got != 0.5 {
+		t.Errorf("unrated: got %v", got)
+	}
+	if got := ratingScore(&five, 1); got != 0.55 {
+		t.Errorf("one five-star review should barely move the rating, got %v", got)
+	}
+	if got := ratingScore(&five, 40); got != 1 {
+		t.Errorf("many five-star reviews: got %v", got)
+	}
+	if proximityScore(&near, 10) != 1 || proximityScore(&far, 10) != 0.5 || proximityScore(nil, 10) != 0 {
+		t.Errorf("unexpected proximity scores")
+	}
+	if availabilityScore(&now, now, 14*24*time.Hour) != 1 || availabilityScore(nil, now, time.Hour) != 0 {
+		t.Errorf("unexpected availability scores")
+	}
+	late := now.Add(15 * 24 * time.Hour)
+	if got := availabilityScore(&late, now, 14*24*time.Hour); got != 0 {
+		t.Errorf("slot beyond the horizon: got %v", got)
+	}
+
+	best := Candidate{Specialties: []string{"Orthopedic"}, Rating: &five, ReviewCount: 10, DistanceKm: &near, NextSlot: &now}
+	if got := DefaultScorer.Score(p, best); math.Abs(got-1) > 1e-9 {
+		t.Errorf("a perfect candidate should score 1, got %v", got)
+	}
+	if got := DefaultScorer.Score(p, Candidate{NextSlot: &soon}); got <= 0 || got >= 0.5 {
+		t.Errorf("an unmatched candidate scored %v", got)
+	}
+	if got := (WeightedScorer{}).Score(p, best); got != 0 {
+		t.Errorf("zero weights should score 0, got %v", got)
+	}
+}
+
+func TestWeightedScorer_WeightsChangeRanking(t *testing.T) {
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+	p := PatientNeeds{Condition: "shoulder", Now: now}
+	around, distant := 1.0, 80.0
+	later := now.Add(10 * 24 * time.Hour)
+	candidates := map[string]Candidate{
+		// Matches the condition but is far away and booked up
+		"specialist": {Specialties: []string{"Orthopedic"}, DistanceKm: &distant, NextSlot: &later},
+		// Doesn't match but is around the corner with a slot today
+		"nearby": {Specialties: []string{"Neurological"}, DistanceKm: &around, NextSlot: &now},
+	}
+	rank := func(s Scorer) []string {
+		names := []string{"specialist", "nearby"}
+		sort.Slice(names, func(i, j int) bool {
+			return s.Score(p, candidates[names[i]]) > s.Score(p, candidates[names[j]])
+		})
+		return names
+	}
+	if got := rank(DefaultScorer); got[0] != "specialist" {
+		t.Fatalf("default weights should favour the specialty match, got %v", got)
+	}
+	convenience := DefaultScorer
+	convenience.Specialty, convenience.Proximity, convenience.Availability = 0.1, 0.5, 0.4
+	if got := rank(convenience); got[0] != "nearby" {
+		t.Fatalf("convenience weights should favour the nearby therapist, got %v", got)
+	}
+}
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"encoding/json"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -12,6 +13,8 @@ import (
 
 type reminderQueries interface {
 	GetUpcomingRemindersBefore(ctx context.Context, params db.GetUpcomingRemindersBeforeParams) ([]db.GetUpcomingRemindersBeforeRow, error)
+	GetFavouriteOpenings(ctx context.Context, params db.GetFavouriteOpeningsParams) ([]db.GetFavouriteOpeningsRow, error)
+	GetReviewRepliesForPatient(ctx context.Context, params db.GetReviewRepliesForPatientParams) ([]db.GetReviewRepliesForPatientRow, error)
 }
 
 type ReminderService struct {
@@ -23,10 +26,32 @@ func NewReminderService(q reminderQueries, clk clock.Clock) *ReminderService {
 	return &ReminderService{q: q, clk: clk}
 }
 
+// Reminder kinds. Openings are new slots published by a favourited therapist
+// since the patient starred them; replies are therapists' replies to the
+// patient's reviews.
+const (
+	ReminderAppointment = "appointment"
+	ReminderOpening     = "opening"
+	ReminderReply       = "reply"
+)
+
+const (
+	// openingWindow is how long a newly published slot, or a reply to a
+	// review, is announced for.
+	openingWindow = 7 * 24 * time.Hour
+	maxOpenings   = 20
+	maxReplies    = 20
+)
+
 type ReminderItem struct {
 	ID       string `json:"_id"`
+	Kind     string `json:"kind"`
 	Message  string `json:"message"`
 	RemindAt string `json:"remindAt"`
+	// TherapistID is set for openings and replies; ID is then the open
+	// slot's or the reply's.
+	TherapistID string `json:"therapistId,omitempty"`
+	ReviewID    string `json:"reviewId,omitempty"`
 }
 
 func (s *ReminderService) ListForPatient(ctx context.Context, patientID uuid.UUID) ([]ReminderItem, error) {
@@ -47,9 +72,57 @@ func (s *ReminderService) ListForPatient(ctx context.Context, patientID uuid.UUI
 		}
 		out = append(out, ReminderItem{
 			ID:       r.ID.String(),
+			Kind:     ReminderAppointment,
 			Message:  msg,
 			RemindAt: r.ScheduledFor.Format("2006-01-02T15:04:05Z07:00"),
 		})
 	}
+
+	now := s.clk.Now()
+	openings, err := s.q.GetFavouriteOpenings(ctx, db.GetFavouriteOpeningsParams{
+		PatientID: patientID,
+		StartTs:   now,
+		CreatedAt: now.Add(-openingWindow),
+		Limit:     maxOpenings,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, o := range openings {
+		name := o.DisplayName
+		if name == "" {
+			name = "a favourite therapist"
+		}
+		out = append(out, ReminderItem{
+			ID:          o.ID.String(),
+			Kind:        ReminderOpening,
+			Message:     "New opening with " + name + " on " + o.StartTs.Format("2006-01-02 15:04"),
+			RemindAt:    o.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			TherapistID: o.TherapistID.String(),
+		})
+	}
+
+	replies, err := s.q.GetReviewRepliesForPatient(ctx, db.GetReviewRepliesForPatientParams{
+		PatientID: patientID,
+		CreatedAt: now.Add(-openingWindow),
+		Limit:     maxReplies,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, rp := range replies {
+		name := rp.DisplayName
+		if name == "" {
+			name = "Your therapist"
+		}
+		out = append(out, ReminderItem{
+			ID:          rp.ID.String(),
+			Kind:        ReminderReply,
+			Message:     name + " replied to your review",
+			RemindAt:    rp.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
+			TherapistID: rp.TherapistID.String(),
+			ReviewID:    rp.ReviewID.String(),
+		})
+	}
 	return out, nil
 }
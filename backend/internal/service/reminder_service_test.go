@@ -14,14 +14,29 @@ import (
 )
 
 type mockReminderQueries struct {
-	rows []db.GetUpcomingRemindersBeforeRow
-	err  error
+	rows     []db.GetUpcomingRemindersBeforeRow
+	err      error
+	openings []db.GetFavouriteOpeningsRow
+	// openingParams records the last openings lookup.
+	openingParams db.GetFavouriteOpeningsParams
+	replies       []db.GetReviewRepliesForPatientRow
+	replyParams   db.GetReviewRepliesForPatientParams
 }
 
 func (m *mockReminderQueries) GetUpcomingRemindersBefore(_ context.Context, _ db.GetUpcomingRemindersBeforeParams) ([]db.GetUpcomingRemindersBeforeRow, error) {
 	return m.rows, m.err
 }
 
+func (m *mockReminderQueries) GetFavouriteOpenings(_ context.Context, params db.GetFavouriteOpeningsParams) ([]db.GetFavouriteOpeningsRow, error) {
+	m.openingParams = params
+	return m.openings, nil
+}
+
+func (m *mockReminderQueries) GetReviewRepliesForPatient(_ context.Context, params db.GetReviewRepliesForPatientParams) ([]db.GetReviewRepliesForPatientRow, error) {
+	m.replyParams = params
+	return m.replies, nil
+}
+
 func TestListForPatient_ReturnsReminders(t *testing.T) {
 	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
 	apptStart := time.Date(2025, 6, 2, 10, 0, 0, 0, time.UTC)
@@ -103,4 +118,66 @@ func TestListForPatient_EmptyRows(t *testing.T) {
 	if len(items) != 0 {
 		t.Fatalf("expected 0 items, got %d", len(items))
 	}
+}
+
+func TestListForPatient_AppendsFavouriteOpenings(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	tid := uuid.MustParse("33333333-3333-3333-3333-333333333333")
+	mockQ := &mockReminderQueries{
+		rows: []db.GetUpcomingRemindersBeforeRow{
+			{ID: uuid.New(), ScheduledFor: now, AppointmentStart: now.Add(24 * time.Hour)},
+		},
+		openings: []db.GetFavouriteOpeningsRow{
+			{ID: uuid.New(), TherapistID: tid, StartTs: time.Date(2025, 6, 3, 9, 30, 0, 0, time.UTC), CreatedAt: now.Add(-time.Hour), DisplayName: "Jane Doe"},
+			{ID: uuid.New(), TherapistID: tid, StartTs: time.Date(2025, 6, 4, 9, 30, 0, 0, time.UTC), CreatedAt: now.Add(-2 * time.Hour)},
+		},
+	}
+	svc := NewReminderService(mockQ, clock.NewFake(now))
+	items, err := svc.ListForPatient(context.Background(), uuid.MustParse("44444444-4444-4444-4444-444444444444"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 3 || items[0].Kind != ReminderAppointment {
+		t.Fatalf("expected the appointment reminder then 2 openings, got %+v", items)
+	}
+	if items[1].Kind != ReminderOpening || items[1].TherapistID != tid.String() ||
+		items[1].Message != "New opening with Jane Doe on 2025-06-03 09:30" {
+		t.Fatalf("unexpected opening: %+v", items[1])
+	}
+	if items[2].Message != "New opening with a favourite therapist on 2025-06-04 09:30" {
+		t.Fatalf("unexpected fallback message: %q", items[2].Message)
+	}
+	p := mockQ.openingParams
+	if !p.StartTs.Equal(now) || !p.CreatedAt.Equal(now.Add(-openingWindow)) || p.Limit != maxOpenings {
+		t.Fatalf("unexpected openings lookup: %+v", p)
+	}
+}
+
+func TestListForPatient_AppendsReviewReplies(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	tid, rid := uuid.New(), uuid.New()
+	mockQ := &mockReminderQueries{
+		replies: []db.GetReviewRepliesForPatientRow{
+			{ID: uuid.New(), ReviewID: rid, TherapistID: tid, CreatedAt: now.Add(-time.Hour), DisplayName: "Jane Doe"},
+			{ID: uuid.New(), ReviewID: uuid.New(), TherapistID: tid, CreatedAt: now.Add(-2 * time.Hour)},
+		},
+	}
+	svc := NewReminderService(mockQ, clock.NewFake(now))
+	items, err := svc.ListForPatient(context.Background(), uuid.MustParse("44444444-4444-4444-4444-444444444444"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("expected 2 replies, got %+v", items)
+	}
+	if items[0].Kind != ReminderReply || items[0].ReviewID != rid.String() || items[0].TherapistID != tid.String() ||
+		items[0].Message != "Jane Doe replied to your review" {
+		t.Fatalf("unexpected reply: %+v", items[0])
+	}
+	if items[1].Message != "Your therapist replied to your review" {
+		t.Fatalf("unexpected fallback message: %q", items[1].Message)
+	}
+	if p := mockQ.replyParams; !p.CreatedAt.Equal(now.Add(-openingWindow)) || p.Limit != maxReplies {
+		t.Fatalf("unexpected replies lookup: %+v", p)
+	}
 }
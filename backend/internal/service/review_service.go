@@ -3,93 +3,524 @@ package service
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
+	"github.com/divijg19/physiolink/backend/internal/clock"
 	"github.com/divijg19/physiolink/backend/internal/db"
+	"github.com/divijg19/physiolink/backend/internal/openapi"
 )
 
-type ReviewService struct{ db *db.DB }
+var (
+	ErrInvalidRating       = errors.New("rating must be between 1 and 5")
+	ErrAppointmentNotFound = errors.New("appointment not found")
+	ErrAlreadyReviewed     = errors.New("appointment already reviewed")
+	ErrReviewNotFound      = errors.New("review not found")
+	ErrReportReasonMissing = errors.New("a reason is required to report a review")
+	ErrReplyEmpty          = errors.New("a reply can't be empty")
+	ErrAlreadyReplied      = errors.New("review already has a reply")
+)
+
+// Review moderation states. Only published reviews are listed and count
+// toward a therapist's rating.
+const (
+	ReviewPending   = "pending"
+	ReviewPublished = "published"
+	ReviewHidden    = "hidden"
+)
+
+// reviewableStatuses are the appointment statuses that can be reviewed once
+// the session is over.
+var reviewableStatuses = map[string]bool{"confirmed": true, "completed": true}
+
+// Actions recorded in review_revisions.
+const (
+	reviewEdited    = "edited"
+	reviewWithdrawn = "withdrawn"
+)
+
+type ReviewService struct {
+	db  *db.DB
+	clk clock.Clock
+	// editWindow is how long after posting a review its author may edit or
+	// withdraw it.
+	editWindow time.Duration
+	filter     ContentFilter
+}
+
+// NewReviewService uses DefaultContentFilter when filter is nil.
+func NewReviewService(d *db.DB, clk clock.Clock, editWindow time.Duration, filter ContentFilter) *ReviewService {
+	if filter == nil {
+		filter = DefaultContentFilter
+	}
+	return &ReviewService{db: d, clk: clk, editWindow: editWindow, filter: filter}
+}
+
+// NewReview is a patient's review of one of their appointments.
+type NewReview struct {
+	// AppointmentID is the appointment being reviewed. When nil, the
+	// patient's latest unreviewed past appointment with TherapistID is.
+	AppointmentID *uuid.UUID
+	TherapistID   uuid.UUID
+	Rating        int
+	Comment       string
+	SubRatings    SubRatings
+}
+
+// SubRatings rate aspects of a session from 1 to 5, alongside the overall
+// rating. Each is optional; 0 means not rated.
+type SubRatings struct {
+	Communication int `json:"communication"`
+	Punctuality   int `json:"punctuality"`
+	Effectiveness int `json:"effectiveness"`
+	Facility      int `json:"facility"`
+}
+
+func (r SubRatings) valid() bool {
+	for _, v := range []int{r.Communication, r.Punctuality, r.Effectiveness, r.Facility} {
+		if v < 0 || v > 5 {
+			return false
+		}
+	}
+	return true
+}
 
-func NewReviewService(d *db.DB) *ReviewService { return &ReviewService{db: d} }
+// model returns r for a response, or nil when nothing was rated.
+func (r SubRatings) model() *openapi.SubRatings {
+	if r == (SubRatings{}) {
+		return nil
+	}
+	rated := func(v int) *int {
+		if v == 0 {
+			return nil
+		}
+		return &v
+	}
+	return &openapi.SubRatings{
+		Communication: rated(r.Communication),
+		Punctuality:   rated(r.Punctuality),
+		Effectiveness: rated(r.Effectiveness),
+		Facility:      rated(r.Facility),
+	}
+}
+
+// storedSubRatings reads the sub-rating columns of a review.
+func storedSubRatings(communication, punctuality, effectiveness, facility sql.NullInt32) SubRatings {
+	return SubRatings{
+		Communication: int(communication.Int32),
+		Punctuality:   int(punctuality.Int32),
+		Effectiveness: int(effectiveness.Int32),
+		Facility:      int(facility.Int32),
+	}
+}
+
+// reviewSubRatings is the sub-ratings of a locked review.
+func reviewSubRatings(rev db.GetReviewForUpdateRow) SubRatings {
+	return storedSubRatings(rev.CommunicationRating, rev.PunctualityRating, rev.EffectivenessRating, rev.FacilityRating)
+}
+
+func nullRating(v int) sql.NullInt32 { return sql.NullInt32{Int32: int32(v), Valid: v != 0} }
+
+// CreateReview records a patient's review of a confirmed appointment that has
+// ended. Each appointment can be reviewed once. The therapist's rating is
+// updated with the review, unless the content filter holds it for
+// moderation.
+func (s *ReviewService) CreateReview(ctx context.Context, patientID uuid.UUID, in NewReview) (openapi.Review, error) {
+	if in.Rating < 1 || in.Rating > 5 || !in.SubRatings.valid() {
+		return openapi.Review{}, ErrInvalidRating
+	}
+	heldFor, err := s.screen(ctx, in.Comment)
+	if err != nil {
+		return openapi.Review{}, err
+	}
+	status := ReviewPublished
+	if heldFor != "" {
+		status = ReviewPending
+	}
+	tx, err := s.db.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return openapi.Review{}, err
+	}
+	defer tx.Rollback()
+	q := s.db.Queries.WithTx(tx)
 
-// CreateReview enforces that the patient has at least one appointment with the therapist
-// before allowing a review. It links the review to the most recent appointment between them.
-func (s *ReviewService) CreateReview(ctx context.Context, patientID, therapistID uuid.UUID, rating int, comment string) (map[string]interface{}, error) {
-	// find recent appointment
-	var apptID uuid.UUID
-	err := s.db.Pool.QueryRow(ctx, `SELECT id FROM appointments
-        WHERE patient_id=$1 AND therapist_id=$2
-        ORDER BY created_at DESC LIMIT 1`, patientID, therapistID).Scan(&apptID)
+	apptID, therapistID, err := s.reviewableAppointment(ctx, q, patientID, in)
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, &ForbiddenError{Msg: "Only patients with an appointment can leave a review"}
+		return openapi.Review{}, err
+	}
+	row, err := q.CreateReview(ctx, db.CreateReviewParams{
+		AppointmentID:       apptID,
+		PatientID:           patientID,
+		Rating:              int32(in.Rating),
+		Comment:             sql.NullString{String: in.Comment, Valid: in.Comment != ""},
+		Status:              status,
+		ModerationReason:    sql.NullString{String: heldFor, Valid: heldFor != ""},
+		CommunicationRating: nullRating(in.SubRatings.Communication),
+		PunctualityRating:   nullRating(in.SubRatings.Punctuality),
+		EffectivenessRating: nullRating(in.SubRatings.Effectiveness),
+		FacilityRating:      nullRating(in.SubRatings.Facility),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		// Reviewed concurrently; the unique index kept the first one
+		return openapi.Review{}, ErrAlreadyReviewed
+	}
+	if err != nil {
+		return openapi.Review{}, err
+	}
+	if status == ReviewPublished {
+		if err := countRating(ctx, q, therapistID, int32(in.Rating), in.SubRatings, 1); err != nil {
+			return openapi.Review{}, err
 		}
-		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return openapi.Review{}, err
 	}
 
-	var reviewID uuid.UUID
-	err = s.db.Pool.QueryRow(ctx, `INSERT INTO reviews (appointment_id, patient_id, rating, comment)
-        VALUES ($1,$2,$3,$4) RETURNING id`, apptID, patientID, rating, comment).Scan(&reviewID)
+	return openapi.Review{
+		Id:            ptr(row.ID.String()),
+		AppointmentId: ptr(apptID.String()),
+		Therapist:     &openapi.UserRef{Id: ptr(therapistID.String())},
+		Patient:       &openapi.UserRef{Id: ptr(patientID.String())},
+		Rating:        &in.Rating,
+		Comment:       &in.Comment,
+		SubRatings:    in.SubRatings.model(),
+		Status:        ptr(openapi.ReviewStatus(status)),
+		CreatedAt:     &row.CreatedAt,
+	}, nil
+}
+
+// reviewableAppointment returns the appointment in is about and its
+// therapist, or why it can't be reviewed.
+func (s *ReviewService) reviewableAppointment(ctx context.Context, q *db.Queries, patientID uuid.UUID, in NewReview) (uuid.UUID, uuid.UUID, error) {
+	now := s.clk.Now()
+	if in.AppointmentID == nil {
+		id, err := q.GetLatestReviewableAppointment(ctx, db.GetLatestReviewableAppointmentParams{PatientID: patientID, TherapistID: in.TherapistID, Now: now})
+		if errors.Is(err, sql.ErrNoRows) {
+			return uuid.Nil, uuid.Nil, &ForbiddenError{Msg: "Only patients with a completed appointment they haven't reviewed can leave a review"}
+		}
+		return id, in.TherapistID, err
+	}
+	appt, err := q.GetAppointmentForReview(ctx, db.GetAppointmentForReviewParams{ID: *in.AppointmentID, PatientID: patientID})
+	if errors.Is(err, sql.ErrNoRows) {
+		return uuid.Nil, uuid.Nil, ErrAppointmentNotFound
+	}
 	if err != nil {
-		return nil, err
+		return uuid.Nil, uuid.Nil, err
 	}
+	switch {
+	case in.TherapistID != uuid.Nil && in.TherapistID != appt.TherapistID:
+		return uuid.Nil, uuid.Nil, ErrAppointmentNotFound
+	case !reviewableStatuses[appt.Status] || appt.EndTs.After(now):
+		return uuid.Nil, uuid.Nil, &ForbiddenError{Msg: "Only completed appointments can be reviewed"}
+	case appt.Reviewed:
+		return uuid.Nil, uuid.Nil, ErrAlreadyReviewed
+	}
+	return appt.ID, appt.TherapistID, nil
+}
 
-	// Update average rating on therapist profile
-	var avg sql.NullFloat64
-	_ = s.db.Pool.QueryRow(ctx, `SELECT AVG(r.rating)::float
-		FROM appointments a JOIN reviews r ON r.appointment_id = a.id
-		WHERE a.therapist_id = $1`, therapistID).Scan(&avg)
-	if avg.Valid {
-		_, _ = s.db.Pool.Exec(ctx, `UPDATE profiles SET rating = $2 WHERE user_id = $1`, therapistID, avg.Float64)
+// ReviewEdit is the new content of a review.
+type ReviewEdit struct {
+	Rating     int
+	Comment    string
+	SubRatings SubRatings
+}
+
+// UpdateReview lets the author of a review change it within the edit window.
+// The version it replaces is kept for moderation. An edit the content filter
+// flags is held for moderation like a new review.
+func (s *ReviewService) UpdateReview(ctx context.Context, patientID, reviewID uuid.UUID, in ReviewEdit) (openapi.Review, error) {
+	if in.Rating < 1 || in.Rating > 5 || !in.SubRatings.valid() {
+		return openapi.Review{}, ErrInvalidRating
 	}
+	heldFor, err := s.screen(ctx, in.Comment)
+	if err != nil {
+		return openapi.Review{}, err
+	}
+	tx, err := s.db.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return openapi.Review{}, err
+	}
+	defer tx.Rollback()
+	q := s.db.Queries.WithTx(tx)
 
-	out := map[string]interface{}{
-		"_id":       reviewID.String(),
-		"therapist": map[string]interface{}{"_id": therapistID.String()},
-		"patient":   map[string]interface{}{"_id": patientID.String()},
-		"rating":    rating,
-		"comment":   comment,
+	rev, err := s.editableReview(ctx, q, patientID, reviewID)
+	if err != nil {
+		return openapi.Review{}, err
 	}
-	return out, nil
+	if err := archiveReview(ctx, q, rev, reviewEdited); err != nil {
+		return openapi.Review{}, err
+	}
+	now := s.clk.Now()
+	status, err := q.UpdateReview(ctx, db.UpdateReviewParams{
+		ID:                  reviewID,
+		Rating:              int32(in.Rating),
+		Comment:             sql.NullString{String: in.Comment, Valid: in.Comment != ""},
+		UpdatedAt:           sql.NullTime{Time: now, Valid: true},
+		HeldFor:             heldFor,
+		CommunicationRating: nullRating(in.SubRatings.Communication),
+		PunctualityRating:   nullRating(in.SubRatings.Punctuality),
+		EffectivenessRating: nullRating(in.SubRatings.Effectiveness),
+		FacilityRating:      nullRating(in.SubRatings.Facility),
+	})
+	if err != nil {
+		return openapi.Review{}, err
+	}
+	if rev.Status == ReviewPublished {
+		if err := countRating(ctx, q, rev.TherapistID, rev.Rating, reviewSubRatings(rev), -1); err != nil {
+			return openapi.Review{}, err
+		}
+	}
+	if status == ReviewPublished {
+		if err := countRating(ctx, q, rev.TherapistID, int32(in.Rating), in.SubRatings, 1); err != nil {
+			return openapi.Review{}, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return openapi.Review{}, err
+	}
+
+	return openapi.Review{
+		Id:            ptr(reviewID.String()),
+		AppointmentId: ptr(rev.AppointmentID.String()),
+		Therapist:     &openapi.UserRef{Id: ptr(rev.TherapistID.String())},
+		Patient:       &openapi.UserRef{Id: ptr(patientID.String())},
+		Rating:        &in.Rating,
+		Comment:       &in.Comment,
+		SubRatings:    in.SubRatings.model(),
+		Status:        ptr(openapi.ReviewStatus(status)),
+		CreatedAt:     &rev.CreatedAt,
+		UpdatedAt:     &now,
+	}, nil
 }
 
-func (s *ReviewService) GetReviewsForTherapist(ctx context.Context, therapistID uuid.UUID) ([]map[string]interface{}, error) {
+// DeleteReview withdraws a review within the edit window. The review is kept
+// for moderation, and its appointment can't be reviewed again.
+func (s *ReviewService) DeleteReview(ctx context.Context, patientID, reviewID uuid.UUID) error {
+	tx, err := s.db.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+	q := s.db.Queries.WithTx(tx)
+
+	rev, err := s.editableReview(ctx, q, patientID, reviewID)
+	if err != nil {
+		return err
+	}
+	if err := archiveReview(ctx, q, rev, reviewWithdrawn); err != nil {
+		return err
+	}
+	if err := q.DeleteReview(ctx, reviewID); err != nil {
+		return err
+	}
+	if rev.Status == ReviewPublished {
+		if err := countRating(ctx, q, rev.TherapistID, rev.Rating, reviewSubRatings(rev), -1); err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// ReportReview flags a published review for the moderation queue. Reporting
+// the same review again is a no-op.
+func (s *ReviewService) ReportReview(ctx context.Context, reporterID, reviewID uuid.UUID, reason string) error {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return ErrReportReasonMissing
+	}
+	rev, err := s.db.Queries.GetReviewStatus(ctx, reviewID)
+	if errors.Is(err, sql.ErrNoRows) || err == nil && rev.Status != ReviewPublished {
+		return ErrReviewNotFound
+	}
+	if err != nil {
+		return err
+	}
+	if rev.PatientID == reporterID {
+		return &ForbiddenError{Msg: "You can't report your own review"}
+	}
+	return s.db.Queries.CreateReviewReport(ctx, db.CreateReviewReportParams{ReviewID: reviewID, ReporterID: reporterID, Reason: reason})
+}
+
+// ReplyToReview posts the reviewed therapist's public reply to a published
+// review. Each review takes one reply; the patient sees it in their
+// reminders.
+func (s *ReviewService) ReplyToReview(ctx context.Context, therapistID, reviewID uuid.UUID, body string) (openapi.ReviewReply, error) {
+	body = strings.TrimSpace(body)
+	if body == "" {
+		return openapi.ReviewReply{}, ErrReplyEmpty
+	}
+	rev, err := s.db.Queries.GetReviewStatus(ctx, reviewID)
+	if errors.Is(err, sql.ErrNoRows) || err == nil && rev.Status != ReviewPublished {
+		return openapi.ReviewReply{}, ErrReviewNotFound
+	}
+	if err != nil {
+		return openapi.ReviewReply{}, err
+	}
+	if rev.TherapistID != therapistID {
+		return openapi.ReviewReply{}, &ForbiddenError{Msg: "Only the reviewed therapist can reply"}
+	}
+	row, err := s.db.Queries.CreateReviewReply(ctx, db.CreateReviewReplyParams{ReviewID: reviewID, TherapistID: therapistID, Body: body})
+	if errors.Is(err, sql.ErrNoRows) {
+		return openapi.ReviewReply{}, ErrAlreadyReplied
+	}
+	if err != nil {
+		return openapi.ReviewReply{}, err
+	}
+	return openapi.ReviewReply{Body: &body, CreatedAt: &row.CreatedAt}, nil
+}
+
+// screen runs a comment through the content filter, returning why it is
+// held or "".
+func (s *ReviewService) screen(ctx context.Context, comment string) (string, error) {
+	if strings.TrimSpace(comment) == "" {
+		return "", nil
+	}
+	return s.filter.Flag(ctx, comment)
+}
+
+// editableReview locks a review for its author, or says why they can't
+// change it.
+func (s *ReviewService) editableReview(ctx context.Context, q *db.Queries, patientID, reviewID uuid.UUID) (db.GetReviewForUpdateRow, error) {
+	rev, err := q.GetReviewForUpdate(ctx, reviewID)
+	if errors.Is(err, sql.ErrNoRows) {
+		return rev, ErrReviewNotFound
+	}
+	if err != nil {
+		return rev, err
+	}
+	if rev.PatientID != patientID {
+		return rev, &ForbiddenError{Msg: "Only the author can change a review"}
+	}
+	if s.clk.Now().After(rev.CreatedAt.Add(s.editWindow)) {
+		return rev, &ForbiddenError{Msg: "This review can no longer be changed"}
+	}
+	return rev, nil
+}
+
+// archiveReview keeps the current version of a review before it is edited
+// or withdrawn.
+func archiveReview(ctx context.Context, q *db.Queries, rev db.GetReviewForUpdateRow, action string) error {
+	return q.CreateReviewRevision(ctx, db.CreateReviewRevisionParams{
+		ReviewID:            rev.ID,
+		AppointmentID:       rev.AppointmentID,
+		PatientID:           rev.PatientID,
+		Rating:              rev.Rating,
+		Comment:             rev.Comment,
+		WrittenAt:           rev.WrittenAt,
+		Action:              action,
+		CommunicationRating: rev.CommunicationRating,
+		PunctualityRating:   rev.PunctualityRating,
+		EffectivenessRating: rev.EffectivenessRating,
+		FacilityRating:      rev.FacilityRating,
+	})
+}
+
+// countRating adds (delta 1) or removes (delta -1) a published review's
+// ratings in its therapist's totals and sets the average on their profile to
+// match, clearing it when no reviews are left. The totals row stays locked
+// until the transaction ends, so concurrent reviews apply one at a time.
+func countRating(ctx context.Context, q *db.Queries, therapistID uuid.UUID, rating int32, sub SubRatings, delta int32) error {
+	agg, err := q.AdjustTherapistRating(ctx, db.AdjustTherapistRatingParams{
+		TherapistID:         therapistID,
+		Rating:              rating,
+		Delta:               delta,
+		CommunicationRating: nullRating(sub.Communication),
+		PunctualityRating:   nullRating(sub.Punctuality),
+		EffectivenessRating: nullRating(sub.Effectiveness),
+		FacilityRating:      nullRating(sub.Facility),
+	})
+	if err != nil {
+		return err
+	}
+	var avg sql.NullString
+	if agg.ReviewCount > 0 {
+		avg = sql.NullString{String: strconv.FormatFloat(float64(agg.RatingSum)/float64(agg.ReviewCount), 'f', 2, 64), Valid: true}
+	}
+	return q.UpdateProfileRating(ctx, db.UpdateProfileRatingParams{UserID: therapistID, Rating: avg})
+}
+
+func (s *ReviewService) GetReviewsForTherapist(ctx context.Context, therapistID uuid.UUID) ([]openapi.Review, error) {
 	rows, err := s.db.Queries.GetReviewsForTherapist(ctx, therapistID)
 	if err != nil {
 		return nil, err
 	}
-	var out []map[string]interface{}
+	out := make([]openapi.Review, 0, len(rows))
 	for _, r := range rows {
-		firstName, lastName := "", ""
-		if r.PatientName.Valid {
-			displayName := r.PatientName.String
-			if idx := strings.Index(displayName, " "); idx > 0 {
-				firstName = displayName[:idx]
-				lastName = strings.TrimSpace(displayName[idx+1:])
-			} else {
-				firstName = displayName
-			}
-		}
-		patient := map[string]interface{}{
-			"_id": r.PatientID.String(),
-			"profile": map[string]interface{}{
-				"firstName": firstName,
-				"lastName":  lastName,
-			},
-		}
-		m := map[string]interface{}{
-			"_id":     r.ReviewID,
-			"patient": patient,
-			"rating":  r.Rating,
+		out = append(out, reviewModel(r))
+	}
+	return out, nil
+}
+
+// ReviewPage is one page of a therapist's reviews, newest first.
+type ReviewPage struct {
+	Data       []openapi.Review `json:"data"`
+	NextCursor string           `json:"nextCursor,omitempty"`
+}
+
+type reviewCursor struct {
+	CreatedAt time.Time `json:"c"`
+	ID        uuid.UUID `json:"i"`
+}
+
+// ListReviewsForTherapist pages through reviews with a keyset cursor; pass ""
+// for the first page.
+func (s *ReviewService) ListReviewsForTherapist(ctx context.Context, therapistID uuid.UUID, cursor string, limit int) (ReviewPage, error) {
+	limit = pageLimit(limit)
+	var cur reviewCursor
+	if cursor != "" {
+		if err := decodeCursor(cursor, &cur); err != nil {
+			return ReviewPage{}, err
 		}
-		if r.Comment.Valid {
-			m["comment"] = r.Comment.String
+	}
+	rows, err := s.db.Queries.GetReviewsForTherapistPage(ctx, db.GetReviewsForTherapistPageParams{
+		TherapistID: therapistID,
+		Column2:     cursor != "",
+		Column3:     cur.CreatedAt,
+		Column4:     cur.ID,
+		Limit:       int32(limit + 1),
+	})
+	if err != nil {
+		return ReviewPage{}, err
+	}
+	page := ReviewPage{Data: make([]openapi.Review, 0, len(rows))}
+	if len(rows) > limit {
+		rows = rows[:limit]
+		last := rows[limit-1]
+		id, err := uuid.Parse(last.ReviewID)
+		if err != nil {
+			return ReviewPage{}, err
 		}
-		out = append(out, m)
+		page.NextCursor = encodeCursor(reviewCursor{CreatedAt: last.CreatedAt, ID: id})
 	}
-	return out, nil
+	for _, r := range rows {
+		page.Data = append(page.Data, reviewModel(db.GetReviewsForTherapistRow(r)))
+	}
+	return page, nil
+}
+
+func reviewModel(r db.GetReviewsForTherapistRow) openapi.Review {
+	m := openapi.Review{
+		Id:            &r.ReviewID,
+		AppointmentId: ptr(r.AppointmentID.String()),
+		Patient:       userRef(r.PatientID, r.PatientName.String),
+		Rating:        ptr(int(r.Rating)),
+		CreatedAt:     &r.CreatedAt,
+	}
+	if r.Comment.Valid {
+		m.Comment = &r.Comment.String
+	}
+	if r.UpdatedAt.Valid {
+		m.UpdatedAt = &r.UpdatedAt.Time
+	}
+	m.SubRatings = storedSubRatings(r.CommunicationRating, r.PunctualityRating, r.EffectivenessRating, r.FacilityRating).model()
+	if r.ReplyBody.Valid {
+		m.Reply = &openapi.ReviewReply{Body: &r.ReplyBody.String, CreatedAt: &r.ReplyCreatedAt.Time}
+	}
+	return m
 }
 
 type ForbiddenError struct{ Msg string }
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCreateReview_RejectsRatingOutOfRange(t *testing.T) {
+	// The rating is checked before the database is touched
+	s := &ReviewService{}
+	for _, rating := range []int{0, 6, -1} {
+		_, err := s.CreateReview(context.Background(), uuid.New(), NewReview{TherapistID: uuid.New(), Rating: rating})
+		if !errors.Is(err, ErrInvalidRating) {
+			t.Errorf("rating %d: expected ErrInvalidRating, got %v", rating, err)
+		}
+	}
+}
+
+func TestUpdateReview_RejectsRatingOutOfRange(t *testing.T) {
+	s := &ReviewService{}
+	_, err := s.UpdateReview(context.Background(), uuid.New(), uuid.New(), ReviewEdit{Rating: 0})
+	if !errors.Is(err, ErrInvalidRating) {
+		t.Fatalf("expected ErrInvalidRating, got %v", err)
+	}
+}
+
+func TestReportReview_RequiresReason(t *testing.T) {
+	s := &ReviewService{}
+	if err := s.ReportReview(context.Background(), uuid.New(), uuid.New(), "  "); !errors.Is(err, ErrReportReasonMissing) {
+		t.Fatalf("expected ErrReportReasonMissing, got %v", err)
+	}
+}
+
+func TestModerateReview_ChecksDecisionFirst(t *testing.T) {
+	s := &AdminService{}
+	if _, err := s.ModerateReview(context.Background(), uuid.New(), uuid.New(), "delete", ""); !errors.Is(err, ErrReviewDecision) {
+		t.Errorf("unknown decision: expected ErrReviewDecision, got %v", err)
+	}
+	if _, err := s.ModerateReview(context.Background(), uuid.New(), uuid.New(), ReviewHide, " "); !errors.Is(err, ErrModerationReasonRequired) {
+		t.Errorf("hide without reason: expected ErrModerationReasonRequired, got %v", err)
+	}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/db"
+)
+
+var ErrSessionNotFound = errors.New("session not found")
+
+// maxUserAgentLen keeps arbitrary client headers out of the sessions table.
+const maxUserAgentLen = 256
+
+// Session is a signed-in device as shown to its owner.
+type Session struct {
+	ID         uuid.UUID `json:"_id"`
+	UserAgent  string    `json:"userAgent"`
+	IPAddress  string    `json:"ipAddress"`
+	CreatedAt  time.Time `json:"createdAt"`
+	LastSeenAt time.Time `json:"lastSeenAt"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	Current    bool      `json:"current"`
+	// ImpersonatedBy is the admin using the session on the user's behalf.
+	ImpersonatedBy *uuid.UUID `json:"impersonatedBy,omitempty"`
+}
+
+type SessionService struct {
+	db *db.DB
+}
+
+func NewSessionService(d *db.DB) *SessionService {
+	return &SessionService{db: d}
+}
+
+// Create records a session for a token about to be issued and returns the id
+// to embed in it as the "sid" claim. impersonator is set for an admin's
+// session as userID.
+func (s *SessionService) Create(ctx context.Context, userID uuid.UUID, impersonator uuid.NullUUID, userAgent, ip string, expiresAt time.Time) (uuid.UUID, error) {
+	if len(userAgent) > maxUserAgentLen {
+		userAgent = userAgent[:maxUserAgentLen]
+	}
+	return s.db.Queries.CreateSession(ctx, db.CreateSessionParams{
+		UserID:         userID,
+		UserAgent:      userAgent,
+		IpAddress:      ip,
+		ExpiresAt:      expiresAt,
+		ImpersonatorID: impersonator,
+	})
+}
+
+// Touch updates last-seen and reports whether the session is still active.
+func (s *SessionService) Touch(ctx context.Context, sessionID uuid.UUID) (bool, error) {
+	n, err := s.db.Queries.TouchSession(ctx, sessionID)
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+// List returns the user's active sessions, most recently used first. current
+// is the caller's own session.
+func (s *SessionService) List(ctx context.Context, userID, current uuid.UUID) ([]Session, error) {
+	rows, err := s.db.Queries.ListUserSessions(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]Session, 0, len(rows))
+	for _, r := range rows {
+		sess := Session{
+			ID:         r.ID,
+			UserAgent:  r.UserAgent,
+			IPAddress:  r.IpAddress,
+			CreatedAt:  r.CreatedAt,
+			LastSeenAt: r.LastSeenAt,
+			ExpiresAt:  r.ExpiresAt,
+			Current:    r.ID == current,
+		}
+		if r.ImpersonatorID.Valid {
+			sess.ImpersonatedBy = &r.ImpersonatorID.UUID
+		}
+		out = append(out, sess)
+	}
+	return out, nil
+}
+
+// Revoke signs out one of the user's own sessions.
+func (s *SessionService) Revoke(ctx context.Context, userID, sessionID uuid.UUID) error {
+	n, err := s.db.Queries.RevokeSession(ctx, db.RevokeSessionParams{ID: sessionID, UserID: userID})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrSessionNotFound
+	}
+	return nil
+}
+
+// RevokeOthers signs out every session of the user except keep and returns
+// the revoked ids.
+func (s *SessionService) RevokeOthers(ctx context.Context, userID, keep uuid.UUID) ([]uuid.UUID, error) {
+	return s.db.Queries.RevokeOtherSessions(ctx, db.RevokeOtherSessionsParams{UserID: userID, ID: keep})
+}
@@ -1,219 +1,494 @@
-package service
-
-import (
-	"context"
-	"database/sql"
-	"fmt"
-	"strings"
-
-	"github.com/google/uuid"
-
-	"github.com/divijg19/physiolink/backend/internal/db"
-)
-
-type TherapistService struct {
-	db *db.DB
-}
-
-func NewTherapistService(d *db.DB) *TherapistService { return &TherapistService{db: d} }
-
-type TherapistQueryParams struct {
-	Specialty string
-	Location  string
-	Page      int
-	Limit     int
-	Sort      string
-	Date      string
-	Available bool
-}
-
-type TherapistSummary struct {
-	ID             string                 `json:"_id"`
-	Email          string                 `json:"email"`
-	Profile        map[string]interface{} `json:"profile"`
-	AvailableSlots int                    `json:"availableSlotsCount"`
-	ReviewCount    int                    `json:"reviewCount"`
-}
-
-type TherapistListResult struct {
-	Data       []TherapistSummary `json:"data"`
-	Total      int                `json:"total"`
-	Page       int                `json:"page"`
-	TotalPages int                `json:"totalPages"`
-}
-
-// GetAllTherapists returns paginated list of PT users with optional filters.
-func (s *TherapistService) GetAllTherapists(ctx context.Context, p TherapistQueryParams) (TherapistListResult, error) {
-	page := p.Page
-	if page < 1 {
-		page = 1
-	}
-	limit := p.Limit
-	if limit < 1 {
-		limit = 20
-	}
-	if limit > 100 {
-		limit = 100
-	}
-	offset := (page - 1) * limit
-
-	// Use sqlc queries for therapist list and counts
-	specParam := "%" + p.Specialty + "%"
-	locParam := "%" + p.Location + "%"
-	total64, err := s.db.Queries.GetTherapistCount(ctx, db.GetTherapistCountParams{Column1: specParam, Column2: locParam})
-	if err != nil {
-		return TherapistListResult{}, err
-	}
-	total := int(total64)
-
-	therapists, err := s.db.Queries.GetTherapists(ctx, db.GetTherapistsParams{Column1: specParam, Column2: locParam, Limit: int32(limit), Offset: int32(offset)})
-	if err != nil {
-		return TherapistListResult{}, err
-	}
-
-	var out []TherapistSummary
-	var ids []uuid.UUID
-	for _, t := range therapists {
-		displayName := t.DisplayName
-		firstName, lastName := displayName, ""
-		if idx := strings.Index(displayName, " "); idx > 0 {
-			firstName = displayName[:idx]
-			lastName = strings.TrimSpace(displayName[idx+1:])
-		}
-		specialty := ""
-		if len(t.Specialties) > 0 {
-			specialty = t.Specialties[0]
-		}
-		prof := map[string]interface{}{
-			"firstName": firstName,
-			"lastName":  lastName,
-			"specialty": specialty,
-		}
-		if t.Address.Valid {
-			prof["address"] = string(t.Address.RawMessage)
-		}
-		if t.Rating.Valid {
-			// rating stored as string in generated type for compatibility; attempt parse
-			prof["rating"] = t.Rating.String
-		}
-		out = append(out, TherapistSummary{ID: t.ID.String(), Email: t.Email, Profile: prof, AvailableSlots: 0, ReviewCount: 0})
-		ids = append(ids, t.ID)
-	}
-
-	// Aggregate available slot counts
-	if len(ids) > 0 {
-		// convert []uuid.UUID to []uuid.UUID param for sqlc
-		availRows, _ := s.db.Queries.GetAvailabilityCounts(ctx, db.GetAvailabilityCountsParams{Column1: ids, Column2: p.Date})
-		mAvail := map[string]int64{}
-		for _, r := range availRows {
-			mAvail[r.TherapistID] = r.Count
-		}
-		revRows, _ := s.db.Queries.GetReviewCounts(ctx, ids)
-		mRev := map[string]int64{}
-		for _, r := range revRows {
-			// column name from generator is ATherapistID
-			mRev[r.ATherapistID] = r.Count
-		}
-		for i := range out {
-			if c, ok := mAvail[out[i].ID]; ok {
-				out[i].AvailableSlots = int(c)
-			}
-			if c, ok := mRev[out[i].ID]; ok {
-				out[i].ReviewCount = int(c)
-			}
-		}
-	}
-
-	totalPages := total / limit
-	if total%limit != 0 {
-		totalPages++
-	}
-	if totalPages == 0 {
-		totalPages = 1
-	}
-	return TherapistListResult{Data: out, Total: total, Page: page, TotalPages: totalPages}, nil
-}
-
-// GetTherapistByID returns a single PT with basic profile and placeholder slot/review counts.
-func (s *TherapistService) GetTherapistByID(ctx context.Context, id string, date string) (map[string]interface{}, error) {
-	q := `SELECT u.id, u.email, COALESCE(p.display_name,''), COALESCE(p.specialties, ARRAY[]::text[]), p.address, COALESCE(p.bio,''), p.rating
-          FROM users u LEFT JOIN profiles p ON p.user_id = u.id
-          WHERE u.id = $1 AND u.role = 'pt'`
-	var email, displayName, bio string
-	var uid string
-	var specialties []string
-	var address []byte
-	var rating sql.NullFloat64
-	if err := s.db.Pool.QueryRow(ctx, q, id).Scan(&uid, &email, &displayName, &specialties, &address, &bio, &rating); err != nil {
-		return nil, fmt.Errorf("therapist not found")
-	}
-	firstName, lastName := displayName, ""
-	if idx := strings.Index(displayName, " "); idx > 0 {
-		firstName = displayName[:idx]
-		lastName = strings.TrimSpace(displayName[idx+1:])
-	}
-	specialty := ""
-	if len(specialties) > 0 {
-		specialty = specialties[0]
-	}
-	prof := map[string]interface{}{
-		"firstName": firstName,
-		"lastName":  lastName,
-		"specialty": specialty,
-		"bio":       bio,
-	}
-	if address != nil {
-		prof["address"] = string(address)
-	}
-	if rating.Valid {
-		prof["rating"] = rating.Float64
-	}
-
-	// available slots
-	var slotsQuery string
-	var rowsArgs []interface{}
-	if date != "" {
-		slotsQuery = `SELECT id::text, start_ts, end_ts FROM availability_slots
-					  WHERE therapist_id = $1::uuid AND status = 'open' AND start_ts::date = $2::date
-					  ORDER BY start_ts ASC LIMIT 20`
-		rowsArgs = []interface{}{id, date}
-	} else {
-		slotsQuery = `SELECT id::text, start_ts, end_ts FROM availability_slots
-					  WHERE therapist_id = $1::uuid AND status = 'open'
-					  ORDER BY start_ts ASC LIMIT 20`
-		rowsArgs = []interface{}{id}
-	}
-	rows, err := s.db.Pool.Query(ctx, slotsQuery, rowsArgs...)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	slots := make([]map[string]interface{}, 0, 20)
-	for rows.Next() {
-		var sid string
-		var startTs, endTs interface{}
-		if err := rows.Scan(&sid, &startTs, &endTs); err != nil {
-			return nil, err
-		}
-		slots = append(slots, map[string]interface{}{
-			"_id":       sid,
-			"startTime": startTs,
-			"endTime":   endTs,
-		})
-	}
-	// review count
-	qrev := `SELECT COUNT(r.id)
-			 FROM appointments a JOIN reviews r ON r.appointment_id = a.id
-			 WHERE a.therapist_id = $1::uuid`
-	var reviewCount int
-	_ = s.db.Pool.QueryRow(ctx, qrev, id).Scan(&reviewCount)
-
-	out := map[string]interface{}{
-		"_id":            uid,
-		"email":          email,
-		"profile":        prof,
-		"availableSlots": slots,
-		"reviewCount":    reviewCount,
-	}
-	return out, nil
-}
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"html"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/sqlc-dev/pqtype"
+
+	"github.com/divijg19/physiolink/backend/internal/db"
+	"github.com/divijg19/physiolink/backend/internal/openapi"
+)
+
+type TherapistService struct {
+	db *db.DB
+}
+
+func NewTherapistService(d *db.DB) *TherapistService { return &TherapistService{db: d} }
+
+// Therapist list orderings. The default is relevance when searching with a
+// query, distance when searching near a point and newest first otherwise.
+const (
+	SortNewest       = "newest"
+	SortRelevance    = "relevance"
+	SortDistance     = "distance"
+	SortRating       = "rating"
+	SortReviews      = "reviews"
+	SortAvailability = "availability"
+	SortName         = "name"
+)
+
+var therapistSorts = map[string]bool{
+	"":               true,
+	SortNewest:       true,
+	SortRelevance:    true,
+	SortDistance:     true,
+	SortRating:       true,
+	SortReviews:      true,
+	SortAvailability: true,
+	SortName:         true,
+}
+
+// maxSearchRadiusKm bounds a "near me" search.
+const maxSearchRadiusKm = 500
+
+var (
+	ErrInvalidSort       = errors.New("invalid sort")
+	ErrInvalidDate       = errors.New("invalid date, expected YYYY-MM-DD")
+	ErrInvalidRadius     = errors.New("radiusKm must be between 0 and 500")
+	ErrLocationRequired  = errors.New("lat and lng are required for radiusKm and distance sort")
+	ErrInvalidPriceRange = errors.New("minPrice must not exceed maxPrice")
+)
+
+type TherapistQueryParams struct {
+	// Query is a free-text search over name, specialties, credentials and bio,
+	// in web search syntax ("quoted phrases", -exclusions, or).
+	Query     string
+	Specialty string
+	Location  string
+	Page      int
+	Limit     int
+	Sort      string
+	Date      string
+	Available bool
+	// Verified keeps only therapists whose credentials an admin approved.
+	Verified bool
+	// Insurer and Language keep therapists who accept that insurer or speak
+	// that language, ignoring case.
+	Insurer  string
+	Language string
+	// MinPrice and MaxPrice bound the session price in major units; setting
+	// either drops therapists without a price. Currency keeps prices in one
+	// ISO 4217 currency.
+	MinPrice *float64
+	MaxPrice *float64
+	Currency string
+	// Lat and Lng search near a point; RadiusKm, when positive, limits the
+	// results to that distance.
+	Lat      *float64
+	Lng      *float64
+	RadiusKm float64
+	// Cursor continues from a previous result's NextCursor instead of Page.
+	// Cursor pages skip the count, so Total, Page and TotalPages are left unset.
+	Cursor string
+}
+
+// therapistCursor is the sort key of the last therapist on a page. Filter
+// ties it to the search it came from.
+type therapistCursor struct {
+	Filter string    `json:"f"`
+	Num    string    `json:"n"`
+	Text   string    `json:"t"`
+	Age    float64   `json:"a"`
+	ID     uuid.UUID `json:"i"`
+}
+
+// GetAllTherapists returns paginated list of PT users with optional filters.
+// Available keeps only therapists with an upcoming open slot (on Date, when
+// set); Sort is one of the Sort* constants.
+func (s *TherapistService) GetAllTherapists(ctx context.Context, p TherapistQueryParams) (openapi.TherapistList, error) {
+	if !therapistSorts[p.Sort] {
+		return openapi.TherapistList{}, ErrInvalidSort
+	}
+	if err := checkCoordinates(p.Lat, p.Lng); err != nil {
+		return openapi.TherapistList{}, err
+	}
+	near := p.Lat != nil
+	if p.RadiusKm < 0 || p.RadiusKm > maxSearchRadiusKm {
+		return openapi.TherapistList{}, ErrInvalidRadius
+	}
+	if !near && (p.RadiusKm > 0 || p.Sort == SortDistance) {
+		return openapi.TherapistList{}, ErrLocationRequired
+	}
+	query := strings.TrimSpace(p.Query)
+	sort := p.Sort
+	if sort == "" && query != "" {
+		sort = SortRelevance
+	} else if sort == "" && near {
+		sort = SortDistance
+	}
+	var lat, lng float64
+	var box geoBox
+	if near {
+		lat, lng = *p.Lat, *p.Lng
+		if p.RadiusKm > 0 {
+			box = boundingBox(lat, lng, p.RadiusKm)
+		}
+	}
+	if p.Date != "" {
+		if _, err := time.Parse(time.DateOnly, p.Date); err != nil {
+			return openapi.TherapistList{}, ErrInvalidDate
+		}
+	}
+	minCents, err := priceBound(p.MinPrice)
+	if err != nil {
+		return openapi.TherapistList{}, err
+	}
+	maxCents, err := priceBound(p.MaxPrice)
+	if err != nil {
+		return openapi.TherapistList{}, err
+	}
+	if minCents >= 0 && maxCents >= 0 && minCents > maxCents {
+		return openapi.TherapistList{}, ErrInvalidPriceRange
+	}
+	currency := ""
+	if p.Currency != "" {
+		c, err := checkCurrency(p.Currency)
+		if err != nil {
+			return openapi.TherapistList{}, err
+		}
+		currency = c
+	}
+	insurer, language := strings.TrimSpace(p.Insurer), strings.TrimSpace(p.Language)
+	page := p.Page
+	if page < 1 {
+		page = 1
+	}
+	limit := pageLimit(p.Limit)
+	offset := (page - 1) * limit
+
+	p.Insurer, p.Language, p.Currency = insurer, language, currency
+	filter := therapistFilter(p, query, sort)
+	var cur therapistCursor
+	var curNum float64
+	if p.Cursor != "" {
+		if err := decodeCursor(p.Cursor, &cur); err != nil {
+			return openapi.TherapistList{}, err
+		}
+		n, err := strconv.ParseFloat(cur.Num, 64)
+		if err != nil || cur.Filter != filter {
+			return openapi.TherapistList{}, ErrInvalidCursor
+		}
+		curNum, offset = n, 0
+	}
+
+	// Use sqlc queries for therapist list and counts
+	specParam := "%" + p.Specialty + "%"
+	locParam := "%" + p.Location + "%"
+	total := 0
+	if p.Cursor == "" {
+		total64, err := s.db.Queries.GetTherapistCount(ctx, db.GetTherapistCountParams{
+			Column1:  specParam,
+			Column2:  locParam,
+			Column3:  p.Date,
+			Column4:  p.Available,
+			Column5:  query,
+			Column6:  lat,
+			Column7:  lng,
+			Column8:  p.RadiusKm,
+			Column9:  box.minLat,
+			Column10: box.maxLat,
+			Column11: box.minLng,
+			Column12: box.maxLng,
+			Column13: p.Verified,
+			Column14: insurer,
+			Column15: language,
+			Column16: minCents,
+			Column17: maxCents,
+			Column18: currency,
+		})
+		if err != nil {
+			return openapi.TherapistList{}, err
+		}
+		total = int(total64)
+	}
+
+	therapists, err := s.db.Queries.GetTherapists(ctx, db.GetTherapistsParams{
+		Column1: specParam,
+		Column2: locParam,
+		Column3: p.Date,
+		Column4: p.Available,
+		Column5: sort,
+		// One extra row tells whether there is a next page
+		Limit:    int32(limit + 1),
+		Offset:   int32(offset),
+		Column8:  query,
+		Column9:  lat,
+		Column10: lng,
+		Column11: p.RadiusKm,
+		Column12: box.minLat,
+		Column13: box.maxLat,
+		Column14: box.minLng,
+		Column15: box.maxLng,
+		Column16: near,
+		Column17: p.Cursor != "",
+		Column18: curNum,
+		Column19: cur.Text,
+		Column20: cur.Age,
+		Column21: cur.ID,
+		Column22: p.Verified,
+		Column23: insurer,
+		Column24: language,
+		Column25: minCents,
+		Column26: maxCents,
+		Column27: currency,
+	})
+	if err != nil {
+		return openapi.TherapistList{}, err
+	}
+	var next string
+	if len(therapists) > limit {
+		therapists = therapists[:limit]
+		last := therapists[limit-1]
+		next = encodeCursor(therapistCursor{
+			Filter: filter,
+			Num:    strconv.FormatFloat(last.SortNum, 'g', -1, 64),
+			Text:   last.SortText,
+			Age:    last.SortAge,
+			ID:     last.ID,
+		})
+	}
+
+	out := make([]openapi.Therapist, 0, len(therapists))
+	var ids []uuid.UUID
+	for _, t := range therapists {
+		prof := therapistProfile(t.DisplayName, t.Specialties, t.IsVerified)
+		prof.Languages, prof.Insurers = ptr(nonNil(t.Languages)), ptr(nonNil(t.Insurers))
+		profilePrice(&prof, t.SessionPriceCents, t.PriceCurrency)
+		prof.Address = profileAddress(t.Address, t.Latitude, t.Longitude)
+		prof.Rating = profileRating(t.Rating)
+		summary := openapi.Therapist{
+			Id:                  ptr(t.ID.String()),
+			Email:               ptr(t.Email),
+			Profile:             &prof,
+			AvailableSlotsCount: ptr(0),
+			ReviewCount:         ptr(int(t.ReviewCount.Int64)),
+		}
+		if s := highlightSnippet(t.Snippet); s != "" {
+			summary.Snippet = &s
+		}
+		if t.NextSlot.Valid {
+			summary.NextAvailableAt = &t.NextSlot.Time
+		}
+		if t.DistanceKm.Valid {
+			d := math.Round(t.DistanceKm.Float64*100) / 100
+			summary.DistanceKm = &d
+		}
+		out = append(out, summary)
+		ids = append(ids, t.ID)
+	}
+
+	// Aggregate available slot counts; review counts come with the page
+	if len(ids) > 0 {
+		// convert []uuid.UUID to []uuid.UUID param for sqlc
+		availRows, _ := s.db.Queries.GetAvailabilityCounts(ctx, db.GetAvailabilityCountsParams{Column1: ids, Column2: p.Date})
+		mAvail := map[string]int64{}
+		for _, r := range availRows {
+			mAvail[r.TherapistID] = r.Count
+		}
+		for i := range out {
+			if c, ok := mAvail[*out[i].Id]; ok {
+				out[i].AvailableSlotsCount = ptr(int(c))
+			}
+		}
+	}
+
+	res := openapi.TherapistList{Data: &out}
+	if next != "" {
+		res.NextCursor = &next
+	}
+	if p.Cursor != "" {
+		// Later cursor pages have no count; page/total only describe offset paging
+		return res, nil
+	}
+	totalPages := total / limit
+	if total%limit != 0 {
+		totalPages++
+	}
+	if totalPages == 0 {
+		totalPages = 1
+	}
+	res.Total, res.Page, res.TotalPages = &total, &page, &totalPages
+	return res, nil
+}
+
+// therapistFilter fingerprints the search a cursor belongs to, so a cursor
+// can't be replayed against a different filter or sort.
+func therapistFilter(p TherapistQueryParams, query, sort string) string {
+	coord := func(f *float64) string {
+		if f == nil {
+			return ""
+		}
+		return strconv.FormatFloat(*f, 'g', -1, 64)
+	}
+	sum := sha256.Sum256([]byte(strings.Join([]string{
+		sort, query, p.Specialty, p.Location, p.Date, strconv.FormatBool(p.Available), strconv.FormatBool(p.Verified),
+		coord(p.Lat), coord(p.Lng), strconv.FormatFloat(p.RadiusKm, 'g', -1, 64),
+		strings.ToLower(p.Insurer), strings.ToLower(p.Language), coord(p.MinPrice), coord(p.MaxPrice), p.Currency,
+	}, "\x00")))
+	return hex.EncodeToString(sum[:8])
+}
+
+// priceBound converts an optional price filter to cents, with -1 for none.
+func priceBound(price *float64) (int32, error) {
+	if price == nil {
+		return -1, nil
+	}
+	c, err := priceCents(*price)
+	return c.Int32, err
+}
+
+const earthRadiusKm = 6371.0088
+
+// geoBox is a latitude/longitude range that contains every point within some
+// distance of a centre. It lets the index discard most rows before the exact
+// haversine check.
+type geoBox struct {
+	minLat, maxLat, minLng, maxLng float64
+}
+
+// boundingBox returns the box around (lat, lng) for radiusKm. Near the poles or
+// across the antimeridian it widens to every longitude rather than wrap.
+func boundingBox(lat, lng, radiusKm float64) geoBox {
+	angle := radiusKm / earthRadiusKm
+	latRad := lat * math.Pi / 180
+	b := geoBox{
+		minLat: math.Max(lat-angle*180/math.Pi, -90),
+		maxLat: math.Min(lat+angle*180/math.Pi, 90),
+		minLng: -180,
+		maxLng: 180,
+	}
+	if b.minLat == -90 || b.maxLat == 90 {
+		return b
+	}
+	dLng := math.Asin(math.Sin(angle)/math.Cos(latRad)) * 180 / math.Pi
+	if lng-dLng >= -180 && lng+dLng <= 180 {
+		b.minLng, b.maxLng = lng-dLng, lng+dLng
+	}
+	return b
+}
+
+// highlightSnippet escapes a search headline and turns the \x02/\x03 match
+// markers the query asks ts_headline for into <mark> tags.
+func highlightSnippet(s string) string {
+	if s == "" {
+		return ""
+	}
+	return strings.NewReplacer("\x02", "<mark>", "\x03", "</mark>").Replace(html.EscapeString(s))
+}
+
+// GetTherapistByID returns a single PT with their profile, open slots (on
+// date, when given) and rating summary.
+func (s *TherapistService) GetTherapistByID(ctx context.Context, id string, date string) (openapi.Therapist, error) {
+	q := `SELECT u.id, u.email, COALESCE(p.display_name,''), COALESCE(p.specialties, ARRAY[]::text[]), p.address, COALESCE(p.bio,''), p.rating, p.latitude, p.longitude, COALESCE(p.is_verified, false),
+                 p.session_price_cents, p.price_currency, COALESCE(p.insurers, ARRAY[]::text[]), COALESCE(p.languages, ARRAY[]::text[]),
+                 COALESCE(tr.review_count, 0), COALESCE(tr.rating_sum, 0), COALESCE(tr.stars_1, 0), COALESCE(tr.stars_2, 0),
+                 COALESCE(tr.stars_3, 0), COALESCE(tr.stars_4, 0), COALESCE(tr.stars_5, 0),
+                 COALESCE(tr.communication_sum, 0), COALESCE(tr.communication_count, 0), COALESCE(tr.punctuality_sum, 0), COALESCE(tr.punctuality_count, 0),
+                 COALESCE(tr.effectiveness_sum, 0), COALESCE(tr.effectiveness_count, 0), COALESCE(tr.facility_sum, 0), COALESCE(tr.facility_count, 0)
+          FROM users u LEFT JOIN profiles p ON p.user_id = u.id
+          LEFT JOIN therapist_ratings tr ON tr.therapist_id = u.id
+          WHERE u.id = $1 AND u.role = 'pt' AND u.disabled_at IS NULL`
+	var email, displayName, bio string
+	var uid string
+	var specialties []string
+	var address []byte
+	var rating, lat, lng sql.NullFloat64
+	var verified bool
+	var priceCents sql.NullInt32
+	var currency sql.NullString
+	var insurers, languages []string
+	var agg db.TherapistRating
+	if err := s.db.Pool.QueryRow(ctx, q, id).Scan(&uid, &email, &displayName, &specialties, &address, &bio, &rating, &lat, &lng, &verified,
+		&priceCents, &currency, &insurers, &languages,
+		&agg.ReviewCount, &agg.RatingSum, &agg.Stars1, &agg.Stars2, &agg.Stars3, &agg.Stars4, &agg.Stars5,
+		&agg.CommunicationSum, &agg.CommunicationCount, &agg.PunctualitySum, &agg.PunctualityCount,
+		&agg.EffectivenessSum, &agg.EffectivenessCount, &agg.FacilitySum, &agg.FacilityCount); err != nil {
+		return openapi.Therapist{}, fmt.Errorf("therapist not found")
+	}
+	prof := therapistProfile(displayName, specialties, verified)
+	prof.Bio = &bio
+	prof.Languages, prof.Insurers = ptr(nonNil(languages)), ptr(nonNil(insurers))
+	profilePrice(&prof, priceCents, currency)
+	prof.Address = profileAddress(pqtype.NullRawMessage{RawMessage: address, Valid: address != nil}, lat, lng)
+	if rating.Valid {
+		prof.Rating = &rating.Float64
+	}
+
+	// available slots
+	var slotsQuery string
+	var rowsArgs []interface{}
+	if date != "" {
+		slotsQuery = `SELECT id::text, start_ts, end_ts FROM availability_slots
+					  WHERE therapist_id = $1::uuid AND status = 'open' AND start_ts::date = $2::date
+					  ORDER BY start_ts ASC LIMIT 20`
+		rowsArgs = []interface{}{id, date}
+	} else {
+		slotsQuery = `SELECT id::text, start_ts, end_ts FROM availability_slots
+					  WHERE therapist_id = $1::uuid AND status = 'open'
+					  ORDER BY start_ts ASC LIMIT 20`
+		rowsArgs = []interface{}{id}
+	}
+	rows, err := s.db.Pool.Query(ctx, slotsQuery, rowsArgs...)
+	if err != nil {
+		return openapi.Therapist{}, err
+	}
+	defer rows.Close()
+	slots := make([]openapi.Appointment, 0, 20)
+	for rows.Next() {
+		var sid string
+		var startTs, endTs time.Time
+		if err := rows.Scan(&sid, &startTs, &endTs); err != nil {
+			return openapi.Therapist{}, err
+		}
+		slots = append(slots, openapi.Appointment{Id: &sid, StartTime: &startTs, EndTime: &endTs})
+	}
+	ratings := ratingSummary(agg)
+
+	return openapi.Therapist{
+		Id:             &uid,
+		Email:          &email,
+		Profile:        &prof,
+		AvailableSlots: &slots,
+		ReviewCount:    ratings.Count,
+		Ratings:        &ratings,
+	}, nil
+}
+
+func ratingSummary(agg db.TherapistRating) openapi.RatingSummary {
+	out := openapi.RatingSummary{
+		Count:     ptr(int(agg.ReviewCount)),
+		Histogram: &[]int{int(agg.Stars1), int(agg.Stars2), int(agg.Stars3), int(agg.Stars4), int(agg.Stars5)},
+	}
+	if agg.ReviewCount > 0 {
+		out.Average = ptr(float64(agg.RatingSum) / float64(agg.ReviewCount))
+	}
+	// Sub-ratings are optional, so each is averaged over the reviews that
+	// gave one and left out when none did.
+	average := func(sum, count int32) *float64 {
+		if count == 0 {
+			return nil
+		}
+		return ptr(float64(sum) / float64(count))
+	}
+	sub := openapi.SubRatingAverages{
+		Communication: average(agg.CommunicationSum, agg.CommunicationCount),
+		Punctuality:   average(agg.PunctualitySum, agg.PunctualityCount),
+		Effectiveness: average(agg.EffectivenessSum, agg.EffectivenessCount),
+		Facility:      average(agg.FacilitySum, agg.FacilityCount),
+	}
+	if sub != (openapi.SubRatingAverages{}) {
+		out.SubRatings = &sub
+	}
+	return out
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/db"
+)
+
+func TestHighlightSnippet_EscapesAndMarks(t *testing.T) {
+	got := highlightSnippet("Treats <b>knee</b> pain \x02sports\x03 injuries")
+	want := "Treats &lt;b&gt;knee&lt;/b&gt; pain <mark>sports</mark> injuries"
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	if highlightSnippet("") != "" {
+		t.Fatalf("expected empty snippet to stay empty")
+	}
+}
+
+func TestBoundingBox_ContainsRadius(t *testing.T) {
+	// Points 10km north and east of Bengaluru must fall inside a 10km box
+	lat, lng := 12.9716, 77.5946
+	b := boundingBox(lat, lng, 10)
+	north := lat + 10/earthRadiusKm*180/math.Pi
+	if b.maxLat < north-1e-9 || b.minLat > lat || b.maxLng <= lng || b.minLng >= lng {
+		t.Fatalf("box %+v does not contain the search radius", b)
+	}
+	if b.maxLng-b.minLng > 1 {
+		t.Fatalf("box %+v is wider than expected", b)
+	}
+}
+
+func TestBoundingBox_WidensAtAntimeridianAndPoles(t *testing.T) {
+	if b := boundingBox(0, 179.99, 50); b.minLng != -180 || b.maxLng != 180 {
+		t.Fatalf("expected all longitudes across the antimeridian, got %+v", b)
+	}
+	if b := boundingBox(89.9, 0, 50); b.maxLat != 90 || b.minLng != -180 || b.maxLng != 180 {
+		t.Fatalf("expected all longitudes near the pole, got %+v", b)
+	}
+}
+
+func TestCheckCoordinates(t *testing.T) {
+	f := func(v float64) *float64 { return &v }
+	if err := checkCoordinates(nil, nil); err != nil {
+		t.Fatalf("expected no coordinates to be valid, got %v", err)
+	}
+	if err := checkCoordinates(f(51.5), f(-0.12)); err != nil {
+		t.Fatalf("expected valid pair, got %v", err)
+	}
+	if err := checkCoordinates(f(51.5), nil); err != ErrInvalidCoordinates {
+		t.Fatalf("expected half a pair to fail, got %v", err)
+	}
+	if err := checkCoordinates(f(91), f(0)); err != ErrInvalidCoordinates {
+		t.Fatalf("expected out of range latitude to fail, got %v", err)
+	}
+}
+
+func TestCursor_RoundTripAndRejectsGarbage(t *testing.T) {
+	in := therapistCursor{Filter: "f", Num: "+Inf", Text: "amy", Age: -1.7e9, ID: uuid.New()}
+	var out therapistCursor
+	if err := decodeCursor(encodeCursor(in), &out); err != nil || out != in {
+		t.Fatalf("round trip = %+v, %v; want %+v", out, err, in)
+	}
+	if err := decodeCursor("not a cursor!", &out); err != ErrInvalidCursor {
+		t.Fatalf("expected ErrInvalidCursor, got %v", err)
+	}
+}
+
+func TestTherapistFilter_DependsOnSearch(t *testing.T) {
+	p := TherapistQueryParams{Specialty: "knee"}
+	if therapistFilter(p, "", SortName) == therapistFilter(p, "", SortRating) {
+		t.Fatalf("expected sort to change the filter")
+	}
+	p2 := p
+	p2.Page = 3
+	p2.Cursor = "x"
+	if therapistFilter(p, "", SortName) != therapistFilter(p2, "", SortName) {
+		t.Fatalf("expected paging fields not to change the filter")
+	}
+	price := 80.0
+	p3 := p
+	p3.MaxPrice = &price
+	if therapistFilter(p, "", SortName) == therapistFilter(p3, "", SortName) {
+		t.Fatalf("expected a price bound to change the filter")
+	}
+	p4 := p
+	p4.Insurer = "Bupa"
+	if therapistFilter(p, "", SortName) == therapistFilter(p4, "", SortName) {
+		t.Fatalf("expected an insurer to change the filter")
+	}
+}
+
+func TestRatingSummary(t *testing.T) {
+	r := ratingSummary(db.TherapistRating{ReviewCount: 4, RatingSum: 17, Stars4: 3, Stars5: 1})
+	if *r.Count != 4 || *r.Average != 4.25 || !reflect.DeepEqual(*r.Histogram, []int{0, 0, 0, 3, 1}) {
+		t.Fatalf("unexpected summary %+v", r)
+	}
+	if r.SubRatings != nil {
+		t.Fatalf("expected no sub-rating averages without sub-ratings, got %+v", *r.SubRatings)
+	}
+	if r := ratingSummary(db.TherapistRating{}); *r.Count != 0 || r.Average != nil || len(*r.Histogram) != 5 {
+		t.Fatalf("expected an empty summary without an average, got %+v", r)
+	}
+}
+
+func TestRatingSummary_SubRatings(t *testing.T) {
+	r := ratingSummary(db.TherapistRating{ReviewCount: 3, RatingSum: 12, CommunicationSum: 9, CommunicationCount: 2, FacilitySum: 3, FacilityCount: 1})
+	if r.SubRatings == nil {
+		t.Fatal("expected sub-rating averages")
+	}
+	sub := *r.SubRatings
+	if *sub.Communication != 4.5 || *sub.Facility != 3 || sub.Punctuality != nil || sub.Effectiveness != nil {
+		t.Fatalf("unexpected sub-rating averages %+v", sub)
+	}
+}
@@ -0,0 +1,317 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"net/http"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/blob"
+	"github.com/divijg19/physiolink/backend/internal/db"
+	"github.com/divijg19/physiolink/backend/internal/imaging"
+)
+
+// Upload kinds. Avatars are visible to any signed-in user; documents only to
+// their owner and admins.
+const (
+	UploadAvatar   = "avatar"
+	UploadDocument = "document"
+)
+
+const (
+	MaxAvatarBytes   = 5 << 20
+	MaxDocumentBytes = 10 << 20
+	// avatarMaxSide bounds the stored original; thumbnails are square.
+	avatarMaxSide   = 1024
+	downloadURLLife = 15 * time.Minute
+)
+
+// AvatarSizes are the thumbnail edge lengths generated for every avatar.
+var AvatarSizes = []int{64, 128, 256}
+
+var (
+	avatarTypes   = []string{"image/jpeg", "image/png", "image/gif"}
+	documentTypes = []string{"application/pdf", "image/jpeg", "image/png"}
+	typeExt       = map[string]string{"image/jpeg": ".jpg", "image/png": ".png", "image/gif": ".gif", "application/pdf": ".pdf"}
+)
+
+var (
+	ErrFileTooLarge      = errors.New("file is too large")
+	ErrEmptyFile         = errors.New("file is empty")
+	ErrUnsupportedFile   = errors.New("unsupported file type")
+	ErrInvalidImage      = errors.New("file is not a valid image")
+	ErrUploadNotFound    = errors.New("upload not found")
+	ErrUnknownUploadSize = errors.New("unknown thumbnail size")
+)
+
+// Upload describes a stored file. URL and Thumbnails point at the API, which
+// redirects to a short-lived signed link.
+type Upload struct {
+	ID          uuid.UUID         `json:"_id"`
+	Kind        string            `json:"kind"`
+	Filename    string            `json:"filename"`
+	ContentType string            `json:"contentType"`
+	Size        int64             `json:"size"`
+	URL         string            `json:"url"`
+	Thumbnails  map[string]string `json:"thumbnails,omitempty"`
+	CreatedAt   time.Time         `json:"createdAt"`
+}
+
+type UploadService struct {
+	db    *db.DB
+	store blob.BlobStore
+}
+
+func NewUploadService(d *db.DB, store blob.BlobStore) *UploadService {
+	return &UploadService{db: d, store: store}
+}
+
+// UploadAvatar stores a profile picture with its thumbnails and points the
+// owner's profile at it. The image is re-encoded, which drops its metadata.
+func (s *UploadService) UploadAvatar(ctx context.Context, ownerID uuid.UUID, filename string, body io.Reader) (Upload, error) {
+	data, ctype, err := readUpload(body, MaxAvatarBytes, avatarTypes)
+	if err != nil {
+		return Upload{}, err
+	}
+	img, format, err := imaging.Decode(data)
+	if err != nil {
+		return Upload{}, ErrInvalidImage
+	}
+	original, ctype, err := imaging.Encode(imaging.Fit(img, avatarMaxSide), format)
+	if err != nil {
+		return Upload{}, err
+	}
+	files := map[string][]byte{"original": original}
+	variants := make([]string, 0, len(AvatarSizes))
+	for _, size := range AvatarSizes {
+		thumb, _, err := imaging.Encode(imaging.Thumbnail(img, size), format)
+		if err != nil {
+			return Upload{}, err
+		}
+		name := strconv.Itoa(size)
+		files[name] = thumb
+		variants = append(variants, name)
+	}
+
+	prefix := "avatars/" + ownerID.String() + "/" + uuid.NewString()
+	key := prefix + "/original" + typeExt[ctype]
+	if err := s.putAll(ctx, prefix, ctype, files); err != nil {
+		return Upload{}, err
+	}
+
+	tx, err := s.db.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		s.deleteAll(prefix, ctype, files)
+		return Upload{}, err
+	}
+	defer tx.Rollback()
+	q := s.db.Queries.WithTx(tx)
+	u, err := q.CreateUpload(ctx, db.CreateUploadParams{
+		OwnerID:     ownerID,
+		Kind:        UploadAvatar,
+		Filename:    cleanFilename(filename),
+		ContentType: ctype,
+		SizeBytes:   int64(len(original)),
+		StorageKey:  key,
+		Variants:    variants,
+	})
+	if err == nil {
+		err = q.SetProfileImage(ctx, db.SetProfileImageParams{
+			UserID:          ownerID,
+			ProfileImageUrl: sql.NullString{String: uploadPath(u.ID), Valid: true},
+		})
+	}
+	if err == nil {
+		err = tx.Commit()
+	}
+	if err != nil {
+		s.deleteAll(prefix, ctype, files)
+		return Upload{}, err
+	}
+	return toUpload(u), nil
+}
+
+// UploadDocument stores a credential document as sent, once its content
+// has been sniffed as a PDF or image.
+func (s *UploadService) UploadDocument(ctx context.Context, ownerID uuid.UUID, filename string, body io.Reader) (Upload, error) {
+	data, ctype, err := readUpload(body, MaxDocumentBytes, documentTypes)
+	if err != nil {
+		return Upload{}, err
+	}
+	u, err := s.db.Queries.GetUserByID(ctx, ownerID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Upload{}, ErrUserNotFound
+		}
+		return Upload{}, err
+	}
+	if !IsTherapistRole(u.Role) {
+		return Upload{}, ErrNotTherapist
+	}
+
+	prefix := "documents/" + ownerID.String() + "/" + uuid.NewString()
+	files := map[string][]byte{"original": data}
+	if err := s.putAll(ctx, prefix, ctype, files); err != nil {
+		return Upload{}, err
+	}
+	up, err := s.db.Queries.CreateUpload(ctx, db.CreateUploadParams{
+		OwnerID:     ownerID,
+		Kind:        UploadDocument,
+		Filename:    cleanFilename(filename),
+		ContentType: ctype,
+		SizeBytes:   int64(len(data)),
+		StorageKey:  prefix + "/original" + typeExt[ctype],
+		Variants:    []string{},
+	})
+	if err != nil {
+		s.deleteAll(prefix, ctype, files)
+		return Upload{}, err
+	}
+	return toUpload(up), nil
+}
+
+// GetDocument returns one of ownerID's documents, so other records can
+// reference it.
+func (s *UploadService) GetDocument(ctx context.Context, ownerID, id uuid.UUID) (Upload, error) {
+	u, err := s.db.Queries.GetUpload(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return Upload{}, ErrUploadNotFound
+		}
+		return Upload{}, err
+	}
+	if u.Kind != UploadDocument || u.OwnerID != ownerID {
+		return Upload{}, ErrUploadNotFound
+	}
+	return toUpload(u), nil
+}
+
+// DownloadURL returns a signed link to an upload, or to one of its
+// thumbnails when size is set. Documents are reported as missing to anyone
+// but their owner and admins.
+func (s *UploadService) DownloadURL(ctx context.Context, requesterID, id uuid.UUID, size string) (string, error) {
+	u, err := s.db.Queries.GetUpload(ctx, id)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", ErrUploadNotFound
+		}
+		return "", err
+	}
+	if u.Kind == UploadDocument && u.OwnerID != requesterID {
+		r, err := s.db.Queries.GetUserByID(ctx, requesterID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return "", err
+		}
+		if err != nil || r.Role != RoleAdmin || r.DisabledAt.Valid {
+			return "", ErrUploadNotFound
+		}
+	}
+	key := u.StorageKey
+	if size != "" {
+		if !slices.Contains(u.Variants, size) {
+			return "", ErrUnknownUploadSize
+		}
+		key = path.Dir(key) + "/" + size + path.Ext(key)
+	}
+	return s.store.SignedURL(ctx, key, downloadURLLife)
+}
+
+func (s *UploadService) putAll(ctx context.Context, prefix, ctype string, files map[string][]byte) error {
+	for name, data := range files {
+		key := prefix + "/" + name + typeExt[ctype]
+		if err := s.store.Put(ctx, key, ctype, bytes.NewReader(data), int64(len(data))); err != nil {
+			s.deleteAll(prefix, ctype, files)
+			return err
+		}
+	}
+	return nil
+}
+
+// deleteAll removes what putAll stored after a failed upload. It runs on a
+// fresh context since the request's may already be cancelled.
+func (s *UploadService) deleteAll(prefix, ctype string, files map[string][]byte) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	for name := range files {
+		_ = s.store.Delete(ctx, prefix+"/"+name+typeExt[ctype])
+	}
+}
+
+// readUpload reads at most max bytes and sniffs the content type from the
+// data itself; the client's Content-Type is never trusted.
+func readUpload(body io.Reader, max int64, allowed []string) ([]byte, string, error) {
+	data, err := io.ReadAll(io.LimitReader(body, max+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if int64(len(data)) > max {
+		return nil, "", ErrFileTooLarge
+	}
+	if len(data) == 0 {
+		return nil, "", ErrEmptyFile
+	}
+	ctype := sniffContentType(data)
+	if !slices.Contains(allowed, ctype) {
+		return nil, "", ErrUnsupportedFile
+	}
+	return data, ctype, nil
+}
+
+func sniffContentType(data []byte) string {
+	ctype := http.DetectContentType(data)
+	if i := strings.IndexByte(ctype, ';'); i >= 0 {
+		ctype = ctype[:i]
+	}
+	return ctype
+}
+
+// cleanFilename keeps the base name the client sent, for display only.
+func cleanFilename(name string) string {
+	name = path.Base(strings.ReplaceAll(name, `\`, "/"))
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name))
+	if name == "" || name == "." || name == "/" {
+		return "upload"
+	}
+	if r := []rune(name); len(r) > 255 {
+		name = string(r[:255])
+	}
+	return name
+}
+
+func uploadPath(id uuid.UUID) string {
+	return "/api/uploads/" + id.String()
+}
+
+func toUpload(u db.Upload) Upload {
+	out := Upload{
+		ID:          u.ID,
+		Kind:        u.Kind,
+		Filename:    u.Filename,
+		ContentType: u.ContentType,
+		Size:        u.SizeBytes,
+		URL:         uploadPath(u.ID),
+		CreatedAt:   u.CreatedAt,
+	}
+	if len(u.Variants) > 0 {
+		out.Thumbnails = make(map[string]string, len(u.Variants))
+		for _, v := range u.Variants {
+			out.Thumbnails[v] = out.URL + "?size=" + v
+		}
+	}
+	return out
+}
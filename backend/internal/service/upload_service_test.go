@@ -0,0 +1,76 @@
+package service
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/db"
+)
+
+func TestReadUpload_SniffsAndLimits(t *testing.T) {
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 2, 2))); err != nil {
+		t.Fatal(err)
+	}
+	pngData := buf.Bytes()
+
+	// The declared type doesn't matter; the bytes decide
+	if _, ctype, err := readUpload(bytes.NewReader(pngData), MaxAvatarBytes, avatarTypes); err != nil || ctype != "image/png" {
+		t.Fatalf("readUpload(png) = %q, %v", ctype, err)
+	}
+	if _, ctype, err := readUpload(strings.NewReader("%PDF-1.7\n..."), MaxDocumentBytes, documentTypes); err != nil || ctype != "application/pdf" {
+		t.Fatalf("readUpload(pdf) = %q, %v", ctype, err)
+	}
+
+	cases := map[string]struct {
+		data    []byte
+		max     int64
+		allowed []string
+		want    error
+	}{
+		"pdf as avatar":  {[]byte("%PDF-1.7\n"), MaxAvatarBytes, avatarTypes, ErrUnsupportedFile},
+		"html document":  {[]byte("<html><script>alert(1)</script>"), MaxDocumentBytes, documentTypes, ErrUnsupportedFile},
+		"empty":          {nil, MaxAvatarBytes, avatarTypes, ErrEmptyFile},
+		"one byte over":  {pngData, int64(len(pngData) - 1), avatarTypes, ErrFileTooLarge},
+		"exactly at max": {pngData, int64(len(pngData)), avatarTypes, nil},
+	}
+	for name, c := range cases {
+		if _, _, err := readUpload(bytes.NewReader(c.data), c.max, c.allowed); !errors.Is(err, c.want) {
+			t.Errorf("%s: got %v, want %v", name, err, c.want)
+		}
+	}
+}
+
+func TestCleanFilename(t *testing.T) {
+	cases := map[string]string{
+		"licence.pdf":            "licence.pdf",
+		`C:\Users\me\scan.png`:   "scan.png",
+		"../../etc/passwd":       "passwd",
+		" bad\x00\nname.jpg ":    "badname.jpg",
+		"":                       "upload",
+		"/":                      "upload",
+		strings.Repeat("é", 300): strings.Repeat("é", 255),
+	}
+	for in, want := range cases {
+		if got := cleanFilename(in); got != want {
+			t.Errorf("cleanFilename(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
+
+func TestToUpload_LinksThumbnails(t *testing.T) {
+	id := uuid.New()
+	u := toUpload(db.Upload{ID: id, Kind: UploadAvatar, Variants: []string{"64", "128"}})
+	if u.URL != "/api/uploads/"+id.String() || u.Thumbnails["128"] != u.URL+"?size=128" || len(u.Thumbnails) != 2 {
+		t.Fatalf("unexpected upload %+v", u)
+	}
+	if d := toUpload(db.Upload{ID: id, Kind: UploadDocument}); d.Thumbnails != nil {
+		t.Fatalf("documents have no thumbnails, got %v", d.Thumbnails)
+	}
+}
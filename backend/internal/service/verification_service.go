@@ -0,0 +1,190 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/divijg19/physiolink/backend/internal/db"
+)
+
+// Credential submission states. Only a pending submission can be reviewed.
+const (
+	CredentialPending  = "pending"
+	CredentialApproved = "approved"
+	CredentialRejected = "rejected"
+)
+
+const maxCredentialDocuments = 10
+
+var (
+	ErrNotTherapist            = errors.New("only therapists can submit credentials")
+	ErrCredentialsIncomplete   = errors.New("licence number, issuing body and at least one document are required")
+	ErrInvalidDocument         = errors.New("each document needs a name and an http(s) url or one of your uploaded documents")
+	ErrSubmissionPending       = errors.New("a credential submission is already awaiting review")
+	ErrSubmissionNotFound      = errors.New("credential submission not found")
+	ErrSubmissionReviewed      = errors.New("credential submission has already been reviewed")
+	ErrInvalidSubmissionStatus = errors.New("invalid credential submission status")
+	ErrRejectionReasonRequired = errors.New("notes are required to reject a submission")
+)
+
+// CredentialDocument references a licence or certificate, either one
+// uploaded through the API (UploadID) or hosted elsewhere (URL).
+type CredentialDocument struct {
+	Name     string     `json:"name"`
+	URL      string     `json:"url"`
+	UploadID *uuid.UUID `json:"uploadId,omitempty"`
+}
+
+// CredentialSubmissionInput is what a therapist sends for review.
+type CredentialSubmissionInput struct {
+	LicenceNumber string               `json:"licenceNumber"`
+	IssuingBody   string               `json:"issuingBody"`
+	Documents     []CredentialDocument `json:"documents"`
+}
+
+type CredentialSubmission struct {
+	ID             uuid.UUID            `json:"_id"`
+	TherapistID    uuid.UUID            `json:"therapistId"`
+	TherapistName  string               `json:"therapistName,omitempty"`
+	TherapistEmail string               `json:"therapistEmail,omitempty"`
+	LicenceNumber  string               `json:"licenceNumber"`
+	IssuingBody    string               `json:"issuingBody"`
+	Documents      []CredentialDocument `json:"documents"`
+	Status         string               `json:"status"`
+	ReviewNotes    string               `json:"reviewNotes,omitempty"`
+	ReviewedBy     *uuid.UUID           `json:"reviewedBy,omitempty"`
+	ReviewedAt     *time.Time           `json:"reviewedAt,omitempty"`
+	CreatedAt      time.Time            `json:"createdAt"`
+}
+
+type VerificationService struct {
+	db *db.DB
+}
+
+func NewVerificationService(d *db.DB) *VerificationService {
+	return &VerificationService{db: d}
+}
+
+// SubmitCredentials queues a therapist's licence details for admin review.
+// A therapist can have one submission pending at a time.
+func (s *VerificationService) SubmitCredentials(ctx context.Context, therapistID uuid.UUID, in CredentialSubmissionInput) (CredentialSubmission, error) {
+	in, err := checkCredentials(in)
+	if err != nil {
+		return CredentialSubmission{}, err
+	}
+	u, err := s.db.Queries.GetUserByID(ctx, therapistID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return CredentialSubmission{}, ErrUserNotFound
+		}
+		return CredentialSubmission{}, err
+	}
+	if !IsTherapistRole(u.Role) {
+		return CredentialSubmission{}, ErrNotTherapist
+	}
+	for i, d := range in.Documents {
+		if d.UploadID == nil {
+			continue
+		}
+		up, err := s.db.Queries.GetUpload(ctx, *d.UploadID)
+		if errors.Is(err, sql.ErrNoRows) || err == nil && (up.OwnerID != therapistID || up.Kind != UploadDocument) {
+			return CredentialSubmission{}, ErrInvalidDocument
+		}
+		if err != nil {
+			return CredentialSubmission{}, err
+		}
+		in.Documents[i].URL = uploadPath(up.ID)
+	}
+	docs, err := json.Marshal(in.Documents)
+	if err != nil {
+		return CredentialSubmission{}, err
+	}
+	c, err := s.db.Queries.CreateCredentialSubmission(ctx, db.CreateCredentialSubmissionParams{
+		TherapistID:   therapistID,
+		LicenceNumber: in.LicenceNumber,
+		IssuingBody:   in.IssuingBody,
+		Documents:     docs,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return CredentialSubmission{}, ErrSubmissionPending
+		}
+		return CredentialSubmission{}, err
+	}
+	return toCredentialSubmission(c), nil
+}
+
+// ListMySubmissions returns a therapist's submissions, newest first, with
+// the admin's notes on any that were reviewed.
+func (s *VerificationService) ListMySubmissions(ctx context.Context, therapistID uuid.UUID) ([]CredentialSubmission, error) {
+	rows, err := s.db.Queries.ListTherapistCredentialSubmissions(ctx, therapistID)
+	if err != nil {
+		return nil, err
+	}
+	out := make([]CredentialSubmission, 0, len(rows))
+	for _, c := range rows {
+		out = append(out, toCredentialSubmission(c))
+	}
+	return out, nil
+}
+
+// checkCredentials trims and validates a submission.
+func checkCredentials(in CredentialSubmissionInput) (CredentialSubmissionInput, error) {
+	in.LicenceNumber = strings.TrimSpace(in.LicenceNumber)
+	in.IssuingBody = strings.TrimSpace(in.IssuingBody)
+	if in.LicenceNumber == "" || in.IssuingBody == "" || len(in.Documents) == 0 {
+		return in, ErrCredentialsIncomplete
+	}
+	if len(in.Documents) > maxCredentialDocuments {
+		return in, ErrInvalidDocument
+	}
+	docs := make([]CredentialDocument, 0, len(in.Documents))
+	for _, d := range in.Documents {
+		d.Name = strings.TrimSpace(d.Name)
+		if d.UploadID != nil {
+			// The URL is filled in once the upload is checked
+			if d.Name == "" {
+				return in, ErrInvalidDocument
+			}
+			d.URL = ""
+			docs = append(docs, d)
+			continue
+		}
+		u, err := url.Parse(strings.TrimSpace(d.URL))
+		if d.Name == "" || err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return in, ErrInvalidDocument
+		}
+		d.URL = u.String()
+		docs = append(docs, d)
+	}
+	in.Documents = docs
+	return in, nil
+}
+
+func toCredentialSubmission(c db.CredentialSubmission) CredentialSubmission {
+	out := CredentialSubmission{
+		ID:            c.ID,
+		TherapistID:   c.TherapistID,
+		LicenceNumber: c.LicenceNumber,
+		IssuingBody:   c.IssuingBody,
+		Documents:     []CredentialDocument{},
+		Status:        c.Status,
+		ReviewNotes:   c.ReviewNotes.String,
+		CreatedAt:     c.CreatedAt,
+	}
+	_ = json.Unmarshal(c.Documents, &out.Documents)
+	if c.ReviewedBy.Valid {
+		out.ReviewedBy = &c.ReviewedBy.UUID
+	}
+	if c.ReviewedAt.Valid {
+		out.ReviewedAt = &c.ReviewedAt.Time
+	}
+	return out
+}
@@ -0,0 +1,38 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCheckCredentials(t *testing.T) {
+	doc := CredentialDocument{Name: " licence.pdf ", URL: "https://files.example.com/licence.pdf"}
+	in, err := checkCredentials(CredentialSubmissionInput{LicenceNumber: " PT-1 ", IssuingBody: "HCPC", Documents: []CredentialDocument{doc}})
+	if err != nil || in.LicenceNumber != "PT-1" || in.Documents[0].Name != "licence.pdf" {
+		t.Fatalf("checkCredentials = %+v, %v", in, err)
+	}
+
+	id := uuid.New()
+	in, err = checkCredentials(CredentialSubmissionInput{LicenceNumber: "PT-1", IssuingBody: "HCPC", Documents: []CredentialDocument{{Name: "scan", URL: "ignored", UploadID: &id}}})
+	if err != nil || in.Documents[0].URL != "" || *in.Documents[0].UploadID != id {
+		t.Fatalf("uploaded document: %+v, %v", in, err)
+	}
+
+	cases := map[string]struct {
+		in   CredentialSubmissionInput
+		want error
+	}{
+		"no documents":   {CredentialSubmissionInput{LicenceNumber: "PT-1", IssuingBody: "HCPC"}, ErrCredentialsIncomplete},
+		"blank licence":  {CredentialSubmissionInput{LicenceNumber: " ", IssuingBody: "HCPC", Documents: []CredentialDocument{doc}}, ErrCredentialsIncomplete},
+		"unnamed":        {CredentialSubmissionInput{LicenceNumber: "PT-1", IssuingBody: "HCPC", Documents: []CredentialDocument{{URL: doc.URL}}}, ErrInvalidDocument},
+		"not http":       {CredentialSubmissionInput{LicenceNumber: "PT-1", IssuingBody: "HCPC", Documents: []CredentialDocument{{Name: "x", URL: "javascript:alert(1)"}}}, ErrInvalidDocument},
+		"unnamed upload": {CredentialSubmissionInput{LicenceNumber: "PT-1", IssuingBody: "HCPC", Documents: []CredentialDocument{{UploadID: &id}}}, ErrInvalidDocument},
+		"too many files": {CredentialSubmissionInput{LicenceNumber: "PT-1", IssuingBody: "HCPC", Documents: make([]CredentialDocument, maxCredentialDocuments+1)}, ErrInvalidDocument},
+	}
+	for name, c := range cases {
+		if _, err := checkCredentials(c.in); err != c.want {
+			t.Errorf("%s: got %v, want %v", name, err, c.want)
+		}
+	}
+}
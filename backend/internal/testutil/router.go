@@ -3,32 +3,57 @@ package testutil
 import (
 	"net/http"
 
+	"github.com/divijg19/physiolink/backend/internal/blob"
 	"github.com/divijg19/physiolink/backend/internal/clock"
 	"github.com/divijg19/physiolink/backend/internal/config"
 	"github.com/divijg19/physiolink/backend/internal/db"
 	"github.com/divijg19/physiolink/backend/internal/handlers"
+	mware "github.com/divijg19/physiolink/backend/internal/middleware"
 	"github.com/divijg19/physiolink/backend/internal/server"
 	"github.com/divijg19/physiolink/backend/internal/service"
 )
 
 // NewRouterWithServices wires up database-backed services and handlers using the provided clock.
+// Uploads go to a local blob store in uploadDir, typically t.TempDir().
 // Caller is responsible for connecting the DB and closing it when done.
-func NewRouterWithServices(cfg *config.Config, database *db.DB, clk clock.Clock) http.Handler {
+func NewRouterWithServices(cfg *config.Config, database *db.DB, clk clock.Clock, uploadDir string) http.Handler {
 	// create services
 	authSvc := service.NewAuthService(database, cfg)
 	profileSvc := service.NewProfileService(database, cfg)
 	therapistSvc := service.NewTherapistService(database)
-	reviewSvc := service.NewReviewService(database)
+	favouriteSvc := service.NewFavouriteService(database)
+	recommendationSvc := service.NewRecommendationService(database, nil, clk)
+	reviewSvc := service.NewReviewService(database, clk, cfg.ReviewEditWindow, nil)
 	reminderSvc := service.NewReminderService(database.Queries, clk)
+	mfaSvc := service.NewMFAService(database, clk)
+	oidcSvc := service.NewOIDCService(database)
+	adminSvc := service.NewAdminService(database)
+	sessionSvc := service.NewSessionService(database)
+	apiKeySvc := service.NewAPIKeyService(database)
+	scheduleSvc := service.NewClinicScheduleService(database)
 	apptSvc := service.NewAppointmentService(database, nil)
+	verificationSvc := service.NewVerificationService(database)
+	store := blob.NewLocal(uploadDir, "/files", []byte(cfg.Uploads.SigningKey), clk)
+	uploadSvc := service.NewUploadService(database, store)
 
 	// register handlers
 	handlers.InitAuth(authSvc, cfg)
+	handlers.InitMFA(mfaSvc)
+	handlers.InitOIDC(oidcSvc, nil, cfg.OIDCAppRedirects)
 	handlers.InitProfile(profileSvc)
+	handlers.InitVerification(verificationSvc)
+	handlers.InitUploads(uploadSvc, store)
 	handlers.InitTherapists(therapistSvc)
+	handlers.InitFavourites(favouriteSvc)
+	handlers.InitRecommendations(recommendationSvc)
 	handlers.InitReviews(reviewSvc)
 	handlers.InitAppointments(apptSvc)
 	handlers.InitReminders(reminderSvc)
+	handlers.InitAdmin(adminSvc)
+	handlers.InitSessions(sessionSvc)
+	mware.InitSessions(sessionSvc, mware.DefaultSessionCacheTTL)
+	handlers.InitIntegrations(scheduleSvc)
+	mware.InitAPIKeys(apiKeySvc)
 
 	return server.NewRouter(cfg)
 }
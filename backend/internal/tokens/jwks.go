@@ -0,0 +1,107 @@
+package tokens
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+)
+
+// JWK is the public part of a signing key as described in RFC 7517.
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Alg string `json:"alg"`
+	Use string `json:"use"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// OKP (Ed25519)
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKS struct {
+	Keys []JWK `json:"keys"`
+}
+
+// JWKS returns every asymmetric key in the set, including verify-only keys,
+// so consumers keep accepting tokens signed before a rotation. The legacy
+// HMAC secret is never published.
+func (ks *KeySet) JWKS() JWKS {
+	out := JWKS{Keys: []JWK{}}
+	for _, k := range ks.byKID {
+		switch pub := k.public.(type) {
+		case *rsa.PublicKey:
+			out.Keys = append(out.Keys, JWK{
+				Kty: "RSA",
+				Kid: k.ID,
+				Alg: k.Method.Alg(),
+				Use: "sig",
+				N:   b64(pub.N.Bytes()),
+				E:   b64(big.NewInt(int64(pub.E)).Bytes()),
+			})
+		case ed25519.PublicKey:
+			out.Keys = append(out.Keys, JWK{
+				Kty: "OKP",
+				Kid: k.ID,
+				Alg: k.Method.Alg(),
+				Use: "sig",
+				Crv: "Ed25519",
+				X:   b64(pub),
+			})
+		}
+	}
+	sort.Slice(out.Keys, func(i, j int) bool { return out.Keys[i].Kid < out.Keys[j].Kid })
+	return out
+}
+
+// ParseJWKS builds a verify-only KeySet from a published key set, e.g. an
+// identity provider's jwks_uri. Keys we can't use (EC, encryption keys,
+// missing kid) are skipped.
+func ParseJWKS(data []byte) (*KeySet, error) {
+	var set JWKS
+	if err := json.Unmarshal(data, &set); err != nil {
+		return nil, err
+	}
+	ks := NewHMAC("")
+	for _, j := range set.Keys {
+		if j.Kid == "" || (j.Use != "" && j.Use != "sig") {
+			continue
+		}
+		var pub interface{}
+		switch {
+		case j.Kty == "RSA":
+			n, err := base64.RawURLEncoding.DecodeString(j.N)
+			if err != nil {
+				return nil, fmt.Errorf("key %q: %w", j.Kid, err)
+			}
+			e, err := base64.RawURLEncoding.DecodeString(j.E)
+			if err != nil {
+				return nil, fmt.Errorf("key %q: %w", j.Kid, err)
+			}
+			pub = &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		case j.Kty == "OKP" && j.Crv == "Ed25519":
+			x, err := base64.RawURLEncoding.DecodeString(j.X)
+			if err != nil || len(x) != ed25519.PublicKeySize {
+				return nil, fmt.Errorf("key %q: invalid Ed25519 key", j.Kid)
+			}
+			pub = ed25519.PublicKey(x)
+		default:
+			continue
+		}
+		k, err := NewKey(j.Kid, pub)
+		if err != nil {
+			return nil, err
+		}
+		if err := ks.Add(k); err != nil {
+			return nil, err
+		}
+	}
+	return ks, nil
+}
+
+func b64(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
@@ -0,0 +1,225 @@
+// Package tokens signs and verifies our JWTs. A KeySet holds one active
+// signing key plus any number of verify-only keys indexed by "kid", so keys
+// can be rotated without invalidating sessions issued under the previous key.
+package tokens
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+var ErrUnknownKey = errors.New("unknown signing key")
+
+// Key is a single signing or verification key.
+type Key struct {
+	ID     string
+	Method jwt.SigningMethod
+	// private is nil for retired keys that may only verify.
+	private crypto.PrivateKey
+	public  crypto.PublicKey
+}
+
+// KeySet signs with its active key and verifies against every key it holds.
+// Tokens without a "kid" header are treated as legacy HS256 tokens and checked
+// against the shared secret, if one is configured.
+type KeySet struct {
+	active *Key
+	byKID  map[string]*Key
+	legacy []byte
+}
+
+// NewHMAC returns a KeySet that signs and verifies HS256 with secret only,
+// matching tokens issued before asymmetric keys were introduced. An empty
+// secret yields a KeySet that rejects everything.
+func NewHMAC(secret string) *KeySet {
+	ks := &KeySet{byKID: map[string]*Key{}}
+	if secret != "" {
+		ks.legacy = []byte(secret)
+	}
+	return ks
+}
+
+// LoadDir reads every *.pem file in dir; the file name without extension is
+// the kid. RSA keys sign with RS256 and Ed25519 keys with EdDSA. Files holding
+// only a public key are kept for verification after their private half has
+// been retired. activeKID selects the signing key; when empty the
+// lexicographically last private key wins, so date-named files rotate
+// naturally. legacySecret, if non-empty, keeps HS256 tokens valid until they
+// expire.
+func LoadDir(dir, activeKID, legacySecret string) (*KeySet, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	ks := NewHMAC(legacySecret)
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		if err != nil {
+			return nil, err
+		}
+		kid := strings.TrimSuffix(filepath.Base(f), filepath.Ext(f))
+		k, err := ParsePEM(kid, b)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", f, err)
+		}
+		if err := ks.Add(k); err != nil {
+			return nil, err
+		}
+		if k.private != nil && activeKID == "" {
+			ks.active = k
+		}
+	}
+	if activeKID != "" {
+		k, ok := ks.byKID[activeKID]
+		if !ok || k.private == nil {
+			return nil, fmt.Errorf("active key %q has no private key in %s", activeKID, dir)
+		}
+		ks.active = k
+	}
+	if ks.active == nil {
+		return nil, fmt.Errorf("no private signing key found in %s", dir)
+	}
+	return ks, nil
+}
+
+// ParsePEM decodes a PKCS#8, PKCS#1 or PKIX encoded RSA or Ed25519 key.
+func ParsePEM(kid string, data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block")
+	}
+	var parsed interface{}
+	var err error
+	switch block.Type {
+	case "PRIVATE KEY":
+		parsed, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	case "RSA PRIVATE KEY":
+		parsed, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "PUBLIC KEY":
+		parsed, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return NewKey(kid, parsed)
+}
+
+// NewKey wraps an RSA or Ed25519 private or public key.
+func NewKey(kid string, k interface{}) (*Key, error) {
+	switch v := k.(type) {
+	case *rsa.PrivateKey:
+		return &Key{ID: kid, Method: jwt.SigningMethodRS256, private: v, public: &v.PublicKey}, nil
+	case *rsa.PublicKey:
+		return &Key{ID: kid, Method: jwt.SigningMethodRS256, public: v}, nil
+	case ed25519.PrivateKey:
+		return &Key{ID: kid, Method: jwt.SigningMethodEdDSA, private: v, public: v.Public()}, nil
+	case ed25519.PublicKey:
+		return &Key{ID: kid, Method: jwt.SigningMethodEdDSA, public: v}, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", k)
+	}
+}
+
+// Add registers a key for verification. Use SetActive to sign with it.
+func (ks *KeySet) Add(k *Key) error {
+	if k.ID == "" {
+		return errors.New("key id required")
+	}
+	if _, dup := ks.byKID[k.ID]; dup {
+		return fmt.Errorf("duplicate key id %q", k.ID)
+	}
+	ks.byKID[k.ID] = k
+	return nil
+}
+
+// SetActive switches signing to the key with the given kid.
+func (ks *KeySet) SetActive(kid string) error {
+	k, ok := ks.byKID[kid]
+	if !ok || k.private == nil {
+		return ErrUnknownKey
+	}
+	ks.active = k
+	return nil
+}
+
+// Sign issues a token signed by the active key, falling back to legacy HS256
+// when no asymmetric key is configured.
+func (ks *KeySet) Sign(claims jwt.Claims) (string, error) {
+	if ks.active != nil {
+		t := jwt.NewWithClaims(ks.active.Method, claims)
+		t.Header["kid"] = ks.active.ID
+		return t.SignedString(ks.active.private)
+	}
+	if ks.legacy == nil {
+		return "", ErrUnknownKey
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(ks.legacy)
+}
+
+// Parse verifies tokenStr and returns its claims. The algorithm is pinned
+// to the key selected by "kid", so a token can't pick a weaker method. opts
+// add checks such as jwt.WithIssuer or jwt.WithAudience.
+func (ks *KeySet) Parse(tokenStr string, opts ...jwt.ParserOption) (jwt.MapClaims, error) {
+	claims := jwt.MapClaims{}
+	opts = append([]jwt.ParserOption{jwt.WithValidMethods(ks.methods())}, opts...)
+	token, err := jwt.NewParser(opts...).ParseWithClaims(tokenStr, claims, ks.keyFunc)
+	if err != nil {
+		return nil, err
+	}
+	if !token.Valid {
+		return nil, errors.New("invalid token")
+	}
+	return claims, nil
+}
+
+// HasKey reports whether kid is known, so callers holding a remote key set
+// can tell when it needs refreshing.
+func (ks *KeySet) HasKey(kid string) bool {
+	_, ok := ks.byKID[kid]
+	return ok
+}
+
+func (ks *KeySet) keyFunc(t *jwt.Token) (interface{}, error) {
+	kid, _ := t.Header["kid"].(string)
+	if kid == "" {
+		if ks.legacy == nil || t.Method.Alg() != jwt.SigningMethodHS256.Alg() {
+			return nil, ErrUnknownKey
+		}
+		return ks.legacy, nil
+	}
+	k, ok := ks.byKID[kid]
+	if !ok || t.Method.Alg() != k.Method.Alg() {
+		return nil, ErrUnknownKey
+	}
+	return k.public, nil
+}
+
+func (ks *KeySet) methods() []string {
+	seen := map[string]bool{}
+	var out []string
+	if ks.legacy != nil {
+		seen["HS256"] = true
+		out = append(out, "HS256")
+	}
+	for _, k := range ks.byKID {
+		if alg := k.Method.Alg(); !seen[alg] {
+			seen[alg] = true
+			out = append(out, alg)
+		}
+	}
+	return out
+}
@@ -0,0 +1,208 @@
+package tokens
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func testClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"user": map[string]interface{}{"id": "11111111-1111-1111-1111-111111111111", "role": "patient"},
+		"exp":  time.Now().Add(time.Hour).Unix(),
+	}
+}
+
+func newEd25519(t *testing.T, kid string) *Key {
+	t.Helper()
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k, err := NewKey(kid, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func newRSA(t *testing.T, kid string) *Key {
+	t.Helper()
+	priv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	k, err := NewKey(kid, priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return k
+}
+
+func TestRotationKeepsOldTokensValid(t *testing.T) {
+	ks := NewHMAC("")
+	oldKey, newKey := newRSA(t, "2026-01"), newEd25519(t, "2026-02")
+	if err := ks.Add(oldKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.SetActive("2026-01"); err != nil {
+		t.Fatal(err)
+	}
+	oldTok, err := ks.Sign(testClaims())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := ks.Add(newKey); err != nil {
+		t.Fatal(err)
+	}
+	if err := ks.SetActive("2026-02"); err != nil {
+		t.Fatal(err)
+	}
+	newTok, err := ks.Sign(testClaims())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tok := range map[string]string{"old": oldTok, "new": newTok} {
+		if _, err := ks.Parse(tok); err != nil {
+			t.Fatalf("%s token rejected: %v", name, err)
+		}
+	}
+	parsed, _, _ := jwt.NewParser().ParseUnverified(newTok, jwt.MapClaims{})
+	if parsed.Header["kid"] != "2026-02" || parsed.Method.Alg() != "EdDSA" {
+		t.Fatalf("unexpected header %v", parsed.Header)
+	}
+}
+
+func TestParseRejectsAlgorithmMismatch(t *testing.T) {
+	ks := NewHMAC("secret")
+	k := newRSA(t, "rsa")
+	if err := ks.Add(k); err != nil {
+		t.Fatal(err)
+	}
+	// An HS256 token pointing at the RSA kid must not verify with the secret.
+	tok := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims())
+	tok.Header["kid"] = "rsa"
+	s, err := tok.SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.Parse(s); err == nil {
+		t.Fatal("expected mismatched alg to be rejected")
+	}
+}
+
+func TestLegacyHS256(t *testing.T) {
+	legacy, err := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims()).SignedString([]byte("secret"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := NewHMAC("secret").Parse(legacy); err != nil {
+		t.Fatalf("legacy token rejected: %v", err)
+	}
+	if _, err := NewHMAC("").Parse(legacy); err == nil {
+		t.Fatal("expected legacy token to be rejected without a secret")
+	}
+	if _, err := NewHMAC("").Sign(testClaims()); err == nil {
+		t.Fatal("expected sign to fail without keys")
+	}
+}
+
+func TestLoadDirAndJWKS(t *testing.T) {
+	dir := t.TempDir()
+	_, edPriv, _ := ed25519.GenerateKey(rand.Reader)
+	rsaPriv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edDER, _ := x509.MarshalPKCS8PrivateKey(edPriv)
+	retiredDER, _ := x509.MarshalPKIXPublicKey(&rsaPriv.PublicKey)
+	writePEM(t, filepath.Join(dir, "a-retired.pem"), "PUBLIC KEY", retiredDER)
+	writePEM(t, filepath.Join(dir, "b-current.pem"), "PRIVATE KEY", edDER)
+
+	ks, err := LoadDir(dir, "", "secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tok, err := ks.Sign(testClaims())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ks.Parse(tok); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := LoadDir(dir, "a-retired", ""); err == nil {
+		t.Fatal("expected a public-only key to be refused as active")
+	}
+	legacy, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, testClaims()).SignedString([]byte("secret"))
+	if _, err := ks.Parse(legacy); err != nil {
+		t.Fatalf("legacy token rejected with the secret loaded: %v", err)
+	}
+	retired, err := LoadDir(dir, "", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := retired.Parse(legacy); err == nil {
+		t.Fatal("expected legacy token to be rejected once HS256 is retired")
+	}
+
+	set := ks.JWKS()
+	if len(set.Keys) != 2 {
+		t.Fatalf("expected 2 keys, got %d", len(set.Keys))
+	}
+	if set.Keys[0].Kid != "a-retired" || set.Keys[0].Kty != "RSA" || set.Keys[0].E != "AQAB" {
+		t.Fatalf("unexpected RSA jwk %+v", set.Keys[0])
+	}
+	if set.Keys[1].Kty != "OKP" || set.Keys[1].Crv != "Ed25519" || set.Keys[1].Alg != "EdDSA" {
+		t.Fatalf("unexpected Ed25519 jwk %+v", set.Keys[1])
+	}
+}
+
+func writePEM(t *testing.T, path, typ string, der []byte) {
+	t.Helper()
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: typ, Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestParseJWKSRoundTrip(t *testing.T) {
+	signer := NewHMAC("")
+	for _, k := range []*Key{newRSA(t, "rsa"), newEd25519(t, "ed")} {
+		if err := signer.Add(k); err != nil {
+			t.Fatal(err)
+		}
+	}
+	published, err := json.Marshal(signer.JWKS())
+	if err != nil {
+		t.Fatal(err)
+	}
+	verifier, err := ParseJWKS(published)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, kid := range []string{"rsa", "ed"} {
+		if err := signer.SetActive(kid); err != nil {
+			t.Fatal(err)
+		}
+		tok, err := signer.Sign(testClaims())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := verifier.Parse(tok); err != nil {
+			t.Fatalf("%s: %v", kid, err)
+		}
+	}
+	if _, err := verifier.Sign(testClaims()); err == nil {
+		t.Fatal("a key set built from JWKS must not sign")
+	}
+}
@@ -0,0 +1,65 @@
+package totp
+
+import (
+	"encoding/base32"
+	"strings"
+	"testing"
+	"time"
+)
+
+// RFC 6238 appendix B vectors for SHA-1 (last six digits).
+func TestCode_RFC6238Vectors(t *testing.T) {
+	secret := base32.StdEncoding.EncodeToString([]byte("12345678901234567890"))
+	cases := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, c := range cases {
+		got, err := Code(secret, time.Unix(c.unix, 0))
+		if err != nil {
+			t.Fatalf("code: %v", err)
+		}
+		if got != c.want {
+			t.Fatalf("at %d: expected %s, got %s", c.unix, c.want, got)
+		}
+	}
+}
+
+func TestValidate_AcceptsSkewAndReturnsStep(t *testing.T) {
+	secret, err := GenerateSecret()
+	if err != nil {
+		t.Fatalf("secret: %v", err)
+	}
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	prev, _ := Code(secret, now.Add(-Period))
+
+	step, ok := Validate(secret, prev, now, 1)
+	if !ok {
+		t.Fatalf("expected previous step code to validate")
+	}
+	if step != Step(now)-1 {
+		t.Fatalf("expected step %d, got %d", Step(now)-1, step)
+	}
+	if _, ok := Validate(secret, prev, now, 0); ok {
+		t.Fatalf("expected previous step code to fail without skew")
+	}
+	if _, ok := Validate(secret, "12345", now, 1); ok {
+		t.Fatalf("expected short code to fail")
+	}
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := ProvisioningURI("JBSWY3DPEHPK3PXP", "Physiolink", "pt@example.com")
+	if !strings.HasPrefix(uri, "otpauth://totp/Physiolink:pt@example.com?") {
+		t.Fatalf("unexpected uri: %s", uri)
+	}
+	if !strings.Contains(uri, "secret=JBSWY3DPEHPK3PXP") || !strings.Contains(uri, "issuer=Physiolink") {
+		t.Fatalf("missing parameters: %s", uri)
+	}
+}
@@ -0,0 +1,15 @@
+package views
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/divijg19/physiolink/backend/internal/middleware"
+)
+
+// csrfHeaders is rendered into hx-headers on <body> so every HTMX request
+// from the page carries the CSRF token.
+func csrfHeaders(ctx context.Context) string {
+	b, _ := json.Marshal(map[string]string{middleware.CSRFHeader: middleware.CSRFToken(ctx)})
+	return string(b)
+}
@@ -0,0 +1,17 @@
+-- API keys let clinic systems call the API without a user password. Only a
+-- SHA-256 hash of the key is stored; the prefix identifies it in logs and UIs.
+CREATE TABLE IF NOT EXISTS api_keys (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  clinic_id UUID NOT NULL REFERENCES clinics(id) ON DELETE CASCADE,
+  name TEXT NOT NULL,
+  prefix TEXT NOT NULL,
+  key_hash TEXT NOT NULL,
+  scopes TEXT[] NOT NULL DEFAULT '{}',
+  created_by UUID REFERENCES users(id) ON DELETE SET NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  last_used_at TIMESTAMPTZ,
+  revoked_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_api_keys_prefix ON api_keys(prefix);
+CREATE INDEX IF NOT EXISTS ix_api_keys_clinic ON api_keys(clinic_id);
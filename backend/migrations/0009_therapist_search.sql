@@ -0,0 +1,14 @@
+-- Full-text search over therapist profiles: name, specialties, credentials and bio
+CREATE OR REPLACE FUNCTION profile_search_document(display_name TEXT, bio TEXT, specialties TEXT[], extra JSONB)
+RETURNS tsvector
+LANGUAGE sql IMMUTABLE AS $$
+  SELECT setweight(to_tsvector('english', coalesce(display_name, '')), 'A') ||
+         setweight(to_tsvector('english', coalesce(array_to_string(specialties, ' '), '')), 'B') ||
+         setweight(to_tsvector('english', coalesce(extra->>'credentials', '')), 'C') ||
+         setweight(to_tsvector('english', coalesce(bio, '')), 'D')
+$$;
+
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS search_tsv tsvector
+  GENERATED ALWAYS AS (profile_search_document(display_name, bio, specialties, profile_extra)) STORED;
+
+CREATE INDEX IF NOT EXISTS ix_profiles_search ON profiles USING GIN (search_tsv);
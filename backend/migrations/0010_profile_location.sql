@@ -0,0 +1,26 @@
+-- Coordinates for profile addresses, for "near me" therapist search without PostGIS.
+-- The structured address itself lives in profiles.address (JSONB).
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION;
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION;
+
+DO $$
+BEGIN
+  ALTER TABLE profiles ADD CONSTRAINT profiles_coordinates_check CHECK (
+    (latitude IS NULL) = (longitude IS NULL)
+    AND (latitude IS NULL OR (latitude BETWEEN -90 AND 90 AND longitude BETWEEN -180 AND 180))
+  );
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+-- Serves the bounding-box prefilter; the exact distance is checked afterwards
+CREATE INDEX IF NOT EXISTS ix_profiles_coordinates ON profiles(latitude, longitude) WHERE latitude IS NOT NULL;
+
+-- Great-circle distance in kilometres
+CREATE OR REPLACE FUNCTION haversine_km(lat1 DOUBLE PRECISION, lng1 DOUBLE PRECISION, lat2 DOUBLE PRECISION, lng2 DOUBLE PRECISION)
+RETURNS DOUBLE PRECISION
+LANGUAGE sql IMMUTABLE STRICT AS $$
+  SELECT 2 * 6371.0088 * asin(least(1, sqrt(
+    power(sin(radians(lat2 - lat1) / 2), 2) +
+    cos(radians(lat1)) * cos(radians(lat2)) * power(sin(radians(lng2 - lng1) / 2), 2)
+  )))
+$$;
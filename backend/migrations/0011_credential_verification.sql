@@ -0,0 +1,32 @@
+-- Therapists submit their licence for review; approving a submission marks
+-- the profile verified. The flag used to be read from profile_extra, where
+-- nothing ever set it.
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS is_verified BOOLEAN NOT NULL DEFAULT false;
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS verified_at TIMESTAMPTZ;
+
+-- Keep any flag set by hand, then drop the old key
+UPDATE profiles
+SET is_verified = true, verified_at = COALESCE(verified_at, now())
+WHERE profile_extra->>'isVerified' = 'true';
+UPDATE profiles SET profile_extra = profile_extra - 'isVerified' WHERE profile_extra ? 'isVerified';
+
+CREATE INDEX IF NOT EXISTS ix_profiles_verified ON profiles(user_id) WHERE is_verified;
+
+-- documents is a JSON array of {name, url} references to the uploaded files
+CREATE TABLE IF NOT EXISTS credential_submissions (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  therapist_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  licence_number TEXT NOT NULL,
+  issuing_body TEXT NOT NULL,
+  documents JSONB NOT NULL DEFAULT '[]',
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'approved', 'rejected')),
+  review_notes TEXT,
+  reviewed_by UUID REFERENCES users(id) ON DELETE SET NULL,
+  reviewed_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+-- A therapist has at most one submission waiting for review
+CREATE UNIQUE INDEX IF NOT EXISTS ux_credential_submissions_pending ON credential_submissions(therapist_id) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS ix_credential_submissions_therapist ON credential_submissions(therapist_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS ix_credential_submissions_status ON credential_submissions(status, created_at);
@@ -0,0 +1,61 @@
+-- Typed profile fields. They used to live in profile_extra as JSON, where they
+-- couldn't be constrained, indexed or queried.
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS age INTEGER;
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS gender TEXT;
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS condition TEXT;
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS goals TEXT;
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS credentials TEXT;
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS location TEXT;
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS profile_image_url TEXT;
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS years_experience INTEGER;
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS languages TEXT[] NOT NULL DEFAULT '{}';
+
+DO $$
+BEGIN
+  ALTER TABLE profiles ADD CONSTRAINT profiles_age_check CHECK (age BETWEEN 0 AND 130);
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+DO $$
+BEGIN
+  ALTER TABLE profiles ADD CONSTRAINT profiles_years_experience_check CHECK (years_experience BETWEEN 0 AND 80);
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+-- Backfill from the JSON, keeping any value already in a column. Ages that
+-- aren't a plausible whole number are dropped.
+UPDATE profiles SET
+  age = COALESCE(age, CASE WHEN profile_extra->>'age' ~ '^\d{1,3}$' AND (profile_extra->>'age')::int <= 130
+                           THEN (profile_extra->>'age')::int END),
+  gender = COALESCE(gender, NULLIF(profile_extra->>'gender', '')),
+  condition = COALESCE(condition, NULLIF(profile_extra->>'condition', '')),
+  goals = COALESCE(goals, NULLIF(profile_extra->>'goals', '')),
+  credentials = COALESCE(credentials, NULLIF(profile_extra->>'credentials', '')),
+  location = COALESCE(location, NULLIF(profile_extra->>'location', '')),
+  profile_image_url = COALESCE(profile_image_url, NULLIF(profile_extra->>'profileImageUrl', ''))
+WHERE profile_extra IS NOT NULL;
+
+-- The search document read credentials from profile_extra; rebuild it on the column
+ALTER TABLE profiles DROP COLUMN IF EXISTS search_tsv;
+DROP FUNCTION IF EXISTS profile_search_document(TEXT, TEXT, TEXT[], JSONB);
+
+CREATE OR REPLACE FUNCTION profile_search_document(display_name TEXT, bio TEXT, specialties TEXT[], credentials TEXT)
+RETURNS tsvector
+LANGUAGE sql IMMUTABLE AS $$
+  SELECT setweight(to_tsvector('english', coalesce(display_name, '')), 'A') ||
+         setweight(to_tsvector('english', coalesce(array_to_string(specialties, ' '), '')), 'B') ||
+         setweight(to_tsvector('english', coalesce(credentials, '')), 'C') ||
+         setweight(to_tsvector('english', coalesce(bio, '')), 'D')
+$$;
+
+ALTER TABLE profiles ADD COLUMN search_tsv tsvector
+  GENERATED ALWAYS AS (profile_search_document(display_name, bio, specialties, credentials)) STORED;
+
+CREATE INDEX IF NOT EXISTS ix_profiles_search ON profiles USING GIN (search_tsv);
+CREATE INDEX IF NOT EXISTS ix_profiles_specialties ON profiles USING GIN (specialties);
+CREATE INDEX IF NOT EXISTS ix_profiles_languages ON profiles USING GIN (languages);
+
+-- profile_extra keeps only keys without a column of their own
+UPDATE profiles
+SET profile_extra = NULLIF(profile_extra - ARRAY['age', 'gender', 'condition', 'goals', 'credentials', 'location', 'profileImageUrl'], '{}'::jsonb)
+WHERE profile_extra IS NOT NULL;
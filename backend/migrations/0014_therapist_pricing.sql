@@ -0,0 +1,27 @@
+-- What a session costs and who pays for it. Prices are stored in minor units
+-- (pence, cents) with their ISO 4217 currency; insurers is the list of
+-- insurers a therapist accepts, as they name them.
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS session_price_cents INTEGER;
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS price_currency TEXT;
+ALTER TABLE profiles ADD COLUMN IF NOT EXISTS insurers TEXT[] NOT NULL DEFAULT '{}';
+
+DO $$
+BEGIN
+  ALTER TABLE profiles ADD CONSTRAINT profiles_session_price_check CHECK (session_price_cents BETWEEN 0 AND 10000000);
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+DO $$
+BEGIN
+  ALTER TABLE profiles ADD CONSTRAINT profiles_price_currency_check CHECK (price_currency ~ '^[A-Z]{3}$');
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+-- A price means nothing without its currency
+DO $$
+BEGIN
+  ALTER TABLE profiles ADD CONSTRAINT profiles_price_has_currency CHECK (session_price_cents IS NULL OR price_currency IS NOT NULL);
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
+
+CREATE INDEX IF NOT EXISTS ix_profiles_session_price ON profiles(session_price_cents) WHERE session_price_cents IS NOT NULL;
@@ -0,0 +1,24 @@
+-- Therapists a patient has starred. created_at doubles as the point from
+-- which new openings with the therapist are announced to the patient.
+CREATE TABLE IF NOT EXISTS favourites (
+  patient_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  therapist_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (patient_id, therapist_id)
+);
+
+CREATE INDEX IF NOT EXISTS ix_favourites_therapist ON favourites(therapist_id);
+
+-- The latest time each user opened a therapist's profile. One row per pair;
+-- older rows beyond the history length are pruned on write.
+CREATE TABLE IF NOT EXISTS therapist_views (
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  therapist_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  viewed_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  PRIMARY KEY (user_id, therapist_id)
+);
+
+CREATE INDEX IF NOT EXISTS ix_therapist_views_recent ON therapist_views(user_id, viewed_at DESC);
+
+-- Openings are looked up by when they were published
+CREATE INDEX IF NOT EXISTS ix_slots_therapist_created ON availability_slots(therapist_id, created_at DESC) WHERE status = 'open';
@@ -0,0 +1,41 @@
+-- A review is of one appointment, and an appointment gets at most one review.
+-- Where a patient already reviewed the same appointment more than once, the
+-- latest review stands; the older ones move to reviews_archive.
+CREATE TABLE IF NOT EXISTS reviews_archive (
+  id UUID NOT NULL,
+  appointment_id UUID NOT NULL,
+  patient_id UUID NOT NULL,
+  rating INT NOT NULL,
+  comment TEXT,
+  created_at TIMESTAMPTZ NOT NULL,
+  -- superseded: a newer review of the same appointment replaced it;
+  -- rating_out_of_range: the review stays, with its rating brought into range
+  reason TEXT NOT NULL CHECK (reason IN ('superseded', 'rating_out_of_range')),
+  archived_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+WITH superseded AS (
+  DELETE FROM reviews r
+  USING reviews newer
+  WHERE newer.appointment_id = r.appointment_id
+    AND (newer.created_at, newer.id) > (r.created_at, r.id)
+  RETURNING r.id, r.appointment_id, r.patient_id, r.rating, r.comment, r.created_at
+)
+INSERT INTO reviews_archive (id, appointment_id, patient_id, rating, comment, created_at, reason)
+SELECT id, appointment_id, patient_id, rating, comment, created_at, 'superseded' FROM superseded;
+
+CREATE UNIQUE INDEX IF NOT EXISTS ux_reviews_appointment ON reviews(appointment_id);
+
+-- Ratings are 1 to 5 stars; the API used to accept any number. The original
+-- rating is kept in reviews_archive before it is brought into range.
+INSERT INTO reviews_archive (id, appointment_id, patient_id, rating, comment, created_at, reason)
+SELECT id, appointment_id, patient_id, rating, comment, created_at, 'rating_out_of_range'
+FROM reviews WHERE rating NOT BETWEEN 1 AND 5;
+
+UPDATE reviews SET rating = LEAST(GREATEST(rating, 1), 5) WHERE rating NOT BETWEEN 1 AND 5;
+
+DO $$
+BEGIN
+  ALTER TABLE reviews ADD CONSTRAINT reviews_rating_check CHECK (rating BETWEEN 1 AND 5);
+EXCEPTION WHEN duplicate_object THEN NULL;
+END $$;
@@ -0,0 +1,21 @@
+-- Authors can edit or withdraw a review for a while after posting it.
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS updated_at TIMESTAMPTZ;
+
+-- Every version an edit replaced, and every withdrawn review, is kept here
+-- for moderation. Rows outlive the review they came from, so review_id is
+-- not a foreign key.
+CREATE TABLE IF NOT EXISTS review_revisions (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  review_id UUID NOT NULL,
+  appointment_id UUID NOT NULL REFERENCES appointments(id) ON DELETE CASCADE,
+  patient_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  rating INT NOT NULL,
+  comment TEXT,
+  -- written_at is when this version was posted; action is edited or withdrawn
+  written_at TIMESTAMPTZ NOT NULL,
+  action TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS ix_review_revisions_review ON review_revisions(review_id, created_at);
+CREATE INDEX IF NOT EXISTS ix_review_revisions_withdrawn ON review_revisions(appointment_id) WHERE action = 'withdrawn';
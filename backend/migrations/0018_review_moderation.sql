@@ -0,0 +1,22 @@
+-- Reviews are pending (held for an admin), published or hidden. Only
+-- published reviews are listed or count toward a therapist's rating.
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS status TEXT NOT NULL DEFAULT 'published';
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS moderation_reason TEXT;
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS moderated_by UUID REFERENCES users(id) ON DELETE SET NULL;
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS moderated_at TIMESTAMPTZ;
+
+CREATE INDEX IF NOT EXISTS ix_reviews_pending ON reviews(created_at) WHERE status = 'pending';
+
+-- Reviews flagged by users. A report stays open until an admin moderates
+-- the review.
+CREATE TABLE IF NOT EXISTS review_reports (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  review_id UUID NOT NULL REFERENCES reviews(id) ON DELETE CASCADE,
+  reporter_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  reason TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+  resolved_at TIMESTAMPTZ,
+  UNIQUE (review_id, reporter_id)
+);
+
+CREATE INDEX IF NOT EXISTS ix_review_reports_open ON review_reports(review_id) WHERE resolved_at IS NULL;
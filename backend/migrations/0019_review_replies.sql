@@ -0,0 +1,9 @@
+-- A therapist's public reply to a review of one of their appointments. Each
+-- review gets at most one.
+CREATE TABLE IF NOT EXISTS review_replies (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  review_id UUID NOT NULL UNIQUE REFERENCES reviews(id) ON DELETE CASCADE,
+  therapist_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  body TEXT NOT NULL,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
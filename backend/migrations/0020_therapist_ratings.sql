@@ -0,0 +1,45 @@
+-- Running totals of each therapist's published reviews, kept in step with
+-- reviews in the same transaction. profiles.rating is their average.
+CREATE TABLE IF NOT EXISTS therapist_ratings (
+  therapist_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+  review_count INT NOT NULL DEFAULT 0 CHECK (review_count >= 0),
+  rating_sum INT NOT NULL DEFAULT 0,
+  stars_1 INT NOT NULL DEFAULT 0,
+  stars_2 INT NOT NULL DEFAULT 0,
+  stars_3 INT NOT NULL DEFAULT 0,
+  stars_4 INT NOT NULL DEFAULT 0,
+  stars_5 INT NOT NULL DEFAULT 0,
+  updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+INSERT INTO therapist_ratings (therapist_id, review_count, rating_sum, stars_1, stars_2, stars_3, stars_4, stars_5)
+SELECT a.therapist_id, COUNT(*), SUM(r.rating),
+       COUNT(*) FILTER (WHERE r.rating = 1), COUNT(*) FILTER (WHERE r.rating = 2),
+       COUNT(*) FILTER (WHERE r.rating = 3), COUNT(*) FILTER (WHERE r.rating = 4),
+       COUNT(*) FILTER (WHERE r.rating = 5)
+FROM reviews r
+JOIN appointments a ON a.id = r.appointment_id
+WHERE r.status = 'published'
+GROUP BY a.therapist_id
+ON CONFLICT (therapist_id) DO NOTHING;
+
+-- Derive profiles.rating from the totals so it agrees with the review count
+-- and histogram; therapists with nothing published have no rating.
+UPDATE profiles p
+SET rating = tr.rating_sum::numeric / NULLIF(tr.review_count, 0)
+FROM therapist_ratings tr
+WHERE tr.therapist_id = p.user_id;
+
+UPDATE profiles p
+SET rating = NULL
+WHERE p.rating IS NOT NULL
+  AND NOT EXISTS (SELECT 1 FROM therapist_ratings tr WHERE tr.therapist_id = p.user_id);
+
+-- rating_score smooths an average rating toward prior_mean, as if the
+-- therapist had 5 more reviews at that rating, so that a handful of reviews
+-- can't outrank a long record. It is NULL without reviews.
+CREATE OR REPLACE FUNCTION rating_score(rating_sum BIGINT, review_count BIGINT, prior_mean DOUBLE PRECISION)
+RETURNS DOUBLE PRECISION
+LANGUAGE sql IMMUTABLE STRICT AS $$
+  SELECT (rating_sum + 5 * prior_mean) / (review_count + 5) WHERE review_count > 0
+$$;
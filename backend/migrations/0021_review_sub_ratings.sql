@@ -0,0 +1,21 @@
+-- Optional 1 to 5 ratings of particular aspects of a session, alongside the
+-- overall rating.
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS communication_rating INT CHECK (communication_rating BETWEEN 1 AND 5);
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS punctuality_rating INT CHECK (punctuality_rating BETWEEN 1 AND 5);
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS effectiveness_rating INT CHECK (effectiveness_rating BETWEEN 1 AND 5);
+ALTER TABLE reviews ADD COLUMN IF NOT EXISTS facility_rating INT CHECK (facility_rating BETWEEN 1 AND 5);
+
+ALTER TABLE review_revisions ADD COLUMN IF NOT EXISTS communication_rating INT;
+ALTER TABLE review_revisions ADD COLUMN IF NOT EXISTS punctuality_rating INT;
+ALTER TABLE review_revisions ADD COLUMN IF NOT EXISTS effectiveness_rating INT;
+ALTER TABLE review_revisions ADD COLUMN IF NOT EXISTS facility_rating INT;
+
+-- Per-aspect totals over the published reviews that rated it
+ALTER TABLE therapist_ratings ADD COLUMN IF NOT EXISTS communication_sum INT NOT NULL DEFAULT 0;
+ALTER TABLE therapist_ratings ADD COLUMN IF NOT EXISTS communication_count INT NOT NULL DEFAULT 0;
+ALTER TABLE therapist_ratings ADD COLUMN IF NOT EXISTS punctuality_sum INT NOT NULL DEFAULT 0;
+ALTER TABLE therapist_ratings ADD COLUMN IF NOT EXISTS punctuality_count INT NOT NULL DEFAULT 0;
+ALTER TABLE therapist_ratings ADD COLUMN IF NOT EXISTS effectiveness_sum INT NOT NULL DEFAULT 0;
+ALTER TABLE therapist_ratings ADD COLUMN IF NOT EXISTS effectiveness_count INT NOT NULL DEFAULT 0;
+ALTER TABLE therapist_ratings ADD COLUMN IF NOT EXISTS facility_sum INT NOT NULL DEFAULT 0;
+ALTER TABLE therapist_ratings ADD COLUMN IF NOT EXISTS facility_count INT NOT NULL DEFAULT 0;
@@ -0,0 +1,3 @@
+-- The admin behind an impersonation session (the token's "act" claim), so
+-- support sessions are listed and revoked like any other
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS impersonator_id UUID REFERENCES users(id) ON DELETE CASCADE;
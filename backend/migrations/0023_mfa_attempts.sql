@@ -0,0 +1,14 @@
+-- Login challenges issued after the password step; each one allows a single
+-- code attempt
+CREATE TABLE IF NOT EXISTS mfa_challenges (
+  id UUID PRIMARY KEY,
+  user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+  expires_at TIMESTAMPTZ NOT NULL,
+  used_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS ix_mfa_challenges_expires ON mfa_challenges(expires_at);
+
+-- Wrong codes since the last accepted one; too many lock the second factor
+ALTER TABLE user_mfa ADD COLUMN IF NOT EXISTS failed_attempts INT NOT NULL DEFAULT 0;
+ALTER TABLE user_mfa ADD COLUMN IF NOT EXISTS locked_until TIMESTAMPTZ;